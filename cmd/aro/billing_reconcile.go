@@ -0,0 +1,103 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/policy"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/billing"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+)
+
+// billingReconcile compares the Billing and OpenShiftClusters collections and
+// writes a JSON report of any inconsistencies to stdout.  In "fix" mode the
+// inconsistencies are also repaired.
+func billingReconcile(ctx context.Context, log *logrus.Entry) error {
+	var fix bool
+	switch strings.ToLower(flag.Arg(1)) {
+	case "report":
+	case "fix":
+		fix = true
+	default:
+		return fmt.Errorf("invalid mode %q", flag.Arg(1))
+	}
+
+	_env, err := env.NewEnv(ctx, log, env.COMPONENT_RP)
+	if err != nil {
+		return err
+	}
+
+	msiToken, err := _env.NewMSITokenCredential()
+	if err != nil {
+		return err
+	}
+
+	aead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.EncryptionSecretV2Name, env.EncryptionSecretName, _env.FeatureIsSet(env.FeatureEnableEncryptionEnvelopes))
+	if err != nil {
+		return err
+	}
+
+	if err := env.ValidateVars(envDatabaseAccountName); err != nil {
+		return err
+	}
+
+	dbAccountName := os.Getenv(envDatabaseAccountName)
+	clientOptions := &policy.ClientOptions{
+		ClientOptions: _env.Environment().ManagedIdentityCredentialOptions().ClientOptions,
+	}
+	dbAuthorizer, err := database.NewMasterKeyAuthorizer(ctx, msiToken, clientOptions, _env.SubscriptionID(), _env.ResourceGroup(), dbAccountName)
+	if err != nil {
+		return err
+	}
+
+	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, &noop.Noop{}, aead, dbAccountName)
+	if err != nil {
+		return err
+	}
+
+	dbName, err := DBName(env.IsLocalDevelopmentMode())
+	if err != nil {
+		return err
+	}
+
+	dbBilling, err := database.NewBilling(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	dbSubscriptions, err := database.NewSubscriptions(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	m, err := billing.NewManager(_env, dbBilling, dbSubscriptions, log)
+	if err != nil {
+		return err
+	}
+
+	report, err := billing.NewReconciler(log, dbBilling, dbOpenShiftClusters, dbSubscriptions, m).Reconcile(ctx, fix)
+	if err != nil {
+		return err
+	}
+
+	e := json.NewEncoder(os.Stdout)
+	e.SetIndent("", "    ")
+	return e.Encode(report)
+}
@@ -22,12 +22,15 @@ import (
 
 func usage() {
 	fmt.Fprint(flag.CommandLine.Output(), "usage:\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s billing-reconcile {report,fix}\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s dbtoken\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s deploy config.yaml location\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s gateway\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s mirror [release_image...]\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s monitor\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s network-plan cluster.json plan.json\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s portal\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s reseal {report,fix}\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s rp\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s operator {master,worker}\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s update-versions\n", os.Args[0])
@@ -52,6 +55,9 @@ func main() {
 
 	var err error
 	switch strings.ToLower(flag.Arg(0)) {
+	case "billing-reconcile":
+		checkArgs(2)
+		err = billingReconcile(ctx, log)
 	case "dbtoken":
 		checkArgs(1)
 		err = dbtoken(ctx, log)
@@ -67,6 +73,12 @@ func main() {
 	case "monitor":
 		checkArgs(1)
 		err = monitor(ctx, log)
+	case "network-plan":
+		checkArgs(3)
+		err = networkPlan(ctx, log)
+	case "reseal":
+		checkArgs(2)
+		err = resealCmd(ctx, log)
 	case "rp":
 		checkArgs(1)
 		err = rp(ctx, log, audit)
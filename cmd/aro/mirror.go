@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -160,7 +161,13 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 		}
 	} else {
 		for _, arg := range flag.Args()[1:] {
-			if strings.EqualFold(arg, "latest") {
+			if strings.HasPrefix(arg, "oci:") {
+				// release staged in a local OCI layout directory
+				releases = append(releases, pkgmirror.Node{
+					Version: arg,
+					Payload: arg,
+				})
+			} else if strings.EqualFold(arg, "latest") {
 				releases = append(releases, pkgmirror.Node{
 					Version: version.DefaultInstallStream.Version.String(),
 					Payload: version.DefaultInstallStream.PullSpec,
@@ -184,19 +191,37 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 		}
 	}
 
+	// releases can be staged to a local OCI layout directory instead of the
+	// destination ACR
+	releaseDst := dstAcr + acrDomainSuffix
+	if os.Getenv("DST_OCI_LAYOUT") != "" {
+		releaseDst = "oci:" + os.Getenv("DST_OCI_LAYOUT")
+	}
+
+	var reports []*pkgmirror.Report
 	for _, release := range releases {
 		if _, ok := doNotMirrorTags[release.Version]; ok {
 			log.Printf("skipping mirror of release %s", release.Version)
 			continue
 		}
 		log.Printf("mirroring release %s", release.Version)
-		err = pkgmirror.Mirror(ctx, log, dstAcr+acrDomainSuffix, release.Payload, dstAuth, srcAuthQuay)
+		report, err := pkgmirror.Mirror(ctx, log, releaseDst, release.Payload, dstAuth, srcAuthQuay)
+		if report != nil {
+			reports = append(reports, report)
+		}
 		if err != nil {
 			log.Errorf("%s: %s\n", release, err)
 			errorOccurred = true
 		}
 	}
 
+	if os.Getenv("MIRROR_REPORT") != "" {
+		err = writeMirrorReport(os.Getenv("MIRROR_REPORT"), reports)
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Print("done")
 
 	if errorOccurred {
@@ -205,3 +230,12 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 
 	return nil
 }
+
+func writeMirrorReport(path string, reports []*pkgmirror.Report) error {
+	b, err := json.MarshalIndent(reports, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0666)
+}
@@ -0,0 +1,67 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/validate"
+	"github.com/Azure/ARO-RP/pkg/validate/dynamic"
+)
+
+// networkPlan validates the networking planned for a cluster without access to
+// Azure and writes a JSON report of the problems found to stdout.  It fails if
+// any errors are found so that it can gate CI pipelines.
+func networkPlan(ctx context.Context, log *logrus.Entry) error {
+	oc := &api.OpenShiftCluster{}
+	err := readJSONFile(flag.Arg(1), oc)
+	if err != nil {
+		return err
+	}
+
+	// clusters are planned before they are created
+	if oc.Properties.ProvisioningState == "" {
+		oc.Properties.ProvisioningState = api.ProvisioningStateCreating
+	}
+
+	plan := &dynamic.NetworkPlan{}
+	err = readJSONFile(flag.Arg(2), plan)
+	if err != nil {
+		return err
+	}
+
+	report, err := validate.ValidateNetworkPlan(ctx, log, oc, plan)
+	if err != nil {
+		return err
+	}
+
+	e := json.NewEncoder(os.Stdout)
+	e.SetIndent("", "    ")
+	err = e.Encode(report)
+	if err != nil {
+		return err
+	}
+
+	if errs := report.Errors(); len(errs) > 0 {
+		return fmt.Errorf("network plan has %d error(s)", len(errs))
+	}
+
+	return nil
+}
+
+func readJSONFile(path string, v interface{}) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
@@ -107,6 +107,11 @@ func operator(ctx context.Context, log *logrus.Entry) error {
 			client)).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller %s: %v", alertwebhook.ControllerName, err)
 		}
+		if err = mgr.Add(alertwebhook.NewReceiver(
+			log.WithField("component", "alertwebhook-receiver"),
+			client)); err != nil {
+			return fmt.Errorf("unable to add alertwebhook receiver: %v", err)
+		}
 		if err = (workaround.NewReconciler(
 			log.WithField("controller", workaround.ControllerName),
 			client)).SetupWithManager(mgr); err != nil {
@@ -144,7 +149,7 @@ func operator(ctx context.Context, log *logrus.Entry) error {
 		}
 		if err = (node.NewReconciler(
 			log.WithField("controller", node.ControllerName),
-			client, kubernetescli)).SetupWithManager(mgr); err != nil {
+			client, kubernetescli, mgr.GetEventRecorderFor(node.ControllerName))).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller %s: %v", node.ControllerName, err)
 		}
 		if err = (subnets.NewReconciler(
@@ -163,7 +168,8 @@ func operator(ctx context.Context, log *logrus.Entry) error {
 			return fmt.Errorf("unable to create controller %s: %v", banner.ControllerName, err)
 		}
 		if err = (machineset.NewReconciler(
-			log.WithField("controller", machineset.ControllerName), client)).SetupWithManager(mgr); err != nil {
+			log.WithField("controller", machineset.ControllerName),
+			client, mgr.GetEventRecorderFor(machineset.ControllerName))).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller %s: %v", machineset.ControllerName, err)
 		}
 		if err = (imageconfig.NewReconciler(
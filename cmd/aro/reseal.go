@@ -0,0 +1,113 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/policy"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/reseal"
+)
+
+// resealCmd finds documents with secure fields which were not sealed with the
+// current encryption key and writes a JSON report to stdout.  In "fix" mode the
+// documents are also resealed.
+func resealCmd(ctx context.Context, log *logrus.Entry) error {
+	var fix bool
+	switch strings.ToLower(flag.Arg(1)) {
+	case "report":
+	case "fix":
+		fix = true
+	default:
+		return fmt.Errorf("invalid mode %q", flag.Arg(1))
+	}
+
+	_env, err := env.NewEnv(ctx, log, env.COMPONENT_RP)
+	if err != nil {
+		return err
+	}
+
+	msiToken, err := _env.NewMSITokenCredential()
+	if err != nil {
+		return err
+	}
+
+	aead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.EncryptionSecretV2Name, env.EncryptionSecretName, _env.FeatureIsSet(env.FeatureEnableEncryptionEnvelopes))
+	if err != nil {
+		return err
+	}
+
+	if err := env.ValidateVars(envDatabaseAccountName); err != nil {
+		return err
+	}
+
+	dbAccountName := os.Getenv(envDatabaseAccountName)
+	clientOptions := &policy.ClientOptions{
+		ClientOptions: _env.Environment().ManagedIdentityCredentialOptions().ClientOptions,
+	}
+	dbAuthorizer, err := database.NewMasterKeyAuthorizer(ctx, msiToken, clientOptions, _env.SubscriptionID(), _env.ResourceGroup(), dbAccountName)
+	if err != nil {
+		return err
+	}
+
+	dbName, err := DBName(env.IsLocalDevelopmentMode())
+	if err != nil {
+		return err
+	}
+
+	sweeper, err := newResealSweeper(ctx, log, _env, dbAuthorizer, &noop.Noop{}, aead, dbAccountName, dbName)
+	if err != nil {
+		return err
+	}
+
+	report, err := sweeper.Sweep(ctx, fix)
+	if err != nil {
+		return err
+	}
+
+	e := json.NewEncoder(os.Stdout)
+	e.SetIndent("", "    ")
+	return e.Encode(report)
+}
+
+// newResealSweeper returns a reseal.Sweeper with its own database client, as
+// the client must not be shared while sweeping
+func newResealSweeper(ctx context.Context, log *logrus.Entry, _env env.Core, dbAuthorizer cosmosdb.Authorizer, m metrics.Emitter, aead encryption.Resealer, dbAccountName, dbName string) (reseal.Sweeper, error) {
+	tracker := database.NewResealTracker(aead)
+
+	dbc, err := database.NewDatabaseClient(log.WithField("component", "database-reseal"), _env, dbAuthorizer, m, tracker, dbAccountName)
+	if err != nil {
+		return nil, err
+	}
+
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	dbAsyncOperations, err := database.NewAsyncOperations(ctx, _env.IsLocalDevelopmentMode(), dbc, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	dbClusterManagerConfigurations, err := database.NewClusterManagerConfigurations(ctx, dbc, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return reseal.NewSweeper(log.WithField("component", "reseal"), tracker, dbOpenShiftClusters, dbAsyncOperations, dbClusterManagerConfigurations), nil
+}
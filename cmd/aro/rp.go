@@ -26,6 +26,7 @@ import (
 	_ "github.com/Azure/ARO-RP/pkg/api/v20230701preview"
 	_ "github.com/Azure/ARO-RP/pkg/api/v20230904"
 	_ "github.com/Azure/ARO-RP/pkg/api/v20231122"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20240812preview"
 	"github.com/Azure/ARO-RP/pkg/backend"
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
@@ -99,7 +100,7 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		return err
 	}
 
-	aead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.EncryptionSecretV2Name, env.EncryptionSecretName)
+	aead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.EncryptionSecretV2Name, env.EncryptionSecretName, _env.FeatureIsSet(env.FeatureEnableEncryptionEnvelopes))
 	if err != nil {
 		return err
 	}
@@ -163,7 +164,7 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 
 	go database.EmitMetrics(ctx, log, dbOpenShiftClusters, metrics)
 
-	feAead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.FrontendEncryptionSecretV2Name, env.FrontendEncryptionSecretName)
+	feAead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.FrontendEncryptionSecretV2Name, env.FrontendEncryptionSecretName, _env.FeatureIsSet(env.FeatureEnableEncryptionEnvelopes))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,34 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// MaintenanceTaskList represents a list of the maintenance tasks which can be
+// invoked on a cluster.
+type MaintenanceTaskList struct {
+	MaintenanceTasks []*MaintenanceTaskDefinition `json:"value"`
+}
+
+// MaintenanceTaskDefinition represents a maintenance task which can be invoked
+// on a cluster by name.
+type MaintenanceTaskDefinition struct {
+	// The name of the task, used as the cluster's maintenanceTask.
+	Name MaintenanceTask `json:"name,omitempty"`
+
+	// The revision of the task, which is increased whenever its steps,
+	// preconditions or impact change.
+	Version int `json:"version,omitempty"`
+
+	// A description of what the task does.
+	Description string `json:"description,omitempty"`
+
+	// The step groups run by the task, in order.
+	Steps []string `json:"steps,omitempty"`
+
+	// The conditions which the cluster must meet before the task may be
+	// invoked.
+	Preconditions []string `json:"preconditions,omitempty"`
+
+	// Whether invoking the task sets the customer maintenance signal.
+	CustomerImpacting bool `json:"customerImpacting"`
+}
@@ -0,0 +1,52 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+type maintenanceTaskConverter struct{}
+
+// maintenanceTaskConverter.ToExternal returns a new external representation
+// of the internal object.  ToExternal does not modify its argument; there is
+// no pointer aliasing between the passed and returned objects.
+func (maintenanceTaskConverter) ToExternal(d *api.MaintenanceTaskDefinition) interface{} {
+	out := &MaintenanceTaskDefinition{
+		Name:              MaintenanceTask(d.Name),
+		Version:           d.Version,
+		Description:       d.Description,
+		CustomerImpacting: d.CustomerImpacting,
+	}
+
+	if d.Steps != nil {
+		out.Steps = make([]string, 0, len(d.Steps))
+		for _, step := range d.Steps {
+			out.Steps = append(out.Steps, string(step))
+		}
+	}
+
+	if d.Preconditions != nil {
+		out.Preconditions = make([]string, 0, len(d.Preconditions))
+		for _, p := range d.Preconditions {
+			out.Preconditions = append(out.Preconditions, string(p))
+		}
+	}
+
+	return out
+}
+
+// ToExternalList returns a slice of external representations of the internal
+// objects
+func (c maintenanceTaskConverter) ToExternalList(defs []*api.MaintenanceTaskDefinition) interface{} {
+	l := &MaintenanceTaskList{
+		MaintenanceTasks: make([]*MaintenanceTaskDefinition, 0, len(defs)),
+	}
+
+	for _, d := range defs {
+		l.MaintenanceTasks = append(l.MaintenanceTasks, c.ToExternal(d).(*MaintenanceTaskDefinition))
+	}
+
+	return l
+}
@@ -48,16 +48,21 @@ type OpenShiftClusterProperties struct {
 	// WorkerProfiles is used to store the worker profile data that was sent in the api request
 	WorkerProfiles []WorkerProfile `json:"workerProfiles,omitempty"`
 	// WorkerProfilesStatus is used to store the enriched worker profile data
-	WorkerProfilesStatus            []WorkerProfile   `json:"workerProfilesStatus,omitempty" swagger:"readOnly"`
-	APIServerProfile                APIServerProfile  `json:"apiserverProfile,omitempty"`
-	IngressProfiles                 []IngressProfile  `json:"ingressProfiles,omitempty"`
-	Install                         *Install          `json:"install,omitempty"`
-	StorageSuffix                   string            `json:"storageSuffix,omitempty"`
-	RegistryProfiles                []RegistryProfile `json:"registryProfiles,omitempty"`
-	ImageRegistryStorageAccountName string            `json:"imageRegistryStorageAccountName,omitempty"`
-	InfraID                         string            `json:"infraId,omitempty"`
-	HiveProfile                     HiveProfile       `json:"hiveProfile,omitempty"`
-	MaintenanceState                MaintenanceState  `json:"maintenanceState,omitempty"`
+	WorkerProfilesStatus            []WorkerProfile           `json:"workerProfilesStatus,omitempty" swagger:"readOnly"`
+	APIServerProfile                APIServerProfile          `json:"apiserverProfile,omitempty"`
+	IngressProfiles                 []IngressProfile          `json:"ingressProfiles,omitempty"`
+	Install                         *Install                  `json:"install,omitempty"`
+	StorageSuffix                   string                    `json:"storageSuffix,omitempty"`
+	RegistryProfiles                []RegistryProfile         `json:"registryProfiles,omitempty"`
+	ImageRegistryStorageAccountName string                    `json:"imageRegistryStorageAccountName,omitempty"`
+	InfraID                         string                    `json:"infraId,omitempty"`
+	HiveProfile                     HiveProfile               `json:"hiveProfile,omitempty"`
+	MaintenanceState                MaintenanceState          `json:"maintenanceState,omitempty"`
+	MaintenanceWindowProfile        *MaintenanceWindowProfile `json:"maintenanceWindowProfile,omitempty"`
+	DeferredMaintenanceTask         MaintenanceTask           `json:"deferredMaintenanceTask,omitempty"`
+	RollingNodeOperation            *RollingNodeOperation     `json:"rollingNodeOperation,omitempty"`
+	EtcdSnapshotProfile             *EtcdSnapshotProfile      `json:"etcdSnapshotProfile,omitempty" mutable:"true"`
+	OperationProgress               *OperationProgress        `json:"operationProgress,omitempty"`
 }
 
 // ProvisioningState represents a provisioning state.
@@ -105,6 +110,28 @@ const (
 	MaintenanceTaskOperator   MaintenanceTask = "OperatorUpdate"
 	MaintenanceTaskRenewCerts MaintenanceTask = "CertificatesRenewal"
 
+	// Rolling node operation signal should only be set by the admin
+	// rollingnodeoperation endpoint, which also records the operation to run
+	MaintenanceTaskRollingNodeOperation MaintenanceTask = "RollingNodeOperation"
+
+	// ACR token rotation signal is set by the backend on the cluster's
+	// rotation schedule, and can be set by an admin to rotate the ACR token
+	// password immediately
+	MaintenanceTaskACRTokenRotation MaintenanceTask = "ACRTokenRotation"
+
+	// Etcd snapshot signal is set by the master monitor on the cluster's
+	// snapshot schedule, and can be set by an admin to take an etcd snapshot
+	// immediately
+	MaintenanceTaskEtcdSnapshot MaintenanceTask = "EtcdSnapshot"
+
+	// Targeted fix of the machine config server user data secrets
+	MaintenanceTaskFixMCSUserData MaintenanceTask = "FixMCSUserData"
+
+	// Customer certificate rotation signal is set by the master monitor when
+	// a new version of a customer supplied certificate secret is added, and
+	// can be set by an admin to serve the latest versions immediately
+	MaintenanceTaskCustomerCertificates MaintenanceTask = "CustomerCertificatesRotation"
+
 	//
 	// Maintenance tasks for updating customer maintenance signals
 	//
@@ -387,10 +414,11 @@ type WorkerProfile struct {
 
 // APIServerProfile represents an API server profile.
 type APIServerProfile struct {
-	Visibility Visibility `json:"visibility,omitempty"`
-	URL        string     `json:"url,omitempty"`
-	IP         string     `json:"ip,omitempty"`
-	IntIP      string     `json:"intIp,omitempty"`
+	Visibility          Visibility `json:"visibility,omitempty"`
+	URL                 string     `json:"url,omitempty"`
+	IP                  string     `json:"ip,omitempty"`
+	IntIP               string     `json:"intIp,omitempty"`
+	CertificateSecretID string     `json:"certificateSecretId,omitempty"`
 }
 
 // Visibility represents visibility.
@@ -404,9 +432,10 @@ const (
 
 // IngressProfile represents an ingress profile.
 type IngressProfile struct {
-	Name       string     `json:"name,omitempty"`
-	Visibility Visibility `json:"visibility,omitempty"`
-	IP         string     `json:"ip,omitempty"`
+	Name                string     `json:"name,omitempty"`
+	Visibility          Visibility `json:"visibility,omitempty"`
+	IP                  string     `json:"ip,omitempty"`
+	CertificateSecretID string     `json:"certificateSecretId,omitempty"`
 }
 
 // Install represents an install process.
@@ -424,10 +453,144 @@ const (
 	InstallPhaseRemoveBootstrap
 )
 
+// RollingNodeOperation represents an admin operation which is performed on a
+// set of nodes one node at a time.
+type RollingNodeOperation struct {
+	Action         RollingNodeOperationAction `json:"action,omitempty"`
+	VMSize         VMSize                     `json:"vmSize,omitempty"`
+	Nodes          []RollingNodeOperationNode `json:"nodes,omitempty"`
+	AbortRequested bool                       `json:"abortRequested,omitempty"`
+	StartTime      *time.Time                 `json:"startTime,omitempty"`
+	EndTime        *time.Time                 `json:"endTime,omitempty"`
+}
+
+// RollingNodeOperationAction represents the action of a rolling node operation.
+type RollingNodeOperationAction string
+
+// RollingNodeOperationAction constants.
+const (
+	RollingNodeOperationActionResize   RollingNodeOperationAction = "Resize"
+	RollingNodeOperationActionRedeploy RollingNodeOperationAction = "Redeploy"
+	RollingNodeOperationActionReboot   RollingNodeOperationAction = "Reboot"
+)
+
+// RollingNodeOperationNode represents the progress of a rolling node
+// operation on a node.
+type RollingNodeOperationNode struct {
+	Name    string                        `json:"name,omitempty"`
+	State   RollingNodeOperationNodeState `json:"state,omitempty"`
+	Message string                        `json:"message,omitempty"`
+}
+
+// RollingNodeOperationNodeState represents the state of a rolling node
+// operation on a node.
+type RollingNodeOperationNodeState string
+
+// RollingNodeOperationNodeState constants.
+const (
+	RollingNodeOperationNodeStatePending    RollingNodeOperationNodeState = "Pending"
+	RollingNodeOperationNodeStateInProgress RollingNodeOperationNodeState = "InProgress"
+	RollingNodeOperationNodeStateSucceeded  RollingNodeOperationNodeState = "Succeeded"
+	RollingNodeOperationNodeStateFailed     RollingNodeOperationNodeState = "Failed"
+	RollingNodeOperationNodeStateAborted    RollingNodeOperationNodeState = "Aborted"
+)
+
+// EtcdSnapshotProfile represents the etcd snapshot schedule of a cluster.
+// LastSnapshotName and LastSnapshotTime are set by the RP.
+type EtcdSnapshotProfile struct {
+	Interval         string     `json:"interval,omitempty"`
+	Retention        int        `json:"retention,omitempty"`
+	LastSnapshotName string     `json:"lastSnapshotName,omitempty"`
+	LastSnapshotTime *time.Time `json:"lastSnapshotTime,omitempty"`
+}
+
+// MaintenanceWindowProfile represents the times at which the customer allows
+// planned maintenance to start.  It is set by the customer.
+type MaintenanceWindowProfile struct {
+	TimeZone      string                `json:"timeZone,omitempty"`
+	Windows       []MaintenanceWindow   `json:"windows,omitempty"`
+	BlackoutDates []MaintenanceBlackout `json:"blackoutDates,omitempty"`
+}
+
+// MaintenanceWindow represents a weekly recurring maintenance window.
+type MaintenanceWindow struct {
+	DayOfWeek     string `json:"dayOfWeek,omitempty"`
+	StartTime     string `json:"startTime,omitempty"`
+	DurationHours int    `json:"durationHours,omitempty"`
+}
+
+// MaintenanceBlackout represents an inclusive range of dates during which
+// planned maintenance may not start.
+type MaintenanceBlackout struct {
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+}
+
+// OperationProgress represents the progress of the operation which the
+// backend is running on a cluster.
+type OperationProgress struct {
+	Phase           string     `json:"phase,omitempty"`
+	Step            string     `json:"step,omitempty"`
+	StepStartTime   *time.Time `json:"stepStartTime,omitempty"`
+	StepsCompleted  int        `json:"stepsCompleted,omitempty"`
+	StepsTotal      int        `json:"stepsTotal,omitempty"`
+	PercentComplete int        `json:"percentComplete,omitempty"`
+}
+
+// EtcdSnapshot represents an etcd snapshot stored in the cluster storage
+// account.
+type EtcdSnapshot struct {
+	Name         string    `json:"name,omitempty"`
+	CreationTime time.Time `json:"creationTime,omitempty"`
+	Size         int64     `json:"size,omitempty"`
+}
+
+// DiagnosticBundle represents an encrypted diagnostic bundle stored in the
+// cluster storage account.
+type DiagnosticBundle struct {
+	Name         string    `json:"name,omitempty"`
+	CreationTime time.Time `json:"creationTime,omitempty"`
+	Size         int64     `json:"size,omitempty"`
+}
+
+// DeletePreviewItem represents an object which is affected by deleting a
+// cluster.  Action is one of Delete, Modify or Retain; Modify and Retain
+// objects live outside the cluster resource group.
+type DeletePreviewItem struct {
+	Kind    string `json:"kind,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Action  string `json:"action,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// NSGFlowLogs represents the configuration of the NSG flow logs preview
+// feature of a cluster and the flow logs applied by the ARO operator.
+type NSGFlowLogs struct {
+	Enabled                                 bool               `json:"enabled"`
+	Version                                 int                `json:"version,omitempty"`
+	NetworkWatcherID                        string             `json:"networkWatcherID,omitempty"`
+	StorageAccountResourceID                string             `json:"storageAccountResourceId,omitempty"`
+	RetentionDays                           int32              `json:"retentionDays,omitempty"`
+	TrafficAnalyticsLogAnalyticsWorkspaceID string             `json:"trafficAnalyticsLogAnalyticsWorkspaceId,omitempty"`
+	TrafficAnalyticsInterval                string             `json:"trafficAnalyticsInterval,omitempty"`
+	FlowLogs                                []NSGFlowLogStatus `json:"flowLogs,omitempty"`
+}
+
+// NSGFlowLogStatus represents the flow log applied to a network security
+// group of a cluster.
+type NSGFlowLogStatus struct {
+	NetworkSecurityGroupID string `json:"networkSecurityGroupID,omitempty"`
+	FlowLogID              string `json:"flowLogID,omitempty"`
+	Enabled                bool   `json:"enabled"`
+	Error                  string `json:"error,omitempty"`
+}
+
 // RegistryProfile represents a registry profile
 type RegistryProfile struct {
-	Name     string `json:"name,omitempty"`
-	Username string `json:"username,omitempty"`
+	Name                 string     `json:"name,omitempty"`
+	Username             string     `json:"username,omitempty"`
+	PasswordCreationTime *time.Time `json:"passwordCreationTime,omitempty"`
 }
 
 // ArchitectureVersion represents an architecture version
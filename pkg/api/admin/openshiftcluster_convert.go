@@ -32,6 +32,7 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 			CreatedBy:               oc.Properties.CreatedBy,
 			ProvisionedBy:           oc.Properties.ProvisionedBy,
 			MaintenanceState:        MaintenanceState(oc.Properties.MaintenanceState),
+			DeferredMaintenanceTask: MaintenanceTask(oc.Properties.DeferredMaintenanceTask),
 			ClusterProfile: ClusterProfile{
 				Domain:               oc.Properties.ClusterProfile.Domain,
 				Version:              oc.Properties.ClusterProfile.Version,
@@ -65,10 +66,11 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				DiskEncryptionSetID: oc.Properties.MasterProfile.DiskEncryptionSetID,
 			},
 			APIServerProfile: APIServerProfile{
-				Visibility: Visibility(oc.Properties.APIServerProfile.Visibility),
-				URL:        oc.Properties.APIServerProfile.URL,
-				IP:         oc.Properties.APIServerProfile.IP,
-				IntIP:      oc.Properties.APIServerProfile.IntIP,
+				Visibility:          Visibility(oc.Properties.APIServerProfile.Visibility),
+				URL:                 oc.Properties.APIServerProfile.URL,
+				IP:                  oc.Properties.APIServerProfile.IP,
+				IntIP:               oc.Properties.APIServerProfile.IntIP,
+				CertificateSecretID: oc.Properties.APIServerProfile.CertificateSecretID,
 			},
 			StorageSuffix:                   oc.Properties.StorageSuffix,
 			ImageRegistryStorageAccountName: oc.Properties.ImageRegistryStorageAccountName,
@@ -151,9 +153,10 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.IngressProfiles = make([]IngressProfile, 0, len(oc.Properties.IngressProfiles))
 		for _, p := range oc.Properties.IngressProfiles {
 			out.Properties.IngressProfiles = append(out.Properties.IngressProfiles, IngressProfile{
-				Name:       p.Name,
-				Visibility: Visibility(p.Visibility),
-				IP:         p.IP,
+				Name:                p.Name,
+				Visibility:          Visibility(p.Visibility),
+				IP:                  p.IP,
+				CertificateSecretID: p.CertificateSecretID,
 			})
 		}
 	}
@@ -177,6 +180,7 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		for i, v := range oc.Properties.RegistryProfiles {
 			out.Properties.RegistryProfiles[i].Name = v.Name
 			out.Properties.RegistryProfiles[i].Username = v.Username
+			out.Properties.RegistryProfiles[i].PasswordCreationTime = v.PasswordCreationTime
 		}
 	}
 
@@ -185,6 +189,88 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		CreatedByHive: oc.Properties.HiveProfile.CreatedByHive,
 	}
 
+	if oc.Properties.RollingNodeOperation != nil {
+		out.Properties.RollingNodeOperation = rollingNodeOperationToExternal(oc.Properties.RollingNodeOperation)
+	}
+
+	if oc.Properties.EtcdSnapshotProfile != nil {
+		out.Properties.EtcdSnapshotProfile = &EtcdSnapshotProfile{
+			Interval:         oc.Properties.EtcdSnapshotProfile.Interval,
+			Retention:        oc.Properties.EtcdSnapshotProfile.Retention,
+			LastSnapshotName: oc.Properties.EtcdSnapshotProfile.LastSnapshotName,
+		}
+		if oc.Properties.EtcdSnapshotProfile.LastSnapshotTime != nil {
+			t := *oc.Properties.EtcdSnapshotProfile.LastSnapshotTime
+			out.Properties.EtcdSnapshotProfile.LastSnapshotTime = &t
+		}
+	}
+
+	if oc.Properties.MaintenanceWindowProfile != nil {
+		out.Properties.MaintenanceWindowProfile = &MaintenanceWindowProfile{
+			TimeZone: oc.Properties.MaintenanceWindowProfile.TimeZone,
+		}
+		for _, w := range oc.Properties.MaintenanceWindowProfile.Windows {
+			out.Properties.MaintenanceWindowProfile.Windows = append(out.Properties.MaintenanceWindowProfile.Windows, MaintenanceWindow{
+				DayOfWeek:     w.DayOfWeek,
+				StartTime:     w.StartTime,
+				DurationHours: w.DurationHours,
+			})
+		}
+		for _, b := range oc.Properties.MaintenanceWindowProfile.BlackoutDates {
+			out.Properties.MaintenanceWindowProfile.BlackoutDates = append(out.Properties.MaintenanceWindowProfile.BlackoutDates, MaintenanceBlackout{
+				StartDate: b.StartDate,
+				EndDate:   b.EndDate,
+			})
+		}
+	}
+
+	if oc.Properties.OperationProgress != nil {
+		out.Properties.OperationProgress = &OperationProgress{
+			Phase:           oc.Properties.OperationProgress.Phase,
+			Step:            oc.Properties.OperationProgress.Step,
+			StepsCompleted:  oc.Properties.OperationProgress.StepsCompleted,
+			StepsTotal:      oc.Properties.OperationProgress.StepsTotal,
+			PercentComplete: oc.Properties.OperationProgress.PercentComplete,
+		}
+		if oc.Properties.OperationProgress.StepStartTime != nil {
+			t := *oc.Properties.OperationProgress.StepStartTime
+			out.Properties.OperationProgress.StepStartTime = &t
+		}
+	}
+
+	return out
+}
+
+// rollingNodeOperationToExternal returns a new external representation of the
+// internal rolling node operation
+func rollingNodeOperationToExternal(o *api.RollingNodeOperation) *RollingNodeOperation {
+	out := &RollingNodeOperation{
+		Action:         RollingNodeOperationAction(o.Action),
+		VMSize:         VMSize(o.VMSize),
+		AbortRequested: o.AbortRequested,
+	}
+
+	if o.Nodes != nil {
+		out.Nodes = make([]RollingNodeOperationNode, 0, len(o.Nodes))
+		for _, n := range o.Nodes {
+			out.Nodes = append(out.Nodes, RollingNodeOperationNode{
+				Name:    n.Name,
+				State:   RollingNodeOperationNodeState(n.State),
+				Message: n.Message,
+			})
+		}
+	}
+
+	if o.StartTime != nil {
+		t := *o.StartTime
+		out.StartTime = &t
+	}
+
+	if o.EndTime != nil {
+		t := *o.EndTime
+		out.EndTime = &t
+	}
+
 	return out
 }
 
@@ -328,6 +414,7 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.APIServerProfile.URL = oc.Properties.APIServerProfile.URL
 	out.Properties.APIServerProfile.IP = oc.Properties.APIServerProfile.IP
 	out.Properties.APIServerProfile.IntIP = oc.Properties.APIServerProfile.IntIP
+	out.Properties.APIServerProfile.CertificateSecretID = oc.Properties.APIServerProfile.CertificateSecretID
 	out.Properties.IngressProfiles = nil
 	if oc.Properties.IngressProfiles != nil {
 		out.Properties.IngressProfiles = make([]api.IngressProfile, len(oc.Properties.IngressProfiles))
@@ -335,9 +422,47 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.IngressProfiles[i].Name = oc.Properties.IngressProfiles[i].Name
 			out.Properties.IngressProfiles[i].Visibility = api.Visibility(oc.Properties.IngressProfiles[i].Visibility)
 			out.Properties.IngressProfiles[i].IP = oc.Properties.IngressProfiles[i].IP
+			out.Properties.IngressProfiles[i].CertificateSecretID = oc.Properties.IngressProfiles[i].CertificateSecretID
 		}
 	}
 
+	out.Properties.RollingNodeOperation = nil
+	if oc.Properties.RollingNodeOperation != nil {
+		out.Properties.RollingNodeOperation = &api.RollingNodeOperation{
+			Action:         api.RollingNodeOperationAction(oc.Properties.RollingNodeOperation.Action),
+			VMSize:         api.VMSize(oc.Properties.RollingNodeOperation.VMSize),
+			AbortRequested: oc.Properties.RollingNodeOperation.AbortRequested,
+		}
+		if oc.Properties.RollingNodeOperation.Nodes != nil {
+			out.Properties.RollingNodeOperation.Nodes = make([]api.RollingNodeOperationNode, 0, len(oc.Properties.RollingNodeOperation.Nodes))
+			for _, n := range oc.Properties.RollingNodeOperation.Nodes {
+				out.Properties.RollingNodeOperation.Nodes = append(out.Properties.RollingNodeOperation.Nodes, api.RollingNodeOperationNode{
+					Name:    n.Name,
+					State:   api.RollingNodeOperationNodeState(n.State),
+					Message: n.Message,
+				})
+			}
+		}
+		if oc.Properties.RollingNodeOperation.StartTime != nil {
+			t := *oc.Properties.RollingNodeOperation.StartTime
+			out.Properties.RollingNodeOperation.StartTime = &t
+		}
+		if oc.Properties.RollingNodeOperation.EndTime != nil {
+			t := *oc.Properties.RollingNodeOperation.EndTime
+			out.Properties.RollingNodeOperation.EndTime = &t
+		}
+	}
+	// Only the schedule is converted: the encryption key and the record of the
+	// last snapshot are maintained by the RP, and removing the profile would
+	// lose the key needed to restore existing snapshots.  Scheduled snapshots
+	// are disabled by clearing the interval instead.
+	if oc.Properties.EtcdSnapshotProfile != nil {
+		if out.Properties.EtcdSnapshotProfile == nil {
+			out.Properties.EtcdSnapshotProfile = &api.EtcdSnapshotProfile{}
+		}
+		out.Properties.EtcdSnapshotProfile.Interval = oc.Properties.EtcdSnapshotProfile.Interval
+		out.Properties.EtcdSnapshotProfile.Retention = oc.Properties.EtcdSnapshotProfile.Retention
+	}
 	out.Properties.Install = nil
 	if oc.Properties.Install != nil {
 		out.Properties.Install = &api.Install{
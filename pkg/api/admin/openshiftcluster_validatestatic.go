@@ -5,6 +5,7 @@ package admin
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/util/immutable"
@@ -29,14 +30,43 @@ func (sv openShiftClusterStaticValidator) validateDelta(oc, current *OpenShiftCl
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
 	}
 
+	// A rolling node operation is requested via the rollingnodeoperation
+	// endpoint.  An admin update with an unchanged maintenance task resumes it.
+	if oc.Properties.MaintenanceTask == MaintenanceTaskRollingNodeOperation &&
+		current.Properties.MaintenanceTask != MaintenanceTaskRollingNodeOperation {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTask", "Rolling node operations must be requested via the rollingnodeoperation endpoint.")
+	}
+
+	err = validateEtcdSnapshotProfile(oc.Properties.EtcdSnapshotProfile)
+	if err != nil {
+		return err
+	}
+
 	return validateMaintenanceTask(oc.Properties.MaintenanceTask)
 }
 
+func validateEtcdSnapshotProfile(p *EtcdSnapshotProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if p.Interval != "" {
+		d, err := time.ParseDuration(p.Interval)
+		if err != nil || d < time.Hour {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.etcdSnapshotProfile.interval", "The provided interval '%s' is invalid: it must be a duration of at least 1h.", p.Interval)
+		}
+	}
+
+	if p.Retention < 0 || p.Retention > 100 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.etcdSnapshotProfile.retention", "The provided retention '%d' is invalid: it must be between 1 and 100.", p.Retention)
+	}
+
+	return nil
+}
+
 func validateMaintenanceTask(task MaintenanceTask) error {
 	if !(task == "" ||
-		task == MaintenanceTaskEverything ||
-		task == MaintenanceTaskOperator ||
-		task == MaintenanceTaskRenewCerts ||
+		api.GetMaintenanceTaskDefinition(api.MaintenanceTask(task)) != nil ||
 		task == MaintenanceTaskPending ||
 		task == MaintenanceTaskNone ||
 		task == MaintenanceTaskCustomerActionNeeded) {
@@ -663,6 +663,19 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 				oc.Properties.MaintenanceTask = MaintenanceTaskOperator
 			},
 		},
+		{
+			name: "maintenanceTask change to a registered targeted task is allowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{
+					Properties: OpenShiftClusterProperties{
+						MaintenanceTask: "",
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceTask = MaintenanceTaskFixMCSUserData
+			},
+		},
 		{
 			name: "maintenanceTask change to blank allowed",
 			oc: func() *OpenShiftCluster {
@@ -715,6 +728,101 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 				oc.Properties.MaintenanceTask = ""
 			},
 		},
+		{
+			name: "maintenanceTask change to rolling node operation is disallowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{
+					Properties: OpenShiftClusterProperties{
+						MaintenanceTask: "",
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceTask = MaintenanceTaskRollingNodeOperation
+			},
+			wantErr: "400: InvalidParameter: properties.maintenanceTask: Rolling node operations must be requested via the rollingnodeoperation endpoint.",
+		},
+		{
+			name: "maintenanceTask unchanged rolling node operation allowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{
+					Properties: OpenShiftClusterProperties{
+						MaintenanceTask: MaintenanceTaskRollingNodeOperation,
+						RollingNodeOperation: &RollingNodeOperation{
+							Action: RollingNodeOperationActionReboot,
+							Nodes: []RollingNodeOperationNode{
+								{Name: "node", State: RollingNodeOperationNodeStateAborted},
+							},
+						},
+					},
+				}
+			},
+		},
+		{
+			name: "rollingNodeOperation change is disallowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{
+					Properties: OpenShiftClusterProperties{
+						RollingNodeOperation: &RollingNodeOperation{
+							Action: RollingNodeOperationActionReboot,
+						},
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.RollingNodeOperation.Action = RollingNodeOperationActionRedeploy
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.rollingNodeOperation.action: Changing property 'properties.rollingNodeOperation.action' is not allowed.",
+		},
+		{
+			name: "maintenanceTask change to etcd snapshot allowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{
+					Properties: OpenShiftClusterProperties{
+						MaintenanceTask: "",
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceTask = MaintenanceTaskEtcdSnapshot
+			},
+		},
+		{
+			name: "etcdSnapshotProfile change allowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EtcdSnapshotProfile = &EtcdSnapshotProfile{
+					Interval:  "24h",
+					Retention: 7,
+				}
+			},
+		},
+		{
+			name: "etcdSnapshotProfile with short interval is disallowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EtcdSnapshotProfile = &EtcdSnapshotProfile{
+					Interval: "10m",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.etcdSnapshotProfile.interval: The provided interval '10m' is invalid: it must be a duration of at least 1h.",
+		},
+		{
+			name: "etcdSnapshotProfile with invalid retention is disallowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EtcdSnapshotProfile = &EtcdSnapshotProfile{
+					Retention: -1,
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.etcdSnapshotProfile.retention: The provided retention '-1' is invalid: it must be between 1 and 100.",
+		},
 		{
 			name: "maintenanceTask change to other values is disallowed",
 			oc: func() *OpenShiftCluster {
@@ -16,5 +16,6 @@ func init() {
 		OpenShiftClusterStaticValidator: openShiftClusterStaticValidator{},
 		OpenShiftVersionConverter:       openShiftVersionConverter{},
 		OpenShiftVersionStaticValidator: openShiftVersionStaticValidator{},
+		MaintenanceTaskConverter:        maintenanceTaskConverter{},
 	}
 }
@@ -20,5 +20,14 @@ type AsyncOperation struct {
 	StartTime time.Time  `json:"startTime,omitempty" deep:"-"`
 	EndTime   *time.Time `json:"endTime,omitempty" deep:"-"`
 
+	PercentComplete int                       `json:"percentComplete,omitempty"`
+	Properties      *AsyncOperationProperties `json:"properties,omitempty"`
+
 	Error *CloudErrorBody `json:"error,omitempty"`
 }
+
+// AsyncOperationProperties represents the progress of an asyncOperation
+type AsyncOperationProperties struct {
+	Phase string `json:"phase,omitempty"`
+	Step  string `json:"step,omitempty"`
+}
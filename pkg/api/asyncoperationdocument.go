@@ -3,6 +3,10 @@ package api
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import (
+	"time"
+)
+
 // AsyncOperationDocuments represents asyncOperation documents.
 // pkg/database/cosmosdb requires its definition.
 type AsyncOperationDocuments struct {
@@ -33,6 +37,32 @@ type AsyncOperationDocument struct {
 
 	OpenShiftClusterKey string            `json:"openShiftClusterKey,omitempty"`
 	OpenShiftCluster    *OpenShiftCluster `json:"openShiftCluster,omitempty"`
+
+	// DeleteReport is recorded by the backend when a cluster is deleted
+	DeleteReport *DeleteReport `json:"deleteReport,omitempty"`
+}
+
+// DeleteReport records the objects which were left behind after a cluster was
+// deleted
+type DeleteReport struct {
+	MissingFields
+
+	Time       time.Time          `json:"time,omitempty"`
+	LeftBehind []DeleteReportItem `json:"leftBehind,omitempty"`
+
+	// Errors are the checks which could not be completed
+	Errors []string `json:"errors,omitempty"`
+}
+
+// DeleteReportItem is an object which was left behind after a cluster was
+// deleted
+type DeleteReportItem struct {
+	MissingFields
+
+	Kind    string `json:"kind,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 func (c *AsyncOperationDocument) String() string {
@@ -103,6 +103,8 @@ const (
 	CloudErrorCodeScopeLocked                        = "ScopeLocked"
 	CloudErrorCodeRequestDisallowedByPolicy          = "RequestDisallowedByPolicy"
 	CloudErrorCodeInvalidNetworkAddress              = "InvalidNetworkAddress"
+	CloudErrorCodePreconditionFailed                 = "PreconditionFailed"
+	CloudErrorCodeTooManyRequests                    = "TooManyRequests"
 )
 
 // NewCloudError returns a new CloudError
@@ -0,0 +1,199 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// MaintenanceTaskStep names a group of steps run by a maintenance task.  The
+// steps of each group are implemented by the cluster manager.
+type MaintenanceTaskStep string
+
+const (
+	// Generic fix-up or setup actions that are fairly safe to always take,
+	// and don't require a running cluster.  Must be first.
+	MaintenanceTaskStepPrepare MaintenanceTaskStep = "Prepare"
+
+	MaintenanceTaskStepAzureResources         MaintenanceTaskStep = "AzureResources"
+	MaintenanceTaskStepDatabaseIntIP          MaintenanceTaskStep = "DatabaseIntIP"
+	MaintenanceTaskStepStartCluster           MaintenanceTaskStep = "StartCluster"
+	MaintenanceTaskStepClusterAccess          MaintenanceTaskStep = "ClusterAccess"
+	MaintenanceTaskStepMCSCertificate         MaintenanceTaskStep = "MCSCertificate"
+	MaintenanceTaskStepMCSUserData            MaintenanceTaskStep = "MCSUserData"
+	MaintenanceTaskStepGatewayUpgrade         MaintenanceTaskStep = "GatewayUpgrade"
+	MaintenanceTaskStepACRToken               MaintenanceTaskStep = "ACRToken"
+	MaintenanceTaskStepCertificates           MaintenanceTaskStep = "Certificates"
+	MaintenanceTaskStepRegistryStorageAccount MaintenanceTaskStep = "RegistryStorageAccount"
+	MaintenanceTaskStepMTUSize                MaintenanceTaskStep = "MTUSize"
+	MaintenanceTaskStepOperatorDeployer       MaintenanceTaskStep = "OperatorDeployer"
+	MaintenanceTaskStepMDSDCertificate        MaintenanceTaskStep = "MDSDCertificate"
+	MaintenanceTaskStepOperator               MaintenanceTaskStep = "Operator"
+	MaintenanceTaskStepRollingNodeOperation   MaintenanceTaskStep = "RollingNodeOperation"
+	MaintenanceTaskStepEtcdSnapshot           MaintenanceTaskStep = "EtcdSnapshot"
+	MaintenanceTaskStepHiveAdoption           MaintenanceTaskStep = "HiveAdoption"
+
+	// Records the RP version which last admin updated the cluster.  PUCM
+	// scripts rely on this to determine if the cluster has been fully admin
+	// updated, so only the Everything task may run it, last.
+	MaintenanceTaskStepProvisionedBy MaintenanceTaskStep = "ProvisionedBy"
+)
+
+// MaintenanceTaskPrecondition names a condition which the cluster must meet
+// before a maintenance task may be invoked on it
+type MaintenanceTaskPrecondition string
+
+const (
+	// The cluster version must be supported by the ARO operator
+	MaintenanceTaskPreconditionOperatorSupported MaintenanceTaskPrecondition = "OperatorSupported"
+
+	// A rolling node operation must have been requested and not have finished
+	MaintenanceTaskPreconditionRollingNodeOperationRequested MaintenanceTaskPrecondition = "RollingNodeOperationRequested"
+)
+
+// MaintenanceTaskDefinition describes a maintenance task which can be run on a
+// cluster by an admin update
+type MaintenanceTaskDefinition struct {
+	Name MaintenanceTask
+
+	// Version is increased whenever the steps, preconditions or impact of the
+	// task change, so that callers can tell which revision of a task they
+	// invoked and pin the revision they expect
+	Version int
+
+	Description string
+
+	// Steps lists the step groups run by the task, in order
+	Steps []MaintenanceTaskStep
+
+	// Preconditions are checked when the task is invoked by name
+	Preconditions []MaintenanceTaskPrecondition
+
+	// CustomerImpacting tasks set the planned or unplanned maintenance signal
+	// when they are invoked
+	CustomerImpacting bool
+}
+
+// MaintenanceTaskDefinitions lists the registered maintenance tasks.  To add a
+// targeted fix, register a new task here which runs the relevant step groups
+// rather than extending Everything.
+var MaintenanceTaskDefinitions = []*MaintenanceTaskDefinition{
+	{
+		Name:        MaintenanceTaskEverything,
+		Version:     1,
+		Description: "Reconciles all RP-managed Azure and cluster resources and updates the ARO operator.",
+		Steps: []MaintenanceTaskStep{
+			MaintenanceTaskStepPrepare,
+			MaintenanceTaskStepAzureResources,
+			MaintenanceTaskStepDatabaseIntIP,
+			MaintenanceTaskStepStartCluster,
+			MaintenanceTaskStepClusterAccess,
+			MaintenanceTaskStepMCSCertificate,
+			MaintenanceTaskStepMCSUserData,
+			MaintenanceTaskStepGatewayUpgrade,
+			MaintenanceTaskStepACRToken,
+			MaintenanceTaskStepCertificates,
+			MaintenanceTaskStepRegistryStorageAccount,
+			MaintenanceTaskStepMTUSize,
+			MaintenanceTaskStepOperatorDeployer,
+			MaintenanceTaskStepOperator,
+			MaintenanceTaskStepHiveAdoption,
+			MaintenanceTaskStepProvisionedBy,
+		},
+		CustomerImpacting: true,
+	},
+	{
+		Name:        MaintenanceTaskOperator,
+		Version:     1,
+		Description: "Updates the ARO operator.",
+		Steps: []MaintenanceTaskStep{
+			MaintenanceTaskStepPrepare,
+			MaintenanceTaskStepStartCluster,
+			MaintenanceTaskStepOperatorDeployer,
+			MaintenanceTaskStepOperator,
+		},
+		Preconditions: []MaintenanceTaskPrecondition{
+			MaintenanceTaskPreconditionOperatorSupported,
+		},
+		CustomerImpacting: true,
+	},
+	{
+		Name:        MaintenanceTaskRenewCerts,
+		Version:     1,
+		Description: "Renews the machine config server, API server, ingress and MDSD certificates.",
+		Steps: []MaintenanceTaskStep{
+			MaintenanceTaskStepPrepare,
+			MaintenanceTaskStepDatabaseIntIP,
+			MaintenanceTaskStepStartCluster,
+			MaintenanceTaskStepMCSCertificate,
+			MaintenanceTaskStepMCSUserData,
+			MaintenanceTaskStepCertificates,
+			MaintenanceTaskStepOperatorDeployer,
+			MaintenanceTaskStepMDSDCertificate,
+		},
+		CustomerImpacting: true,
+	},
+	{
+		Name:        MaintenanceTaskRollingNodeOperation,
+		Version:     1,
+		Description: "Runs the requested rolling node operation.",
+		Steps: []MaintenanceTaskStep{
+			MaintenanceTaskStepPrepare,
+			MaintenanceTaskStepStartCluster,
+			MaintenanceTaskStepRollingNodeOperation,
+		},
+		Preconditions: []MaintenanceTaskPrecondition{
+			MaintenanceTaskPreconditionRollingNodeOperationRequested,
+		},
+		CustomerImpacting: true,
+	},
+	{
+		Name:        MaintenanceTaskACRTokenRotation,
+		Version:     1,
+		Description: "Rotates the ACR token password used to pull images.",
+		Steps: []MaintenanceTaskStep{
+			MaintenanceTaskStepPrepare,
+			MaintenanceTaskStepStartCluster,
+			MaintenanceTaskStepACRToken,
+		},
+	},
+	{
+		Name:        MaintenanceTaskEtcdSnapshot,
+		Version:     1,
+		Description: "Takes an etcd snapshot.",
+		Steps: []MaintenanceTaskStep{
+			MaintenanceTaskStepPrepare,
+			MaintenanceTaskStepStartCluster,
+			MaintenanceTaskStepEtcdSnapshot,
+		},
+	},
+	{
+		Name:        MaintenanceTaskCustomerCertificates,
+		Version:     1,
+		Description: "Serves the latest versions of the customer supplied API server and ingress certificates.",
+		Steps: []MaintenanceTaskStep{
+			MaintenanceTaskStepPrepare,
+			MaintenanceTaskStepStartCluster,
+			MaintenanceTaskStepCertificates,
+		},
+	},
+	{
+		Name:        MaintenanceTaskFixMCSUserData,
+		Version:     1,
+		Description: "Fixes the machine config server user data secrets used by the machine API.",
+		Steps: []MaintenanceTaskStep{
+			MaintenanceTaskStepPrepare,
+			MaintenanceTaskStepStartCluster,
+			MaintenanceTaskStepMCSUserData,
+		},
+	},
+}
+
+// GetMaintenanceTaskDefinition returns the registered maintenance task with
+// the given name, or nil
+func GetMaintenanceTaskDefinition(t MaintenanceTask) *MaintenanceTaskDefinition {
+	for _, d := range MaintenanceTaskDefinitions {
+		if d.Name == t {
+			return d
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestMaintenanceTaskDefinitions(t *testing.T) {
+	names := map[MaintenanceTask]bool{}
+
+	for _, def := range MaintenanceTaskDefinitions {
+		if names[def.Name] {
+			t.Errorf("task %s: registered more than once", def.Name)
+		}
+		names[def.Name] = true
+
+		if def.Description == "" {
+			t.Errorf("task %s: no description", def.Name)
+		}
+
+		if len(def.Steps) == 0 || def.Steps[0] != MaintenanceTaskStepPrepare {
+			t.Errorf("task %s: must start with %s", def.Name, MaintenanceTaskStepPrepare)
+		}
+
+		for i, step := range def.Steps {
+			if step == MaintenanceTaskStepProvisionedBy && (def.Name != MaintenanceTaskEverything || i != len(def.Steps)-1) {
+				t.Errorf("task %s: %s may only run last in %s", def.Name, step, MaintenanceTaskEverything)
+			}
+		}
+
+		if def.Version < 1 {
+			t.Errorf("task %s: version must be at least 1", def.Name)
+		}
+
+		if def.Name.IsMaintenanceOngoingTask() != def.CustomerImpacting {
+			t.Errorf("task %s: ongoing maintenance task must match customer impacting", def.Name)
+		}
+	}
+
+	for _, task := range []MaintenanceTask{
+		MaintenanceTaskPending,
+		MaintenanceTaskNone,
+		MaintenanceTaskCustomerActionNeeded,
+	} {
+		if GetMaintenanceTaskDefinition(task) != nil {
+			t.Errorf("signal %s: must not be registered as a task", task)
+		}
+
+		if task.IsMaintenanceOngoingTask() {
+			t.Errorf("signal %s: must not be an ongoing maintenance task", task)
+		}
+	}
+}
@@ -163,6 +163,34 @@ type OpenShiftClusterProperties struct {
 	HiveProfile HiveProfile `json:"hiveProfile,omitempty"`
 
 	MaintenanceState MaintenanceState `json:"maintenanceState,omitempty"`
+
+	// MaintenanceWindowProfile restricts when planned maintenance may run
+	MaintenanceWindowProfile *MaintenanceWindowProfile `json:"maintenanceWindowProfile,omitempty"`
+
+	// DeferredMaintenanceTask is planned maintenance which was requested
+	// outside the customer's maintenance windows.  It is queued as an admin
+	// update when a window next opens.
+	DeferredMaintenanceTask MaintenanceTask `json:"deferredMaintenanceTask,omitempty"`
+
+	// RollingNodeOperation is non-nil once a rolling node operation has been
+	// requested and records its progress
+	RollingNodeOperation *RollingNodeOperation `json:"rollingNodeOperation,omitempty"`
+
+	// EtcdSnapshotProfile configures the scheduled etcd snapshots of the
+	// cluster and records the last snapshot taken
+	EtcdSnapshotProfile *EtcdSnapshotProfile `json:"etcdSnapshotProfile,omitempty"`
+
+	// APIServerCertificateSecretVersion and IngressCertificateSecretVersion
+	// are the versions of the customer supplied certificates which the
+	// cluster serves.  The master monitor compares them with the latest
+	// versions of the secrets to rotate the certificates.
+	APIServerCertificateSecretVersion string `json:"apiServerCertificateSecretVersion,omitempty"`
+	IngressCertificateSecretVersion   string `json:"ingressCertificateSecretVersion,omitempty"`
+
+	// OperationProgress records the progress of the operation which the
+	// backend is running on the cluster.  It is cleared when the operation
+	// succeeds and left in place when it fails.
+	OperationProgress *OperationProgress `json:"operationProgress,omitempty"`
 }
 
 // ProvisioningState represents a provisioning state
@@ -192,6 +220,41 @@ const (
 	MaintenanceStateCustomerActionNeeded MaintenanceState = "CustomerActionNeeded"
 )
 
+// MaintenanceWindowProfile represents the times at which the customer allows
+// planned maintenance to start.  Unplanned maintenance is not restricted.
+type MaintenanceWindowProfile struct {
+	MissingFields
+
+	// TimeZone is the IANA time zone, e.g. "Europe/London", in which the
+	// windows and blackout dates are interpreted
+	TimeZone string `json:"timeZone,omitempty"`
+
+	Windows       []MaintenanceWindow   `json:"windows,omitempty"`
+	BlackoutDates []MaintenanceBlackout `json:"blackoutDates,omitempty"`
+}
+
+// MaintenanceWindow represents a weekly recurring maintenance window
+type MaintenanceWindow struct {
+	MissingFields
+
+	// DayOfWeek is the day on which the window starts, e.g. "Saturday"
+	DayOfWeek string `json:"dayOfWeek,omitempty"`
+
+	// StartTime is the time at which the window starts, as HH:MM
+	StartTime string `json:"startTime,omitempty"`
+
+	DurationHours int `json:"durationHours,omitempty"`
+}
+
+// MaintenanceBlackout represents a range of dates, as YYYY-MM-DD, during
+// which planned maintenance may not start.  Both dates are inclusive.
+type MaintenanceBlackout struct {
+	MissingFields
+
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+}
+
 type MaintenanceTask string
 
 const (
@@ -203,6 +266,29 @@ const (
 	MaintenanceTaskOperator   MaintenanceTask = "OperatorUpdate"
 	MaintenanceTaskRenewCerts MaintenanceTask = "CertificatesRenewal"
 
+	// Rolling node operation signal should only be set by the admin
+	// rollingnodeoperation endpoint, which also records the operation to run
+	MaintenanceTaskRollingNodeOperation MaintenanceTask = "RollingNodeOperation"
+
+	// ACR token rotation signal is set by the backend when the cluster's ACR
+	// token password is due to be rotated, or by an admin to rotate it
+	// immediately
+	MaintenanceTaskACRTokenRotation MaintenanceTask = "ACRTokenRotation"
+
+	// Etcd snapshot signal is set by the master monitor when the cluster's next
+	// scheduled etcd snapshot is due, or by an admin to take a snapshot
+	// immediately
+	MaintenanceTaskEtcdSnapshot MaintenanceTask = "EtcdSnapshot"
+
+	// Targeted fix of the machine config server user data secrets, without
+	// the rest of an Everything admin update
+	MaintenanceTaskFixMCSUserData MaintenanceTask = "FixMCSUserData"
+
+	// Customer certificate rotation signal is set by the master monitor when
+	// a new version of a customer supplied certificate secret is added, or by
+	// an admin to serve the latest versions immediately
+	MaintenanceTaskCustomerCertificates MaintenanceTask = "CustomerCertificatesRotation"
+
 	//
 	// Maintenance tasks for updating customer maintenance signals
 	//
@@ -220,11 +306,12 @@ const (
 
 // IsMaintenanceOngoingTask returns true if the maintenance task should change state to maintenance ongoing (planned/unplanned)
 func (t MaintenanceTask) IsMaintenanceOngoingTask() bool {
-	result := (t == MaintenanceTaskEverything) ||
-		(t == MaintenanceTaskOperator) ||
-		(t == MaintenanceTaskRenewCerts) ||
-		(t == "")
-	return result
+	if t == "" {
+		return true
+	}
+
+	def := GetMaintenanceTaskDefinition(t)
+	return def != nil && def.CustomerImpacting
 }
 
 // Cluster-scoped flags
@@ -693,6 +780,11 @@ type APIServerProfile struct {
 	URL        string     `json:"url,omitempty"`
 	IP         string     `json:"ip,omitempty"`
 	IntIP      string     `json:"intIp,omitempty"`
+
+	// CertificateSecretID is the Key Vault secret identifier of a customer
+	// supplied serving certificate.  If it has no version, the latest version
+	// of the secret is used.
+	CertificateSecretID string `json:"certificateSecretId,omitempty"`
 }
 
 // Visibility represents visibility.
@@ -711,6 +803,10 @@ type IngressProfile struct {
 	Name       string     `json:"name,omitempty"`
 	Visibility Visibility `json:"visibility,omitempty"`
 	IP         string     `json:"ip,omitempty"`
+
+	// CertificateSecretID is the Key Vault secret identifier of a customer
+	// supplied default ingress certificate
+	CertificateSecretID string `json:"certificateSecretId,omitempty"`
 }
 
 // RegistryProfile represents a registry's login
@@ -720,6 +816,10 @@ type RegistryProfile struct {
 	Name     string       `json:"name,omitempty"`
 	Username string       `json:"username,omitempty"`
 	Password SecureString `json:"password,omitempty"`
+
+	// PasswordCreationTime is the time at which Password was generated.  It
+	// is used to schedule the rotation of the password
+	PasswordCreationTime *time.Time `json:"passwordCreationTime,omitempty"`
 }
 
 // Install represents an install process
@@ -739,6 +839,100 @@ const (
 	InstallPhaseRemoveBootstrap
 )
 
+// RollingNodeOperation represents an admin operation which is performed on a
+// set of nodes one node at a time
+type RollingNodeOperation struct {
+	MissingFields
+
+	Action RollingNodeOperationAction `json:"action,omitempty"`
+	VMSize VMSize                     `json:"vmSize,omitempty"`
+	Nodes  []RollingNodeOperationNode `json:"nodes,omitempty"`
+
+	// AbortRequested is set by the admin API to stop the operation before it
+	// starts on the next node
+	AbortRequested bool `json:"abortRequested,omitempty"`
+
+	StartTime *time.Time `json:"startTime,omitempty"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
+
+// RollingNodeOperationAction represents the action of a rolling node operation
+type RollingNodeOperationAction string
+
+// RollingNodeOperationAction constants
+const (
+	RollingNodeOperationActionResize   RollingNodeOperationAction = "Resize"
+	RollingNodeOperationActionRedeploy RollingNodeOperationAction = "Redeploy"
+	RollingNodeOperationActionReboot   RollingNodeOperationAction = "Reboot"
+)
+
+// RollingNodeOperationNode represents the progress of a rolling node
+// operation on a node
+type RollingNodeOperationNode struct {
+	MissingFields
+
+	Name    string                        `json:"name,omitempty"`
+	State   RollingNodeOperationNodeState `json:"state,omitempty"`
+	Message string                        `json:"message,omitempty"`
+}
+
+// RollingNodeOperationNodeState represents the state of a rolling node
+// operation on a node
+type RollingNodeOperationNodeState string
+
+// RollingNodeOperationNodeState constants
+const (
+	RollingNodeOperationNodeStatePending    RollingNodeOperationNodeState = "Pending"
+	RollingNodeOperationNodeStateInProgress RollingNodeOperationNodeState = "InProgress"
+	RollingNodeOperationNodeStateSucceeded  RollingNodeOperationNodeState = "Succeeded"
+	RollingNodeOperationNodeStateFailed     RollingNodeOperationNodeState = "Failed"
+	RollingNodeOperationNodeStateAborted    RollingNodeOperationNodeState = "Aborted"
+)
+
+// IsTerminal returns true if no further nodes will be operated on
+func (o *RollingNodeOperation) IsTerminal() bool {
+	return o.EndTime != nil
+}
+
+// EtcdSnapshotProfile configures the scheduled etcd snapshots of a cluster.
+// Snapshots are encrypted and stored in the cluster storage account.
+type EtcdSnapshotProfile struct {
+	MissingFields
+
+	// Interval is the time between scheduled snapshots, e.g. "24h".  Scheduled
+	// snapshots are disabled if it is empty.
+	Interval string `json:"interval,omitempty"`
+
+	// Retention is the number of snapshots which are kept
+	Retention int `json:"retention,omitempty"`
+
+	// EncryptionKey is used to encrypt snapshots before they leave the
+	// cluster.  It is generated when the first snapshot is taken.
+	EncryptionKey SecureBytes `json:"encryptionKey,omitempty"`
+
+	LastSnapshotName string     `json:"lastSnapshotName,omitempty"`
+	LastSnapshotTime *time.Time `json:"lastSnapshotTime,omitempty"`
+}
+
+// OperationProgress represents the progress of a backend operation
+type OperationProgress struct {
+	MissingFields
+
+	// Phase is the phase of the operation, e.g. "Bootstrap" or "AdminUpdate"
+	Phase string `json:"phase,omitempty"`
+
+	// Step is the name of the step being run
+	Step          string     `json:"step,omitempty"`
+	StepStartTime *time.Time `json:"stepStartTime,omitempty"`
+
+	StepsCompleted int `json:"stepsCompleted,omitempty"`
+	StepsTotal     int `json:"stepsTotal,omitempty"`
+
+	// PercentComplete is estimated from the typical durations of the steps
+	// completed
+	PercentComplete int `json:"percentComplete,omitempty"`
+}
+
 // ArchitectureVersion represents an architecture version
 type ArchitectureVersion int
 
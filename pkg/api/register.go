@@ -37,6 +37,11 @@ type OpenShiftVersionStaticValidator interface {
 	Static(interface{}, *OpenShiftVersion) error
 }
 
+type MaintenanceTaskConverter interface {
+	ToExternal(*MaintenanceTaskDefinition) interface{}
+	ToExternalList([]*MaintenanceTaskDefinition) interface{}
+}
+
 type SyncSetConverter interface {
 	ToExternal(*SyncSet) interface{}
 	ToExternalList([]*SyncSet) interface{}
@@ -69,6 +74,7 @@ type Version struct {
 	OpenShiftClusterAdminKubeconfigConverter OpenShiftClusterAdminKubeconfigConverter
 	OpenShiftVersionConverter                OpenShiftVersionConverter
 	OpenShiftVersionStaticValidator          OpenShiftVersionStaticValidator
+	MaintenanceTaskConverter                 MaintenanceTaskConverter
 	OperationList                            OperationList
 	SyncSetConverter                         SyncSetConverter
 	MachinePoolConverter                     MachinePoolConverter
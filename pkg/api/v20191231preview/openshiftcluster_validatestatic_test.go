@@ -147,7 +147,13 @@ func runTests(t *testing.T, mode testMode, tests []*validateTest) {
 					if cloudErr.StatusCode != http.StatusBadRequest {
 						t.Error(cloudErr.StatusCode)
 					}
-					if cloudErr.Target == "" {
+					if cloudErr.Code == api.CloudErrorCodeMultipleErrorsOccurred {
+						for _, detail := range cloudErr.Details {
+							if detail.Target == "" {
+								t.Error("target is required")
+							}
+						}
+					} else if cloudErr.Target == "" {
 						t.Error("target is required")
 					}
 
@@ -191,6 +197,18 @@ func TestOpenShiftClusterStaticValidate(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: location: The provided location 'invalid' is invalid.",
 		},
+		{
+			name: "multiple errors are all reported",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Location = "invalid"
+				oc.Properties.ConsoleProfile.URL = "\x00"
+				oc.Properties.ServicePrincipalProfile.ClientSecret = ""
+			},
+			wantErr: "400: MultipleErrorsOccurred: : Multiple validation errors occurred. Please see details for more information. Details: " +
+				"InvalidParameter: location: The provided location 'invalid' is invalid., " +
+				"InvalidParameter: properties.consoleProfile.url: The provided console URL '\x00' is invalid., " +
+				"InvalidParameter: properties.servicePrincipalProfile.clientSecret: The provided client secret is invalid.",
+		},
 	}
 
 	runTests(t, testModeCreate, tests)
@@ -49,74 +49,87 @@ func (sv openShiftClusterStaticValidator) Static(_oc interface{}, _current *api.
 		return err
 	}
 
-	err = sv.validate(oc, current == nil)
+	report := &api.ValidationReport{}
+
+	err = sv.validate(report, oc, current == nil)
 	if err != nil {
 		return err
 	}
 
-	if current == nil {
-		return nil
+	// immutable fields are only checked once the request is otherwise valid
+	if current != nil && len(report.Errors()) == 0 {
+		err = report.Add(sv.validateDelta(oc, current))
+		if err != nil {
+			return err
+		}
 	}
 
-	return sv.validateDelta(oc, current)
+	return report.CloudError()
 }
 
-func (sv openShiftClusterStaticValidator) validate(oc *OpenShiftCluster, isCreate bool) error {
+// validate adds all the problems found with oc to report.  It only returns an
+// error if validation could not be completed.
+func (sv openShiftClusterStaticValidator) validate(report *api.ValidationReport, oc *OpenShiftCluster, isCreate bool) error {
 	if !strings.EqualFold(oc.ID, sv.resourceID) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceID, "id", "The provided resource ID '%s' did not match the name in the Url '%s'.", oc.ID, sv.resourceID)
+		_ = report.Add(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceID, "id", "The provided resource ID '%s' did not match the name in the Url '%s'.", oc.ID, sv.resourceID))
 	}
 	if !strings.EqualFold(oc.Name, sv.r.ResourceName) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceName, "name", "The provided resource name '%s' did not match the name in the Url '%s'.", oc.Name, sv.r.ResourceName)
+		_ = report.Add(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceName, "name", "The provided resource name '%s' did not match the name in the Url '%s'.", oc.Name, sv.r.ResourceName))
 	}
 	if !strings.EqualFold(oc.Type, resourceProviderNamespace+"/"+resourceType) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceType, "type", "The provided resource type '%s' did not match the name in the Url '%s'.", oc.Type, resourceProviderNamespace+"/"+resourceType)
+		_ = report.Add(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceType, "type", "The provided resource type '%s' did not match the name in the Url '%s'.", oc.Type, resourceProviderNamespace+"/"+resourceType))
 	}
 	if !strings.EqualFold(oc.Location, sv.location) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "location", "The provided location '%s' is invalid.", oc.Location)
+		_ = report.Add(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "location", "The provided location '%s' is invalid.", oc.Location))
 	}
 
-	return sv.validateProperties("properties", &oc.Properties, isCreate)
+	return sv.validateProperties(report, "properties", &oc.Properties, isCreate)
 }
 
-func (sv openShiftClusterStaticValidator) validateProperties(path string, p *OpenShiftClusterProperties, isCreate bool) error {
+func (sv openShiftClusterStaticValidator) validateProperties(report *api.ValidationReport, path string, p *OpenShiftClusterProperties, isCreate bool) error {
 	switch p.ProvisioningState {
 	case ProvisioningStateCreating, ProvisioningStateUpdating,
 		ProvisioningStateAdminUpdating, ProvisioningStateDeleting,
 		ProvisioningStateSucceeded, ProvisioningStateFailed:
 	default:
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".provisioningState", "The provided provisioning state '%s' is invalid.", p.ProvisioningState)
+		_ = report.Add(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".provisioningState", "The provided provisioning state '%s' is invalid.", p.ProvisioningState))
 	}
-	if err := sv.validateClusterProfile(path+".clusterProfile", &p.ClusterProfile, isCreate); err != nil {
+	if err := report.Add(sv.validateClusterProfile(path+".clusterProfile", &p.ClusterProfile, isCreate)); err != nil {
 		return err
 	}
-	if err := sv.validateConsoleProfile(path+".consoleProfile", &p.ConsoleProfile); err != nil {
+	if err := report.Add(sv.validateConsoleProfile(path+".consoleProfile", &p.ConsoleProfile)); err != nil {
 		return err
 	}
-	if err := sv.validateServicePrincipalProfile(path+".servicePrincipalProfile", &p.ServicePrincipalProfile); err != nil {
+	if err := report.Add(sv.validateServicePrincipalProfile(path+".servicePrincipalProfile", &p.ServicePrincipalProfile)); err != nil {
 		return err
 	}
-	if err := sv.validateNetworkProfile(path+".networkProfile", &p.NetworkProfile); err != nil {
+	if err := report.Add(sv.validateNetworkProfile(path+".networkProfile", &p.NetworkProfile)); err != nil {
 		return err
 	}
-	if err := sv.validateMasterProfile(path+".masterProfile", &p.MasterProfile); err != nil {
+	errs := len(report.Errors())
+	if err := report.Add(sv.validateMasterProfile(path+".masterProfile", &p.MasterProfile)); err != nil {
 		return err
 	}
-	if err := sv.validateAPIServerProfile(path+".apiserverProfile", &p.APIServerProfile); err != nil {
+	// the worker profile is compared against the master profile, so don't
+	// report the same problem twice if the master profile is invalid
+	mp := &p.MasterProfile
+	if len(report.Errors()) > errs {
+		mp = nil
+	}
+	if err := report.Add(sv.validateAPIServerProfile(path+".apiserverProfile", &p.APIServerProfile)); err != nil {
 		return err
 	}
 
 	if isCreate {
 		if len(p.WorkerProfiles) != 1 {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles", "There should be exactly one worker profile.")
-		}
-		if err := sv.validateWorkerProfile(path+".workerProfiles['"+p.WorkerProfiles[0].Name+"']", &p.WorkerProfiles[0], &p.MasterProfile); err != nil {
+			_ = report.Add(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles", "There should be exactly one worker profile."))
+		} else if err := report.Add(sv.validateWorkerProfile(path+".workerProfiles['"+p.WorkerProfiles[0].Name+"']", &p.WorkerProfiles[0], mp)); err != nil {
 			return err
 		}
 
 		if len(p.IngressProfiles) != 1 {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ingressProfiles", "There should be exactly one ingress profile.")
-		}
-		if err := sv.validateIngressProfile(path+".ingressProfiles['"+p.IngressProfiles[0].Name+"']", &p.IngressProfiles[0]); err != nil {
+			_ = report.Add(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ingressProfiles", "There should be exactly one ingress profile."))
+		} else if err := report.Add(sv.validateIngressProfile(path+".ingressProfiles['"+p.IngressProfiles[0].Name+"']", &p.IngressProfiles[0])); err != nil {
 			return err
 		}
 	}
@@ -262,19 +275,21 @@ func (sv openShiftClusterStaticValidator) validateWorkerProfile(path string, wp
 	if !validate.RxSubnetID.MatchString(wp.SubnetID) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid.", wp.SubnetID)
 	}
-	workerVnetID, _, err := apisubnet.Split(wp.SubnetID)
-	if err != nil {
-		return err
-	}
-	masterVnetID, _, err := apisubnet.Split(mp.SubnetID)
-	if err != nil {
-		return err
-	}
-	if !strings.EqualFold(masterVnetID, workerVnetID) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid: must be in the same vnet as master VM subnet '%s'.", wp.SubnetID, mp.SubnetID)
-	}
-	if strings.EqualFold(mp.SubnetID, wp.SubnetID) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid: must be different to master VM subnet '%s'.", wp.SubnetID, mp.SubnetID)
+	if mp != nil {
+		workerVnetID, _, err := apisubnet.Split(wp.SubnetID)
+		if err != nil {
+			return err
+		}
+		masterVnetID, _, err := apisubnet.Split(mp.SubnetID)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(masterVnetID, workerVnetID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid: must be in the same vnet as master VM subnet '%s'.", wp.SubnetID, mp.SubnetID)
+		}
+		if strings.EqualFold(mp.SubnetID, wp.SubnetID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid: must be different to master VM subnet '%s'.", wp.SubnetID, mp.SubnetID)
+		}
 	}
 	if wp.Count < 2 || wp.Count > 50 {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".count", "The provided worker count '%d' is invalid.", wp.Count)
@@ -21,5 +21,6 @@ var (
 		`([a-z0-9]|[a-z0-9][-a-z0-9]{0,61}[a-z0-9])` +
 		`(\.([a-z0-9]|[a-z0-9][-a-z0-9]{0,61}[a-z0-9]))*` +
 		`$`)
-	RxInstallVersion = regexp.MustCompile(`^[4-9]{1}\.[0-9]{1,2}\.[0-9]{1,3}$`)
+	RxInstallVersion   = regexp.MustCompile(`^[4-9]{1}\.[0-9]{1,2}\.[0-9]{1,3}$`)
+	RxKeyVaultSecretID = regexp.MustCompile(`(?i)^https://[a-z][-a-z0-9]{1,22}[a-z0-9]\.vault(\.[-a-z0-9]+)+/secrets/[-a-z0-9]{1,127}(/[0-9a-f]{32})?/?$`)
 )
@@ -0,0 +1,187 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// CloudErrorCodeMultipleErrorsOccurred is returned when validation finds more
+// than one problem; the individual problems are returned as error details
+const CloudErrorCodeMultipleErrorsOccurred = "MultipleErrorsOccurred"
+
+// ValidationSeverity is the severity of a validation finding
+type ValidationSeverity string
+
+// ValidationSeverity constants
+const (
+	ValidationSeverityError   ValidationSeverity = "Error"
+	ValidationSeverityWarning ValidationSeverity = "Warning"
+)
+
+// ValidationFinding is a single problem found during validation
+type ValidationFinding struct {
+	StatusCode int                `json:"-"`
+	Target     string             `json:"target,omitempty"`
+	Code       string             `json:"code,omitempty"`
+	Message    string             `json:"message,omitempty"`
+	Severity   ValidationSeverity `json:"severity,omitempty"`
+}
+
+// ValidationReport accumulates validation findings so that all the problems
+// with a request can be returned at once rather than one per request
+type ValidationReport struct {
+	Findings []ValidationFinding `json:"findings,omitempty"`
+}
+
+// Add records err as an error finding if it is a CloudError, flattening
+// errors which themselves aggregate multiple findings.  Any other non-nil
+// error is not a validation failure and is returned to the caller, which
+// should abort validation.
+func (r *ValidationReport) Add(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	cloudErr, ok := err.(*CloudError)
+	if !ok || cloudErr.CloudErrorBody == nil {
+		return err
+	}
+
+	if cloudErr.Code == CloudErrorCodeMultipleErrorsOccurred {
+		for _, detail := range cloudErr.Details {
+			r.add(ValidationFinding{
+				StatusCode: cloudErr.StatusCode,
+				Target:     detail.Target,
+				Code:       detail.Code,
+				Message:    detail.Message,
+				Severity:   ValidationSeverityError,
+			})
+		}
+		return nil
+	}
+
+	r.add(ValidationFinding{
+		StatusCode: cloudErr.StatusCode,
+		Target:     cloudErr.Target,
+		Code:       cloudErr.Code,
+		Message:    cloudErr.Message,
+		Severity:   ValidationSeverityError,
+	})
+	return nil
+}
+
+// add records f unless the same problem has already been reported, e.g. by a
+// validator which depends on the same resource
+func (r *ValidationReport) add(f ValidationFinding) {
+	for _, existing := range r.Findings {
+		if existing == f {
+			return
+		}
+	}
+
+	r.Findings = append(r.Findings, f)
+}
+
+// AddWarning records a finding which does not fail validation
+func (r *ValidationReport) AddWarning(code, target, message string, a ...interface{}) {
+	r.add(ValidationFinding{
+		Target:   target,
+		Code:     code,
+		Message:  fmt.Sprintf(message, a...),
+		Severity: ValidationSeverityWarning,
+	})
+}
+
+// Errors returns the findings which fail validation
+func (r *ValidationReport) Errors() []ValidationFinding {
+	var errs []ValidationFinding
+	for _, f := range r.Findings {
+		if f.Severity == ValidationSeverityError {
+			errs = append(errs, f)
+		}
+	}
+	return errs
+}
+
+// Warnings returns the findings which do not fail validation
+func (r *ValidationReport) Warnings() []ValidationFinding {
+	var warnings []ValidationFinding
+	for _, f := range r.Findings {
+		if f.Severity == ValidationSeverityWarning {
+			warnings = append(warnings, f)
+		}
+	}
+	return warnings
+}
+
+// CloudError returns nil if the report contains no errors.  A single error is
+// returned unchanged; multiple errors are returned as the details of a
+// MultipleErrorsOccurred CloudError.
+func (r *ValidationReport) CloudError() error {
+	errs := r.Errors()
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0].cloudError()
+	}
+
+	statusCode := errs[0].StatusCode
+	details := make([]CloudErrorBody, 0, len(errs))
+	for _, f := range errs {
+		if f.StatusCode != statusCode {
+			statusCode = http.StatusBadRequest
+		}
+		details = append(details, CloudErrorBody{
+			Code:    f.Code,
+			Message: f.Message,
+			Target:  f.Target,
+		})
+	}
+
+	return &CloudError{
+		StatusCode: statusCode,
+		CloudErrorBody: &CloudErrorBody{
+			Code:    CloudErrorCodeMultipleErrorsOccurred,
+			Message: "Multiple validation errors occurred. Please see details for more information.",
+			Details: details,
+		},
+	}
+}
+
+func (f *ValidationFinding) cloudError() *CloudError {
+	return &CloudError{
+		StatusCode: f.StatusCode,
+		CloudErrorBody: &CloudErrorBody{
+			Code:    f.Code,
+			Message: f.Message,
+			Target:  f.Target,
+		},
+	}
+}
+
+// FirstError returns the first validation error aggregated in err, for callers
+// which expect validation to fail with a single error.  Errors which do not
+// aggregate multiple findings are returned unchanged.
+func FirstError(err error) error {
+	cloudErr, ok := err.(*CloudError)
+	if !ok || cloudErr.CloudErrorBody == nil ||
+		cloudErr.Code != CloudErrorCodeMultipleErrorsOccurred ||
+		len(cloudErr.Details) == 0 {
+		return err
+	}
+
+	detail := cloudErr.Details[0]
+	return &CloudError{
+		StatusCode: cloudErr.StatusCode,
+		CloudErrorBody: &CloudErrorBody{
+			Code:    detail.Code,
+			Message: detail.Message,
+			Target:  detail.Target,
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestValidationReport(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		errs         []error
+		warnings     int
+		wantAddErr   error
+		wantFindings int
+		want         error
+	}{
+		{
+			name: "no findings",
+			errs: []error{nil},
+		},
+		{
+			name:         "warnings do not fail validation",
+			warnings:     1,
+			wantFindings: 1,
+		},
+		{
+			name: "single error is returned unchanged",
+			errs: []error{
+				NewCloudError(http.StatusBadRequest, CloudErrorCodeInvalidParameter, "a", "A is invalid."),
+			},
+			wantFindings: 1,
+			want:         NewCloudError(http.StatusBadRequest, CloudErrorCodeInvalidParameter, "a", "A is invalid."),
+		},
+		{
+			name: "multiple errors are aggregated, flattened and deduplicated",
+			errs: []error{
+				NewCloudError(http.StatusBadRequest, CloudErrorCodeInvalidParameter, "a", "A is invalid."),
+				&CloudError{
+					StatusCode: http.StatusBadRequest,
+					CloudErrorBody: &CloudErrorBody{
+						Code: CloudErrorCodeMultipleErrorsOccurred,
+						Details: []CloudErrorBody{
+							{Code: CloudErrorCodeInvalidParameter, Target: "b", Message: "B is invalid."},
+							{Code: CloudErrorCodeInvalidParameter, Target: "a", Message: "A is invalid."},
+						},
+					},
+				},
+			},
+			warnings:     1,
+			wantFindings: 3,
+			want: &CloudError{
+				StatusCode: http.StatusBadRequest,
+				CloudErrorBody: &CloudErrorBody{
+					Code:    CloudErrorCodeMultipleErrorsOccurred,
+					Message: "Multiple validation errors occurred. Please see details for more information.",
+					Details: []CloudErrorBody{
+						{Code: CloudErrorCodeInvalidParameter, Target: "a", Message: "A is invalid."},
+						{Code: CloudErrorCodeInvalidParameter, Target: "b", Message: "B is invalid."},
+					},
+				},
+			},
+		},
+		{
+			name:       "other errors are returned",
+			errs:       []error{errors.New("random error")},
+			wantAddErr: errors.New("random error"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ValidationReport{}
+
+			for _, err := range tt.errs {
+				addErr := r.Add(err)
+				if !reflect.DeepEqual(addErr, tt.wantAddErr) {
+					t.Errorf("got %v, want %v", addErr, tt.wantAddErr)
+				}
+			}
+			for i := 0; i < tt.warnings; i++ {
+				r.AddWarning(CloudErrorCodeInvalidParameter, "c", "C may be invalid.")
+			}
+
+			if len(r.Findings) != tt.wantFindings {
+				t.Errorf("got %d findings, want %d", len(r.Findings), tt.wantFindings)
+			}
+			if len(r.Warnings()) != tt.warnings {
+				t.Errorf("got %d warnings, want %d", len(r.Warnings()), tt.warnings)
+			}
+
+			got := r.CloudError()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "single error",
+			err:  NewCloudError(http.StatusBadRequest, CloudErrorCodeInvalidParameter, "a", "A is invalid."),
+			want: NewCloudError(http.StatusBadRequest, CloudErrorCodeInvalidParameter, "a", "A is invalid."),
+		},
+		{
+			name: "multiple errors",
+			err: &CloudError{
+				StatusCode: http.StatusBadRequest,
+				CloudErrorBody: &CloudErrorBody{
+					Code: CloudErrorCodeMultipleErrorsOccurred,
+					Details: []CloudErrorBody{
+						{Code: CloudErrorCodeInvalidParameter, Target: "a", Message: "A is invalid."},
+						{Code: CloudErrorCodeInvalidParameter, Target: "b", Message: "B is invalid."},
+					},
+				},
+			},
+			want: NewCloudError(http.StatusBadRequest, CloudErrorCodeInvalidParameter, "a", "A is invalid."),
+		},
+		{
+			name: "other error",
+			err:  errors.New("random error"),
+			want: errors.New("random error"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FirstError(tt.err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -46,6 +46,7 @@ type backend struct {
 
 	ocb *openShiftClusterBackend
 	sb  *subscriptionBackend
+	bb  *billingBackend
 }
 
 // Runnable represents a runnable object
@@ -62,6 +63,8 @@ func NewBackend(ctx context.Context, log *logrus.Entry, env env.Interface, dbAsy
 
 	b.ocb = newOpenShiftClusterBackend(b)
 	b.sb = newSubscriptionBackend(b)
+	b.bb = newBillingBackend(b)
+
 	return b, nil
 }
 
@@ -97,6 +100,8 @@ func (b *backend) Run(ctx context.Context, stop <-chan struct{}, done chan<- str
 	t := time.NewTicker(10 * time.Second)
 	defer t.Stop()
 
+	go b.bb.run(ctx, stop)
+
 	if stop != nil {
 		go func() {
 			defer recover.Panic(b.baseLog)
@@ -0,0 +1,76 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/util/billing"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const billingReconcileInterval = 6 * time.Hour
+
+type billingBackend struct {
+	*backend
+
+	reconciler billing.Reconciler
+}
+
+func newBillingBackend(b *backend) *billingBackend {
+	return &billingBackend{
+		backend: b,
+
+		reconciler: billing.NewReconciler(b.baseLog.WithField("component", "billing-reconciler"), b.dbBilling, b.dbOpenShiftClusters, b.dbSubscriptions, b.billing),
+	}
+}
+
+// run periodically reconciles the Billing collection against the
+// OpenShiftClusters collection and reports any inconsistencies.  Fixing them is
+// left to an operator running `aro billing-reconcile fix`
+func (bb *billingBackend) run(ctx context.Context, stop <-chan struct{}) {
+	defer recover.Panic(bb.baseLog)
+
+	t := time.NewTicker(billingReconcileInterval)
+	defer t.Stop()
+
+	for {
+		err := bb.reconcile(ctx)
+		if err != nil {
+			bb.baseLog.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (bb *billingBackend) reconcile(ctx context.Context) error {
+	report, err := bb.reconciler.Reconcile(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range []billing.FindingKind{
+		billing.FindingKindMissingRecord,
+		billing.FindingKindOrphanedRecord,
+		billing.FindingKindExpiredRecord,
+		billing.FindingKindDeletionTimeDrift,
+		billing.FindingKindCreationTimeDrift,
+	} {
+		bb.m.EmitGauge("backend.billing.reconcile.findings", int64(report.Count(kind)), map[string]string{
+			"kind": string(kind),
+		})
+	}
+
+	bb.m.EmitGauge("backend.billing.reconcile.duration", report.EndTime.Sub(report.StartTime).Milliseconds(), nil)
+
+	bb.baseLog.Printf("billing reconciliation: %d clusters, %d billing records, %d findings", report.Clusters, report.BillingRecords, len(report.Findings))
+
+	return nil
+}
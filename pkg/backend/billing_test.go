@@ -0,0 +1,81 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/util/billing"
+	mock_billing "github.com/Azure/ARO-RP/pkg/util/mocks/billing"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestBillingBackendReconcile(t *testing.T) {
+	ctx := context.Background()
+	startTime := time.Unix(1700000000, 0)
+
+	for _, tt := range []struct {
+		name    string
+		report  *billing.ReconcileReport
+		err     error
+		mocks   func(*mock_metrics.MockEmitter)
+		wantErr string
+	}{
+		{
+			name: "findings are emitted by kind",
+			report: &billing.ReconcileReport{
+				StartTime: startTime,
+				EndTime:   startTime.Add(2 * time.Second),
+				Findings: []billing.Finding{
+					{Kind: billing.FindingKindMissingRecord},
+					{Kind: billing.FindingKindOrphanedRecord},
+					{Kind: billing.FindingKindOrphanedRecord},
+				},
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("backend.billing.reconcile.findings", int64(1), map[string]string{"kind": "MissingRecord"})
+				m.EXPECT().EmitGauge("backend.billing.reconcile.findings", int64(2), map[string]string{"kind": "OrphanedRecord"})
+				m.EXPECT().EmitGauge("backend.billing.reconcile.findings", int64(0), map[string]string{"kind": "ExpiredRecord"})
+				m.EXPECT().EmitGauge("backend.billing.reconcile.findings", int64(0), map[string]string{"kind": "DeletionTimeDrift"})
+				m.EXPECT().EmitGauge("backend.billing.reconcile.findings", int64(0), map[string]string{"kind": "CreationTimeDrift"})
+				m.EXPECT().EmitGauge("backend.billing.reconcile.duration", int64(2000), nil)
+			},
+		},
+		{
+			name:    "reconcile error",
+			err:     errors.New("random error"),
+			mocks:   func(m *mock_metrics.MockEmitter) {},
+			wantErr: "random error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			reconciler := mock_billing.NewMockReconciler(controller)
+			reconciler.EXPECT().Reconcile(gomock.Any(), false).Return(tt.report, tt.err)
+
+			m := mock_metrics.NewMockEmitter(controller)
+			tt.mocks(m)
+
+			bb := &billingBackend{
+				backend: &backend{
+					baseLog: logrus.NewEntry(logrus.StandardLogger()),
+					m:       m,
+				},
+				reconciler: reconciler,
+			}
+
+			err := bb.reconcile(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
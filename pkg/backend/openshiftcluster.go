@@ -5,6 +5,7 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -23,6 +24,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/util/billing"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/installfailure"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	"github.com/Azure/ARO-RP/pkg/util/recover"
 )
@@ -30,7 +32,7 @@ import (
 type openShiftClusterBackend struct {
 	*backend
 
-	newManager func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.Gateway, database.OpenShiftVersions, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, metrics.Emitter) (cluster.Interface, error)
+	newManager func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.AsyncOperations, database.Gateway, database.OpenShiftVersions, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, metrics.Emitter) (cluster.Interface, error)
 }
 
 func newOpenShiftClusterBackend(b *backend) *openShiftClusterBackend {
@@ -129,7 +131,7 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 		}
 	}
 
-	m, err := ocb.newManager(ctx, log, ocb.env, ocb.dbOpenShiftClusters, ocb.dbGateway, ocb.dbOpenShiftVersions, ocb.aead, ocb.billing, doc, subscriptionDoc, hr, ocb.m)
+	m, err := ocb.newManager(ctx, log, ocb.env, ocb.dbOpenShiftClusters, ocb.dbAsyncOperations, ocb.dbGateway, ocb.dbOpenShiftVersions, ocb.aead, ocb.billing, doc, subscriptionDoc, hr, ocb.m)
 	if err != nil {
 		return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateFailed, err)
 	}
@@ -156,7 +158,11 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 		return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateCreating, nil)
 
 	case api.ProvisioningStateAdminUpdating:
-		log.Printf("admin updating (type: %s)", doc.OpenShiftCluster.Properties.MaintenanceTask)
+		if def := api.GetMaintenanceTaskDefinition(doc.OpenShiftCluster.Properties.MaintenanceTask); def != nil {
+			log.Printf("admin updating (type: %s, version: %d)", def.Name, def.Version)
+		} else {
+			log.Printf("admin updating (type: %s)", doc.OpenShiftCluster.Properties.MaintenanceTask)
+		}
 
 		err = m.AdminUpdate(ctx)
 		if err != nil {
@@ -251,6 +257,10 @@ func (ocb *openShiftClusterBackend) updateAsyncOperation(ctx context.Context, lo
 			now := time.Now()
 			asyncdoc.AsyncOperation.EndTime = &now
 
+			if provisioningState == api.ProvisioningStateSucceeded {
+				asyncdoc.AsyncOperation.PercentComplete = 100
+			}
+
 			if provisioningState == api.ProvisioningStateFailed {
 				// if type is CloudError - we want to propagate it to the
 				// asyncOperations errors. Otherwise - return generic error
@@ -293,6 +303,14 @@ func (ocb *openShiftClusterBackend) endLease(ctx context.Context, log *logrus.En
 	var failedProvisioningState api.ProvisioningState
 	initialProvisioningState := doc.OpenShiftCluster.Properties.ProvisioningState
 
+	// Classified install failures carry their reason for metrics; only the
+	// cloud error is returned to the user
+	installFailureReason := installfailure.ReasonOf(backendErr)
+	var installErr *installfailure.Error
+	if errors.As(backendErr, &installErr) {
+		backendErr = installErr.CloudError
+	}
+
 	if initialProvisioningState != api.ProvisioningStateAdminUpdating &&
 		provisioningState == api.ProvisioningStateFailed {
 		failedProvisioningState = initialProvisioningState
@@ -307,6 +325,7 @@ func (ocb *openShiftClusterBackend) endLease(ctx context.Context, log *logrus.En
 		}
 		ocb.asyncOperationResultLog(log, initialProvisioningState, backendErr)
 		ocb.emitMetrics(doc, provisioningState)
+		ocb.emitInstallFailureMetrics(initialProvisioningState, provisioningState, installFailureReason)
 	}
 
 	if initialProvisioningState == api.ProvisioningStateAdminUpdating {
@@ -374,7 +393,27 @@ func (ocb *openShiftClusterBackend) emitMetrics(doc *api.OpenShiftClusterDocumen
 	})
 }
 
+// emitInstallFailureMetrics records the classified reason for a failed
+// cluster install.
+func (ocb *openShiftClusterBackend) emitInstallFailureMetrics(initialProvisioningState, provisioningState api.ProvisioningState, reason string) {
+	if initialProvisioningState != api.ProvisioningStateCreating ||
+		provisioningState != api.ProvisioningStateFailed {
+		return
+	}
+
+	ocb.m.EmitGauge("backend.openshiftcluster.installfailure.count", 1, map[string]string{
+		"reason": reason,
+	})
+}
+
 func (ocb *openShiftClusterBackend) setNoMaintenanceState(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
+	// tasks which are not customer impacting, e.g. scheduled ACR token
+	// rotation, run without setting the maintenance signal and so must not
+	// clear a signal set by someone else
+	if def := api.GetMaintenanceTaskDefinition(doc.OpenShiftCluster.Properties.MaintenanceTask); def != nil && !def.CustomerImpacting {
+		return doc, nil
+	}
+
 	return ocb.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
 		doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateNone
 		return nil
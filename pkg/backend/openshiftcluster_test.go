@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -22,8 +24,10 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
 	"github.com/Azure/ARO-RP/pkg/util/billing"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/installfailure"
 	mock_cluster "github.com/Azure/ARO-RP/pkg/util/mocks/cluster"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
 	"github.com/Azure/ARO-RP/test/util/deterministicuuid"
 	"github.com/Azure/ARO-RP/test/util/testliveconfig"
@@ -213,6 +217,47 @@ func TestBackendTry(t *testing.T) {
 				manager.EXPECT().AdminUpdate(gomock.Any()).Return(nil)
 			},
 		},
+		{
+			name: "StateAdminUpdating success of a task which is not customer impacting leaves the maintenance state",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateAdminUpdating,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							MaintenanceTask:       api.MaintenanceTaskACRTokenRotation,
+							MaintenanceState:      api.MaintenanceStatePending,
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							MaintenanceState:  api.MaintenanceStatePending,
+						},
+					},
+				})
+			},
+			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
+				manager.EXPECT().AdminUpdate(gomock.Any()).Return(nil)
+			},
+		},
 		{
 			name: "StateAdminUpdating run failure populates LastAdminUpdateError, restores previous provisioning state + failed provisioning state, and sets maintenance state to ongoing",
 			fixture: func(f *testdatabase.Fixture) {
@@ -306,7 +351,7 @@ func TestBackendTry(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			createManager := func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.Gateway, database.OpenShiftVersions, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, metrics.Emitter) (cluster.Interface, error) {
+			createManager := func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.AsyncOperations, database.Gateway, database.OpenShiftVersions, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, metrics.Emitter) (cluster.Interface, error) {
 				return manager, nil
 			}
 
@@ -341,3 +386,74 @@ func TestBackendTry(t *testing.T) {
 		})
 	}
 }
+
+func TestEndLeaseInstallFailure(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+	asyncOperationID := "00000000-0000-0000-0000-000000000001"
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+	dbAsyncOperations, clientAsyncOperations := testdatabase.NewFakeAsyncOperations()
+
+	doc := &api.OpenShiftClusterDocument{
+		Key:              strings.ToLower(resourceID),
+		AsyncOperationID: asyncOperationID,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: resourceID,
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateCreating,
+			},
+		},
+	}
+
+	f := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters).WithAsyncOperations(dbAsyncOperations)
+	f.AddOpenShiftClusterDocuments(doc)
+	f.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+		ID:                  asyncOperationID,
+		OpenShiftClusterKey: strings.ToLower(resourceID),
+		AsyncOperation: &api.AsyncOperation{
+			ID:                asyncOperationID,
+			ProvisioningState: api.ProvisioningStateCreating,
+		},
+	})
+	err := f.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("backend.openshiftcluster.installfailure.count", int64(1), map[string]string{
+		"reason": "QuotaExceeded",
+	})
+
+	ocb := &openShiftClusterBackend{
+		backend: &backend{
+			dbAsyncOperations:   dbAsyncOperations,
+			dbOpenShiftClusters: dbOpenShiftClusters,
+			m:                   m,
+		},
+	}
+
+	cloudErr := api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeQuotaExceeded, "", "Quota exceeded.")
+
+	// a classified install failure wrapped by another error keeps its reason
+	// and is returned to the user as its cloud error
+	backendErr := fmt.Errorf("installing: %w", &installfailure.Error{CloudError: cloudErr, Reason: "QuotaExceeded"})
+
+	err = ocb.endLease(ctx, logrus.NewEntry(logrus.StandardLogger()), nil, doc, api.ProvisioningStateFailed, backendErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	asyncdoc, err := clientAsyncOperations.Get(ctx, asyncOperationID, asyncOperationID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(asyncdoc.AsyncOperation.Error, cloudErr.CloudErrorBody) {
+		t.Errorf("got error %#v, want %#v", asyncdoc.AsyncOperation.Error, cloudErr.CloudErrorBody)
+	}
+}
@@ -0,0 +1,137 @@
+package redhatopenshift
+
+// Copyright (c) Microsoft and contributors.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by Microsoft (R) AutoRest Code Generator.
+// Changes may cause incorrect behavior and will be lost if the code is regenerated.
+
+// CreatedByType enumerates the values for created by type.
+type CreatedByType string
+
+const (
+	// Application ...
+	Application CreatedByType = "Application"
+	// Key ...
+	Key CreatedByType = "Key"
+	// ManagedIdentity ...
+	ManagedIdentity CreatedByType = "ManagedIdentity"
+	// User ...
+	User CreatedByType = "User"
+)
+
+// PossibleCreatedByTypeValues returns an array of possible values for the CreatedByType const type.
+func PossibleCreatedByTypeValues() []CreatedByType {
+	return []CreatedByType{Application, Key, ManagedIdentity, User}
+}
+
+// EncryptionAtHost enumerates the values for encryption at host.
+type EncryptionAtHost string
+
+const (
+	// Disabled ...
+	Disabled EncryptionAtHost = "Disabled"
+	// Enabled ...
+	Enabled EncryptionAtHost = "Enabled"
+)
+
+// PossibleEncryptionAtHostValues returns an array of possible values for the EncryptionAtHost const type.
+func PossibleEncryptionAtHostValues() []EncryptionAtHost {
+	return []EncryptionAtHost{Disabled, Enabled}
+}
+
+// FipsValidatedModules enumerates the values for fips validated modules.
+type FipsValidatedModules string
+
+const (
+	// FipsValidatedModulesDisabled ...
+	FipsValidatedModulesDisabled FipsValidatedModules = "Disabled"
+	// FipsValidatedModulesEnabled ...
+	FipsValidatedModulesEnabled FipsValidatedModules = "Enabled"
+)
+
+// PossibleFipsValidatedModulesValues returns an array of possible values for the FipsValidatedModules const type.
+func PossibleFipsValidatedModulesValues() []FipsValidatedModules {
+	return []FipsValidatedModules{FipsValidatedModulesDisabled, FipsValidatedModulesEnabled}
+}
+
+// OutboundType enumerates the values for outbound type.
+type OutboundType string
+
+const (
+	// Loadbalancer ...
+	Loadbalancer OutboundType = "Loadbalancer"
+	// UserDefinedRouting ...
+	UserDefinedRouting OutboundType = "UserDefinedRouting"
+)
+
+// PossibleOutboundTypeValues returns an array of possible values for the OutboundType const type.
+func PossibleOutboundTypeValues() []OutboundType {
+	return []OutboundType{Loadbalancer, UserDefinedRouting}
+}
+
+// PreconfiguredNSG enumerates the values for preconfigured nsg.
+type PreconfiguredNSG string
+
+const (
+	// PreconfiguredNSGDisabled ...
+	PreconfiguredNSGDisabled PreconfiguredNSG = "Disabled"
+	// PreconfiguredNSGEnabled ...
+	PreconfiguredNSGEnabled PreconfiguredNSG = "Enabled"
+)
+
+// PossiblePreconfiguredNSGValues returns an array of possible values for the PreconfiguredNSG const type.
+func PossiblePreconfiguredNSGValues() []PreconfiguredNSG {
+	return []PreconfiguredNSG{PreconfiguredNSGDisabled, PreconfiguredNSGEnabled}
+}
+
+// ProvisioningState enumerates the values for provisioning state.
+type ProvisioningState string
+
+const (
+	// AdminUpdating ...
+	AdminUpdating ProvisioningState = "AdminUpdating"
+	// Cancelled ...
+	Cancelled ProvisioningState = "Cancelled"
+	// Creating ...
+	Creating ProvisioningState = "Creating"
+	// Deleting ...
+	Deleting ProvisioningState = "Deleting"
+	// Failed ...
+	Failed ProvisioningState = "Failed"
+	// Succeeded ...
+	Succeeded ProvisioningState = "Succeeded"
+	// Updating ...
+	Updating ProvisioningState = "Updating"
+)
+
+// PossibleProvisioningStateValues returns an array of possible values for the ProvisioningState const type.
+func PossibleProvisioningStateValues() []ProvisioningState {
+	return []ProvisioningState{AdminUpdating, Cancelled, Creating, Deleting, Failed, Succeeded, Updating}
+}
+
+// Visibility enumerates the values for visibility.
+type Visibility string
+
+const (
+	// Private ...
+	Private Visibility = "Private"
+	// Public ...
+	Public Visibility = "Public"
+)
+
+// PossibleVisibilityValues returns an array of possible values for the Visibility const type.
+func PossibleVisibilityValues() []Visibility {
+	return []Visibility{Private, Public}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	aroclient "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned"
 	"github.com/Azure/ARO-RP/pkg/operator/deploy"
+	keyvaultclient "github.com/Azure/ARO-RP/pkg/util/azureclient/keyvault"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
@@ -55,9 +56,11 @@ type manager struct {
 	log                 *logrus.Entry
 	env                 env.Interface
 	db                  database.OpenShiftClusters
+	dbAsyncOperations   database.AsyncOperations
 	dbGateway           database.Gateway
 	dbOpenShiftVersions database.OpenShiftVersions
 
+	aead              encryption.AEAD
 	billing           billing.Manager
 	doc               *api.OpenShiftClusterDocument
 	subscriptionDoc   *api.SubscriptionDocument
@@ -66,6 +69,7 @@ type manager struct {
 	metricsEmitter    metrics.Emitter
 
 	spGraphClient         *utilgraph.GraphServiceClient
+	spKeyvault            keyvaultclient.BaseClient
 	disks                 compute.DisksClient
 	virtualMachines       compute.VirtualMachinesClient
 	interfaces            network.InterfacesClient
@@ -113,7 +117,7 @@ type manager struct {
 }
 
 // New returns a cluster manager
-func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database.OpenShiftClusters, dbGateway database.Gateway, dbOpenShiftVersions database.OpenShiftVersions, aead encryption.AEAD,
+func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database.OpenShiftClusters, dbAsyncOperations database.AsyncOperations, dbGateway database.Gateway, dbOpenShiftVersions database.OpenShiftVersions, aead encryption.AEAD,
 	billing billing.Manager, doc *api.OpenShiftClusterDocument, subscriptionDoc *api.SubscriptionDocument, hiveClusterManager hive.ClusterManager, metricsEmitter metrics.Emitter,
 ) (Interface, error) {
 	r, err := azure.ParseResourceID(doc.OpenShiftCluster.ID)
@@ -152,8 +156,10 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 		log:                   log,
 		env:                   _env,
 		db:                    db,
+		dbAsyncOperations:     dbAsyncOperations,
 		dbGateway:             dbGateway,
 		dbOpenShiftVersions:   dbOpenShiftVersions,
+		aead:                  aead,
 		billing:               billing,
 		doc:                   doc,
 		subscriptionDoc:       subscriptionDoc,
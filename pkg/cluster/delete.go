@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"sort"
 	"strings"
 	"time"
 
@@ -24,8 +23,8 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/acrtoken"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+	"github.com/Azure/ARO-RP/pkg/util/clusterdelete"
 	"github.com/Azure/ARO-RP/pkg/util/dns"
-	"github.com/Azure/ARO-RP/pkg/util/rbac"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 )
 
@@ -151,24 +150,6 @@ func (m *manager) disconnectSecurityGroup(ctx context.Context, resourceID string
 	return nil
 }
 
-// deleteOrder maps resource types to the deletion level.  We walk the levels
-// from lowest to highest, deleting all the resources in the given level in
-// parallel and waiting for completion before we proceed.  Any type not in the
-// map is considered to be at level 0.  Keys must be lower case.
-var deleteOrder = map[string]int{
-	"microsoft.compute/virtualmachines":                 -3, // first, and before microsoft.compute/disks, microsoft.network/networkinterfaces
-	"microsoft.network/privatelinkservices":             -3, // before microsoft.network/loadbalancers
-	"microsoft.network/privateendpoints":                -3, // before microsoft.network/networkinterfaces
-	"microsoft.compute/galleries/applications/versions": -2, // before microsoft.compute/galleries/applications
-	"microsoft.compute/galleries/images/versions":       -2, // before microsoft.compute/galleries/images
-	"microsoft.compute/galleries/applications":          -1, // before microsoft.compute/galleries
-	"microsoft.compute/galleries/images":                -1, // before microsoft.compute/galleries
-	"microsoft.compute/galleries/serviceArtifacts":      -1, // before microsoft.compute/galleries
-	"microsoft.network/networkinterfaces":               -1, // before microsoft.network/loadbalancers
-	"microsoft.network/privatednszones":                 1,  // after everything else: get other deletions underway first
-	"microsoft.compute/galleries":                       1,  // after everything else in case there are nested microsoft.compute/galleries resources
-}
-
 func (m *manager) deleteResources(ctx context.Context) error {
 	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
 
@@ -182,30 +163,10 @@ func (m *manager) deleteResources(ctx context.Context) error {
 		return err
 	}
 
-	// group our resources by level
-	resourceMap := map[int][]*mgmtfeatures.GenericResourceExpanded{}
-	for i, resource := range resources {
-		level := deleteOrder[strings.ToLower(*resource.Type)]
-		resourceMap[level] = append(resourceMap[level], &resources[i])
-	}
-
-	levels := make([]int, 0, len(resourceMap))
-	for level := range resourceMap {
-		levels = append(levels, level)
-	}
-	sort.Ints(levels)
-
-	for _, level := range levels {
-		// ensure that resource deletion order is deterministic
-		sort.Slice(resourceMap[level], func(i, j int) bool {
-			return strings.Compare(
-				strings.ToLower(*resourceMap[level][i].ID),
-				strings.ToLower(*resourceMap[level][j].ID)) < 0
-		})
-
+	for _, level := range clusterdelete.Levels(resources) {
 		// asynchronously delete all resources in the level
-		futures := make([]mgmtfeatures.ResourcesDeleteByIDFuture, 0, len(resourceMap[level]))
-		for _, resource := range resourceMap[level] {
+		futures := make([]mgmtfeatures.ResourcesDeleteByIDFuture, 0, len(level))
+		for _, resource := range level {
 			apiVersion := azureclient.APIVersion(*resource.Type)
 			if apiVersion == "" {
 				m.log.Warnf("skipping resource %s", *resource.ID)
@@ -245,7 +206,7 @@ func (m *manager) deleteResources(ctx context.Context) error {
 
 		// wait for all the deletions to complete
 		for i, future := range futures {
-			m.log.Printf("waiting for deletion of %s", *resourceMap[level][i].ID)
+			m.log.Printf("waiting for deletion of %s", *level[i].ID)
 
 			err = future.WaitForCompletionRef(ctx, m.resources.Client())
 			if err != nil {
@@ -293,8 +254,7 @@ func (m *manager) deleteRoleAssignments(ctx context.Context) error {
 	}
 
 	for _, assignment := range roleAssignments {
-		if !strings.EqualFold(*assignment.Scope, resourceGroupID) ||
-			strings.HasSuffix(strings.ToLower(*assignment.RoleDefinitionID), strings.ToLower(rbac.RoleOwner)) /* should only matter in development */ {
+		if !clusterdelete.IsClusterRoleAssignment(assignment, resourceGroupID) {
 			continue
 		}
 
@@ -317,9 +277,7 @@ func (m *manager) deleteRoleDefinition(ctx context.Context) error {
 	}
 
 	for _, definition := range roleDefinitions {
-		if len(*definition.AssignableScopes) != 1 ||
-			!strings.EqualFold((*definition.AssignableScopes)[0], resourceGroupID) ||
-			!strings.HasPrefix(*definition.RoleName, "Azure Red Hat OpenShift cluster") {
+		if !clusterdelete.IsClusterRoleDefinition(definition, resourceGroupID) {
 			continue
 		}
 
@@ -511,5 +469,13 @@ func (m *manager) Delete(ctx context.Context) error {
 		}
 	}
 
-	return m.billing.Delete(ctx, m.doc)
+	err = m.billing.Delete(ctx, m.doc)
+	if err != nil {
+		return err
+	}
+
+	m.log.Print("checking for leftovers")
+	m.reportLeftovers(ctx)
+
+	return nil
 }
@@ -0,0 +1,173 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+	"github.com/Azure/ARO-RP/pkg/util/clusterdelete"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// reportLeftovers looks for objects which Delete should have removed but which
+// still exist, and records them in a report against the async operation of the
+// deletion.  It is best effort: the deletion has already succeeded, so checks
+// which fail are recorded in the report rather than returned.
+//
+// Signed certificates, the ACR token and the Hive namespace are not checked:
+// their deletion is confirmed before Delete returns.
+func (m *manager) reportLeftovers(ctx context.Context) {
+	report := m.deleteReport(ctx)
+
+	for _, item := range report.LeftBehind {
+		m.log.Warnf("left behind %s %s: %s", item.Kind, item.ID, item.Message)
+	}
+	for _, err := range report.Errors {
+		m.log.Warnf("could not check for leftovers: %s", err)
+	}
+
+	m.metricsEmitter.EmitGauge("backend.openshiftcluster.delete.leftbehind", int64(len(report.LeftBehind)), nil)
+
+	if m.doc.AsyncOperationID == "" {
+		return
+	}
+
+	_, err := m.dbAsyncOperations.Patch(ctx, m.doc.AsyncOperationID, func(asyncdoc *api.AsyncOperationDocument) error {
+		asyncdoc.DeleteReport = report
+		return nil
+	})
+	if err != nil {
+		m.log.Warnf("could not record delete report: %v", err)
+	}
+}
+
+func (m *manager) deleteReport(ctx context.Context) *api.DeleteReport {
+	report := &api.DeleteReport{
+		Time: m.now().UTC(),
+	}
+
+	leftBehind := func(kind clusterdelete.Kind, id, resourceType, message string) {
+		report.LeftBehind = append(report.LeftBehind, api.DeleteReportItem{
+			Kind:    string(kind),
+			ID:      id,
+			Type:    resourceType,
+			Message: message,
+		})
+	}
+
+	checkFailed := func(check string, err error) {
+		report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", check, err))
+	}
+
+	resourceGroupID := m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID
+	resourceGroup := stringutils.LastTokenByte(resourceGroupID, '/')
+
+	rg, err := m.resourceGroups.Get(ctx, resourceGroup)
+	switch {
+	case azureerrors.ResourceGroupNotFound(err) || azureerrors.IsNotFoundError(err):
+	case err != nil:
+		checkFailed("resource group", err)
+	case rg.ManagedBy != nil && strings.EqualFold(*rg.ManagedBy, m.doc.OpenShiftCluster.ID):
+		leftBehind(clusterdelete.KindResourceGroup, resourceGroupID, "", "")
+
+		resources, err := m.resources.ListByResourceGroup(ctx, resourceGroup, "", "", nil)
+		if err != nil {
+			checkFailed("resources", err)
+		}
+
+		for _, resource := range resources {
+			leftBehind(clusterdelete.KindResource, *resource.ID, *resource.Type, "")
+		}
+	}
+
+	roleAssignments, err := m.roleAssignments.ListForResourceGroup(ctx, resourceGroup, "")
+	if err != nil && !azureerrors.ResourceGroupNotFound(err) && !azureerrors.IsNotFoundError(err) {
+		checkFailed("role assignments", err)
+	}
+
+	for _, assignment := range roleAssignments {
+		if clusterdelete.IsClusterRoleAssignment(assignment, resourceGroupID) {
+			leftBehind(clusterdelete.KindRoleAssignment, *assignment.ID, "Microsoft.Authorization/roleAssignments", "")
+		}
+	}
+
+	// role definitions are not removed with the resource group which is
+	// their assignable scope, so check for them even if it is gone
+	roleDefinitions, err := m.roleDefinitions.List(ctx, resourceGroupID, "")
+	if err != nil && !azureerrors.ResourceGroupNotFound(err) && !azureerrors.IsNotFoundError(err) {
+		checkFailed("role definitions", err)
+	}
+
+	for _, definition := range roleDefinitions {
+		if clusterdelete.IsClusterRoleDefinition(definition, resourceGroupID) {
+			leftBehind(clusterdelete.KindRoleDefinition, *definition.ID, "Microsoft.Authorization/roleDefinitions", *definition.RoleName)
+		}
+	}
+
+	records, err := m.dns.ListRecords(ctx, m.doc.OpenShiftCluster)
+	if err != nil {
+		checkFailed("dns records", err)
+	}
+
+	for _, record := range records {
+		leftBehind(clusterdelete.KindDNSRecord, record+"."+m.env.Domain(), "", "")
+	}
+
+	pe, err := m.fpPrivateEndpoints.Get(ctx, m.env.ResourceGroup(), env.RPPrivateEndpointPrefix+m.doc.ID, "")
+	switch {
+	case azureerrors.IsNotFoundError(err):
+	case err != nil:
+		checkFailed("private endpoint", err)
+	default:
+		leftBehind(clusterdelete.KindPrivateEndpoint, *pe.ID, "Microsoft.Network/privateEndpoints", "")
+	}
+
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID != "" {
+		_, err = m.dbGateway.Get(ctx, m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID)
+		switch {
+		case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		case err != nil:
+			checkFailed("gateway record", err)
+		default:
+			leftBehind(clusterdelete.KindGatewayRecord, m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID, "", "")
+		}
+	}
+
+	subnetIDs, err := m.getSubnetIds()
+	if err != nil {
+		checkFailed("subnets", err)
+	}
+
+	seen := map[string]bool{}
+	for _, subnetID := range subnetIDs {
+		if seen[strings.ToLower(subnetID)] {
+			continue
+		}
+		seen[strings.ToLower(subnetID)] = true
+
+		s, err := m.subnet.Get(ctx, subnetID)
+		switch {
+		case azureerrors.IsNotFoundError(err):
+			continue
+		case err != nil:
+			checkFailed("subnet "+subnetID, err)
+			continue
+		}
+
+		if s.SubnetPropertiesFormat != nil &&
+			s.NetworkSecurityGroup != nil && s.NetworkSecurityGroup.ID != nil &&
+			strings.HasPrefix(strings.ToLower(*s.NetworkSecurityGroup.ID), strings.ToLower(resourceGroupID)+"/") {
+			leftBehind(clusterdelete.KindSubnet, subnetID, "Microsoft.Network/virtualNetworks/subnets", fmt.Sprintf("network security group %s is still attached", *s.NetworkSecurityGroup.ID))
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,292 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_authorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/authorization"
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	mock_dns "github.com/Azure/ARO-RP/pkg/util/mocks/dns"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	mock_subnet "github.com/Azure/ARO-RP/pkg/util/mocks/subnet"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestReportLeftovers(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+	asyncOperationID := "11111111-1111-1111-1111-111111111111"
+	docID := "22222222-2222-2222-2222-222222222222"
+	resourceGroupID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/aro-cluster"
+	vnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet"
+	nsgID := resourceGroupID + "/providers/Microsoft.Network/networkSecurityGroups/cluster-nsg"
+	gatewayID := "gateway-link-id"
+
+	notFound := autorest.DetailedError{
+		StatusCode: http.StatusNotFound,
+	}
+
+	type mocks struct {
+		resourceGroups     *mock_features.MockResourceGroupsClient
+		resources          *mock_features.MockResourcesClient
+		roleAssignments    *mock_authorization.MockRoleAssignmentsClient
+		roleDefinitions    *mock_authorization.MockRoleDefinitionsClient
+		dns                *mock_dns.MockManager
+		fpPrivateEndpoints *mock_network.MockPrivateEndpointsClient
+		subnet             *mock_subnet.MockManager
+	}
+
+	for _, tt := range []struct {
+		name       string
+		mocks      func(*mocks)
+		gateway    bool
+		wantReport *api.DeleteReport
+		wantGauge  int64
+	}{
+		{
+			name: "nothing left behind",
+			mocks: func(m *mocks) {
+				m.resourceGroups.EXPECT().Get(gomock.Any(), "aro-cluster").Return(mgmtfeatures.ResourceGroup{}, notFound)
+				m.roleAssignments.EXPECT().ListForResourceGroup(gomock.Any(), "aro-cluster", "").Return(nil, notFound)
+				m.roleDefinitions.EXPECT().List(gomock.Any(), resourceGroupID, "").Return(nil, nil)
+				m.dns.EXPECT().ListRecords(gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.fpPrivateEndpoints.EXPECT().Get(gomock.Any(), "rpResourceGroup", "rp-pe-"+docID, "").Return(mgmtnetwork.PrivateEndpoint{}, notFound)
+				m.subnet.EXPECT().Get(gomock.Any(), vnetID+"/subnets/master").Return(&mgmtnetwork.Subnet{
+					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{},
+				}, nil)
+				m.subnet.EXPECT().Get(gomock.Any(), vnetID+"/subnets/worker").Return(nil, notFound)
+			},
+			wantReport: &api.DeleteReport{
+				Time: now,
+			},
+		},
+		{
+			name:    "objects left behind",
+			gateway: true,
+			mocks: func(m *mocks) {
+				m.resourceGroups.EXPECT().Get(gomock.Any(), "aro-cluster").Return(mgmtfeatures.ResourceGroup{
+					ManagedBy: to.StringPtr(resourceID),
+				}, nil)
+				m.resources.EXPECT().ListByResourceGroup(gomock.Any(), "aro-cluster", "", "", nil).Return([]mgmtfeatures.GenericResourceExpanded{
+					{
+						ID:   to.StringPtr(nsgID),
+						Type: to.StringPtr("Microsoft.Network/networkSecurityGroups"),
+					},
+				}, nil)
+				m.roleAssignments.EXPECT().ListForResourceGroup(gomock.Any(), "aro-cluster", "").Return(nil, nil)
+				m.roleDefinitions.EXPECT().List(gomock.Any(), resourceGroupID, "").Return([]mgmtauthorization.RoleDefinition{
+					{
+						ID: to.StringPtr("/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleDefinitions/definition"),
+						RoleDefinitionProperties: &mgmtauthorization.RoleDefinitionProperties{
+							RoleName:         to.StringPtr("Azure Red Hat OpenShift cluster-12345"),
+							AssignableScopes: &[]string{resourceGroupID},
+						},
+					},
+				}, nil)
+				m.dns.EXPECT().ListRecords(gomock.Any(), gomock.Any()).Return([]string{"*.apps.cluster"}, nil)
+				m.fpPrivateEndpoints.EXPECT().Get(gomock.Any(), "rpResourceGroup", "rp-pe-"+docID, "").Return(mgmtnetwork.PrivateEndpoint{
+					ID: to.StringPtr("/subscriptions/rpSubscription/resourceGroups/rpResourceGroup/providers/Microsoft.Network/privateEndpoints/rp-pe-" + docID),
+				}, nil)
+				m.subnet.EXPECT().Get(gomock.Any(), vnetID+"/subnets/master").Return(&mgmtnetwork.Subnet{
+					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+						NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{
+							ID: to.StringPtr(nsgID),
+						},
+					},
+				}, nil)
+				m.subnet.EXPECT().Get(gomock.Any(), vnetID+"/subnets/worker").Return(&mgmtnetwork.Subnet{
+					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+						NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{
+							ID: to.StringPtr(vnetID + "-rg/providers/Microsoft.Network/networkSecurityGroups/customer-nsg"),
+						},
+					},
+				}, nil)
+			},
+			wantReport: &api.DeleteReport{
+				Time: now,
+				LeftBehind: []api.DeleteReportItem{
+					{
+						Kind: "ResourceGroup",
+						ID:   resourceGroupID,
+					},
+					{
+						Kind: "Resource",
+						ID:   nsgID,
+						Type: "Microsoft.Network/networkSecurityGroups",
+					},
+					{
+						Kind:    "RoleDefinition",
+						ID:      "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleDefinitions/definition",
+						Type:    "Microsoft.Authorization/roleDefinitions",
+						Message: "Azure Red Hat OpenShift cluster-12345",
+					},
+					{
+						Kind: "DNSRecord",
+						ID:   "*.apps.cluster.location.aroapp.io",
+					},
+					{
+						Kind: "PrivateEndpoint",
+						ID:   "/subscriptions/rpSubscription/resourceGroups/rpResourceGroup/providers/Microsoft.Network/privateEndpoints/rp-pe-" + docID,
+						Type: "Microsoft.Network/privateEndpoints",
+					},
+					{
+						Kind: "GatewayRecord",
+						ID:   gatewayID,
+					},
+					{
+						Kind:    "Subnet",
+						ID:      vnetID + "/subnets/master",
+						Type:    "Microsoft.Network/virtualNetworks/subnets",
+						Message: "network security group " + nsgID + " is still attached",
+					},
+				},
+			},
+			wantGauge: 7,
+		},
+		{
+			name: "checks fail",
+			mocks: func(m *mocks) {
+				m.resourceGroups.EXPECT().Get(gomock.Any(), "aro-cluster").Return(mgmtfeatures.ResourceGroup{}, fmt.Errorf("random error"))
+				m.roleAssignments.EXPECT().ListForResourceGroup(gomock.Any(), "aro-cluster", "").Return(nil, nil)
+				m.roleDefinitions.EXPECT().List(gomock.Any(), resourceGroupID, "").Return(nil, nil)
+				m.dns.EXPECT().ListRecords(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("random error"))
+				m.fpPrivateEndpoints.EXPECT().Get(gomock.Any(), "rpResourceGroup", "rp-pe-"+docID, "").Return(mgmtnetwork.PrivateEndpoint{}, notFound)
+				m.subnet.EXPECT().Get(gomock.Any(), vnetID+"/subnets/master").Return(nil, fmt.Errorf("random error"))
+				m.subnet.EXPECT().Get(gomock.Any(), vnetID+"/subnets/worker").Return(nil, notFound)
+			},
+			wantReport: &api.DeleteReport{
+				Time: now,
+				Errors: []string{
+					"resource group: random error",
+					"dns records: random error",
+					"subnet " + vnetID + "/subnets/master: random error",
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().Domain().AnyTimes().Return("location.aroapp.io")
+			_env.EXPECT().ResourceGroup().AnyTimes().Return("rpResourceGroup")
+
+			m := &mocks{
+				resourceGroups:     mock_features.NewMockResourceGroupsClient(controller),
+				resources:          mock_features.NewMockResourcesClient(controller),
+				roleAssignments:    mock_authorization.NewMockRoleAssignmentsClient(controller),
+				roleDefinitions:    mock_authorization.NewMockRoleDefinitionsClient(controller),
+				dns:                mock_dns.NewMockManager(controller),
+				fpPrivateEndpoints: mock_network.NewMockPrivateEndpointsClient(controller),
+				subnet:             mock_subnet.NewMockManager(controller),
+			}
+			tt.mocks(m)
+
+			dbAsyncOperations, _ := testdatabase.NewFakeAsyncOperations()
+			dbGateway, _ := testdatabase.NewFakeGateway()
+
+			fixture := testdatabase.NewFixture().WithAsyncOperations(dbAsyncOperations).WithGateway(dbGateway)
+			fixture.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+				ID:                  asyncOperationID,
+				OpenShiftClusterKey: strings.ToLower(resourceID),
+				AsyncOperation: &api.AsyncOperation{
+					ProvisioningState: api.ProvisioningStateDeleting,
+				},
+			})
+			if tt.gateway {
+				fixture.AddGatewayDocuments(&api.GatewayDocument{
+					ID:      gatewayID,
+					Gateway: &api.Gateway{},
+				})
+			}
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			metrics := newfakeMetricsEmitter()
+
+			oc := &api.OpenShiftCluster{
+				ID: resourceID,
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						ResourceGroupID: resourceGroupID,
+					},
+					MasterProfile: api.MasterProfile{
+						SubnetID: vnetID + "/subnets/master",
+					},
+					WorkerProfiles: []api.WorkerProfile{
+						{
+							Name:     "worker1",
+							SubnetID: vnetID + "/subnets/worker",
+						},
+						{
+							Name:     "worker2",
+							SubnetID: vnetID + "/subnets/worker",
+						},
+					},
+				},
+			}
+			if tt.gateway {
+				oc.Properties.NetworkProfile.GatewayPrivateLinkID = gatewayID
+			}
+
+			mgr := &manager{
+				log:                logrus.NewEntry(logrus.StandardLogger()),
+				env:                _env,
+				dbAsyncOperations:  dbAsyncOperations,
+				dbGateway:          dbGateway,
+				metricsEmitter:     metrics,
+				resourceGroups:     m.resourceGroups,
+				resources:          m.resources,
+				roleAssignments:    m.roleAssignments,
+				roleDefinitions:    m.roleDefinitions,
+				dns:                m.dns,
+				fpPrivateEndpoints: m.fpPrivateEndpoints,
+				subnet:             m.subnet,
+				now:                func() time.Time { return now },
+				doc: &api.OpenShiftClusterDocument{
+					ID:               docID,
+					AsyncOperationID: asyncOperationID,
+					OpenShiftCluster: oc,
+				},
+			}
+
+			mgr.reportLeftovers(ctx)
+
+			asyncdoc, err := dbAsyncOperations.Get(ctx, asyncOperationID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, err := range deep.Equal(asyncdoc.DeleteReport, tt.wantReport) {
+				t.Error(err)
+			}
+
+			if metrics.Metrics["backend.openshiftcluster.delete.leftbehind"] != tt.wantGauge {
+				t.Error(metrics.Metrics["backend.openshiftcluster.delete.leftbehind"])
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+	"github.com/Azure/ARO-RP/pkg/util/diagnostics"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// saveDiagnosticBundle stores a diagnostic bundle of a failed installation in
+// the cluster storage account.  It is best effort: failing to save the bundle
+// must not mask the installation error.
+func (m *manager) saveDiagnosticBundle(ctx context.Context) {
+	name, err := m._saveDiagnosticBundle(ctx)
+	if err != nil {
+		m.log.Warnf("could not save diagnostic bundle: %v", err)
+		return
+	}
+
+	m.log.Printf("saved diagnostic bundle %s", name)
+}
+
+func (m *manager) _saveDiagnosticBundle(ctx context.Context) (string, error) {
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	account := "cluster" + m.doc.OpenShiftCluster.Properties.StorageSuffix
+
+	blobService, err := m.storage.BlobService(ctx, resourceGroup, account, mgmtstorage.Permissions("crwdl"), mgmtstorage.SignedResourceTypes("co"))
+	if err != nil {
+		return "", err
+	}
+
+	now := m.now().UTC()
+
+	data, err := m.collectDiagnosticBundle(ctx, blobService)
+	if err != nil {
+		return "", err
+	}
+
+	container := blobService.GetContainerReference(diagnostics.Container)
+	_, err = container.CreateIfNotExists(nil)
+	if err != nil {
+		return "", err
+	}
+
+	name := diagnostics.BlobName(now)
+
+	err = container.GetBlobReference(name).CreateBlockBlobFromReader(bytes.NewReader(data), nil)
+	if err != nil {
+		return "", err
+	}
+
+	expired, err := diagnostics.Prune(container, m.now())
+	for _, name := range expired {
+		m.log.Printf("removed expired diagnostic bundle %s", name)
+	}
+
+	return name, err
+}
+
+// collectDiagnosticBundle gathers the installation state, the bootstrap node
+// serial log and the default set of cluster resources and operator logs into
+// a bundle, and returns it encrypted
+func (m *manager) collectDiagnosticBundle(ctx context.Context, blobService *azstorage.BlobStorageClient) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	b := diagnostics.NewBundle(buf, m.now().UTC())
+
+	err := b.AddJSON("install.json", map[string]interface{}{
+		"provisioningState": m.doc.OpenShiftCluster.Properties.ProvisioningState,
+		"install":           m.doc.OpenShiftCluster.Properties.Install,
+		"operationProgress": m.doc.OpenShiftCluster.Properties.OperationProgress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m.installViaHive && m.hiveClusterManager != nil {
+		cd, err := m.hiveClusterManager.GetClusterDeployment(ctx, m.doc)
+		if err != nil {
+			b.Errorf("clusterdeployment: %v", err)
+		} else {
+			cd.ManagedFields = nil
+
+			err = b.AddJSON("hive/clusterdeployment.json", cd)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	serialLog, err := m.bootstrapSerialLog(ctx, blobService)
+	switch {
+	case err != nil:
+		b.Errorf("bootstrap serial log: %v", err)
+	case serialLog != nil:
+		err = b.Add("bootstrap/serial.log", serialLog)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = diagnostics.NewCollector(m.kubernetescli, m.dynamiccli, nil).Collect(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.aead.Seal(buf.Bytes())
+}
+
+// bootstrapSerialLog returns the serial log of the bootstrap node, or nil if
+// the bootstrap node does not exist
+func (m *manager) bootstrapSerialLog(ctx context.Context, blobService *azstorage.BlobStorageClient) ([]byte, error) {
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+	if infraID == "" {
+		return nil, nil
+	}
+
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	vm, err := m.virtualMachines.Get(ctx, resourceGroup, infraID+"-bootstrap", mgmtcompute.InstanceView)
+	if azureerrors.IsNotFoundError(err) {
+		// the bootstrap node is removed once the installation has progressed
+		// beyond bootstrapping
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if vm.InstanceView == nil || vm.InstanceView.BootDiagnostics == nil || vm.InstanceView.BootDiagnostics.SerialConsoleLogBlobURI == nil {
+		return nil, nil
+	}
+
+	u, err := url.Parse(*vm.InstanceView.BootDiagnostics.SerialConsoleLogBlobURI)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("serialConsoleLogBlobURI has %d parts, expected 3", len(parts))
+	}
+
+	rc, err := blobService.GetContainerReference(parts[1]).GetBlobReference(parts[2]).Get(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,148 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+)
+
+func TestCollectDiagnosticBundle(t *testing.T) {
+	ctx := context.Background()
+
+	aead, err := encryption.NewXChaCha20Poly1305(ctx, make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name       string
+		infraID    string
+		mocks      func(*mock_compute.MockVirtualMachinesClient)
+		wantFiles  []string
+		wantErrors string
+	}{
+		{
+			name:      "installation failed before infrastructure was created",
+			wantFiles: []string{"install.json"},
+		},
+		{
+			name:    "bootstrap node removed",
+			infraID: "infra",
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient) {
+				vms.EXPECT().
+					Get(gomock.Any(), "resourceGroup", "infra-bootstrap", mgmtcompute.InstanceView).
+					Return(mgmtcompute.VirtualMachine{}, autorest.DetailedError{StatusCode: http.StatusNotFound})
+			},
+			wantFiles: []string{"install.json"},
+		},
+		{
+			name:    "bootstrap serial log unavailable",
+			infraID: "infra",
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient) {
+				vms.EXPECT().
+					Get(gomock.Any(), "resourceGroup", "infra-bootstrap", mgmtcompute.InstanceView).
+					Return(mgmtcompute.VirtualMachine{}, errors.New("random error"))
+			},
+			wantFiles:  []string{"errors.txt", "install.json"},
+			wantErrors: "bootstrap serial log: random error\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			vms := mock_compute.NewMockVirtualMachinesClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(vms)
+			}
+
+			m := &manager{
+				log:  logrus.NewEntry(logrus.StandardLogger()),
+				aead: aead,
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateCreating,
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: "/subscriptions/subscriptionId/resourceGroups/resourceGroup",
+							},
+							InfraID: tt.infraID,
+						},
+					},
+				},
+				virtualMachines: vms,
+				kubernetescli:   fake.NewSimpleClientset(),
+				now:             func() time.Time { return time.Unix(0, 0) },
+			}
+
+			sealed, err := m.collectDiagnosticBundle(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := aead.Open(sealed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gr, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			files := map[string]string{}
+			tr := tar.NewReader(gr)
+			for {
+				h, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				b, err := io.ReadAll(tr)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				files[h.Name] = string(b)
+			}
+
+			var names []string
+			for name := range files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			if !reflect.DeepEqual(names, tt.wantFiles) {
+				t.Error(names)
+			}
+
+			if files["errors.txt"] != tt.wantErrors {
+				t.Error(files["errors.txt"])
+			}
+		})
+	}
+}
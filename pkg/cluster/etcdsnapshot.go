@@ -0,0 +1,266 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"time"
+
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/etcdsnapshot"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// etcdSnapshotPollInterval is a var so that it can be overridden in tests
+var etcdSnapshotPollInterval = 10 * time.Second
+
+// takeEtcdSnapshot takes an etcd snapshot on a control plane node.  The
+// snapshot is encrypted with the cluster's snapshot key before it is uploaded
+// to the cluster storage account, and snapshots beyond the cluster's retention
+// are then removed.
+func (m *manager) takeEtcdSnapshot(ctx context.Context) error {
+	err := m.ensureEtcdSnapshotEncryptionKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	node, err := m.etcdSnapshotNode(ctx)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	account := "cluster" + m.doc.OpenShiftCluster.Properties.StorageSuffix
+
+	blobService, err := m.storage.BlobService(ctx, resourceGroup, account, mgmtstorage.Permissions("cdl"), mgmtstorage.SignedResourceTypes("co"))
+	if err != nil {
+		return err
+	}
+
+	container := blobService.GetContainerReference(etcdsnapshot.Container)
+	_, err = container.CreateIfNotExists(nil)
+	if err != nil {
+		return err
+	}
+
+	now := m.now().UTC()
+	name := etcdsnapshot.BlobName(now)
+
+	snapshotURL, err := m.storage.BlobSASURL(ctx, resourceGroup, account, etcdsnapshot.Container, name, mgmtstorage.Permissions("cw"))
+	if err != nil {
+		return err
+	}
+
+	m.log.Printf("taking etcd snapshot %s on node %s", name, node)
+
+	secret, job := etcdsnapshot.SnapshotJob(node, snapshotURL, m.doc.OpenShiftCluster.Properties.EtcdSnapshotProfile.EncryptionKey)
+	err = m.runEtcdSnapshotJob(ctx, secret, job)
+	if err != nil {
+		return err
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.EtcdSnapshotProfile.LastSnapshotName = name
+		doc.OpenShiftCluster.Properties.EtcdSnapshotProfile.LastSnapshotTime = &now
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return m.pruneEtcdSnapshots(container)
+}
+
+// ensureEtcdSnapshotEncryptionKey generates the cluster's snapshot encryption
+// key when the first snapshot is taken
+func (m *manager) ensureEtcdSnapshotEncryptionKey(ctx context.Context) error {
+	p := m.doc.OpenShiftCluster.Properties.EtcdSnapshotProfile
+	if p != nil && len(p.EncryptionKey) > 0 {
+		return nil
+	}
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return err
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		if doc.OpenShiftCluster.Properties.EtcdSnapshotProfile == nil {
+			doc.OpenShiftCluster.Properties.EtcdSnapshotProfile = &api.EtcdSnapshotProfile{}
+		}
+		if len(doc.OpenShiftCluster.Properties.EtcdSnapshotProfile.EncryptionKey) == 0 {
+			doc.OpenShiftCluster.Properties.EtcdSnapshotProfile.EncryptionKey = key
+		}
+		return nil
+	})
+	return err
+}
+
+// etcdSnapshotNode returns the first ready control plane node
+func (m *manager) etcdSnapshotNode(ctx context.Context) (string, error) {
+	masters, err := m.kubernetescli.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: "node-role.kubernetes.io/master",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Slice(masters.Items, func(i, j int) bool { return masters.Items[i].Name < masters.Items[j].Name })
+
+	for i := range masters.Items {
+		if ready.NodeIsReady(&masters.Items[i]) {
+			return masters.Items[i].Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no ready master node found")
+}
+
+// runEtcdSnapshotJob runs the given job to completion and removes it
+// afterwards.  The logs of a failed job are logged.
+func (m *manager) runEtcdSnapshotJob(ctx context.Context, secret *corev1.Secret, job *batchv1.Job) error {
+	// remove anything left behind by a previous attempt which was interrupted
+	err := m.deleteEtcdSnapshotJob(ctx, secret, job)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err := m.deleteEtcdSnapshotJob(ctx, secret, job)
+		if err != nil {
+			m.log.Error(err)
+		}
+	}()
+
+	_, err = m.kubernetescli.CoreV1().Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = m.kubernetescli.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(*job.Spec.ActiveDeadlineSeconds)*time.Second+time.Minute)
+	defer cancel()
+
+	var jobErr error
+	err = wait.PollImmediateUntil(etcdSnapshotPollInterval, func() (bool, error) {
+		j, err := m.kubernetescli.BatchV1().Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			m.log.Info(err)
+			return false, nil
+		}
+
+		for _, c := range j.Status.Conditions {
+			if c.Status != corev1.ConditionTrue {
+				continue
+			}
+
+			switch c.Type {
+			case batchv1.JobComplete:
+				return true, nil
+			case batchv1.JobFailed:
+				jobErr = fmt.Errorf("job %s failed: %s", job.Name, c.Message)
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}, timeoutCtx.Done())
+	if err != nil {
+		jobErr = fmt.Errorf("job %s did not complete: %w", job.Name, err)
+	}
+
+	if jobErr != nil {
+		m.logEtcdSnapshotJobPods(ctx, job)
+	}
+
+	return jobErr
+}
+
+func (m *manager) logEtcdSnapshotJobPods(ctx context.Context, job *batchv1.Job) {
+	pods, err := m.kubernetescli.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(job.Spec.Template.Labels).String(),
+	})
+	if err != nil {
+		m.log.Error(err)
+		return
+	}
+
+	for _, pod := range pods.Items {
+		b, err := m.kubernetescli.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+		if err != nil {
+			m.log.Error(err)
+			continue
+		}
+
+		m.log.Printf("pod %s logs: %s", pod.Name, string(b))
+	}
+}
+
+func (m *manager) deleteEtcdSnapshotJob(ctx context.Context, secret *corev1.Secret, job *batchv1.Job) error {
+	propagationPolicy := metav1.DeletePropagationBackground
+
+	err := m.kubernetescli.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	err = m.kubernetescli.CoreV1().Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// pruneEtcdSnapshots removes the oldest snapshots beyond the cluster's
+// retention
+func (m *manager) pruneEtcdSnapshots(container *azstorage.Container) error {
+	var names []string
+
+	params := azstorage.ListBlobsParameters{}
+	for {
+		res, err := container.ListBlobs(params)
+		if err != nil {
+			return err
+		}
+
+		for _, blob := range res.Blobs {
+			names = append(names, blob.Name)
+		}
+
+		if res.NextMarker == "" {
+			break
+		}
+		params.Marker = res.NextMarker
+	}
+
+	for _, name := range etcdsnapshot.Expired(names, etcdsnapshot.Retention(m.doc.OpenShiftCluster.Properties.EtcdSnapshotProfile)) {
+		m.log.Printf("removing expired etcd snapshot %s", name)
+
+		_, err := container.GetBlobReference(name).DeleteIfExists(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/etcdsnapshot"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEtcdSnapshotNode(t *testing.T) {
+	ctx := context.Background()
+
+	node := func(name, role string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"node-role.kubernetes.io/" + role: "",
+				},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: ready,
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		nodes    []kruntime.Object
+		wantNode string
+		wantErr  string
+	}{
+		{
+			name: "first ready master is chosen",
+			nodes: []kruntime.Object{
+				node("master-2", "master", corev1.ConditionTrue),
+				node("master-0", "master", corev1.ConditionFalse),
+				node("master-1", "master", corev1.ConditionTrue),
+				node("worker-0", "worker", corev1.ConditionTrue),
+			},
+			wantNode: "master-1",
+		},
+		{
+			name: "no ready master",
+			nodes: []kruntime.Object{
+				node("master-0", "master", corev1.ConditionFalse),
+				node("worker-0", "worker", corev1.ConditionTrue),
+			},
+			wantErr: "no ready master node found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				kubernetescli: fake.NewSimpleClientset(tt.nodes...),
+			}
+
+			node, err := m.etcdSnapshotNode(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if node != tt.wantNode {
+				t.Error(node)
+			}
+		})
+	}
+}
+
+func TestRunEtcdSnapshotJob(t *testing.T) {
+	ctx := context.Background()
+
+	etcdSnapshotPollInterval = time.Millisecond
+	defer func() { etcdSnapshotPollInterval = 10 * time.Second }()
+
+	for _, tt := range []struct {
+		name      string
+		condition batchv1.JobCondition
+		wantErr   string
+	}{
+		{
+			name: "job completes",
+			condition: batchv1.JobCondition{
+				Type:   batchv1.JobComplete,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "job fails",
+			condition: batchv1.JobCondition{
+				Type:    batchv1.JobFailed,
+				Status:  corev1.ConditionTrue,
+				Message: "Job has reached the specified backoff limit",
+			},
+			wantErr: "job aro-etcd-snapshot failed: Job has reached the specified backoff limit",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kubernetescli := fake.NewSimpleClientset()
+			kubernetescli.PrependReactor("create", "jobs", func(action ktesting.Action) (bool, kruntime.Object, error) {
+				job := action.(ktesting.CreateAction).GetObject().(*batchv1.Job)
+				job.Status.Conditions = []batchv1.JobCondition{tt.condition}
+				return false, nil, nil
+			})
+
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				kubernetescli: kubernetescli,
+			}
+
+			secret, job := etcdsnapshot.SnapshotJob("master-0", "https://cluster.blob.core.windows.net/etcd-snapshots/snapshot", []byte("key"))
+
+			err := m.runEtcdSnapshotJob(ctx, secret, job)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			_, err = kubernetescli.BatchV1().Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+			if !kerrors.IsNotFound(err) {
+				t.Errorf("job was not removed: %v", err)
+			}
+
+			_, err = kubernetescli.CoreV1().Secrets(secret.Namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+			if !kerrors.IsNotFound(err) {
+				t.Errorf("secret was not removed: %v", err)
+			}
+		})
+	}
+}
+
+func TestEnsureEtcdSnapshotEncryptionKey(t *testing.T) {
+	ctx := context.Background()
+
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+
+	for _, tt := range []struct {
+		name    string
+		profile *api.EtcdSnapshotProfile
+		wantKey []byte
+	}{
+		{
+			name: "key is generated",
+		},
+		{
+			name: "key is generated for existing profile",
+			profile: &api.EtcdSnapshotProfile{
+				Interval: "24h",
+			},
+		},
+		{
+			name: "existing key is kept",
+			profile: &api.EtcdSnapshotProfile{
+				EncryptionKey: []byte("existing"),
+			},
+			wantKey: []byte("existing"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:   api.ProvisioningStateAdminUpdating,
+						EtcdSnapshotProfile: tt.profile,
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterdoc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				doc: clusterdoc,
+				db:  fakeOpenShiftClustersDatabase,
+			}
+
+			err = m.ensureEtcdSnapshotEncryptionKey(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			key := doc.OpenShiftCluster.Properties.EtcdSnapshotProfile.EncryptionKey
+			if tt.wantKey != nil && !bytes.Equal(key, tt.wantKey) {
+				t.Errorf("got key %q", key)
+			}
+			if tt.wantKey == nil && len(key) != 32 {
+				t.Errorf("got key of length %d", len(key))
+			}
+
+			if tt.profile != nil && doc.OpenShiftCluster.Properties.EtcdSnapshotProfile.Interval != tt.profile.Interval {
+				t.Error(doc.OpenShiftCluster.Properties.EtcdSnapshotProfile.Interval)
+			}
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/coreos/go-semver/semver"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	imageregistryclient "github.com/openshift/client-go/imageregistry/clientset/versioned"
 	machineclient "github.com/openshift/client-go/machine/clientset/versioned"
@@ -30,146 +29,44 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
-const (
-	operatorCutoffVersion = "4.7.0" // OCP versions older than this will not receive ARO operator updates
-)
-
 // AdminUpdate performs an admin update of an ARO cluster
 func (m *manager) AdminUpdate(ctx context.Context) error {
 	toRun := m.adminUpdate()
-	return m.runSteps(ctx, toRun, "adminUpdate")
+	return m.runSteps(ctx, toRun, "adminUpdate", m.progress("AdminUpdate", toRun, 0))
 }
 
+// adminUpdate returns the steps of the cluster's maintenance task.  An empty
+// task runs Everything.
 func (m *manager) adminUpdate() []steps.Step {
 	task := m.doc.OpenShiftCluster.Properties.MaintenanceTask
-	isEverything := task == api.MaintenanceTaskEverything || task == ""
-	isOperator := task == api.MaintenanceTaskOperator
-	isRenewCerts := task == api.MaintenanceTaskRenewCerts
-
-	// Generic fix-up or setup actions that are fairly safe to always take, and
-	// don't require a running cluster
-	toRun := []steps.Step{
-		steps.Action(m.initializeKubernetesClients), // must be first
-		steps.Action(m.ensureBillingRecord),         // belt and braces
-		steps.Action(m.ensureDefaults),
-
-		// TODO: this relies on an authorizer that isn't exposed in the manager
-		// struct, so we'll rebuild the fpAuthorizer and use the error catching
-		// to advance
-		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.fixupClusterSPObjectID),
-		steps.Action(m.fixInfraID), // Old clusters lacks infraID in the database. Which makes code prone to errors.
-	}
-
-	if isEverything {
-		toRun = append(toRun,
-			steps.Action(m.ensureResourceGroup), // re-create RP RBAC if needed after tenant migration
-			steps.Action(m.createOrUpdateDenyAssignment),
-			steps.Action(m.ensureServiceEndpoints),
-			steps.Action(m.populateRegistryStorageAccountName), // must go before migrateStorageAccounts
-			steps.Action(m.migrateStorageAccounts),
-			steps.Action(m.fixSSH),
-			// steps.Action(m.removePrivateDNSZone), // TODO(mj): re-enable once we communicate this out
-		)
-	}
-
-	if isEverything || isRenewCerts {
-		toRun = append(toRun,
-			steps.Action(m.populateDatabaseIntIP),
-		)
-	}
-
-	// Make sure the VMs are switched on and we have an APIServer
-	toRun = append(toRun,
-		steps.Action(m.startVMs),
-		steps.Condition(m.apiServersReady, 30*time.Minute, true),
-	)
-
-	// Requires Kubernetes clients
-	if isEverything {
-		toRun = append(toRun,
-			steps.Action(m.fixSREKubeconfig),
-			steps.Action(m.fixUserAdminKubeconfig),
-			steps.Action(m.createOrUpdateRouterIPFromCluster),
-		)
-	}
-
-	if isEverything || isRenewCerts {
-		toRun = append(toRun,
-			steps.Action(m.fixMCSCert),
-			steps.Action(m.fixMCSUserData),
-		)
-	}
-
-	if isEverything {
-		toRun = append(toRun,
-			steps.Action(m.ensureGatewayUpgrade),
-			steps.Action(m.rotateACRTokenPassword),
-		)
-	}
-
-	if isEverything || isRenewCerts {
-		toRun = append(toRun,
-			steps.Action(m.configureAPIServerCertificate),
-			steps.Action(m.configureIngressCertificate),
-		)
-	}
-
-	if isEverything {
-		toRun = append(toRun,
-			steps.Action(m.populateRegistryStorageAccountName),
-			steps.Action(m.ensureMTUSize),
-		)
-	}
-
-	if isEverything || isOperator || isRenewCerts {
-		toRun = append(toRun,
-			steps.Action(m.initializeOperatorDeployer))
-	}
-
-	if isRenewCerts {
-		toRun = append(toRun,
-			steps.Action(m.renewMDSDCertificate),
-		)
+	if task == "" {
+		task = api.MaintenanceTaskEverything
+	}
+
+	def := api.GetMaintenanceTaskDefinition(task)
+	if def == nil {
+		// unknown tasks only make sure the VMs are switched on and we have an
+		// APIServer
+		def = &api.MaintenanceTaskDefinition{
+			Steps: []api.MaintenanceTaskStep{
+				api.MaintenanceTaskStepPrepare,
+				api.MaintenanceTaskStepStartCluster,
+			},
+		}
 	}
 
-	// Update the ARO Operator
-	if (isEverything || isOperator) && m.shouldUpdateOperator() {
-		toRun = append(toRun,
-			steps.Action(m.ensureAROOperator),
-			steps.Condition(m.aroDeploymentReady, 20*time.Minute, true),
-			steps.Condition(m.ensureAROOperatorRunningDesiredVersion, 5*time.Minute, true),
-		)
-	}
+	groups := m.maintenanceTaskSteps()
 
-	// Hive cluster adoption and reconciliation
-	if isEverything && m.adoptViaHive && !m.clusterWasCreatedByHive() {
-		toRun = append(toRun,
-			steps.Action(m.hiveCreateNamespace),
-			steps.Action(m.hiveEnsureResources),
-			steps.Condition(m.hiveClusterDeploymentReady, 5*time.Minute, false),
-			steps.Action(m.hiveResetCorrelationData),
-		)
-	}
-
-	// We don't run this on an operator-only deploy as PUCM scripts then cannot
-	// determine if the cluster has been fully admin-updated
-	if isEverything {
-		toRun = append(toRun,
-			steps.Action(m.updateProvisionedBy), // Run this last so we capture the resource provider only once the upgrade has been fully performed
-		)
+	var toRun []steps.Step
+	for _, step := range def.Steps {
+		toRun = append(toRun, groups[step]...)
 	}
 
 	return toRun
 }
 
 func (m *manager) shouldUpdateOperator() bool {
-	runningVersion, err := semver.NewVersion(m.doc.OpenShiftCluster.Properties.ClusterProfile.Version)
-	if err != nil {
-		return false
-	}
-
-	cutoffVersion := semver.New(operatorCutoffVersion)
-	return cutoffVersion.Compare(*runningVersion) <= 0
+	return version.OperatorSupported(m.doc.OpenShiftCluster.Properties.ClusterProfile.Version)
 }
 
 func (m *manager) clusterWasCreatedByHive() bool {
@@ -220,7 +117,7 @@ func (m *manager) Update(ctx context.Context) error {
 		)
 	}
 
-	return m.runSteps(ctx, s, "update")
+	return m.runSteps(ctx, s, "update", m.progress("Update", s, 0))
 }
 
 func (m *manager) runPodmanInstaller(ctx context.Context) error {
@@ -379,14 +276,22 @@ func (m *manager) Install(ctx context.Context) error {
 		return fmt.Errorf("unrecognised phase %s", m.doc.OpenShiftCluster.Properties.Install.Phase)
 	}
 	m.log.Printf("starting phase %s", m.doc.OpenShiftCluster.Properties.Install.Phase)
-	return m.runSteps(ctx, steps[m.doc.OpenShiftCluster.Properties.Install.Phase], "install")
+	err = m.runSteps(ctx, steps[m.doc.OpenShiftCluster.Properties.Install.Phase], "install", m.installProgress(steps, m.doc.OpenShiftCluster.Properties.Install.Phase))
+	if err != nil {
+		m.saveDiagnosticBundle(ctx)
+	}
+
+	return err
 }
 
-func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic string) error {
+// runSteps runs s, emitting the durations of the steps as metrics.  If progress is not nil, it is called before each step and the
+// progress recorded on the cluster document is cleared once all steps have
+// succeeded.
+func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic string, progress steps.ProgressFunc) error {
 	var err error
 	if metricsTopic != "" {
 		var stepsTimeRun map[string]int64
-		stepsTimeRun, err = steps.Run(ctx, m.log, 10*time.Second, s, m.now)
+		stepsTimeRun, err = steps.RunWithProgress(ctx, m.log, 10*time.Second, s, m.now, progress)
 		if err == nil {
 			var totalInstallTime int64
 			for stepName, duration := range stepsTimeRun {
@@ -399,12 +304,21 @@ func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic str
 			m.metricsEmitter.EmitGauge(metricName, totalInstallTime, nil)
 		}
 	} else {
-		_, err = steps.Run(ctx, m.log, 10*time.Second, s, nil)
+		_, err = steps.RunWithProgress(ctx, m.log, 10*time.Second, s, nil, progress)
 	}
 	if err != nil {
 		m.gatherFailureLogs(ctx)
+		return err
 	}
-	return err
+
+	if progress != nil {
+		err = m.setOperationProgress(ctx, nil)
+		if err != nil {
+			m.log.Warnf("failed to clear progress: %s", err)
+		}
+	}
+
+	return nil
 }
 
 func (m *manager) startInstallation(ctx context.Context) error {
@@ -178,7 +178,7 @@ func TestStepRunnerWithInstaller(t *testing.T) {
 				now:           func() time.Time { return time.Now() },
 			}
 
-			err := m.runSteps(ctx, tt.steps, "")
+			err := m.runSteps(ctx, tt.steps, "", nil)
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 
 			err = testlog.AssertLoggingOutput(h, tt.wantEntries)
@@ -290,7 +290,7 @@ func TestInstallationTimeMetrics(t *testing.T) {
 				now:            func() time.Time { return time.Now().Add(time.Duration(tt.timePerStep) * time.Second) },
 			}
 
-			err := m.runSteps(ctx, tt.steps, tt.metricsTopic)
+			err := m.runSteps(ctx, tt.steps, tt.metricsTopic, nil)
 			if err != nil {
 				if len(fm.Metrics) != 0 {
 					t.Error("fake metrics obj should be empty when run steps failed")
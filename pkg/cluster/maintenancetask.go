@@ -0,0 +1,111 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+)
+
+// maintenanceTaskSteps returns the steps of each step group which can be run
+// by a registered maintenance task.  Groups whose steps don't apply to the
+// cluster are empty.
+func (m *manager) maintenanceTaskSteps() map[api.MaintenanceTaskStep][]steps.Step {
+	groups := map[api.MaintenanceTaskStep][]steps.Step{
+		api.MaintenanceTaskStepPrepare: {
+			steps.Action(m.initializeKubernetesClients), // must be first
+			steps.Action(m.ensureBillingRecord),         // belt and braces
+			steps.Action(m.ensureDefaults),
+
+			// TODO: this relies on an authorizer that isn't exposed in the manager
+			// struct, so we'll rebuild the fpAuthorizer and use the error catching
+			// to advance
+			steps.AuthorizationRetryingAction(m.fpAuthorizer, m.fixupClusterSPObjectID),
+			steps.Action(m.fixInfraID), // Old clusters lacks infraID in the database. Which makes code prone to errors.
+		},
+		api.MaintenanceTaskStepAzureResources: {
+			steps.Action(m.ensureResourceGroup), // re-create RP RBAC if needed after tenant migration
+			steps.Action(m.createOrUpdateDenyAssignment),
+			steps.Action(m.ensureServiceEndpoints),
+			steps.Action(m.populateRegistryStorageAccountName), // must go before migrateStorageAccounts
+			steps.Action(m.migrateStorageAccounts),
+			steps.Action(m.fixSSH),
+			// steps.Action(m.removePrivateDNSZone), // TODO(mj): re-enable once we communicate this out
+		},
+		api.MaintenanceTaskStepDatabaseIntIP: {
+			steps.Action(m.populateDatabaseIntIP),
+		},
+		// Make sure the VMs are switched on and we have an APIServer
+		api.MaintenanceTaskStepStartCluster: {
+			steps.Action(m.startVMs),
+			steps.Condition(m.apiServersReady, 30*time.Minute, true),
+		},
+		// Requires Kubernetes clients
+		api.MaintenanceTaskStepClusterAccess: {
+			steps.Action(m.fixSREKubeconfig),
+			steps.Action(m.fixUserAdminKubeconfig),
+			steps.Action(m.createOrUpdateRouterIPFromCluster),
+		},
+		api.MaintenanceTaskStepMCSCertificate: {
+			steps.Action(m.fixMCSCert),
+		},
+		api.MaintenanceTaskStepMCSUserData: {
+			steps.Action(m.fixMCSUserData),
+		},
+		api.MaintenanceTaskStepGatewayUpgrade: {
+			steps.Action(m.ensureGatewayUpgrade),
+		},
+		api.MaintenanceTaskStepACRToken: {
+			steps.Action(m.rotateACRTokenPassword),
+		},
+		api.MaintenanceTaskStepCertificates: {
+			steps.Action(m.configureAPIServerCertificate),
+			steps.Action(m.configureIngressCertificate),
+		},
+		api.MaintenanceTaskStepRegistryStorageAccount: {
+			steps.Action(m.populateRegistryStorageAccountName),
+		},
+		api.MaintenanceTaskStepMTUSize: {
+			steps.Action(m.ensureMTUSize),
+		},
+		api.MaintenanceTaskStepOperatorDeployer: {
+			steps.Action(m.initializeOperatorDeployer),
+		},
+		api.MaintenanceTaskStepMDSDCertificate: {
+			steps.Action(m.renewMDSDCertificate),
+		},
+		api.MaintenanceTaskStepRollingNodeOperation: {
+			steps.Action(m.runRollingNodeOperation),
+		},
+		api.MaintenanceTaskStepEtcdSnapshot: {
+			steps.Action(m.takeEtcdSnapshot),
+		},
+		api.MaintenanceTaskStepProvisionedBy: {
+			steps.Action(m.updateProvisionedBy), // Run this last so we capture the resource provider only once the upgrade has been fully performed
+		},
+	}
+
+	// Update the ARO Operator
+	if m.shouldUpdateOperator() {
+		groups[api.MaintenanceTaskStepOperator] = []steps.Step{
+			steps.Action(m.ensureAROOperator),
+			steps.Condition(m.aroDeploymentReady, 20*time.Minute, true),
+			steps.Condition(m.ensureAROOperatorRunningDesiredVersion, 5*time.Minute, true),
+		}
+	}
+
+	// Hive cluster adoption and reconciliation
+	if m.adoptViaHive && !m.clusterWasCreatedByHive() {
+		groups[api.MaintenanceTaskStepHiveAdoption] = []steps.Step{
+			steps.Action(m.hiveCreateNamespace),
+			steps.Action(m.hiveEnsureResources),
+			steps.Condition(m.hiveClusterDeploymentReady, 5*time.Minute, false),
+			steps.Action(m.hiveResetCorrelationData),
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,78 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+)
+
+// progress returns a steps.ProgressFunc which publishes the progress of an
+// operation to the cluster document and to its async operation.  all are the
+// steps of the whole operation, of which the steps being run start at offset.
+func (m *manager) progress(phase string, all []steps.Step, offset int) steps.ProgressFunc {
+	return func(ctx context.Context, i int, step steps.Step) {
+		now := m.now().UTC()
+
+		err := m.setOperationProgress(ctx, &api.OperationProgress{
+			Phase:           phase,
+			Step:            steps.Name(step),
+			StepStartTime:   &now,
+			StepsCompleted:  offset + i,
+			StepsTotal:      len(all),
+			PercentComplete: steps.PercentComplete(all, offset+i, stepDurations),
+		})
+		if err != nil {
+			// progress is informational: failing to publish it must not fail
+			// the operation
+			m.log.Warnf("failed to publish progress: %s", err)
+		}
+	}
+}
+
+// installProgress returns a steps.ProgressFunc which publishes the progress of
+// the given install phase.  Progress is reported across all the install
+// phases, which are run by separate backend leases.
+func (m *manager) installProgress(phases map[api.InstallPhase][]steps.Step, phase api.InstallPhase) steps.ProgressFunc {
+	var all []steps.Step
+	var offset int
+	for p := api.InstallPhaseBootstrap; phases[p] != nil; p++ {
+		if p == phase {
+			offset = len(all)
+		}
+		all = append(all, phases[p]...)
+	}
+
+	return m.progress(strings.TrimPrefix(phase.String(), "InstallPhase"), all, offset)
+}
+
+// setOperationProgress records p on the cluster document and, unless p is
+// nil, publishes it to the cluster's async operation
+func (m *manager) setOperationProgress(ctx context.Context, p *api.OperationProgress) error {
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.OperationProgress = p
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if p == nil || m.doc.AsyncOperationID == "" {
+		return nil
+	}
+
+	_, err = m.dbAsyncOperations.Patch(ctx, m.doc.AsyncOperationID, func(asyncdoc *api.AsyncOperationDocument) error {
+		asyncdoc.AsyncOperation.PercentComplete = p.PercentComplete
+		asyncdoc.AsyncOperation.Properties = &api.AsyncOperationProperties{
+			Phase: p.Phase,
+			Step:  p.Step,
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,171 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestInstallProgress(t *testing.T) {
+	ctx := context.Background()
+
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+	asyncOperationID := "11111111-1111-1111-1111-111111111111"
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	phases := map[api.InstallPhase][]steps.Step{
+		api.InstallPhaseBootstrap: {
+			steps.Action(successfulActionStep),
+			steps.Action(successfulActionStep),
+			steps.Action(successfulActionStep),
+		},
+		api.InstallPhaseRemoveBootstrap: {
+			steps.Action(successfulActionStep),
+			steps.Condition(successfulConditionStep, time.Minute, true),
+		},
+	}
+
+	for _, tt := range []struct {
+		name             string
+		asyncOperationID string
+		phase            api.InstallPhase
+		i                int
+		wantProgress     *api.OperationProgress
+		wantAsync        bool
+	}{
+		{
+			name:             "first phase",
+			asyncOperationID: asyncOperationID,
+			phase:            api.InstallPhaseBootstrap,
+			i:                1,
+			wantProgress: &api.OperationProgress{
+				Phase:           "Bootstrap",
+				Step:            "successfulActionStep",
+				StepStartTime:   &now,
+				StepsCompleted:  1,
+				StepsTotal:      5,
+				PercentComplete: 20,
+			},
+			wantAsync: true,
+		},
+		{
+			name:             "later phase counts earlier phases as completed",
+			asyncOperationID: asyncOperationID,
+			phase:            api.InstallPhaseRemoveBootstrap,
+			i:                1,
+			wantProgress: &api.OperationProgress{
+				Phase:           "RemoveBootstrap",
+				Step:            "successfulConditionStep",
+				StepStartTime:   &now,
+				StepsCompleted:  4,
+				StepsTotal:      5,
+				PercentComplete: 80,
+			},
+			wantAsync: true,
+		},
+		{
+			name:  "no async operation",
+			phase: api.InstallPhaseBootstrap,
+			wantProgress: &api.OperationProgress{
+				Phase:         "Bootstrap",
+				Step:          "successfulActionStep",
+				StepStartTime: &now,
+				StepsTotal:    5,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+			dbAsyncOperations, _ := testdatabase.NewFakeAsyncOperations()
+
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters).WithAsyncOperations(dbAsyncOperations)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key:              strings.ToLower(resourceID),
+				AsyncOperationID: tt.asyncOperationID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateCreating,
+					},
+				},
+			})
+			fixture.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+				ID:                  asyncOperationID,
+				OpenShiftClusterKey: strings.ToLower(resourceID),
+				AsyncOperation: &api.AsyncOperation{
+					ProvisioningState: api.ProvisioningStateCreating,
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := dbOpenShiftClusters.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log:               logrus.NewEntry(logrus.StandardLogger()),
+				doc:               doc,
+				db:                dbOpenShiftClusters,
+				dbAsyncOperations: dbAsyncOperations,
+				now:               func() time.Time { return now },
+			}
+
+			m.installProgress(phases, tt.phase)(ctx, tt.i, phases[tt.phase][tt.i])
+
+			doc, err = dbOpenShiftClusters.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, err := range deep.Equal(doc.OpenShiftCluster.Properties.OperationProgress, tt.wantProgress) {
+				t.Error(err)
+			}
+
+			asyncdoc, err := dbAsyncOperations.Get(ctx, asyncOperationID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wantProperties *api.AsyncOperationProperties
+			var wantPercentComplete int
+			if tt.wantAsync {
+				wantProperties = &api.AsyncOperationProperties{
+					Phase: tt.wantProgress.Phase,
+					Step:  tt.wantProgress.Step,
+				}
+				wantPercentComplete = tt.wantProgress.PercentComplete
+			}
+
+			for _, err := range deep.Equal(asyncdoc.AsyncOperation.Properties, wantProperties) {
+				t.Error(err)
+			}
+			if asyncdoc.AsyncOperation.PercentComplete != wantPercentComplete {
+				t.Error(asyncdoc.AsyncOperation.PercentComplete)
+			}
+
+			err = m.setOperationProgress(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.doc.OpenShiftCluster.Properties.OperationProgress != nil {
+				t.Error("progress was not cleared")
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/kubectl/pkg/drain"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	rollingNodeOperationDrainTimeout = 30 * time.Minute
+	rollingNodeOperationReadyTimeout = 30 * time.Minute
+	rollingNodeOperationMCPTimeout   = time.Hour
+)
+
+// rollingNodeOperationPollInterval is a var so that it can be overridden in
+// tests
+var rollingNodeOperationPollInterval = 10 * time.Second
+
+// runRollingNodeOperation performs the requested action on each node in turn.
+// Each node is cordoned and drained using evictions, so that
+// PodDisruptionBudgets are respected, before the action is performed.  The
+// node is then uncordoned and the operation waits for it to become Ready and
+// for the machine config pools to settle before moving on to the next node.
+//
+// Progress is recorded in the cluster document so that it can be polled, and
+// an abort requested via the admin API takes effect before the next node is
+// started.  Nodes which have already succeeded are skipped if the operation is
+// resumed by a further admin update.
+func (m *manager) runRollingNodeOperation(ctx context.Context) error {
+	if m.doc.OpenShiftCluster.Properties.RollingNodeOperation == nil {
+		return fmt.Errorf("no rolling node operation requested")
+	}
+
+	err := m.patchRollingNodeOperation(ctx, func(o *api.RollingNodeOperation) {
+		if o.StartTime == nil {
+			now := m.now().UTC()
+			o.StartTime = &now
+		}
+		o.EndTime = nil
+	})
+	if err != nil {
+		return err
+	}
+
+	o := m.doc.OpenShiftCluster.Properties.RollingNodeOperation
+
+	for _, node := range o.Nodes {
+		if node.State == api.RollingNodeOperationNodeStateSucceeded {
+			continue
+		}
+
+		var aborted bool
+		err = m.patchRollingNodeOperation(ctx, func(o *api.RollingNodeOperation) {
+			if !o.AbortRequested {
+				setRollingNodeOperationNodeState(o, node.Name, api.RollingNodeOperationNodeStateInProgress, "")
+				return
+			}
+
+			aborted = true
+			for i := range o.Nodes {
+				if o.Nodes[i].State != api.RollingNodeOperationNodeStateSucceeded {
+					o.Nodes[i].State = api.RollingNodeOperationNodeStateAborted
+				}
+			}
+			now := m.now().UTC()
+			o.EndTime = &now
+		})
+		if err != nil {
+			return err
+		}
+		if aborted {
+			return fmt.Errorf("rolling node operation aborted before node %s", node.Name)
+		}
+
+		m.log.Printf("rolling node operation %s: starting on node %s", o.Action, node.Name)
+
+		err = m.rollingNodeOperationOnNode(ctx, o, node.Name)
+		if err != nil {
+			patchErr := m.patchRollingNodeOperation(ctx, func(o *api.RollingNodeOperation) {
+				setRollingNodeOperationNodeState(o, node.Name, api.RollingNodeOperationNodeStateFailed, err.Error())
+				now := m.now().UTC()
+				o.EndTime = &now
+			})
+			if patchErr != nil {
+				m.log.Error(patchErr)
+			}
+			return err
+		}
+
+		err = m.patchRollingNodeOperation(ctx, func(o *api.RollingNodeOperation) {
+			setRollingNodeOperationNodeState(o, node.Name, api.RollingNodeOperationNodeStateSucceeded, "")
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.Action == api.RollingNodeOperationActionResize {
+		err = m.updateMasterProfileVMSize(ctx, o)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.patchRollingNodeOperation(ctx, func(o *api.RollingNodeOperation) {
+		now := m.now().UTC()
+		o.EndTime = &now
+	})
+}
+
+func (m *manager) patchRollingNodeOperation(ctx context.Context, f func(*api.RollingNodeOperation)) error {
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		if doc.OpenShiftCluster.Properties.RollingNodeOperation == nil {
+			return fmt.Errorf("no rolling node operation requested")
+		}
+
+		f(doc.OpenShiftCluster.Properties.RollingNodeOperation)
+		return nil
+	})
+	return err
+}
+
+func setRollingNodeOperationNodeState(o *api.RollingNodeOperation, name string, state api.RollingNodeOperationNodeState, message string) {
+	for i := range o.Nodes {
+		if o.Nodes[i].Name == name {
+			o.Nodes[i].State = state
+			o.Nodes[i].Message = message
+		}
+	}
+}
+
+func (m *manager) rollingNodeOperationOnNode(ctx context.Context, o *api.RollingNodeOperation, nodeName string) error {
+	resourceGroupName := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	node, err := m.kubernetescli.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	drainer := &drain.Helper{
+		Ctx:                 ctx,
+		Client:              m.kubernetescli,
+		Force:               true,
+		GracePeriodSeconds:  -1,
+		IgnoreAllDaemonSets: true,
+		Timeout:             rollingNodeOperationDrainTimeout,
+		DeleteEmptyDirData:  true,
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			m.log.Printf("evicted pod %s/%s", pod.Namespace, pod.Name)
+		},
+		Out:    m.log.Writer(),
+		ErrOut: m.log.Writer(),
+	}
+
+	err = drain.RunCordonOrUncordon(drainer, node, true)
+	if err != nil {
+		return err
+	}
+
+	m.log.Printf("draining node %s", nodeName)
+	err = drain.RunNodeDrain(drainer, nodeName)
+	if err != nil {
+		return err
+	}
+
+	switch o.Action {
+	case api.RollingNodeOperationActionResize:
+		err = m.resizeNode(ctx, resourceGroupName, nodeName, o.VMSize)
+	case api.RollingNodeOperationActionRedeploy:
+		err = m.virtualMachines.RedeployAndWait(ctx, resourceGroupName, nodeName)
+	case api.RollingNodeOperationActionReboot:
+		err = m.virtualMachines.StopAndWait(ctx, resourceGroupName, nodeName, false)
+		if err == nil {
+			err = m.virtualMachines.StartAndWait(ctx, resourceGroupName, nodeName)
+		}
+	default:
+		err = fmt.Errorf("unsupported rolling node operation action %q", o.Action)
+	}
+	if err != nil {
+		return err
+	}
+
+	node, err = m.kubernetescli.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = drain.RunCordonOrUncordon(drainer, node, false)
+	if err != nil {
+		return err
+	}
+
+	m.log.Printf("waiting for node %s to be ready", nodeName)
+	err = m.pollRollingNodeOperation(ctx, rollingNodeOperationReadyTimeout, func() (bool, error) {
+		node, err := m.kubernetescli.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			m.log.Print(err)
+			return false, nil
+		}
+
+		return ready.NodeIsReady(node), nil
+	})
+	if err != nil {
+		return fmt.Errorf("node %s did not become ready: %w", nodeName, err)
+	}
+
+	m.log.Print("waiting for machine config pools to settle")
+	err = m.pollRollingNodeOperation(ctx, rollingNodeOperationMCPTimeout, func() (bool, error) {
+		mcps, err := m.mcocli.MachineconfigurationV1().MachineConfigPools().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			m.log.Print(err)
+			return false, nil
+		}
+
+		for _, mcp := range mcps.Items {
+			if !ready.MachineConfigPoolIsReady(&mcp) || mcp.Status.DegradedMachineCount > 0 {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("machine config pools did not settle: %w", err)
+	}
+
+	return nil
+}
+
+func (m *manager) pollRollingNodeOperation(ctx context.Context, timeout time.Duration, condition wait.ConditionFunc) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return wait.PollImmediateUntil(rollingNodeOperationPollInterval, condition, timeoutCtx.Done())
+}
+
+// resizeNode resizes the node's VM and records the new size in its Machine so
+// that the two do not drift
+func (m *manager) resizeNode(ctx context.Context, resourceGroupName, nodeName string, vmSize api.VMSize) error {
+	vm, err := m.virtualMachines.Get(ctx, resourceGroupName, nodeName, mgmtcompute.InstanceView)
+	if err != nil {
+		return err
+	}
+
+	vm.HardwareProfile.VMSize = mgmtcompute.VirtualMachineSizeTypes(vmSize)
+
+	m.log.Printf("resizing VM %s to %s", nodeName, vmSize)
+	err = m.virtualMachines.CreateOrUpdateAndWait(ctx, resourceGroupName, nodeName, vm)
+	if err != nil {
+		return err
+	}
+
+	node, err := m.kubernetescli.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	namespace, name, found := strings.Cut(node.Annotations["machine.openshift.io/machine"], "/")
+	if !found {
+		m.log.Printf("node %s has no machine annotation, not updating machine", nodeName)
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		machine, err := m.maocli.MachineV1beta1().Machines(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if machine.Spec.ProviderSpec.Value == nil {
+			return nil
+		}
+
+		var providerSpec map[string]interface{}
+		err = json.Unmarshal(machine.Spec.ProviderSpec.Value.Raw, &providerSpec)
+		if err != nil {
+			return err
+		}
+
+		providerSpec["vmSize"] = string(vmSize)
+
+		machine.Spec.ProviderSpec.Value.Raw, err = json.Marshal(providerSpec)
+		if err != nil {
+			return err
+		}
+
+		_, err = m.maocli.MachineV1beta1().Machines(namespace).Update(ctx, machine, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// updateMasterProfileVMSize records the new master VM size in the cluster
+// document once every master node has been resized
+func (m *manager) updateMasterProfileVMSize(ctx context.Context, o *api.RollingNodeOperation) error {
+	masters, err := m.kubernetescli.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: "node-role.kubernetes.io/master",
+	})
+	if err != nil {
+		return err
+	}
+
+	resized := map[string]struct{}{}
+	for _, node := range o.Nodes {
+		resized[node.Name] = struct{}{}
+	}
+
+	for _, master := range masters.Items {
+		if _, found := resized[master.Name]; !found {
+			return nil
+		}
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.MasterProfile.VMSize = o.VMSize
+		return nil
+	})
+	return err
+}
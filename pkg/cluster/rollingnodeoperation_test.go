@@ -0,0 +1,305 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/golang/mock/gomock"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	machinefake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	mcofake "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRunRollingNodeOperation(t *testing.T) {
+	ctx := context.Background()
+
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+	clusterRGName := "test-cluster"
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rollingNodeOperationPollInterval = time.Millisecond
+	defer func() { rollingNodeOperationPollInterval = 10 * time.Second }()
+
+	node := func(name, role string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"node-role.kubernetes.io/" + role: "",
+				},
+				Annotations: map[string]string{
+					"machine.openshift.io/machine": "openshift-machine-api/" + name,
+				},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	machine := func(name string) *machinev1beta1.Machine {
+		return &machinev1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openshift-machine-api",
+			},
+			Spec: machinev1beta1.MachineSpec{
+				ProviderSpec: machinev1beta1.ProviderSpec{
+					Value: &kruntime.RawExtension{
+						Raw: []byte(`{"apiVersion":"machine.openshift.io/v1beta1","kind":"AzureMachineProviderSpec","vmSize":"Standard_D8s_v3"}`),
+					},
+				},
+			},
+		}
+	}
+
+	mcp := &mcv1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "master",
+		},
+		Status: mcv1.MachineConfigPoolStatus{
+			MachineCount:        3,
+			UpdatedMachineCount: 3,
+			ReadyMachineCount:   3,
+		},
+	}
+
+	for _, tt := range []struct {
+		name            string
+		operation       *api.RollingNodeOperation
+		mocks           func(*mock_compute.MockVirtualMachinesClient)
+		wantNodes       []api.RollingNodeOperationNode
+		wantMasterSize  api.VMSize
+		wantMachineSize string
+		wantErr         string
+	}{
+		{
+			name: "reboot skips nodes which have succeeded",
+			operation: &api.RollingNodeOperation{
+				Action: api.RollingNodeOperationActionReboot,
+				Nodes: []api.RollingNodeOperationNode{
+					{Name: "master-0", State: api.RollingNodeOperationNodeStateSucceeded},
+					{Name: "master-1", State: api.RollingNodeOperationNodeStatePending},
+				},
+			},
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient) {
+				gomock.InOrder(
+					vms.EXPECT().StopAndWait(gomock.Any(), clusterRGName, "master-1", false).Return(nil),
+					vms.EXPECT().StartAndWait(gomock.Any(), clusterRGName, "master-1").Return(nil),
+				)
+			},
+			wantNodes: []api.RollingNodeOperationNode{
+				{Name: "master-0", State: api.RollingNodeOperationNodeStateSucceeded},
+				{Name: "master-1", State: api.RollingNodeOperationNodeStateSucceeded},
+			},
+			wantMasterSize:  "Standard_D8s_v3",
+			wantMachineSize: "Standard_D8s_v3",
+		},
+		{
+			name: "resize of every master updates the machines and master profile",
+			operation: &api.RollingNodeOperation{
+				Action: api.RollingNodeOperationActionResize,
+				VMSize: "Standard_D16s_v3",
+				Nodes: []api.RollingNodeOperationNode{
+					{Name: "master-0", State: api.RollingNodeOperationNodeStatePending},
+					{Name: "master-1", State: api.RollingNodeOperationNodeStatePending},
+				},
+			},
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient) {
+				for _, name := range []string{"master-0", "master-1"} {
+					vm := mgmtcompute.VirtualMachine{
+						VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{
+							HardwareProfile: &mgmtcompute.HardwareProfile{
+								VMSize: mgmtcompute.VirtualMachineSizeTypesStandardD8sV3,
+							},
+						},
+					}
+					resized := vm
+					resized.VirtualMachineProperties = &mgmtcompute.VirtualMachineProperties{
+						HardwareProfile: &mgmtcompute.HardwareProfile{
+							VMSize: mgmtcompute.VirtualMachineSizeTypesStandardD16sV3,
+						},
+					}
+
+					gomock.InOrder(
+						vms.EXPECT().Get(gomock.Any(), clusterRGName, name, mgmtcompute.InstanceView).Return(vm, nil),
+						vms.EXPECT().CreateOrUpdateAndWait(gomock.Any(), clusterRGName, name, resized).Return(nil),
+					)
+				}
+			},
+			wantNodes: []api.RollingNodeOperationNode{
+				{Name: "master-0", State: api.RollingNodeOperationNodeStateSucceeded},
+				{Name: "master-1", State: api.RollingNodeOperationNodeStateSucceeded},
+			},
+			wantMasterSize:  "Standard_D16s_v3",
+			wantMachineSize: "Standard_D16s_v3",
+		},
+		{
+			name: "abort stops before the next node",
+			operation: &api.RollingNodeOperation{
+				Action:         api.RollingNodeOperationActionRedeploy,
+				AbortRequested: true,
+				Nodes: []api.RollingNodeOperationNode{
+					{Name: "master-0", State: api.RollingNodeOperationNodeStateSucceeded},
+					{Name: "master-1", State: api.RollingNodeOperationNodeStatePending},
+				},
+			},
+			wantNodes: []api.RollingNodeOperationNode{
+				{Name: "master-0", State: api.RollingNodeOperationNodeStateSucceeded},
+				{Name: "master-1", State: api.RollingNodeOperationNodeStateAborted},
+			},
+			wantMasterSize:  "Standard_D8s_v3",
+			wantMachineSize: "Standard_D8s_v3",
+			wantErr:         "rolling node operation aborted before node master-1",
+		},
+		{
+			name: "failure is recorded on the node",
+			operation: &api.RollingNodeOperation{
+				Action: api.RollingNodeOperationActionRedeploy,
+				Nodes: []api.RollingNodeOperationNode{
+					{Name: "master-0", State: api.RollingNodeOperationNodeStatePending},
+					{Name: "master-1", State: api.RollingNodeOperationNodeStatePending},
+				},
+			},
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient) {
+				vms.EXPECT().RedeployAndWait(gomock.Any(), clusterRGName, "master-0").Return(errors.New("random error"))
+			},
+			wantNodes: []api.RollingNodeOperationNode{
+				{Name: "master-0", State: api.RollingNodeOperationNodeStateFailed, Message: "random error"},
+				{Name: "master-1", State: api.RollingNodeOperationNodeStatePending},
+			},
+			wantMasterSize:  "Standard_D8s_v3",
+			wantMachineSize: "Standard_D8s_v3",
+			wantErr:         "random error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			vms := mock_compute.NewMockVirtualMachinesClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(vms)
+			}
+
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateAdminUpdating,
+						MaintenanceTask:   api.MaintenanceTaskRollingNodeOperation,
+						ClusterProfile: api.ClusterProfile{
+							ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/" + clusterRGName,
+						},
+						MasterProfile: api.MasterProfile{
+							VMSize: "Standard_D8s_v3",
+						},
+						RollingNodeOperation: tt.operation,
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterdoc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				doc:             clusterdoc,
+				db:              fakeOpenShiftClustersDatabase,
+				virtualMachines: vms,
+				kubernetescli:   fake.NewSimpleClientset(node("master-0", "master"), node("master-1", "master"), node("worker-0", "worker")),
+				maocli:          machinefake.NewSimpleClientset(machine("master-0"), machine("master-1")),
+				mcocli:          mcofake.NewSimpleClientset(mcp),
+				now:             func() time.Time { return now },
+			}
+
+			err = m.runRollingNodeOperation(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			doc, err := fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			o := doc.OpenShiftCluster.Properties.RollingNodeOperation
+			if len(o.Nodes) != len(tt.wantNodes) {
+				t.Fatalf("got %d nodes", len(o.Nodes))
+			}
+			for i := range o.Nodes {
+				if o.Nodes[i].Name != tt.wantNodes[i].Name ||
+					o.Nodes[i].State != tt.wantNodes[i].State ||
+					o.Nodes[i].Message != tt.wantNodes[i].Message {
+					t.Errorf("node %d: got %#v, want %#v", i, o.Nodes[i], tt.wantNodes[i])
+				}
+			}
+
+			if o.StartTime == nil || !o.StartTime.Equal(now) {
+				t.Errorf("got start time %v", o.StartTime)
+			}
+			if o.EndTime == nil || !o.EndTime.Equal(now) {
+				t.Errorf("got end time %v", o.EndTime)
+			}
+
+			if doc.OpenShiftCluster.Properties.MasterProfile.VMSize != tt.wantMasterSize {
+				t.Error(doc.OpenShiftCluster.Properties.MasterProfile.VMSize)
+			}
+
+			machine, err := m.maocli.MachineV1beta1().Machines("openshift-machine-api").Get(ctx, "master-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var providerSpec map[string]interface{}
+			err = json.Unmarshal(machine.Spec.ProviderSpec.Value.Raw, &providerSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if providerSpec["vmSize"] != tt.wantMachineSize {
+				t.Error(providerSpec["vmSize"])
+			}
+
+			for _, name := range []string{"master-0", "master-1"} {
+				node, err := m.kubernetescli.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if node.Spec.Unschedulable && tt.wantErr == "" {
+					t.Errorf("node %s is still cordoned", name)
+				}
+			}
+		})
+	}
+}
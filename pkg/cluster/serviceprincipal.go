@@ -8,18 +8,24 @@ import (
 	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/jongio/azidext/go/azidext"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	keyvaultclient "github.com/Azure/ARO-RP/pkg/util/azureclient/keyvault"
 	utilgraph "github.com/Azure/ARO-RP/pkg/util/graph"
 )
 
-// initializeClusterSPClients initialized clients, based on cluster service principal
-func (m *manager) initializeClusterSPClients(ctx context.Context) error {
+func (m *manager) clusterSPTokenCredential() (*azidentity.ClientSecretCredential, error) {
 	spp := m.doc.OpenShiftCluster.Properties.ServicePrincipalProfile
 	options := m.env.Environment().ClientSecretCredentialOptions()
-	spTokenCredential, err := azidentity.NewClientSecretCredential(
+	return azidentity.NewClientSecretCredential(
 		m.subscriptionDoc.Subscription.Properties.TenantID,
 		spp.ClientID, string(spp.ClientSecret), options)
+}
+
+// initializeClusterSPClients initialized clients, based on cluster service principal
+func (m *manager) initializeClusterSPClients(ctx context.Context) error {
+	spTokenCredential, err := m.clusterSPTokenCredential()
 	if err != nil {
 		return err
 	}
@@ -29,6 +35,25 @@ func (m *manager) initializeClusterSPClients(ctx context.Context) error {
 	return err
 }
 
+// clusterSPKeyvault returns a client which reads customer key vaults as the
+// cluster service principal.  It is created on first use as it is only needed
+// by clusters with customer supplied certificates.
+func (m *manager) clusterSPKeyvault() (keyvaultclient.BaseClient, error) {
+	if m.spKeyvault != nil {
+		return m.spKeyvault, nil
+	}
+
+	spTokenCredential, err := m.clusterSPTokenCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := []string{m.env.Environment().KeyVaultScope}
+	m.spKeyvault = keyvaultclient.New(azidext.NewTokenCredentialAdapter(spTokenCredential, scopes))
+
+	return m.spKeyvault, nil
+}
+
 func (m *manager) clusterSPObjectID(ctx context.Context) error {
 	var (
 		err               error
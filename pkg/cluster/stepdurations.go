@@ -0,0 +1,72 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+)
+
+// stepDurations are the typical durations of the install steps, by step name,
+// which weight the progress published for operations.  The phases of an
+// operation are run by different backend processes which do not share what
+// they have seen, so the durations are shipped rather than learnt.  They should
+// track the backend.openshiftcluster.install.*.duration.seconds metrics; steps
+// which are not listed, such as those of updates, are taken to last the mean
+// duration of the listed steps of the operation.
+var stepDurations = map[string]time.Duration{
+	// InstallPhaseBootstrap
+	"validateResources":               5 * time.Second,
+	"ensurePreconfiguredNSG":          2 * time.Second,
+	"ensureACRToken":                  5 * time.Second,
+	"ensureInfraID":                   time.Second,
+	"ensureSSHKey":                    time.Second,
+	"ensureStorageSuffix":             time.Second,
+	"populateMTUSize":                 time.Second,
+	"createDNS":                       10 * time.Second,
+	"initializeClusterSPClients":      time.Second,
+	"clusterSPObjectID":               2 * time.Second,
+	"ensureResourceGroup":             5 * time.Second,
+	"ensureServiceEndpoints":          10 * time.Second,
+	"setMasterSubnetPolicies":         5 * time.Second,
+	"deployBaseResourceTemplate":      2 * time.Minute,
+	"attachNSGs":                      15 * time.Second,
+	"updateAPIIPEarly":                2 * time.Second,
+	"createOrUpdateRouterIPEarly":     2 * time.Second,
+	"ensureGatewayCreate":             2 * time.Second,
+	"createAPIServerPrivateEndpoint":  time.Minute,
+	"createCertificates":              20 * time.Second,
+	"hiveCreateNamespace":             2 * time.Second,
+	"runHiveInstaller":                30 * time.Second,
+	"hiveClusterInstallationComplete": 40 * time.Minute,
+	"hiveClusterDeploymentReady":      10 * time.Second,
+	"runPodmanInstaller":              8 * time.Minute,
+	"generateKubeconfigs":             2 * time.Second,
+	"hiveEnsureResources":             5 * time.Second,
+	"hiveResetCorrelationData":        time.Second,
+	"ensureBillingRecord":             time.Second,
+	"initializeKubernetesClients":     time.Second,
+	"initializeOperatorDeployer":      time.Second,
+	"apiServersReady":                 5 * time.Minute,
+	"ensureAROOperator":               30 * time.Second,
+	"incrInstallPhase":                time.Second,
+
+	// InstallPhaseRemoveBootstrap
+	"removeBootstrap":               time.Minute,
+	"removeBootstrapIgnition":       5 * time.Second,
+	"configureAPIServerCertificate": 5 * time.Second,
+	"minimumWorkerNodesReady":       10 * time.Minute,
+	"operatorConsoleExists":         2 * time.Minute,
+	"updateConsoleBranding":         2 * time.Second,
+	"operatorConsoleReady":          5 * time.Minute,
+	"disableSamples":                2 * time.Second,
+	"disableOperatorHubSources":     2 * time.Second,
+	"disableUpdates":                2 * time.Second,
+	"clusterVersionReady":           15 * time.Minute,
+	"aroDeploymentReady":            time.Minute,
+	"updateClusterData":             10 * time.Second,
+	"configureIngressCertificate":   5 * time.Second,
+	"ingressControllerReady":        30 * time.Second,
+	"configureDefaultStorageClass":  2 * time.Second,
+	"finishInstallation":            time.Second,
+}
@@ -5,8 +5,10 @@ package cluster
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 
 	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -15,7 +17,9 @@ import (
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/util/retry"
 
+	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
+	utilcert "github.com/Azure/ARO-RP/pkg/util/cert"
 	"github.com/Azure/ARO-RP/pkg/util/dns"
 	"github.com/Azure/ARO-RP/pkg/util/keyvault"
 	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
@@ -69,17 +73,93 @@ func (m *manager) createCertificates(ctx context.Context) error {
 	return nil
 }
 
-func (m *manager) ensureSecret(ctx context.Context, secrets corev1client.SecretInterface, certificateName string) error {
-	bundle, err := m.env.ClusterKeyvault().GetSecret(ctx, certificateName)
+// servingCertificateDomain returns the domain for which the RP configures
+// serving certificates: the cluster domain if the customer supplies the
+// certificate, otherwise the managed domain, if any.  If it returns "", the
+// cluster keeps the certificates generated by the installer.
+func (m *manager) servingCertificateDomain(secretID string) (string, error) {
+	if secretID != "" {
+		return m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain, nil
+	}
+
+	if m.env.FeatureIsSet(env.FeatureDisableSignedCertificates) {
+		return "", nil
+	}
+
+	return dns.ManagedDomain(m.env, m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+}
+
+func (m *manager) ingressCertificateSecretID() string {
+	for _, p := range m.doc.OpenShiftCluster.Properties.IngressProfiles {
+		if p.Name == "default" {
+			return p.CertificateSecretID
+		}
+	}
+
+	return ""
+}
+
+// servingCertificate returns the key and certificate chain to serve for
+// hostname, and the version of the customer supplied secret which holds them.
+// Customer supplied certificates are read from the customer's key vault as the
+// cluster service principal and checked before they are used.  A secret ID
+// without a version refers to the latest version of the secret: the master
+// monitor queues a certificate rotation when a new version is added.
+// Otherwise the certificate issued into the cluster key vault as
+// certificateName is used.
+func (m *manager) servingCertificate(ctx context.Context, certificateName, secretID, hostname string) (*rsa.PrivateKey, []*x509.Certificate, string, error) {
+	if secretID == "" {
+		bundle, err := m.env.ClusterKeyvault().GetSecret(ctx, certificateName)
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		key, certs, err := utilpem.Parse([]byte(*bundle.Value))
+		return key, certs, "", err
+	}
+
+	kv, err := m.clusterSPKeyvault()
 	if err != nil {
-		return err
+		return nil, nil, "", err
 	}
 
-	key, certs, err := utilpem.Parse([]byte(*bundle.Value))
+	key, certs, version, err := keyvault.GetCertificateSecretByID(ctx, kv, secretID)
 	if err != nil {
-		return err
+		return nil, nil, "", err
+	}
+
+	err = utilcert.ValidateServingCertificate(key, certs, hostname, m.now(), utilcert.ServingCertificateMinimumValidity)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("certificate in secret %s is invalid: %w", secretID, err)
 	}
 
+	return key, certs, version, nil
+}
+
+// setCertificateSecretVersion records the version of a customer supplied
+// certificate which the cluster serves, as returned by field
+func (m *manager) setCertificateSecretVersion(ctx context.Context, field func(*api.OpenShiftClusterProperties) *string, version string) error {
+	if *field(&m.doc.OpenShiftCluster.Properties) == version {
+		return nil
+	}
+
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		*field(&doc.OpenShiftCluster.Properties) = version
+		return nil
+	})
+	return err
+}
+
+func apiServerCertificateSecretVersion(p *api.OpenShiftClusterProperties) *string {
+	return &p.APIServerCertificateSecretVersion
+}
+
+func ingressCertificateSecretVersion(p *api.OpenShiftClusterProperties) *string {
+	return &p.IngressCertificateSecretVersion
+}
+
+func (m *manager) ensureSecret(ctx context.Context, secrets corev1client.SecretInterface, certificateName string, key *rsa.PrivateKey, certs []*x509.Certificate) error {
 	b, err := x509.MarshalPKCS8PrivateKey(key)
 	if err != nil {
 		return err
@@ -121,27 +201,37 @@ func (m *manager) ensureSecret(ctx context.Context, secrets corev1client.SecretI
 }
 
 func (m *manager) configureAPIServerCertificate(ctx context.Context) error {
-	if m.env.FeatureIsSet(env.FeatureDisableSignedCertificates) {
-		return nil
+	secretID := m.doc.OpenShiftCluster.Properties.APIServerProfile.CertificateSecretID
+
+	if secretID == "" {
+		err := m.removeAPIServerCertificate(ctx)
+		if err != nil {
+			return err
+		}
 	}
 
-	managedDomain, err := dns.ManagedDomain(m.env, m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+	domain, err := m.servingCertificateDomain(secretID)
 	if err != nil {
 		return err
 	}
 
-	if managedDomain == "" {
+	if domain == "" {
 		return nil
 	}
 
+	key, certs, version, err := m.servingCertificate(ctx, m.doc.ID+"-apiserver", secretID, "api."+domain)
+	if err != nil {
+		return err
+	}
+
 	for _, namespace := range []string{"openshift-config", "openshift-azure-operator"} {
-		err = m.ensureSecret(ctx, m.kubernetescli.CoreV1().Secrets(namespace), m.doc.ID+"-apiserver")
+		err = m.ensureSecret(ctx, m.kubernetescli.CoreV1().Secrets(namespace), m.doc.ID+"-apiserver", key, certs)
 		if err != nil {
 			return err
 		}
 	}
 
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		apiserver, err := m.configcli.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
 		if err != nil {
 			return err
@@ -150,7 +240,7 @@ func (m *manager) configureAPIServerCertificate(ctx context.Context) error {
 		apiserver.Spec.ServingCerts.NamedCertificates = []configv1.APIServerNamedServingCert{
 			{
 				Names: []string{
-					"api." + managedDomain,
+					"api." + domain,
 				},
 				ServingCertificate: configv1.SecretNameReference{
 					Name: m.doc.ID + "-apiserver",
@@ -161,30 +251,81 @@ func (m *manager) configureAPIServerCertificate(ctx context.Context) error {
 		_, err = m.configcli.ConfigV1().APIServers().Update(ctx, apiserver, metav1.UpdateOptions{})
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	return m.setCertificateSecretVersion(ctx, apiServerCertificateSecretVersion, version)
 }
 
-func (m *manager) configureIngressCertificate(ctx context.Context) error {
-	if m.env.FeatureIsSet(env.FeatureDisableSignedCertificates) {
+// removeAPIServerCertificate stops serving a certificate which the customer no
+// longer supplies
+func (m *manager) removeAPIServerCertificate(ctx context.Context) error {
+	if m.doc.OpenShiftCluster.Properties.APIServerCertificateSecretVersion == "" {
 		return nil
 	}
 
-	managedDomain, err := dns.ManagedDomain(m.env, m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		apiserver, err := m.configcli.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		var namedCertificates []configv1.APIServerNamedServingCert
+		for _, nc := range apiserver.Spec.ServingCerts.NamedCertificates {
+			if nc.ServingCertificate.Name != m.doc.ID+"-apiserver" {
+				namedCertificates = append(namedCertificates, nc)
+			}
+		}
+
+		if len(namedCertificates) == len(apiserver.Spec.ServingCerts.NamedCertificates) {
+			return nil
+		}
+
+		apiserver.Spec.ServingCerts.NamedCertificates = namedCertificates
+
+		_, err = m.configcli.ConfigV1().APIServers().Update(ctx, apiserver, metav1.UpdateOptions{})
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	if managedDomain == "" {
+	return m.setCertificateSecretVersion(ctx, apiServerCertificateSecretVersion, "")
+}
+
+func (m *manager) configureIngressCertificate(ctx context.Context) error {
+	secretID := m.ingressCertificateSecretID()
+
+	if secretID == "" {
+		err := m.removeIngressCertificate(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	domain, err := m.servingCertificateDomain(secretID)
+	if err != nil {
+		return err
+	}
+
+	if domain == "" {
 		return nil
 	}
 
+	key, certs, version, err := m.servingCertificate(ctx, m.doc.ID+"-ingress", secretID, "*.apps."+domain)
+	if err != nil {
+		return err
+	}
+
 	for _, namespace := range []string{"openshift-ingress", "openshift-azure-operator"} {
-		err = m.ensureSecret(ctx, m.kubernetescli.CoreV1().Secrets(namespace), m.doc.ID+"-ingress")
+		err = m.ensureSecret(ctx, m.kubernetescli.CoreV1().Secrets(namespace), m.doc.ID+"-ingress", key, certs)
 		if err != nil {
 			return err
 		}
 	}
 
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		ic, err := m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Get(ctx, "default", metav1.GetOptions{})
 		if err != nil {
 			return err
@@ -197,4 +338,38 @@ func (m *manager) configureIngressCertificate(ctx context.Context) error {
 		_, err = m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Update(ctx, ic, metav1.UpdateOptions{})
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	return m.setCertificateSecretVersion(ctx, ingressCertificateSecretVersion, version)
+}
+
+// removeIngressCertificate stops serving a certificate which the customer no
+// longer supplies
+func (m *manager) removeIngressCertificate(ctx context.Context) error {
+	if m.doc.OpenShiftCluster.Properties.IngressCertificateSecretVersion == "" {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ic, err := m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Get(ctx, "default", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if ic.Spec.DefaultCertificate == nil || ic.Spec.DefaultCertificate.Name != m.doc.ID+"-ingress" {
+			return nil
+		}
+
+		ic.Spec.DefaultCertificate = nil
+
+		_, err = m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Update(ctx, ic, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return m.setCertificateSecretVersion(ctx, ingressCertificateSecretVersion, "")
 }
@@ -0,0 +1,334 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	operatorfake "github.com/openshift/client-go/operator/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/env"
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/keyvault"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+const (
+	testCertificateDocID         = "00000000-0000-0000-0000-000000000000"
+	testCertificateSecretID      = "https://myvault.vault.azure.net/secrets/mycert"
+	testCertificateSecretVersion = "0123456789abcdef0123456789abcdef"
+	testCertificateResourceID    = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+)
+
+// testCertificateDatabase returns a database holding doc, and doc as
+// dequeued, so that the manager can patch it with its lease
+func testCertificateDatabase(t *testing.T, doc *api.OpenShiftClusterDocument) (database.OpenShiftClusters, *api.OpenShiftClusterDocument) {
+	ctx := context.Background()
+
+	doc.Key = strings.ToLower(testCertificateResourceID)
+	doc.OpenShiftCluster.ID = testCertificateResourceID
+	doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+
+	db, _ := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(db)
+	fixture.AddOpenShiftClusterDocuments(doc)
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err = db.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, doc
+}
+
+func testCertificateSecret(t *testing.T, commonName string) string {
+	key, certs, err := utiltls.GenerateKeyAndCertificate(commonName, nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := utilpem.Encode(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := utilpem.Encode(certs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(append(b, c...))
+}
+
+func TestConfigureAPIServerCertificate(t *testing.T) {
+	ctx := context.Background()
+
+	otherCertificate := configv1.APIServerNamedServingCert{
+		Names:              []string{"other.example.com"},
+		ServingCertificate: configv1.SecretNameReference{Name: "other"},
+	}
+
+	for _, tt := range []struct {
+		name          string
+		secretID      string
+		secret        string
+		servedVersion string
+		current       []configv1.APIServerNamedServingCert
+		want          []configv1.APIServerNamedServingCert
+		wantCerts     bool
+		wantVersion   string
+		wantErr       string
+	}{
+		{
+			name:     "customer certificate",
+			secretID: testCertificateSecretID,
+			secret:   testCertificateSecret(t, "api.cluster.example.com"),
+			want: []configv1.APIServerNamedServingCert{
+				{
+					Names:              []string{"api.cluster.example.com"},
+					ServingCertificate: configv1.SecretNameReference{Name: testCertificateDocID + "-apiserver"},
+				},
+			},
+			wantCerts:   true,
+			wantVersion: testCertificateSecretVersion,
+		},
+		{
+			name:     "customer certificate for the wrong hostname",
+			secretID: testCertificateSecretID,
+			secret:   testCertificateSecret(t, "api.other.example.com"),
+			wantErr:  "certificate in secret " + testCertificateSecretID + " is invalid: the certificate is not valid for api.cluster.example.com",
+		},
+		{
+			name:          "customer certificate removed",
+			servedVersion: testCertificateSecretVersion,
+			current: []configv1.APIServerNamedServingCert{
+				otherCertificate,
+				{
+					Names:              []string{"api.cluster.example.com"},
+					ServingCertificate: configv1.SecretNameReference{Name: testCertificateDocID + "-apiserver"},
+				},
+			},
+			want: []configv1.APIServerNamedServingCert{
+				otherCertificate,
+			},
+		},
+		{
+			name: "no customer certificate",
+			current: []configv1.APIServerNamedServingCert{
+				otherCertificate,
+			},
+			want: []configv1.APIServerNamedServingCert{
+				otherCertificate,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().FeatureIsSet(env.FeatureDisableSignedCertificates).Return(false).AnyTimes()
+			_env.EXPECT().Domain().Return("location.aroapp.io").AnyTimes()
+
+			kv := mock_keyvault.NewMockBaseClient(controller)
+			if tt.secretID != "" {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{
+						ID:    to.StringPtr(testCertificateSecretID + "/" + testCertificateSecretVersion),
+						Value: to.StringPtr(tt.secret),
+					}, nil)
+			}
+
+			db, doc := testCertificateDatabase(t, &api.OpenShiftClusterDocument{
+				ID: testCertificateDocID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							Domain: "cluster.example.com",
+						},
+						APIServerProfile: api.APIServerProfile{
+							CertificateSecretID: tt.secretID,
+						},
+						APIServerCertificateSecretVersion: tt.servedVersion,
+					},
+				},
+			})
+
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				env:           _env,
+				db:            db,
+				doc:           doc,
+				spKeyvault:    kv,
+				kubernetescli: fake.NewSimpleClientset(),
+				configcli: configfake.NewSimpleClientset(&configv1.APIServer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "cluster",
+					},
+					Spec: configv1.APIServerSpec{
+						ServingCerts: configv1.APIServerServingCerts{
+							NamedCertificates: tt.current,
+						},
+					},
+				}),
+				now: time.Now,
+			}
+
+			err := m.configureAPIServerCertificate(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+
+			apiserver, err := m.configcli.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(apiserver.Spec.ServingCerts.NamedCertificates, tt.want) {
+				t.Errorf("got %#v", apiserver.Spec.ServingCerts.NamedCertificates)
+			}
+
+			if m.doc.OpenShiftCluster.Properties.APIServerCertificateSecretVersion != tt.wantVersion {
+				t.Errorf("got version %q", m.doc.OpenShiftCluster.Properties.APIServerCertificateSecretVersion)
+			}
+
+			for _, namespace := range []string{"openshift-config", "openshift-azure-operator"} {
+				s, err := m.kubernetescli.CoreV1().Secrets(namespace).Get(ctx, testCertificateDocID+"-apiserver", metav1.GetOptions{})
+				if tt.wantCerts && (err != nil || s.Type != corev1.SecretTypeTLS || len(s.Data[corev1.TLSCertKey]) == 0) {
+					t.Errorf("%s: expected certificate secret, got %v", namespace, err)
+				}
+				if !tt.wantCerts && err == nil {
+					t.Errorf("%s: unexpected certificate secret", namespace)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigureIngressCertificate(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name          string
+		secretID      string
+		servedVersion string
+		current       *corev1.LocalObjectReference
+		want          *corev1.LocalObjectReference
+		wantVersion   string
+	}{
+		{
+			name:        "customer certificate",
+			secretID:    testCertificateSecretID,
+			want:        &corev1.LocalObjectReference{Name: testCertificateDocID + "-ingress"},
+			wantVersion: testCertificateSecretVersion,
+		},
+		{
+			name:          "customer certificate removed",
+			servedVersion: testCertificateSecretVersion,
+			current:       &corev1.LocalObjectReference{Name: testCertificateDocID + "-ingress"},
+		},
+		{
+			name:    "no customer certificate",
+			current: &corev1.LocalObjectReference{Name: "other"},
+			want:    &corev1.LocalObjectReference{Name: "other"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().FeatureIsSet(env.FeatureDisableSignedCertificates).Return(false).AnyTimes()
+			_env.EXPECT().Domain().Return("location.aroapp.io").AnyTimes()
+
+			kv := mock_keyvault.NewMockBaseClient(controller)
+			if tt.secretID != "" {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{
+						ID:    to.StringPtr(testCertificateSecretID + "/" + testCertificateSecretVersion),
+						Value: to.StringPtr(testCertificateSecret(t, "*.apps.cluster.example.com")),
+					}, nil)
+			}
+
+			db, doc := testCertificateDatabase(t, &api.OpenShiftClusterDocument{
+				ID: testCertificateDocID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							Domain: "cluster.example.com",
+						},
+						IngressProfiles: []api.IngressProfile{
+							{
+								Name:                "default",
+								CertificateSecretID: tt.secretID,
+							},
+						},
+						IngressCertificateSecretVersion: tt.servedVersion,
+					},
+				},
+			})
+
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				env:           _env,
+				db:            db,
+				doc:           doc,
+				spKeyvault:    kv,
+				kubernetescli: fake.NewSimpleClientset(),
+				operatorcli: operatorfake.NewSimpleClientset(&operatorv1.IngressController{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "default",
+						Namespace: "openshift-ingress-operator",
+					},
+					Spec: operatorv1.IngressControllerSpec{
+						DefaultCertificate: tt.current,
+					},
+				}),
+				now: time.Now,
+			}
+
+			err := m.configureIngressCertificate(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ic, err := m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Get(ctx, "default", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(ic.Spec.DefaultCertificate, tt.want) {
+				t.Errorf("got %#v", ic.Spec.DefaultCertificate)
+			}
+
+			if m.doc.OpenShiftCluster.Properties.IngressCertificateSecretVersion != tt.wantVersion {
+				t.Errorf("got version %q", m.doc.OpenShiftCluster.Properties.IngressCertificateSecretVersion)
+			}
+		})
+	}
+}
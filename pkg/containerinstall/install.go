@@ -20,6 +20,7 @@ import (
 	"github.com/opencontainers/runtime-spec/specs-go"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/installfailure"
 	"github.com/Azure/ARO-RP/pkg/util/steps"
 )
 
@@ -119,7 +120,14 @@ func (m *manager) containerFinished(context.Context) (bool, error) {
 
 	if inspectData.State.Status == "exited" || inspectData.State.Status == "stopped" {
 		if inspectData.State.ExitCode != 0 {
-			getContainerLogs(m.conn, m.log, containerName)
+			installLog, err := getContainerLogs(m.conn, m.log, containerName)
+			if err == nil {
+				rule := installfailure.Default().Classify(installLog)
+				if rule != nil {
+					m.log.Infof("install failure classified as %s", rule.Reason)
+					return true, rule.NewError(installLog)
+				}
+			}
 			return true, fmt.Errorf("container exited with %d", inspectData.State.ExitCode)
 		}
 		m.success = true
@@ -197,7 +205,7 @@ func (m *manager) cleanupContainers(ctx context.Context) error {
 
 	if !m.success {
 		m.log.Infof("cleaning up failed container %s", containerName)
-		getContainerLogs(m.conn, m.log, containerName)
+		_, _ = getContainerLogs(m.conn, m.log, containerName)
 	}
 
 	_, err := containers.Remove(
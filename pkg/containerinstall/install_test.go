@@ -96,7 +96,7 @@ var _ = Describe("Podman", Ordered, func() {
 		// sometimes logs take a few seconds to flush to disk, so just retry for 10s
 		Eventually(func(g Gomega) {
 			hook.Reset()
-			err = getContainerLogs(conn, log, containerID)
+			_, err = getContainerLogs(conn, log, containerID)
 			g.Expect(err).ToNot(HaveOccurred())
 			entries := []map[string]types.GomegaMatcher{
 				{
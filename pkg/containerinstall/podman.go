@@ -6,6 +6,8 @@ package containerinstall
 import (
 	"context"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/containers/podman/v4/pkg/bindings"
 	"github.com/containers/podman/v4/pkg/bindings/containers"
@@ -24,17 +26,32 @@ func getConnection(ctx context.Context) (context.Context, error) {
 	return bindings.NewConnection(ctx, socket)
 }
 
-func getContainerLogs(ctx context.Context, log *logrus.Entry, containerName string) error {
+// getContainerLogs streams the logs of the given container to log and
+// returns them, interleaved in the order received.
+func getContainerLogs(ctx context.Context, log *logrus.Entry, containerName string) (string, error) {
+	var mu sync.Mutex
+	var logs strings.Builder
+	var wg sync.WaitGroup
+
 	stdout, stderr := make(chan string, 1024), make(chan string, 1024)
+	wg.Add(2)
 	go func() {
+		defer wg.Done()
 		for v := range stdout {
 			log.Infof("stdout: %s", v)
+			mu.Lock()
+			logs.WriteString(v)
+			mu.Unlock()
 		}
 	}()
 
 	go func() {
+		defer wg.Done()
 		for v := range stderr {
 			log.Errorf("stderr: %s", v)
+			mu.Lock()
+			logs.WriteString(v)
+			mu.Unlock()
 		}
 	}()
 	err := containers.Logs(
@@ -44,7 +61,11 @@ func getContainerLogs(ctx context.Context, log *logrus.Entry, containerName stri
 		stdout,
 		stderr,
 	)
-	return err
+	close(stdout)
+	close(stderr)
+	wg.Wait()
+
+	return logs.String(), err
 }
 
 func runContainer(ctx context.Context, log *logrus.Entry, s *specgen.SpecGenerator) (string, error) {
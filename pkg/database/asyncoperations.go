@@ -24,6 +24,7 @@ type AsyncOperations interface {
 	Create(context.Context, *api.AsyncOperationDocument) (*api.AsyncOperationDocument, error)
 	Get(context.Context, string) (*api.AsyncOperationDocument, error)
 	Patch(context.Context, string, func(*api.AsyncOperationDocument) error) (*api.AsyncOperationDocument, error)
+	List(string) cosmosdb.AsyncOperationDocumentIterator
 	NewUUID() string
 }
 
@@ -87,3 +88,7 @@ func (c *asyncOperations) Patch(ctx context.Context, id string, f func(*api.Asyn
 
 	return doc, err
 }
+
+func (c *asyncOperations) List(continuation string) cosmosdb.AsyncOperationDocumentIterator {
+	return c.c.List(&cosmosdb.Options{Continuation: continuation})
+}
@@ -23,6 +23,8 @@ type Billing interface {
 	Get(context.Context, string) (*api.BillingDocument, error)
 	MarkForDeletion(context.Context, string) (*api.BillingDocument, error)
 	UpdateLastBillingTimestamp(context.Context, string, int) (*api.BillingDocument, error)
+	UpdateCreationTimestamp(context.Context, string, int) (*api.BillingDocument, error)
+	UnmarkForDeletion(context.Context, string) (*api.BillingDocument, error)
 	List(string) cosmosdb.BillingDocumentIterator
 	ListAll(context.Context) (*api.BillingDocuments, error)
 	Delete(context.Context, *api.BillingDocument) error
@@ -130,6 +132,14 @@ func (c *billing) MarkForDeletion(ctx context.Context, id string) (*api.BillingD
 	}, &cosmosdb.Options{PreTriggers: []string{"setDeletionBillingTimeStamp"}})
 }
 
+// UnmarkForDeletion clears the deletion timestamp field in the document
+func (c *billing) UnmarkForDeletion(ctx context.Context, id string) (*api.BillingDocument, error) {
+	return c.patch(ctx, id, func(billingdoc *api.BillingDocument) error {
+		billingdoc.Billing.DeletionTime = 0
+		return nil
+	}, nil)
+}
+
 // List produces and iterator for paging through all billing documents.
 func (c *billing) List(continuation string) cosmosdb.BillingDocumentIterator {
 	return c.c.List(&cosmosdb.Options{Continuation: continuation})
@@ -157,3 +167,12 @@ func (c *billing) UpdateLastBillingTimestamp(ctx context.Context, id string, tim
 		return nil
 	}, nil)
 }
+
+// UpdateCreationTimestamp update the creation timestamp field in the document
+// with the time provided, used when reconciling drifted billing records
+func (c *billing) UpdateCreationTimestamp(ctx context.Context, id string, time int) (*api.BillingDocument, error) {
+	return c.patch(ctx, id, func(billingdoc *api.BillingDocument) error {
+		billingdoc.Billing.CreationTime = time
+		return nil
+	}, nil)
+}
@@ -33,6 +33,7 @@ type ClusterManagerConfigurations interface {
 	Update(context.Context, *api.ClusterManagerConfigurationDocument) (*api.ClusterManagerConfigurationDocument, error)
 	Delete(context.Context, *api.ClusterManagerConfigurationDocument) error
 	ChangeFeed() cosmosdb.ClusterManagerConfigurationDocumentIterator
+	List(string) cosmosdb.ClusterManagerConfigurationDocumentIterator
 	NewUUID() string
 }
 
@@ -132,6 +133,10 @@ func (c *clusterManagerConfiguration) ChangeFeed() cosmosdb.ClusterManagerConfig
 	return c.c.ChangeFeed(nil)
 }
 
+func (c *clusterManagerConfiguration) List(continuation string) cosmosdb.ClusterManagerConfigurationDocumentIterator {
+	return c.c.List(&cosmosdb.Options{Continuation: continuation})
+}
+
 func (c *clusterManagerConfiguration) partitionKey(key string) (string, error) {
 	r, err := azure.ParseResourceID(key)
 	return r.SubscriptionID, err
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/azure"
 
@@ -21,8 +22,13 @@ const (
 	OpenShiftClustersQueueLengthQuery   = `SELECT VALUE COUNT(1) FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState IN ("Creating", "Deleting", "Updating", "AdminUpdating") AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000`
 	OpenShiftClustersGetQuery           = `SELECT * FROM OpenShiftClusters doc WHERE doc.key = @key`
 	OpenshiftClustersPrefixQuery        = `SELECT * FROM OpenShiftClusters doc WHERE STARTSWITH(doc.key, @prefix)`
+	OpenShiftClustersFilterQuery        = `SELECT * FROM OpenShiftClusters doc WHERE STARTSWITH(doc.key, @prefix) AND (@keyContains = "" OR CONTAINS(doc.key, @keyContains)) AND (@version = "" OR doc.openShiftCluster.properties.clusterProfile.version = @version) AND (@provisioningState = "" OR StringEquals(doc.openShiftCluster.properties.provisioningState, @provisioningState, true)) AND (@provisionedBy = "" OR doc.openShiftCluster.properties.provisionedBy = @provisionedBy) AND (@createdAfter = "" OR (doc.openShiftCluster.properties.createdAt ?? "") >= @createdAfter) AND (@createdBefore = "" OR (doc.openShiftCluster.properties.createdAt ?? "") < @createdBefore)`
 	OpenshiftClustersClientIdQuery      = `SELECT * FROM OpenShiftClusters doc WHERE doc.clientIdKey = @clientID`
 	OpenshiftClustersResourceGroupQuery = `SELECT * FROM OpenShiftClusters doc WHERE doc.clusterResourceGroupIdKey = @resourceGroupID`
+	OpenShiftClustersEtcdSnapshotQuery  = `SELECT * FROM OpenShiftClusters doc WHERE (doc.openShiftCluster.properties.etcdSnapshotProfile.interval ?? "") != ""`
+	OpenShiftClustersDeferredQuery      = `SELECT * FROM OpenShiftClusters doc WHERE (doc.openShiftCluster.properties.deferredMaintenanceTask ?? "") != ""`
+	OpenShiftClustersACRTokenQuery      = `SELECT * FROM OpenShiftClusters doc WHERE EXISTS(SELECT VALUE p FROM p IN doc.openShiftCluster.properties.registryProfiles WHERE p.name = @name AND (p.passwordCreationTime ?? "") < @createdBefore)`
+	OpenShiftClustersCertificateQuery   = `SELECT * FROM OpenShiftClusters doc WHERE (doc.openShiftCluster.properties.apiserverProfile.certificateSecretId ?? "") != "" OR EXISTS(SELECT VALUE p FROM p IN doc.openShiftCluster.properties.ingressProfiles WHERE (p.certificateSecretId ?? "") != "")`
 )
 
 type OpenShiftClusterDocumentMutator func(*api.OpenShiftClusterDocument) error
@@ -34,6 +40,24 @@ type openShiftClusters struct {
 	uuidGenerator uuid.Generator
 }
 
+// OpenShiftClustersFilter selects the documents returned by ListByFilter.
+// Empty fields other than Prefix match every document.
+type OpenShiftClustersFilter struct {
+	// Prefix and KeyContains are lower case: Prefix is required and
+	// KeyContains is a substring of the document key
+	Prefix      string
+	KeyContains string
+
+	Version           string
+	ProvisioningState string // compared case insensitively
+	ProvisionedBy     string
+
+	// CreatedAfter and CreatedBefore are compared to the second: documents
+	// created within the second of either bound may be returned outside it
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
 // OpenShiftClusters is the database interface for OpenShiftClusterDocuments
 type OpenShiftClusters interface {
 	Create(context.Context, *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error)
@@ -47,11 +71,16 @@ type OpenShiftClusters interface {
 	List(string) cosmosdb.OpenShiftClusterDocumentIterator
 	ListAll(context.Context) (*api.OpenShiftClusterDocuments, error)
 	ListByPrefix(string, string, string) (cosmosdb.OpenShiftClusterDocumentIterator, error)
+	ListByFilter(string, *OpenShiftClustersFilter, string) (cosmosdb.OpenShiftClusterDocumentIterator, error)
 	Dequeue(context.Context) (*api.OpenShiftClusterDocument, error)
 	Lease(context.Context, string) (*api.OpenShiftClusterDocument, error)
 	EndLease(context.Context, string, api.ProvisioningState, api.ProvisioningState, *string) (*api.OpenShiftClusterDocument, error)
 	GetByClientID(ctx context.Context, partitionKey, clientID string) (*api.OpenShiftClusterDocuments, error)
 	GetByClusterResourceGroupID(ctx context.Context, partitionKey, resourceGroupID string) (*api.OpenShiftClusterDocuments, error)
+	ListWithEtcdSnapshotSchedule(context.Context) (*api.OpenShiftClusterDocuments, error)
+	ListWithACRTokenPasswordCreatedBefore(context.Context, string, time.Time) (*api.OpenShiftClusterDocuments, error)
+	ListWithDeferredMaintenanceTask(context.Context) (*api.OpenShiftClusterDocuments, error)
+	ListWithCustomerCertificates(context.Context) (*api.OpenShiftClusterDocuments, error)
 	NewUUID() string
 }
 
@@ -370,3 +399,117 @@ func (c *openShiftClusters) GetByClusterResourceGroupID(ctx context.Context, par
 	}
 	return docs, nil
 }
+
+// createdAtBoundLayout formats the createdAt bounds of
+// OpenShiftClustersFilterQuery
+const createdAtBoundLayout = "2006-01-02T15:04:05"
+
+// ListByFilter returns the documents selected by filter, in the partition of
+// subscriptionID if it is given
+func (c *openShiftClusters) ListByFilter(subscriptionID string, filter *OpenShiftClustersFilter, continuation string) (cosmosdb.OpenShiftClusterDocumentIterator, error) {
+	if filter.Prefix != strings.ToLower(filter.Prefix) {
+		return nil, fmt.Errorf("prefix %q is not lower case", filter.Prefix)
+	}
+
+	if filter.KeyContains != strings.ToLower(filter.KeyContains) {
+		return nil, fmt.Errorf("key substring %q is not lower case", filter.KeyContains)
+	}
+
+	// createdAt is stored as an RFC3339 string with a variable number of
+	// fractional digits, which does not sort as the time it represents within
+	// a second.  Comparing it with whole seconds without a time zone designator
+	// selects every document within the bounds.
+	var createdAfter, createdBefore string
+	if !filter.CreatedAfter.IsZero() {
+		createdAfter = filter.CreatedAfter.UTC().Truncate(time.Second).Format(createdAtBoundLayout)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		t := filter.CreatedBefore.UTC()
+		if t.Truncate(time.Second).Before(t) {
+			t = t.Truncate(time.Second).Add(time.Second)
+		}
+		createdBefore = t.Format(createdAtBoundLayout)
+	}
+
+	return c.c.Query(
+		subscriptionID,
+		&cosmosdb.Query{
+			Query: OpenShiftClustersFilterQuery,
+			Parameters: []cosmosdb.Parameter{
+				{
+					Name:  "@prefix",
+					Value: filter.Prefix,
+				},
+				{
+					Name:  "@keyContains",
+					Value: filter.KeyContains,
+				},
+				{
+					Name:  "@version",
+					Value: filter.Version,
+				},
+				{
+					Name:  "@provisioningState",
+					Value: filter.ProvisioningState,
+				},
+				{
+					Name:  "@provisionedBy",
+					Value: filter.ProvisionedBy,
+				},
+				{
+					Name:  "@createdAfter",
+					Value: createdAfter,
+				},
+				{
+					Name:  "@createdBefore",
+					Value: createdBefore,
+				},
+			},
+		},
+		&cosmosdb.Options{Continuation: continuation},
+	), nil
+}
+
+// ListWithEtcdSnapshotSchedule returns the clusters which have scheduled etcd
+// snapshots enabled
+func (c *openShiftClusters) ListWithEtcdSnapshotSchedule(ctx context.Context) (*api.OpenShiftClusterDocuments, error) {
+	return c.c.QueryAll(ctx, "", &cosmosdb.Query{
+		Query: OpenShiftClustersEtcdSnapshotQuery,
+	}, nil)
+}
+
+// ListWithACRTokenPasswordCreatedBefore returns the clusters whose registry
+// profile of the given name has a password created before createdBefore, or
+// whose password creation time was never recorded.  Creation times are compared
+// as RFC3339 strings, so are only accurate to the second.
+func (c *openShiftClusters) ListWithACRTokenPasswordCreatedBefore(ctx context.Context, name string, createdBefore time.Time) (*api.OpenShiftClusterDocuments, error) {
+	return c.c.QueryAll(ctx, "", &cosmosdb.Query{
+		Query: OpenShiftClustersACRTokenQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@name",
+				Value: name,
+			},
+			{
+				Name:  "@createdBefore",
+				Value: createdBefore.UTC().Format(time.RFC3339),
+			},
+		},
+	}, nil)
+}
+
+// ListWithDeferredMaintenanceTask returns the clusters which have planned
+// maintenance waiting for a maintenance window to open
+func (c *openShiftClusters) ListWithDeferredMaintenanceTask(ctx context.Context) (*api.OpenShiftClusterDocuments, error) {
+	return c.c.QueryAll(ctx, "", &cosmosdb.Query{
+		Query: OpenShiftClustersDeferredQuery,
+	}, nil)
+}
+
+// ListWithCustomerCertificates returns the clusters which serve customer
+// supplied certificates
+func (c *openShiftClusters) ListWithCustomerCertificates(ctx context.Context) (*api.OpenShiftClusterDocuments, error) {
+	return c.c.QueryAll(ctx, "", &cosmosdb.Query{
+		Query: OpenShiftClustersCertificateQuery,
+	}, nil)
+}
@@ -0,0 +1,56 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sync/atomic"
+
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+)
+
+// ResealTracker is an AEAD which records whether any ciphertext it has opened
+// needs resealing.  A database client using a ResealTracker should only be
+// used by a single goroutine, so that what is recorded can be attributed to
+// the documents read.
+type ResealTracker interface {
+	encryption.AEAD
+
+	// Reset forgets the ciphertexts opened so far
+	Reset()
+
+	// Stale returns whether any ciphertext opened since Reset was called needs
+	// resealing
+	Stale() bool
+}
+
+type resealTracker struct {
+	encryption.Resealer
+	stale atomic.Value
+}
+
+var _ ResealTracker = (*resealTracker)(nil)
+
+func NewResealTracker(resealer encryption.Resealer) ResealTracker {
+	t := &resealTracker{
+		Resealer: resealer,
+	}
+	t.stale.Store(false)
+	return t
+}
+
+func (t *resealTracker) Open(input []byte) ([]byte, error) {
+	if t.NeedsReseal(input) {
+		t.stale.Store(true)
+	}
+
+	return t.Resealer.Open(input)
+}
+
+func (t *resealTracker) Reset() {
+	t.stale.Store(false)
+}
+
+func (t *resealTracker) Stale() bool {
+	return t.stale.Load().(bool)
+}
@@ -0,0 +1,63 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mock_encryption "github.com/Azure/ARO-RP/pkg/util/mocks/encryption"
+)
+
+func TestResealTracker(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	resealer := mock_encryption.NewMockResealer(controller)
+	resealer.EXPECT().NeedsReseal([]byte("current")).Return(false).AnyTimes()
+	resealer.EXPECT().NeedsReseal([]byte("stale")).Return(true).AnyTimes()
+	resealer.EXPECT().Open(gomock.Any()).Return([]byte("opened"), nil).AnyTimes()
+
+	tracker := NewResealTracker(resealer)
+
+	for _, step := range []struct {
+		open      []string
+		reset     bool
+		wantStale bool
+	}{
+		{
+			open: []string{"current"},
+		},
+		{
+			open:      []string{"current", "stale", "current"},
+			wantStale: true,
+		},
+		{
+			// state is kept until reset
+			wantStale: true,
+		},
+		{
+			reset: true,
+		},
+	} {
+		if step.reset {
+			tracker.Reset()
+		}
+
+		for _, input := range step.open {
+			b, err := tracker.Open([]byte(input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != "opened" {
+				t.Error(string(b))
+			}
+		}
+
+		if tracker.Stale() != step.wantStale {
+			t.Errorf("%v: got %v, want %v", step.open, tracker.Stale(), step.wantStale)
+		}
+	}
+}
@@ -391,6 +391,7 @@ EOF
 # are not used, but can't easily be refactored out. Should be revisited in the future.
 echo "configuring aro-monitor service"
 cat >/etc/sysconfig/aro-monitor <<EOF
+ACR_RESOURCE_ID='$ACRRESOURCEID'
 AZURE_FP_CLIENT_ID='$FPCLIENTID'
 DOMAIN_NAME='$LOCATION.$CLUSTERPARENTDOMAINNAME'
 CLUSTER_MDSD_ACCOUNT='$CLUSTERMDSDACCOUNT'
@@ -405,6 +406,7 @@ DATABASE_ACCOUNT_NAME='$DATABASEACCOUNTNAME'
 KEYVAULT_PREFIX='$KEYVAULTPREFIX'
 MDM_ACCOUNT='$RPMDMACCOUNT'
 MDM_NAMESPACE=BBM
+RP_FEATURES='$RPFEATURES'
 RPIMAGE='$RPIMAGE'
 EOF
 
@@ -421,6 +423,8 @@ ExecStart=/usr/bin/docker run \
   --name %N \
   --rm \
   --cap-drop net_raw \
+  -e ACR_RESOURCE_ID \
+  -e ACR_TOKEN_ROTATION_INTERVAL \
   -e AZURE_FP_CLIENT_ID \
   -e DOMAIN_NAME \
   -e CLUSTER_MDSD_ACCOUNT \
@@ -435,6 +439,7 @@ ExecStart=/usr/bin/docker run \
   -e KEYVAULT_PREFIX \
   -e MDM_ACCOUNT \
   -e MDM_NAMESPACE \
+  -e RP_FEATURES \
   -m 2.5g \
   -v /run/systemd/journal:/run/systemd/journal \
   -v /var/etw:/var/etw:z \
@@ -34,6 +34,7 @@ const (
 	FeatureRequireD2sV3Workers
 	FeatureDisableReadinessDelay
 	FeatureEnableOCMEndpoints
+	FeatureEnableEncryptionEnvelopes
 )
 
 const (
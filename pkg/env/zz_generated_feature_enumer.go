@@ -7,9 +7,9 @@ import (
 	"fmt"
 )
 
-const _FeatureName = "FeatureDisableDenyAssignmentsFeatureDisableSignedCertificatesFeatureEnableDevelopmentAuthorizerFeatureRequireD2sV3WorkersFeatureDisableReadinessDelayFeatureEnableOCMEndpoints"
+const _FeatureName = "FeatureDisableDenyAssignmentsFeatureDisableSignedCertificatesFeatureEnableDevelopmentAuthorizerFeatureRequireD2sV3WorkersFeatureDisableReadinessDelayFeatureEnableOCMEndpointsFeatureEnableEncryptionEnvelopes"
 
-var _FeatureIndex = [...]uint8{0, 29, 61, 95, 121, 149, 174}
+var _FeatureIndex = [...]uint8{0, 29, 61, 95, 121, 149, 174, 206}
 
 func (i Feature) String() string {
 	if i < 0 || i >= Feature(len(_FeatureIndex)-1) {
@@ -18,7 +18,7 @@ func (i Feature) String() string {
 	return _FeatureName[_FeatureIndex[i]:_FeatureIndex[i+1]]
 }
 
-var _FeatureValues = []Feature{0, 1, 2, 3, 4, 5}
+var _FeatureValues = []Feature{0, 1, 2, 3, 4, 5, 6}
 
 var _FeatureNameToValueMap = map[string]Feature{
 	_FeatureName[0:29]:    0,
@@ -27,6 +27,7 @@ var _FeatureNameToValueMap = map[string]Feature{
 	_FeatureName[95:121]:  3,
 	_FeatureName[121:149]: 4,
 	_FeatureName[149:174]: 5,
+	_FeatureName[174:206]: 6,
 }
 
 // FeatureString retrieves an enum value from the enum constants string name.
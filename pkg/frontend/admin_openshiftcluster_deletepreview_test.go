@@ -0,0 +1,158 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/clusterdelete"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminDeletePreview(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	mockTenantID := "00000000-0000-0000-0000-000000000000"
+	mockDocID := "11111111-1111-1111-1111-111111111111"
+
+	ctx := context.Background()
+
+	fixture := func(f *testdatabase.Fixture) {
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			ID:  mockDocID,
+			Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			},
+		})
+
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: mockTenantID,
+				},
+			},
+		})
+	}
+
+	for _, tt := range []struct {
+		name           string
+		fixture        func(*testdatabase.Fixture)
+		mocks          func(*mock_adminactions.MockAzureActions)
+		wantStatusCode int
+		wantResponse   []byte
+		wantError      string
+	}{
+		{
+			name:    "preview",
+			fixture: fixture,
+			mocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DeletePreview(gomock.Any(), mockDocID).Return([]clusterdelete.Item{
+					{
+						Kind:   clusterdelete.KindResource,
+						ID:     "/subscriptions/sub/resourceGroups/aro-cluster/providers/Microsoft.Network/networkSecurityGroups/nsg",
+						Type:   "Microsoft.Network/networkSecurityGroups",
+						Action: clusterdelete.ActionDelete,
+					},
+					{
+						Kind:    clusterdelete.KindSubnet,
+						ID:      "/subscriptions/sub/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master",
+						Type:    "Microsoft.Network/virtualNetworks/subnets",
+						Action:  clusterdelete.ActionModify,
+						Message: "network security group nsg will be disconnected",
+					},
+				}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: []byte(`[
+    {
+        "kind": "Resource",
+        "id": "/subscriptions/sub/resourceGroups/aro-cluster/providers/Microsoft.Network/networkSecurityGroups/nsg",
+        "type": "Microsoft.Network/networkSecurityGroups",
+        "action": "Delete"
+    },
+    {
+        "kind": "Subnet",
+        "id": "/subscriptions/sub/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master",
+        "type": "Microsoft.Network/virtualNetworks/subnets",
+        "action": "Modify",
+        "message": "network security group nsg will be disconnected"
+    }
+]
+`),
+		},
+		{
+			name:    "nothing to delete",
+			fixture: fixture,
+			mocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DeletePreview(gomock.Any(), mockDocID).Return(nil, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   []byte("[]\n"),
+		},
+		{
+			name:    "error",
+			fixture: fixture,
+			mocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DeletePreview(gomock.Any(), mockDocID).Return(nil, fmt.Errorf("random error"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : Internal server error.",
+		},
+		{
+			name:           "cluster not found",
+			fixture:        func(f *testdatabase.Fixture) {},
+			mocks:          func(a *mock_adminactions.MockAzureActions) {},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      `404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			a := mock_adminactions.NewMockAzureActions(ti.controller)
+			tt.mocks(a)
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.SubscriptionDocument) (adminactions.AzureActions, error) {
+				return a, nil
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/deletepreview", testdatabase.GetResourcePath(mockSubID, "resourceName")),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/diagnostics"
+)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/diagnosticbundles
+func (f *frontend) getAdminOpenShiftClusterDiagnosticBundles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._getAdminOpenShiftClusterDiagnosticBundles(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminOpenShiftClusterDiagnosticBundles(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	doc, err := f.etcdSnapshotsClusterDocument(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := f.etcdSnapshotsAzureActions(ctx, log, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := a.DiagnosticBundleList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make([]admin.DiagnosticBundle, 0, len(blobs))
+	for _, blob := range blobs {
+		bundles = append(bundles, admin.DiagnosticBundle{
+			Name:         blob.Name,
+			CreationTime: time.Time(blob.Properties.LastModified).UTC(),
+			Size:         blob.Properties.ContentLength,
+		})
+	}
+
+	return json.MarshalIndent(bundles, "", "    ")
+}
+
+// postAdminOpenShiftClusterDiagnosticBundles collects a diagnostic bundle
+// from the cluster and stores it encrypted in the cluster storage account.
+// The request body optionally overrides the resources and logs which are
+// gathered.
+func (f *frontend) postAdminOpenShiftClusterDiagnosticBundles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._postAdminOpenShiftClusterDiagnosticBundles(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _postAdminOpenShiftClusterDiagnosticBundles(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	options := diagnostics.DefaultOptions()
+
+	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
+	if len(body) > 0 {
+		options = &diagnostics.Options{}
+
+		err := json.Unmarshal(body, options)
+		if err != nil {
+			return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
+		}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "%s", err)
+	}
+
+	for _, resource := range options.Resources {
+		gvr := schema.GroupVersionResource{Group: resource.Group, Version: resource.Version, Resource: resource.Resource}
+
+		err = validateAdminKubernetesObjects(http.MethodGet, gvr, resource.Namespace, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	doc, err := f.etcdSnapshotsClusterDocument(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := f.etcdSnapshotsAzureActions(ctx, log, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	now := f.now().UTC()
+
+	buf := &bytes.Buffer{}
+	bundle := diagnostics.NewBundle(buf, now)
+
+	err = k.CollectDiagnostics(ctx, bundle, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range bundle.Errors() {
+		log.Warnf("diagnostic bundle: %s", e)
+	}
+
+	err = bundle.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := f.aead.Seal(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	name := diagnostics.BlobName(now)
+
+	err = a.DiagnosticBundleUpload(ctx, name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("saved diagnostic bundle %s", name)
+
+	return json.MarshalIndent(admin.DiagnosticBundle{
+		Name:         name,
+		CreationTime: now,
+		Size:         int64(len(data)),
+	}, "", "    ")
+}
+
+// getAdminOpenShiftClusterDiagnosticBundle downloads the given diagnostic
+// bundle, decrypted, as a gzipped tarball
+func (f *frontend) getAdminOpenShiftClusterDiagnosticBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._getAdminOpenShiftClusterDiagnosticBundle(ctx, w, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+func (f *frontend) _getAdminOpenShiftClusterDiagnosticBundle(ctx context.Context, w http.ResponseWriter, r *http.Request, log *logrus.Entry) error {
+	name := r.URL.Query().Get("name")
+	if !diagnostics.IsBlobName(name) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided name '%s' is invalid.", name)
+	}
+
+	doc, err := f.etcdSnapshotsClusterDocument(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	a, err := f.etcdSnapshotsAzureActions(ctx, log, doc)
+	if err != nil {
+		return err
+	}
+
+	blobs, err := a.DiagnosticBundleList(ctx)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for _, blob := range blobs {
+		if blob.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "", "The diagnostic bundle '%s' was not found.", name)
+	}
+
+	sealed, err := a.DiagnosticBundleGet(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	data, err := f.aead.Open(sealed)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(name[:len(name)-len(".enc")]))
+
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,269 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/diagnostics"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminDiagnosticBundles(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	mockTenantID := "00000000-0000-0000-0000-000000000000"
+	now := time.Date(2023, 1, 2, 0, 0, 5, 0, time.UTC)
+	bundle := diagnostics.BlobName(now)
+
+	ctx := context.Background()
+
+	fixture := func(f *testdatabase.Fixture) {
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			},
+		})
+
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: mockTenantID,
+				},
+			},
+		})
+	}
+
+	blobs := []azstorage.Blob{
+		{
+			Name: bundle,
+			Properties: azstorage.BlobProperties{
+				LastModified:  azstorage.TimeRFC1123(now),
+				ContentLength: 1024,
+			},
+		},
+	}
+
+	// the size of a collected bundle depends on its compression, so it is
+	// filled in when the bundle is uploaded
+	collected := &admin.DiagnosticBundle{
+		Name:         bundle,
+		CreationTime: now,
+	}
+
+	for _, tt := range []struct {
+		name            string
+		method          string
+		path            string
+		body            interface{}
+		kubeMocks       func(*mock_adminactions.MockKubeActions)
+		azureMocks      func(*mock_adminactions.MockAzureActions)
+		wantStatusCode  int
+		wantContentType string
+		wantResponse    interface{}
+		wantError       string
+	}{
+		{
+			name:   "list",
+			method: http.MethodGet,
+			path:   "/diagnosticbundles",
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DiagnosticBundleList(gomock.Any()).Return(blobs, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &[]admin.DiagnosticBundle{
+				{
+					Name:         bundle,
+					CreationTime: now,
+					Size:         1024,
+				},
+			},
+		},
+		{
+			name:   "list without bundles",
+			method: http.MethodGet,
+			path:   "/diagnosticbundles",
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DiagnosticBundleList(gomock.Any()).Return(nil, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   []byte("[]\n"),
+		},
+		{
+			name:   "collect with default options",
+			method: http.MethodPost,
+			path:   "/diagnosticbundles",
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().CollectDiagnostics(gomock.Any(), gomock.Any(), diagnostics.DefaultOptions()).Return(nil)
+			},
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DiagnosticBundleUpload(gomock.Any(), bundle, gomock.Any()).DoAndReturn(func(ctx context.Context, name string, data []byte) error {
+					if !bytes.HasPrefix(data, []byte("FAKE")) {
+						return fmt.Errorf("bundle was not encrypted")
+					}
+					collected.Size = int64(len(data))
+					return nil
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   collected,
+		},
+		{
+			name:   "collect with options",
+			method: http.MethodPost,
+			path:   "/diagnosticbundles",
+			body: &diagnostics.Options{
+				LogNamespaces: []string{"openshift-etcd"},
+				LogLines:      10,
+			},
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().CollectDiagnostics(gomock.Any(), gomock.Any(), &diagnostics.Options{
+					LogNamespaces: []string{"openshift-etcd"},
+					LogLines:      10,
+				}).Return(nil)
+			},
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DiagnosticBundleUpload(gomock.Any(), bundle, gomock.Any()).DoAndReturn(func(ctx context.Context, name string, data []byte) error {
+					collected.Size = int64(len(data))
+					return nil
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   collected,
+		},
+		{
+			name:   "collect secrets",
+			method: http.MethodPost,
+			path:   "/diagnosticbundles",
+			body: &diagnostics.Options{
+				Resources: []diagnostics.Resource{{Version: "v1", Resource: "secrets"}},
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      "403: Forbidden: : Access to secrets is forbidden.",
+		},
+		{
+			name:   "collect oauth tokens",
+			method: http.MethodPost,
+			path:   "/diagnosticbundles",
+			body: &diagnostics.Options{
+				Resources: []diagnostics.Resource{{Group: "oauth.openshift.io", Version: "v1", Resource: "oauthaccesstokens"}},
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      "403: Forbidden: : Access to secrets is forbidden.",
+		},
+		{
+			name:   "collect from customer namespace",
+			method: http.MethodPost,
+			path:   "/diagnosticbundles",
+			body: &diagnostics.Options{
+				LogNamespaces: []string{"customer"},
+				LogLines:      10,
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: : logs may not be gathered from namespace "customer"`,
+		},
+		{
+			name:   "download",
+			method: http.MethodGet,
+			path:   "/diagnosticbundle?name=" + bundle,
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DiagnosticBundleList(gomock.Any()).Return(blobs, nil)
+				a.EXPECT().DiagnosticBundleGet(gomock.Any(), bundle).Return([]byte("FAKEbundle"), nil)
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/gzip",
+			wantResponse:    []byte("bundle"),
+		},
+		{
+			name:           "download invalid name",
+			method:         http.MethodGet,
+			path:           "/diagnosticbundle?name=latest",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: : The provided name 'latest' is invalid.",
+		},
+		{
+			name:   "download not found",
+			method: http.MethodGet,
+			path:   "/diagnosticbundle?name=diagnostics-20230101T000000Z.tar.gz.enc",
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DiagnosticBundleList(gomock.Any()).Return(blobs, nil)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : The diagnostic bundle 'diagnostics-20230101T000000Z.tar.gz.enc' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			if tt.kubeMocks != nil {
+				tt.kubeMocks(k)
+			}
+
+			a := mock_adminactions.NewMockAzureActions(ti.controller)
+			if tt.azureMocks != nil {
+				tt.azureMocks(a)
+			}
+
+			err := ti.buildFixtures(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, testdatabase.NewFakeAEAD(), nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.SubscriptionDocument) (adminactions.AzureActions, error) {
+				return a, nil
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.now = func() time.Time { return now }
+
+			go f.Run(ctx, nil, nil)
+
+			var header http.Header
+			if tt.body != nil {
+				header = http.Header{
+					"Content-Type": []string{"application/json"},
+				}
+			}
+
+			resp, b, err := ti.request(tt.method,
+				fmt.Sprintf("https://server/admin%s%s", testdatabase.GetResourcePath(mockSubID, "resourceName"), tt.path),
+				header, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantContentType != "" && resp.Header.Get("Content-Type") != tt.wantContentType {
+				t.Error(resp.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
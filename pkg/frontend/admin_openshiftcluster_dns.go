@@ -0,0 +1,93 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+)
+
+// getAdminOpenShiftClusterDNSDrift returns the api and *.apps records of the
+// cluster's managed domain which do not match the cluster document
+func (f *frontend) getAdminOpenShiftClusterDNSDrift(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._getAdminOpenShiftClusterDNSDrift(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminOpenShiftClusterDNSDrift(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	a, err := f.dnsAzureActions(ctx, r, log)
+	if err != nil {
+		return nil, err
+	}
+
+	drift, err := a.DNSRecordDrift(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if drift == nil {
+		drift = []dns.RecordDrift{}
+	}
+
+	return json.MarshalIndent(drift, "", "    ")
+}
+
+// postAdminOpenShiftClusterRepairDNS recreates the api and *.apps records of
+// the cluster's managed domain from the cluster document
+func (f *frontend) postAdminOpenShiftClusterRepairDNS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._postAdminOpenShiftClusterRepairDNS(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+func (f *frontend) _postAdminOpenShiftClusterRepairDNS(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	a, err := f.dnsAzureActions(ctx, r, log)
+	if err != nil {
+		return err
+	}
+
+	return a.DNSRepair(ctx)
+}
+
+func (f *frontend) dnsAzureActions(ctx context.Context, r *http.Request, log *logrus.Entry) (adminactions.AzureActions, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	subscriptionDoc, err := f.getSubscriptionDocument(ctx, doc.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.azureActionsFactory(log, f.env, doc.OpenShiftCluster, subscriptionDoc)
+}
@@ -0,0 +1,170 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminDNS(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	mockTenantID := "00000000-0000-0000-0000-000000000000"
+
+	ctx := context.Background()
+
+	type test struct {
+		name           string
+		method         string
+		action         string
+		resourceID     string
+		fixture        func(*testdatabase.Fixture)
+		mocks          func(*mock_adminactions.MockAzureActions)
+		wantStatusCode int
+		wantResponse   []byte
+		wantError      string
+	}
+
+	fixture := func(f *testdatabase.Fixture) {
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			},
+		})
+
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: mockTenantID,
+				},
+			},
+		})
+	}
+
+	for _, tt := range []*test{
+		{
+			name:       "drift",
+			method:     http.MethodGet,
+			action:     "dnsdrift",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture:    fixture,
+			mocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DNSRecordDrift(gomock.Any()).Return([]dns.RecordDrift{
+					{
+						Name:     "api.cluster",
+						Expected: "1.2.3.4",
+						Actual:   []string{"5.6.7.8"},
+					},
+				}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: []byte(`[
+    {
+        "name": "api.cluster",
+        "expected": "1.2.3.4",
+        "actual": [
+            "5.6.7.8"
+        ]
+    }
+]
+`),
+		},
+		{
+			name:       "no drift",
+			method:     http.MethodGet,
+			action:     "dnsdrift",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture:    fixture,
+			mocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DNSRecordDrift(gomock.Any()).Return(nil, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   []byte("[]\n"),
+		},
+		{
+			name:       "repair",
+			method:     http.MethodPost,
+			action:     "repairdns",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture:    fixture,
+			mocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DNSRepair(gomock.Any()).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:       "repair error",
+			method:     http.MethodPost,
+			action:     "repairdns",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture:    fixture,
+			mocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().DNSRepair(gomock.Any()).Return(fmt.Errorf(`recordset "api.cluster" already registered`))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : Internal server error.",
+		},
+		{
+			name:           "cluster not found",
+			method:         http.MethodPost,
+			action:         "repairdns",
+			resourceID:     testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture:        func(f *testdatabase.Fixture) {},
+			mocks:          func(a *mock_adminactions.MockAzureActions) {},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      `404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			a := mock_adminactions.NewMockAzureActions(ti.controller)
+			tt.mocks(a)
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.SubscriptionDocument) (adminactions.AzureActions, error) {
+				return a, nil
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(tt.method,
+				fmt.Sprintf("https://server/admin%s/%s", tt.resourceID, tt.action),
+				nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
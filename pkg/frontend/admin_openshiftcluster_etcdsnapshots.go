@@ -0,0 +1,260 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/etcdsnapshot"
+)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/etcdsnapshots
+func (f *frontend) getAdminOpenShiftClusterEtcdSnapshots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._getAdminOpenShiftClusterEtcdSnapshots(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminOpenShiftClusterEtcdSnapshots(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	doc, err := f.etcdSnapshotsClusterDocument(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := f.etcdSnapshotsAzureActions(ctx, log, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := a.EtcdSnapshotList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]admin.EtcdSnapshot, 0, len(blobs))
+	for _, blob := range blobs {
+		snapshots = append(snapshots, admin.EtcdSnapshot{
+			Name:         blob.Name,
+			CreationTime: time.Time(blob.Properties.LastModified).UTC(),
+			Size:         blob.Properties.ContentLength,
+		})
+	}
+
+	return json.MarshalIndent(snapshots, "", "    ")
+}
+
+// postAdminOpenShiftClusterEtcdRestore starts the disaster recovery restore
+// of an etcd snapshot on the given control plane node.  The restore runs as a
+// job on the node; the cluster API is unavailable while it runs, so the
+// request does not wait for it to complete.
+func (f *frontend) postAdminOpenShiftClusterEtcdRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._postAdminOpenShiftClusterEtcdRestore(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+func (f *frontend) _postAdminOpenShiftClusterEtcdRestore(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	snapshot := r.URL.Query().Get("snapshot")
+	if !etcdsnapshot.IsBlobName(snapshot) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided snapshot '%s' is invalid.", snapshot)
+	}
+
+	vmName := r.URL.Query().Get("vmName")
+	err := validateAdminVMName(vmName)
+	if err != nil {
+		return err
+	}
+
+	doc, err := f.etcdSnapshotsClusterDocument(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	p := doc.OpenShiftCluster.Properties.EtcdSnapshotProfile
+	if p == nil || len(p.EncryptionKey) == 0 {
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "", "No etcd snapshot has been taken of the cluster.")
+	}
+
+	a, err := f.etcdSnapshotsAzureActions(ctx, log, doc)
+	if err != nil {
+		return err
+	}
+
+	blobs, err := a.EtcdSnapshotList(ctx)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for _, blob := range blobs {
+		if blob.Name == snapshot {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "", "The etcd snapshot '%s' was not found.", snapshot)
+	}
+
+	k, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		return err
+	}
+
+	masters, err := etcdRestoreMasterAddresses(ctx, k, vmName)
+	if err != nil {
+		return err
+	}
+
+	snapshotURL, err := a.EtcdSnapshotURL(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+
+	secret, job := etcdsnapshot.RestoreJob(vmName, snapshotURL, p.EncryptionKey, doc.OpenShiftCluster.Properties.SSHKey, masters)
+
+	// jobs are immutable, so remove any job left by a previous restore
+	propagationPolicy := metav1.DeletePropagationBackground
+	err = k.KubeDelete(ctx, job.Kind, job.Namespace, job.Name, false, &propagationPolicy)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	// the job is created first, so that the secret holding the SSH and
+	// encryption keys can be owned by it and removed with it.  The job's pod
+	// waits for the secret to exist.
+	err = etcdRestoreCreate(ctx, k, job)
+	if err != nil {
+		return err
+	}
+
+	b, err := k.KubeGet(ctx, job.Kind, job.Namespace, job.Name)
+	if err != nil {
+		return err
+	}
+
+	created := &batchv1.Job{}
+	err = json.Unmarshal(b, created)
+	if err != nil {
+		return err
+	}
+
+	etcdsnapshot.SetOwner(secret, created)
+
+	err = etcdRestoreCreate(ctx, k, secret)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("started restore of etcd snapshot %s on node %s", snapshot, vmName)
+
+	return nil
+}
+
+func etcdRestoreCreate(ctx context.Context, k adminactions.KubeActions, o runtime.Object) error {
+	un := &unstructured.Unstructured{}
+
+	var err error
+	un.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return err
+	}
+
+	return k.KubeCreateOrUpdate(ctx, un)
+}
+
+// etcdRestoreMasterAddresses validates that vmName is a control plane node
+// and returns the internal addresses of the other control plane nodes
+func etcdRestoreMasterAddresses(ctx context.Context, k adminactions.KubeActions, vmName string) ([]string, error) {
+	b, err := k.KubeList(ctx, "Node", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes corev1.NodeList
+	err = json.Unmarshal(b, &nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	var found bool
+	var addresses []string
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels["node-role.kubernetes.io/master"]; !ok {
+			continue
+		}
+
+		if strings.EqualFold(node.Name, vmName) {
+			found = true
+			continue
+		}
+
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP {
+				addresses = append(addresses, address.Address)
+			}
+		}
+	}
+
+	if !found {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided vmName '%s' is not a master node.", vmName)
+	}
+
+	sort.Strings(addresses)
+
+	return addresses, nil
+}
+
+func (f *frontend) etcdSnapshotsClusterDocument(ctx context.Context, r *http.Request) (*api.OpenShiftClusterDocument, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (f *frontend) etcdSnapshotsAzureActions(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument) (adminactions.AzureActions, error) {
+	subscriptionDoc, err := f.getSubscriptionDocument(ctx, doc.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.azureActionsFactory(log, f.env, doc.OpenShiftCluster, subscriptionDoc)
+}
@@ -0,0 +1,319 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminEtcdSnapshots(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	mockTenantID := "00000000-0000-0000-0000-000000000000"
+	snapshot := "etcd-snapshot-20230102T000000Z.tar.gz.enc"
+	snapshotURL := "https://cluster.blob.core.windows.net/etcd-snapshots/" + snapshot + "?sig=x"
+	creationTime := time.Date(2023, 1, 2, 0, 0, 5, 0, time.UTC)
+
+	ctx := context.Background()
+
+	fixtureWithProfile := func(profile *api.EtcdSnapshotProfile) func(*testdatabase.Fixture) {
+		return func(f *testdatabase.Fixture) {
+			f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+					Properties: api.OpenShiftClusterProperties{
+						EtcdSnapshotProfile: profile,
+					},
+				},
+			})
+
+			f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+				ID: mockSubID,
+				Subscription: &api.Subscription{
+					State: api.SubscriptionStateRegistered,
+					Properties: &api.SubscriptionProperties{
+						TenantID: mockTenantID,
+					},
+				},
+			})
+		}
+	}
+
+	fixture := fixtureWithProfile(&api.EtcdSnapshotProfile{
+		Interval:      "24h",
+		EncryptionKey: api.SecureBytes("key"),
+	})
+
+	blobs := []azstorage.Blob{
+		{
+			Name: snapshot,
+			Properties: azstorage.BlobProperties{
+				LastModified:  azstorage.TimeRFC1123(creationTime),
+				ContentLength: 1024,
+			},
+		},
+	}
+
+	nodes := []byte(`{
+    "kind": "NodeList",
+    "apiVersion": "v1",
+    "items": [
+        {
+            "metadata": {
+                "name": "master-0",
+                "labels": {
+                    "node-role.kubernetes.io/master": ""
+                }
+            },
+            "status": {
+                "addresses": [
+                    {
+                        "type": "InternalIP",
+                        "address": "10.0.0.6"
+                    }
+                ]
+            }
+        },
+        {
+            "metadata": {
+                "name": "master-1",
+                "labels": {
+                    "node-role.kubernetes.io/master": ""
+                }
+            },
+            "status": {
+                "addresses": [
+                    {
+                        "type": "InternalIP",
+                        "address": "10.0.0.7"
+                    }
+                ]
+            }
+        },
+        {
+            "metadata": {
+                "name": "master-2",
+                "labels": {
+                    "node-role.kubernetes.io/master": ""
+                }
+            },
+            "status": {
+                "addresses": [
+                    {
+                        "type": "InternalIP",
+                        "address": "10.0.0.5"
+                    }
+                ]
+            }
+        },
+        {
+            "metadata": {
+                "name": "worker-0",
+                "labels": {
+                    "node-role.kubernetes.io/worker": ""
+                }
+            },
+            "status": {
+                "addresses": [
+                    {
+                        "type": "InternalIP",
+                        "address": "10.0.1.4"
+                    }
+                ]
+            }
+        }
+    ]
+}`)
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		path           string
+		fixture        func(*testdatabase.Fixture)
+		kubeMocks      func(*mock_adminactions.MockKubeActions)
+		azureMocks     func(*mock_adminactions.MockAzureActions)
+		wantStatusCode int
+		wantResponse   *[]admin.EtcdSnapshot
+		wantError      string
+	}{
+		{
+			name:    "list",
+			method:  http.MethodGet,
+			path:    "/etcdsnapshots",
+			fixture: fixture,
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().EtcdSnapshotList(gomock.Any()).Return(blobs, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &[]admin.EtcdSnapshot{
+				{
+					Name:         snapshot,
+					CreationTime: creationTime,
+					Size:         1024,
+				},
+			},
+		},
+		{
+			name:    "restore",
+			method:  http.MethodPost,
+			path:    "/etcdrestore?snapshot=" + snapshot + "&vmName=master-1",
+			fixture: fixture,
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().EtcdSnapshotList(gomock.Any()).Return(blobs, nil)
+				a.EXPECT().EtcdSnapshotURL(gomock.Any(), snapshot).Return(snapshotURL, nil)
+			},
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeList(gomock.Any(), "Node", "").Return(nodes, nil)
+				k.EXPECT().KubeDelete(gomock.Any(), "Job", "openshift-etcd", "aro-etcd-restore", false, gomock.Any()).
+					Return(kerrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, "aro-etcd-restore"))
+				gomock.InOrder(
+					k.EXPECT().KubeCreateOrUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, o *unstructured.Unstructured) error {
+						if o.GetKind() != "Job" {
+							return fmt.Errorf("unexpected kind %s", o.GetKind())
+						}
+						nodeName, _, _ := unstructured.NestedString(o.Object, "spec", "template", "spec", "nodeName")
+						if nodeName != "master-1" {
+							return fmt.Errorf("unexpected node name %q", nodeName)
+						}
+						ttl, _, _ := unstructured.NestedInt64(o.Object, "spec", "ttlSecondsAfterFinished")
+						if ttl != 3600 {
+							return fmt.Errorf("unexpected ttlSecondsAfterFinished %d", ttl)
+						}
+						return nil
+					}),
+					k.EXPECT().KubeGet(gomock.Any(), "Job", "openshift-etcd", "aro-etcd-restore").
+						Return([]byte(`{"metadata": {"name": "aro-etcd-restore", "namespace": "openshift-etcd", "uid": "00000000-0000-0000-0000-000000000001"}}`), nil),
+					k.EXPECT().KubeCreateOrUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, o *unstructured.Unstructured) error {
+						if o.GetKind() != "Secret" {
+							return fmt.Errorf("unexpected kind %s", o.GetKind())
+						}
+						owners := o.GetOwnerReferences()
+						if len(owners) != 1 || owners[0].Kind != "Job" || owners[0].Name != "aro-etcd-restore" || owners[0].UID != "00000000-0000-0000-0000-000000000001" {
+							return fmt.Errorf("unexpected owner references %v", owners)
+						}
+						masters, _, _ := unstructured.NestedString(o.Object, "stringData", "MASTERS")
+						if masters != "10.0.0.5 10.0.0.6" {
+							return fmt.Errorf("unexpected masters %q", masters)
+						}
+						url, _, _ := unstructured.NestedString(o.Object, "stringData", "SNAPSHOT_URL")
+						if url != snapshotURL {
+							return fmt.Errorf("unexpected snapshot URL %q", url)
+						}
+						return nil
+					}),
+				)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "restore invalid snapshot",
+			method:         http.MethodPost,
+			path:           "/etcdrestore?snapshot=latest&vmName=master-1",
+			fixture:        fixture,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: : The provided snapshot 'latest' is invalid.",
+		},
+		{
+			name:           "restore without snapshots taken",
+			method:         http.MethodPost,
+			path:           "/etcdrestore?snapshot=" + snapshot + "&vmName=master-1",
+			fixture:        fixtureWithProfile(nil),
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : No etcd snapshot has been taken of the cluster.",
+		},
+		{
+			name:    "restore snapshot not found",
+			method:  http.MethodPost,
+			path:    "/etcdrestore?snapshot=etcd-snapshot-20230101T000000Z.tar.gz.enc&vmName=master-1",
+			fixture: fixture,
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().EtcdSnapshotList(gomock.Any()).Return(blobs, nil)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : The etcd snapshot 'etcd-snapshot-20230101T000000Z.tar.gz.enc' was not found.",
+		},
+		{
+			name:    "restore on a worker",
+			method:  http.MethodPost,
+			path:    "/etcdrestore?snapshot=" + snapshot + "&vmName=worker-0",
+			fixture: fixture,
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().EtcdSnapshotList(gomock.Any()).Return(blobs, nil)
+			},
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeList(gomock.Any(), "Node", "").Return(nodes, nil)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: : The provided vmName 'worker-0' is not a master node.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			if tt.kubeMocks != nil {
+				tt.kubeMocks(k)
+			}
+
+			a := mock_adminactions.NewMockAzureActions(ti.controller)
+			if tt.azureMocks != nil {
+				tt.azureMocks(a)
+			}
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.SubscriptionDocument) (adminactions.AzureActions, error) {
+				return a, nil
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(tt.method,
+				fmt.Sprintf("https://server/admin%s%s", testdatabase.GetResourcePath(mockSubID, "resourceName"), tt.path),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wantResponse interface{}
+			if tt.wantResponse != nil {
+				wantResponse = tt.wantResponse
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// /admin/maintenancetasks
+func (f *frontend) getAdminMaintenanceTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+
+	converter := f.apis[admin.APIVersion].MaintenanceTaskConverter
+
+	b, err := json.MarshalIndent(converter.ToExternalList(api.MaintenanceTaskDefinitions), "", "    ")
+
+	adminReply(log, w, nil, b, err)
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/maintenancetasks/{maintenanceTask}
+func (f *frontend) postAdminOpenShiftClusterMaintenanceTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(filepath.Dir(r.URL.Path))
+
+	var header http.Header
+	b, err := f._postAdminOpenShiftClusterMaintenanceTask(ctx, r, &header)
+	if err == nil {
+		err = statusCodeError(http.StatusAccepted)
+	}
+
+	adminReply(log, w, header, b, err)
+}
+
+// _postAdminOpenShiftClusterMaintenanceTask queues an admin update which runs
+// the named maintenance task
+func (f *frontend) _postAdminOpenShiftClusterMaintenanceTask(ctx context.Context, r *http.Request, header *http.Header) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+	correlationData := r.Context().Value(middleware.ContextKeyCorrelationData).(*api.CorrelationData)
+
+	def := findMaintenanceTaskDefinition(chi.URLParam(r, "maintenanceTask"))
+	if def == nil {
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "", "The maintenance task '%s' was not found.", chi.URLParam(r, "maintenanceTask"))
+	}
+
+	// callers may pin the version of the task which they expect to run
+	if v := r.URL.Query().Get("version"); v != "" && v != strconv.Itoa(def.Version) {
+		return nil, api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "", "The maintenance task '%s' is at version %d, not version %s.", def.Name, def.Version, v)
+	}
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	err = validateTerminalProvisioningState(doc.OpenShiftCluster.Properties.ProvisioningState)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateMaintenanceTaskPreconditions(doc, def)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.OpenShiftCluster.Properties.MaintenanceTask = def.Name
+	adminUpdateProvisioningState(doc, f.now())
+	doc.CorrelationData = correlationData
+
+	subId := chi.URLParam(r, "subscriptionId")
+	resourceProviderNamespace := chi.URLParam(r, "resourceProviderNamespace")
+
+	// the async operation is created once, outside any retry, and the update
+	// fails rather than retries if the document changed meanwhile
+	u, err := url.Parse(r.Header.Get("Referer"))
+	if err != nil {
+		return nil, err
+	}
+
+	doc.AsyncOperationID, err = f.newAsyncOperation(ctx, subId, resourceProviderNamespace, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Path = f.operationsPath(subId, resourceProviderNamespace, doc.AsyncOperationID)
+	*header = http.Header{
+		"Azure-AsyncOperation": []string{u.String()},
+	}
+
+	_, err = f.dbOpenShiftClusters.Update(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := f.apis[admin.APIVersion].MaintenanceTaskConverter
+
+	return json.MarshalIndent(converter.ToExternal(def), "", "    ")
+}
+
+// findMaintenanceTaskDefinition returns the registered maintenance task with
+// the given name, which is matched case insensitively because request paths
+// are lower cased
+func findMaintenanceTaskDefinition(name string) *api.MaintenanceTaskDefinition {
+	for _, def := range api.MaintenanceTaskDefinitions {
+		if strings.EqualFold(string(def.Name), name) {
+			return def
+		}
+	}
+
+	return nil
+}
+
+// validateMaintenanceTaskPreconditions returns an error if the cluster does not
+// meet the preconditions of the maintenance task
+func validateMaintenanceTaskPreconditions(doc *api.OpenShiftClusterDocument, def *api.MaintenanceTaskDefinition) error {
+	for _, p := range def.Preconditions {
+		switch p {
+		case api.MaintenanceTaskPreconditionOperatorSupported:
+			if !version.OperatorSupported(doc.OpenShiftCluster.Properties.ClusterProfile.Version) {
+				return api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "", "The maintenance task '%s' requires a cluster version supported by the ARO operator, but the cluster version is '%s'.", def.Name, doc.OpenShiftCluster.Properties.ClusterProfile.Version)
+			}
+
+		case api.MaintenanceTaskPreconditionRollingNodeOperationRequested:
+			o := doc.OpenShiftCluster.Properties.RollingNodeOperation
+			if o == nil || o.IsTerminal() {
+				return api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "", "The maintenance task '%s' requires a rolling node operation to be requested via the rollingnodeoperation endpoint.", def.Name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminListMaintenanceTasks(t *testing.T) {
+	ctx := context.Background()
+
+	ti := newTestInfra(t)
+	defer ti.done()
+
+	f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go f.Run(ctx, nil, nil)
+
+	resp, b, err := ti.request(http.MethodGet, "https://server/admin/maintenancetasks", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = validateResponse(resp, b, http.StatusOK, "", api.APIs[admin.APIVersion].MaintenanceTaskConverter.ToExternalList(api.MaintenanceTaskDefinitions))
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAdminInvokeMaintenanceTask(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	ctx := context.Background()
+
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+	clusterDoc := func(provisioningState api.ProvisioningState, maintenanceState api.MaintenanceState, version string) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   resourceID,
+				Name: "resourceName",
+				Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: provisioningState,
+					MaintenanceState:  maintenanceState,
+					ClusterProfile: api.ClusterProfile{
+						Version: version,
+					},
+				},
+			},
+		}
+	}
+
+	asyncOperation := func(c *testdatabase.Checker) {
+		c.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+			OpenShiftClusterKey: strings.ToLower(resourceID),
+			AsyncOperation: &api.AsyncOperation{
+				InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+				ProvisioningState:        api.ProvisioningStateAdminUpdating,
+			},
+		})
+	}
+
+	type test struct {
+		name           string
+		task           string
+		fixture        func(*testdatabase.Fixture)
+		wantDocuments  func(*testdatabase.Checker)
+		wantStatusCode int
+		wantAsync      bool
+		wantResponse   *admin.MaintenanceTaskDefinition
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name: "customer impacting task sets the planned maintenance signal",
+			task: "Everything",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, api.MaintenanceStatePending, "4.10.0"))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				asyncOperation(c)
+				doc := clusterDoc(api.ProvisioningStateAdminUpdating, api.MaintenanceStatePlanned, "4.10.0")
+				doc.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEverything
+				c.AddOpenShiftClusterDocuments(doc)
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantAsync:      true,
+			wantResponse:   api.APIs[admin.APIVersion].MaintenanceTaskConverter.ToExternal(api.GetMaintenanceTaskDefinition(api.MaintenanceTaskEverything)).(*admin.MaintenanceTaskDefinition),
+		},
+		{
+			name: "targeted task leaves the maintenance signal alone",
+			task: "FixMCSUserData",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, api.MaintenanceStateNone, "4.10.0"))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				asyncOperation(c)
+				doc := clusterDoc(api.ProvisioningStateAdminUpdating, api.MaintenanceStateNone, "4.10.0")
+				doc.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskFixMCSUserData
+				c.AddOpenShiftClusterDocuments(doc)
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantAsync:      true,
+			wantResponse: &admin.MaintenanceTaskDefinition{
+				Name:        admin.MaintenanceTaskFixMCSUserData,
+				Version:     1,
+				Description: "Fixes the machine config server user data secrets used by the machine API.",
+				Steps:       []string{"Prepare", "StartCluster", "MCSUserData"},
+			},
+		},
+		{
+			name: "task runs if the pinned version matches",
+			task: "FixMCSUserData?version=1",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, api.MaintenanceStateNone, "4.10.0"))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				asyncOperation(c)
+				doc := clusterDoc(api.ProvisioningStateAdminUpdating, api.MaintenanceStateNone, "4.10.0")
+				doc.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskFixMCSUserData
+				c.AddOpenShiftClusterDocuments(doc)
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantAsync:      true,
+			wantResponse:   api.APIs[admin.APIVersion].MaintenanceTaskConverter.ToExternal(api.GetMaintenanceTaskDefinition(api.MaintenanceTaskFixMCSUserData)).(*admin.MaintenanceTaskDefinition),
+		},
+		{
+			name: "task is rejected if the pinned version does not match",
+			task: "FixMCSUserData?version=2",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, api.MaintenanceStateNone, "4.10.0"))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, api.MaintenanceStateNone, "4.10.0"))
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : The maintenance task 'FixMCSUserData' is at version 1, not version 2.",
+		},
+		{
+			name: "unknown task is not found",
+			task: "Unknown",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, "", "4.10.0"))
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : The maintenance task 'unknown' was not found.",
+		},
+		{
+			name: "task is rejected while the cluster is not in a terminal state",
+			task: "FixMCSUserData",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateUpdating, "", "4.10.0"))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : Request is not allowed in provisioningState 'Updating'.",
+		},
+		{
+			name: "operator update is rejected on clusters the operator does not support",
+			task: "OperatorUpdate",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, "", "4.6.62"))
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : The maintenance task 'OperatorUpdate' requires a cluster version supported by the ARO operator, but the cluster version is '4.6.62'.",
+		},
+		{
+			name: "rolling node operation is rejected if none was requested",
+			task: "RollingNodeOperation",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, "", "4.10.0"))
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : The maintenance task 'RollingNodeOperation' requires a rolling node operation to be requested via the rollingnodeoperation endpoint.",
+		},
+		{
+			name:           "cluster not found",
+			task:           "FixMCSUserData",
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).
+				WithOpenShiftClusters().
+				WithAsyncOperations().
+				WithSubscriptions()
+			defer ti.done()
+
+			if tt.fixture != nil {
+				err := ti.buildFixtures(tt.fixture)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/maintenancetasks/%s", resourceID, tt.task),
+				http.Header{
+					"Content-Type": []string{"application/json"},
+				}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			azureAsyncOperation := resp.Header.Get("Azure-AsyncOperation")
+			if tt.wantAsync {
+				if !strings.HasPrefix(azureAsyncOperation, fmt.Sprintf("https://localhost:8443/subscriptions/%s/providers/microsoft.redhatopenshift/locations/%s/operationsstatus/", mockSubID, ti.env.Location())) {
+					t.Error(azureAsyncOperation)
+				}
+			} else if azureAsyncOperation != "" {
+				t.Error(azureAsyncOperation)
+			}
+
+			var wantResponse interface{}
+			if tt.wantResponse != nil {
+				wantResponse = tt.wantResponse
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantDocuments != nil {
+				tt.wantDocuments(ti.checker)
+				errs := ti.checker.CheckOpenShiftClusters(ti.openShiftClustersClient)
+				for _, i := range errs {
+					t.Error(i)
+				}
+				errs = ti.checker.CheckAsyncOperations(ti.asyncOperationsClient)
+				for _, i := range errs {
+					t.Error(i)
+				}
+			}
+		})
+	}
+}
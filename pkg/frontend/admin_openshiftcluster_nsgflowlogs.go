@@ -0,0 +1,275 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	aropreviewv1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/preview.aro.openshift.io/v1alpha1"
+)
+
+const previewFeatureGroupKind = "PreviewFeature.preview.aro.openshift.io"
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/nsgflowlogs
+func (f *frontend) getAdminOpenShiftClusterNSGFlowLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._getAdminOpenShiftClusterNSGFlowLogs(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminOpenShiftClusterNSGFlowLogs(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	doc, err := f.nsgFlowLogsClusterDocument(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	un, err := getPreviewFeature(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+
+	flowLogs, err := nsgFlowLogsToAdmin(un)
+	if err != nil {
+		return nil, err
+	}
+
+	if flowLogs == nil {
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "", "NSG flow logs have not been configured.")
+	}
+
+	return json.MarshalIndent(flowLogs, "", "    ")
+}
+
+// postAdminOpenShiftClusterNSGFlowLogs enables or disables the NSG flow logs
+// preview feature.  The referenced resources are validated before the
+// configuration is handed to the ARO operator, which applies it and reports
+// the flow logs it has applied in the PreviewFeature status.
+func (f *frontend) postAdminOpenShiftClusterNSGFlowLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._postAdminOpenShiftClusterNSGFlowLogs(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _postAdminOpenShiftClusterNSGFlowLogs(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
+
+	var flowLogs *admin.NSGFlowLogs
+	err := json.Unmarshal(body, &flowLogs)
+	if err != nil || flowLogs == nil {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
+	}
+
+	err = validateAdminNSGFlowLogs(flowLogs, chi.URLParam(r, "subscriptionId"))
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := f.nsgFlowLogsClusterDocument(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if flowLogs.Enabled {
+		subscriptionDoc, err := f.getSubscriptionDocument(ctx, doc.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		a, err := f.azureActionsFactory(log, f.env, doc.OpenShiftCluster, subscriptionDoc)
+		if err != nil {
+			return nil, err
+		}
+
+		err = a.ValidateNSGFlowLogs(ctx, flowLogs.NetworkWatcherID, flowLogs.StorageAccountResourceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	k, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	un, err := getPreviewFeature(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+
+	if un == nil {
+		un = &unstructured.Unstructured{}
+		un.SetAPIVersion(aropreviewv1alpha1.GroupVersion.String())
+		un.SetKind("PreviewFeature")
+		un.SetName(aropreviewv1alpha1.SingletonPreviewFeatureName)
+	}
+
+	_, found, err := unstructured.NestedMap(un.Object, "spec", "nsgFlowLogs")
+	if err != nil {
+		return nil, err
+	}
+
+	if !flowLogs.Enabled && found {
+		// keep the existing configuration, which the operator needs to find
+		// the flow logs to remove
+		err = unstructured.SetNestedField(un.Object, false, "spec", "nsgFlowLogs", "enabled")
+	} else {
+		err = unstructured.SetNestedMap(un.Object, nsgFlowLogsFromAdmin(flowLogs), "spec", "nsgFlowLogs")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = k.KubeCreateOrUpdate(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+
+	flowLogs, err = nsgFlowLogsToAdmin(un)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(flowLogs, "", "    ")
+}
+
+func (f *frontend) nsgFlowLogsClusterDocument(ctx context.Context, r *http.Request) (*api.OpenShiftClusterDocument, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// getPreviewFeature returns the cluster's PreviewFeature, or nil if it has
+// not been created
+func getPreviewFeature(ctx context.Context, k adminactions.KubeActions) (*unstructured.Unstructured, error) {
+	b, err := k.KubeGet(ctx, previewFeatureGroupKind, "", aropreviewv1alpha1.SingletonPreviewFeatureName)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	un := &unstructured.Unstructured{}
+	err = un.UnmarshalJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return un, nil
+}
+
+// nsgFlowLogsFromAdmin returns the PreviewFeature spec.nsgFlowLogs field.  It
+// is built by hand as metav1.Duration does not marshal to the "10m" and "60m"
+// values accepted by the CRD.  Omitted fields are defaulted by the CRD.
+func nsgFlowLogsFromAdmin(flowLogs *admin.NSGFlowLogs) map[string]interface{} {
+	m := map[string]interface{}{
+		"enabled": flowLogs.Enabled,
+	}
+
+	if flowLogs.Version != 0 {
+		m["version"] = int64(flowLogs.Version)
+	}
+	if flowLogs.NetworkWatcherID != "" {
+		m["networkWatcherID"] = flowLogs.NetworkWatcherID
+	}
+	if flowLogs.StorageAccountResourceID != "" {
+		m["storageAccountResourceId"] = flowLogs.StorageAccountResourceID
+	}
+	if flowLogs.RetentionDays != 0 {
+		m["retentionDays"] = int64(flowLogs.RetentionDays)
+	}
+	if flowLogs.TrafficAnalyticsLogAnalyticsWorkspaceID != "" {
+		m["trafficAnalyticsLogAnalyticsWorkspaceId"] = flowLogs.TrafficAnalyticsLogAnalyticsWorkspaceID
+	}
+	if flowLogs.TrafficAnalyticsInterval != "" {
+		m["trafficAnalyticsInterval"] = flowLogs.TrafficAnalyticsInterval
+	}
+
+	return m
+}
+
+// nsgFlowLogsToAdmin returns the NSG flow logs configuration and status of a
+// PreviewFeature, or nil if NSG flow logs are not configured
+func nsgFlowLogsToAdmin(un *unstructured.Unstructured) (*admin.NSGFlowLogs, error) {
+	if un == nil {
+		return nil, nil
+	}
+
+	b, err := un.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var pf *aropreviewv1alpha1.PreviewFeature
+	err = json.Unmarshal(b, &pf)
+	if err != nil {
+		return nil, err
+	}
+
+	if pf.Spec.NSGFlowLogs == nil {
+		return nil, nil
+	}
+
+	flowLogs := &admin.NSGFlowLogs{
+		Enabled:                                 pf.Spec.NSGFlowLogs.Enabled,
+		Version:                                 pf.Spec.NSGFlowLogs.Version,
+		NetworkWatcherID:                        pf.Spec.NSGFlowLogs.NetworkWatcherID,
+		StorageAccountResourceID:                pf.Spec.NSGFlowLogs.StorageAccountResourceID,
+		RetentionDays:                           pf.Spec.NSGFlowLogs.RetentionDays,
+		TrafficAnalyticsLogAnalyticsWorkspaceID: pf.Spec.NSGFlowLogs.TrafficAnalyticsLogAnalyticsWorkspaceID,
+	}
+
+	if pf.Spec.NSGFlowLogs.TrafficAnalyticsInterval.Duration != 0 {
+		flowLogs.TrafficAnalyticsInterval = fmt.Sprintf("%dm", int(pf.Spec.NSGFlowLogs.TrafficAnalyticsInterval.Minutes()))
+	}
+
+	for _, status := range pf.Status.NSGFlowLogs {
+		flowLogs.FlowLogs = append(flowLogs.FlowLogs, admin.NSGFlowLogStatus{
+			NetworkSecurityGroupID: status.NetworkSecurityGroupID,
+			FlowLogID:              status.FlowLogID,
+			Enabled:                status.Enabled,
+			Error:                  status.Error,
+		})
+	}
+
+	return flowLogs, nil
+}
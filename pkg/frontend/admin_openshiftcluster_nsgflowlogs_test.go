@@ -0,0 +1,307 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminNSGFlowLogs(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	mockTenantID := "00000000-0000-0000-0000-000000000000"
+	networkWatcherID := "/subscriptions/" + mockSubID + "/resourceGroups/NetworkWatcherRG/providers/Microsoft.Network/networkWatchers/NetworkWatcher_eastus"
+	storageAccountID := "/subscriptions/" + mockSubID + "/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/flowlogs"
+	nsgID := "/subscriptions/" + mockSubID + "/resourceGroups/aro-cluster/providers/Microsoft.Network/networkSecurityGroups/aro-nsg"
+
+	ctx := context.Background()
+
+	fixture := func(f *testdatabase.Fixture) {
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			},
+		})
+
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: mockTenantID,
+				},
+			},
+		})
+	}
+
+	previewFeatureNotFound := kerrors.NewNotFound(schema.GroupResource{Group: "preview.aro.openshift.io", Resource: "previewfeatures"}, "cluster")
+
+	existingPreviewFeature := []byte(`{
+    "apiVersion": "preview.aro.openshift.io/v1alpha1",
+    "kind": "PreviewFeature",
+    "metadata": {
+        "name": "cluster",
+        "resourceVersion": "1"
+    },
+    "spec": {
+        "nsgFlowLogs": {
+            "enabled": true,
+            "version": 2,
+            "networkWatcherID": "` + networkWatcherID + `",
+            "storageAccountResourceId": "` + storageAccountID + `",
+            "retentionDays": 90,
+            "trafficAnalyticsInterval": "60m"
+        }
+    },
+    "status": {
+        "nsgFlowLogs": [
+            {
+                "networkSecurityGroupID": "` + nsgID + `",
+                "flowLogID": "` + networkWatcherID + `/flowLogs/aro-nsg",
+                "enabled": true
+            }
+        ]
+    }
+}`)
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		body           *admin.NSGFlowLogs
+		fixture        func(*testdatabase.Fixture)
+		kubeMocks      func(*mock_adminactions.MockKubeActions)
+		azureMocks     func(*mock_adminactions.MockAzureActions)
+		wantStatusCode int
+		wantResponse   *admin.NSGFlowLogs
+		wantError      string
+	}{
+		{
+			name:    "get",
+			method:  http.MethodGet,
+			fixture: fixture,
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "PreviewFeature.preview.aro.openshift.io", "", "cluster").Return(existingPreviewFeature, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.NSGFlowLogs{
+				Enabled:                  true,
+				Version:                  2,
+				NetworkWatcherID:         networkWatcherID,
+				StorageAccountResourceID: storageAccountID,
+				RetentionDays:            90,
+				TrafficAnalyticsInterval: "60m",
+				FlowLogs: []admin.NSGFlowLogStatus{
+					{
+						NetworkSecurityGroupID: nsgID,
+						FlowLogID:              networkWatcherID + "/flowLogs/aro-nsg",
+						Enabled:                true,
+					},
+				},
+			},
+		},
+		{
+			name:    "get not configured",
+			method:  http.MethodGet,
+			fixture: fixture,
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "PreviewFeature.preview.aro.openshift.io", "", "cluster").Return(nil, previewFeatureNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : NSG flow logs have not been configured.",
+		},
+		{
+			name:   "enable creates the preview feature",
+			method: http.MethodPost,
+			body: &admin.NSGFlowLogs{
+				Enabled:                  true,
+				NetworkWatcherID:         networkWatcherID,
+				StorageAccountResourceID: storageAccountID,
+				TrafficAnalyticsInterval: "10m",
+			},
+			fixture: fixture,
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().ValidateNSGFlowLogs(gomock.Any(), networkWatcherID, storageAccountID).Return(nil)
+			},
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "PreviewFeature.preview.aro.openshift.io", "", "cluster").Return(nil, previewFeatureNotFound)
+				k.EXPECT().KubeCreateOrUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, un *unstructured.Unstructured) error {
+					want := map[string]interface{}{
+						"apiVersion": "preview.aro.openshift.io/v1alpha1",
+						"kind":       "PreviewFeature",
+						"metadata": map[string]interface{}{
+							"name": "cluster",
+						},
+						"spec": map[string]interface{}{
+							"nsgFlowLogs": map[string]interface{}{
+								"enabled":                  true,
+								"networkWatcherID":         networkWatcherID,
+								"storageAccountResourceId": storageAccountID,
+								"trafficAnalyticsInterval": "10m",
+							},
+						},
+					}
+					for _, diff := range deep.Equal(un.Object, want) {
+						t.Error(diff)
+					}
+					return nil
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.NSGFlowLogs{
+				Enabled:                  true,
+				NetworkWatcherID:         networkWatcherID,
+				StorageAccountResourceID: storageAccountID,
+				TrafficAnalyticsInterval: "10m",
+			},
+		},
+		{
+			name:   "enable fails validation of referenced resources",
+			method: http.MethodPost,
+			body: &admin.NSGFlowLogs{
+				Enabled:                  true,
+				NetworkWatcherID:         networkWatcherID,
+				StorageAccountResourceID: storageAccountID,
+			},
+			fixture: fixture,
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().ValidateNSGFlowLogs(gomock.Any(), networkWatcherID, storageAccountID).
+					Return(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidServicePrincipalPermissions, "storageAccountResourceId", "The cluster service principal does not have permission to perform action 'Microsoft.Storage/storageAccounts/listKeys/action' on the resource '%s'.", storageAccountID))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidServicePrincipalPermissions: storageAccountResourceId: The cluster service principal does not have permission to perform action 'Microsoft.Storage/storageAccounts/listKeys/action' on the resource '" + storageAccountID + "'.",
+		},
+		{
+			name:   "enable with invalid request",
+			method: http.MethodPost,
+			body: &admin.NSGFlowLogs{
+				Enabled:                  true,
+				NetworkWatcherID:         "invalid",
+				StorageAccountResourceID: storageAccountID,
+			},
+			fixture:        fixture,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: networkWatcherID: The provided networkWatcherID 'invalid' is invalid.",
+		},
+		{
+			name:   "disable keeps the existing configuration",
+			method: http.MethodPost,
+			body: &admin.NSGFlowLogs{
+				Enabled: false,
+			},
+			fixture: fixture,
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "PreviewFeature.preview.aro.openshift.io", "", "cluster").Return(existingPreviewFeature, nil)
+				k.EXPECT().KubeCreateOrUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, un *unstructured.Unstructured) error {
+					if un.GetResourceVersion() != "1" {
+						t.Error(un.GetResourceVersion())
+					}
+					flowLogs, _, _ := unstructured.NestedMap(un.Object, "spec", "nsgFlowLogs")
+					if flowLogs["enabled"] != false || flowLogs["networkWatcherID"] != networkWatcherID {
+						t.Error(flowLogs)
+					}
+					return nil
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.NSGFlowLogs{
+				Enabled:                  false,
+				Version:                  2,
+				NetworkWatcherID:         networkWatcherID,
+				StorageAccountResourceID: storageAccountID,
+				RetentionDays:            90,
+				TrafficAnalyticsInterval: "60m",
+				FlowLogs: []admin.NSGFlowLogStatus{
+					{
+						NetworkSecurityGroupID: nsgID,
+						FlowLogID:              networkWatcherID + "/flowLogs/aro-nsg",
+						Enabled:                true,
+					},
+				},
+			},
+		},
+		{
+			name:           "cluster not found",
+			method:         http.MethodGet,
+			fixture:        func(f *testdatabase.Fixture) {},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      `404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			if tt.kubeMocks != nil {
+				tt.kubeMocks(k)
+			}
+
+			a := mock_adminactions.NewMockAzureActions(ti.controller)
+			if tt.azureMocks != nil {
+				tt.azureMocks(a)
+			}
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.SubscriptionDocument) (adminactions.AzureActions, error) {
+				return a, nil
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			var header http.Header
+			var body interface{}
+			if tt.body != nil {
+				header = http.Header{
+					"Content-Type": []string{"application/json"},
+				}
+				body = tt.body
+			}
+
+			resp, b, err := ti.request(tt.method,
+				fmt.Sprintf("https://server/admin%s/nsgflowlogs", testdatabase.GetResourcePath(mockSubID, "resourceName")),
+				header, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wantResponse interface{}
+			if tt.wantResponse != nil {
+				wantResponse = tt.wantResponse
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
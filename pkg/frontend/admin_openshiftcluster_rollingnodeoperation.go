@@ -0,0 +1,186 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/rollingnodeoperation
+func (f *frontend) getAdminOpenShiftClusterRollingNodeOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._getAdminOpenShiftClusterRollingNodeOperation(ctx, r)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminOpenShiftClusterRollingNodeOperation(ctx context.Context, r *http.Request) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	if doc.OpenShiftCluster.Properties.RollingNodeOperation == nil {
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "", "No rolling node operation has been requested.")
+	}
+
+	return f.marshalRollingNodeOperation(doc)
+}
+
+func (f *frontend) postAdminOpenShiftClusterRollingNodeOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	var header http.Header
+	b, err := f._postAdminOpenShiftClusterRollingNodeOperation(ctx, r, &header)
+	if err == nil {
+		err = statusCodeError(http.StatusAccepted)
+	}
+
+	adminReply(log, w, header, b, err)
+}
+
+func (f *frontend) _postAdminOpenShiftClusterRollingNodeOperation(ctx context.Context, r *http.Request, header *http.Header) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
+	correlationData := r.Context().Value(middleware.ContextKeyCorrelationData).(*api.CorrelationData)
+
+	var operation *admin.RollingNodeOperation
+	err := json.Unmarshal(body, &operation)
+	if err != nil || operation == nil {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
+	}
+
+	err = validateAdminRollingNodeOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	err = validateTerminalProvisioningState(doc.OpenShiftCluster.Properties.ProvisioningState)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.OpenShiftCluster.Properties.RollingNodeOperation = &api.RollingNodeOperation{
+		Action: api.RollingNodeOperationAction(operation.Action),
+		VMSize: api.VMSize(operation.VMSize),
+		Nodes:  make([]api.RollingNodeOperationNode, 0, len(operation.Nodes)),
+	}
+	for _, node := range operation.Nodes {
+		doc.OpenShiftCluster.Properties.RollingNodeOperation.Nodes = append(doc.OpenShiftCluster.Properties.RollingNodeOperation.Nodes, api.RollingNodeOperationNode{
+			Name:  node.Name,
+			State: api.RollingNodeOperationNodeStatePending,
+		})
+	}
+
+	doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskRollingNodeOperation
+	adminUpdateProvisioningState(doc, f.now())
+	doc.CorrelationData = correlationData
+
+	subId := chi.URLParam(r, "subscriptionId")
+	resourceProviderNamespace := chi.URLParam(r, "resourceProviderNamespace")
+
+	// the async operation is created once, outside any retry, and the update
+	// fails rather than retries if the document changed meanwhile
+	u, err := url.Parse(r.Header.Get("Referer"))
+	if err != nil {
+		return nil, err
+	}
+
+	doc.AsyncOperationID, err = f.newAsyncOperation(ctx, subId, resourceProviderNamespace, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Path = f.operationsPath(subId, resourceProviderNamespace, doc.AsyncOperationID)
+	*header = http.Header{
+		"Azure-AsyncOperation": []string{u.String()},
+	}
+
+	doc, err = f.dbOpenShiftClusters.Update(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.marshalRollingNodeOperation(doc)
+}
+
+func (f *frontend) deleteAdminOpenShiftClusterRollingNodeOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._deleteAdminOpenShiftClusterRollingNodeOperation(ctx, r)
+
+	adminReply(log, w, nil, b, err)
+}
+
+// _deleteAdminOpenShiftClusterRollingNodeOperation requests that an ongoing
+// rolling node operation is aborted.  The node currently being operated on is
+// completed first.
+func (f *frontend) _deleteAdminOpenShiftClusterRollingNodeOperation(ctx context.Context, r *http.Request) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		if doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateAdminUpdating ||
+			doc.OpenShiftCluster.Properties.MaintenanceTask != api.MaintenanceTaskRollingNodeOperation ||
+			doc.OpenShiftCluster.Properties.RollingNodeOperation == nil ||
+			doc.OpenShiftCluster.Properties.RollingNodeOperation.IsTerminal() {
+			return api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "", "No rolling node operation is in progress.")
+		}
+
+		doc.OpenShiftCluster.Properties.RollingNodeOperation.AbortRequested = true
+		return nil
+	})
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	return f.marshalRollingNodeOperation(doc)
+}
+
+func (f *frontend) marshalRollingNodeOperation(doc *api.OpenShiftClusterDocument) ([]byte, error) {
+	oc := f.apis[admin.APIVersion].OpenShiftClusterConverter.ToExternal(doc.OpenShiftCluster).(*admin.OpenShiftCluster)
+
+	return json.MarshalIndent(oc.Properties.RollingNodeOperation, "", "    ")
+}
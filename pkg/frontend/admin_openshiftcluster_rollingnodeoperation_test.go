@@ -0,0 +1,298 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminRollingNodeOperation(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	ctx := context.Background()
+
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+	clusterDoc := func(provisioningState api.ProvisioningState, task api.MaintenanceTask, operation *api.RollingNodeOperation) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   resourceID,
+				Name: "resourceName",
+				Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState:    provisioningState,
+					MaintenanceTask:      task,
+					RollingNodeOperation: operation,
+				},
+			},
+		}
+	}
+
+	type test struct {
+		name           string
+		method         string
+		body           interface{}
+		fixture        func(*testdatabase.Fixture)
+		wantDocuments  func(*testdatabase.Checker)
+		wantStatusCode int
+		wantAsync      bool
+		wantResponse   *admin.RollingNodeOperation
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:   "post starts a rolling node operation",
+			method: http.MethodPost,
+			body: &admin.RollingNodeOperation{
+				Action: admin.RollingNodeOperationActionResize,
+				VMSize: "Standard_D16s_v3",
+				Nodes: []admin.RollingNodeOperationNode{
+					{Name: "aro-fake-node-master-0"},
+					{Name: "aro-fake-node-master-1"},
+				},
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, "", nil))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					OpenShiftClusterKey: strings.ToLower(resourceID),
+					AsyncOperation: &api.AsyncOperation{
+						InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+						ProvisioningState:        api.ProvisioningStateAdminUpdating,
+					},
+				})
+				doc := clusterDoc(api.ProvisioningStateAdminUpdating, api.MaintenanceTaskRollingNodeOperation, &api.RollingNodeOperation{
+					Action: api.RollingNodeOperationActionResize,
+					VMSize: "Standard_D16s_v3",
+					Nodes: []api.RollingNodeOperationNode{
+						{Name: "aro-fake-node-master-0", State: api.RollingNodeOperationNodeStatePending},
+						{Name: "aro-fake-node-master-1", State: api.RollingNodeOperationNodeStatePending},
+					},
+				})
+				doc.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+				doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateUnplanned
+				c.AddOpenShiftClusterDocuments(doc)
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantAsync:      true,
+			wantResponse: &admin.RollingNodeOperation{
+				Action: admin.RollingNodeOperationActionResize,
+				VMSize: "Standard_D16s_v3",
+				Nodes: []admin.RollingNodeOperationNode{
+					{Name: "aro-fake-node-master-0", State: admin.RollingNodeOperationNodeStatePending},
+					{Name: "aro-fake-node-master-1", State: admin.RollingNodeOperationNodeStatePending},
+				},
+			},
+		},
+		{
+			name:   "post is rejected while the cluster is not in a terminal state",
+			method: http.MethodPost,
+			body: &admin.RollingNodeOperation{
+				Action: admin.RollingNodeOperationActionReboot,
+				Nodes: []admin.RollingNodeOperationNode{
+					{Name: "aro-fake-node-worker-0"},
+				},
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateAdminUpdating, api.MaintenanceTaskEverything, nil))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : Request is not allowed in provisioningState 'AdminUpdating'.",
+		},
+		{
+			name:   "post is rejected for workers when resizing",
+			method: http.MethodPost,
+			body: &admin.RollingNodeOperation{
+				Action: admin.RollingNodeOperationActionResize,
+				VMSize: "Standard_D16s_v3",
+				Nodes: []admin.RollingNodeOperationNode{
+					{Name: "aro-fake-node-worker-0"},
+				},
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, "", nil))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: nodes: The node 'aro-fake-node-worker-0' cannot be resized. It is either not a master node or not adhering to the standard naming convention.",
+		},
+		{
+			name:   "post is rejected for an invalid action",
+			method: http.MethodPost,
+			body: &admin.RollingNodeOperation{
+				Action: "Delete",
+				Nodes: []admin.RollingNodeOperationNode{
+					{Name: "aro-fake-node-worker-0"},
+				},
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, "", nil))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: action: The provided action 'Delete' is invalid.",
+		},
+		{
+			name:   "post is rejected for a repeated node",
+			method: http.MethodPost,
+			body: &admin.RollingNodeOperation{
+				Action: admin.RollingNodeOperationActionRedeploy,
+				Nodes: []admin.RollingNodeOperationNode{
+					{Name: "aro-fake-node-worker-0"},
+					{Name: "aro-fake-node-worker-0"},
+				},
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, "", nil))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: nodes: The node 'aro-fake-node-worker-0' is provided more than once.",
+		},
+		{
+			name:   "get returns the progress",
+			method: http.MethodGet,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateAdminUpdating, api.MaintenanceTaskRollingNodeOperation, &api.RollingNodeOperation{
+					Action: api.RollingNodeOperationActionReboot,
+					Nodes: []api.RollingNodeOperationNode{
+						{Name: "aro-fake-node-worker-0", State: api.RollingNodeOperationNodeStateSucceeded},
+						{Name: "aro-fake-node-worker-1", State: api.RollingNodeOperationNodeStateInProgress},
+					},
+				}))
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.RollingNodeOperation{
+				Action: admin.RollingNodeOperationActionReboot,
+				Nodes: []admin.RollingNodeOperationNode{
+					{Name: "aro-fake-node-worker-0", State: admin.RollingNodeOperationNodeStateSucceeded},
+					{Name: "aro-fake-node-worker-1", State: admin.RollingNodeOperationNodeStateInProgress},
+				},
+			},
+		},
+		{
+			name:   "get without an operation",
+			method: http.MethodGet,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, "", nil))
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : No rolling node operation has been requested.",
+		},
+		{
+			name:   "delete requests an abort",
+			method: http.MethodDelete,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateAdminUpdating, api.MaintenanceTaskRollingNodeOperation, &api.RollingNodeOperation{
+					Action: api.RollingNodeOperationActionRedeploy,
+					Nodes: []api.RollingNodeOperationNode{
+						{Name: "aro-fake-node-worker-0", State: api.RollingNodeOperationNodeStateInProgress},
+					},
+				}))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateAdminUpdating, api.MaintenanceTaskRollingNodeOperation, &api.RollingNodeOperation{
+					Action:         api.RollingNodeOperationActionRedeploy,
+					AbortRequested: true,
+					Nodes: []api.RollingNodeOperationNode{
+						{Name: "aro-fake-node-worker-0", State: api.RollingNodeOperationNodeStateInProgress},
+					},
+				}))
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.RollingNodeOperation{
+				Action:         admin.RollingNodeOperationActionRedeploy,
+				AbortRequested: true,
+				Nodes: []admin.RollingNodeOperationNode{
+					{Name: "aro-fake-node-worker-0", State: admin.RollingNodeOperationNodeStateInProgress},
+				},
+			},
+		},
+		{
+			name:   "delete without an ongoing operation",
+			method: http.MethodDelete,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateSucceeded, api.MaintenanceTaskRollingNodeOperation, &api.RollingNodeOperation{
+					Action: api.RollingNodeOperationActionRedeploy,
+				}))
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : No rolling node operation is in progress.",
+		},
+		{
+			name:           "cluster not found",
+			method:         http.MethodGet,
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).
+				WithOpenShiftClusters().
+				WithAsyncOperations().
+				WithSubscriptions()
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(tt.method,
+				fmt.Sprintf("https://server/admin%s/rollingnodeoperation", resourceID),
+				http.Header{
+					"Content-Type": []string{"application/json"},
+				}, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			azureAsyncOperation := resp.Header.Get("Azure-AsyncOperation")
+			if tt.wantAsync {
+				if !strings.HasPrefix(azureAsyncOperation, fmt.Sprintf("https://localhost:8443/subscriptions/%s/providers/microsoft.redhatopenshift/locations/%s/operationsstatus/", mockSubID, ti.env.Location())) {
+					t.Error(azureAsyncOperation)
+				}
+			} else if azureAsyncOperation != "" {
+				t.Error(azureAsyncOperation)
+			}
+
+			var wantResponse interface{}
+			if tt.wantResponse != nil {
+				wantResponse = tt.wantResponse
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantDocuments != nil {
+				tt.wantDocuments(ti.checker)
+				errs := ti.checker.CheckOpenShiftClusters(ti.openShiftClustersClient)
+				for _, i := range errs {
+					t.Error(i)
+				}
+				errs = ti.checker.CheckAsyncOperations(ti.asyncOperationsClient)
+				for _, i := range errs {
+					t.Error(i)
+				}
+			}
+		})
+	}
+}
@@ -12,15 +12,20 @@ import (
 
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/applens"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/storage"
+	"github.com/Azure/ARO-RP/pkg/util/clusterdelete"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+	utilstorage "github.com/Azure/ARO-RP/pkg/util/storage"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 )
 
@@ -40,14 +45,27 @@ type AzureActions interface {
 	AppLensGetDetector(ctx context.Context, detectorId string) ([]byte, error)
 	AppLensListDetectors(ctx context.Context) ([]byte, error)
 	ResourceDeleteAndWait(ctx context.Context, resourceID string) error
+	DNSRecordDrift(ctx context.Context) ([]dns.RecordDrift, error)
+	DNSRepair(ctx context.Context) error
+	ValidateNSGFlowLogs(ctx context.Context, networkWatcherID, storageAccountID string) error
+	EtcdSnapshotList(ctx context.Context) ([]azstorage.Blob, error)
+	EtcdSnapshotURL(ctx context.Context, name string) (string, error)
+	DeletePreview(ctx context.Context, docID string) ([]clusterdelete.Item, error)
+	DiagnosticBundleList(ctx context.Context) ([]azstorage.Blob, error)
+	DiagnosticBundleUpload(ctx context.Context, name string, data []byte) error
+	DiagnosticBundleGet(ctx context.Context, name string) ([]byte, error)
 }
 
 type azureActions struct {
-	log *logrus.Entry
-	env env.Interface
-	oc  *api.OpenShiftCluster
+	log      *logrus.Entry
+	env      env.Interface
+	oc       *api.OpenShiftCluster
+	tenantID string
 
 	resources          features.ResourcesClient
+	resourceGroups     features.ResourceGroupsClient
+	roleAssignments    authorization.RoleAssignmentsClient
+	roleDefinitions    authorization.RoleDefinitionsClient
 	resourceSkus       compute.ResourceSkusClient
 	virtualMachines    compute.VirtualMachinesClient
 	virtualNetworks    network.VirtualNetworksClient
@@ -56,7 +74,14 @@ type azureActions struct {
 	storageAccounts    storage.AccountsClient
 	networkInterfaces  network.InterfacesClient
 	loadBalancers      network.LoadBalancersClient
+	securityGroups     network.SecurityGroupsClient
 	appLens            applens.AppLensClient
+	dns                dns.Manager
+	storage            utilstorage.Manager
+
+	// clients acting as the cluster service principal
+	spResources   features.ResourcesClient
+	spPermissions authorization.PermissionsClient
 }
 
 // NewAzureActions returns an azureActions
@@ -78,12 +103,22 @@ func NewAzureActions(log *logrus.Entry, env env.Interface, oc *api.OpenShiftClus
 		return nil, err
 	}
 
+	// the managed domain zone is in the RP's subscription
+	localFPAuthorizer, err := env.FPAuthorizer(env.TenantID(), env.Environment().ResourceManagerScope)
+	if err != nil {
+		return nil, err
+	}
+
 	return &azureActions{
-		log: log,
-		env: env,
-		oc:  oc,
+		log:      log,
+		env:      env,
+		oc:       oc,
+		tenantID: subscriptionDoc.Subscription.Properties.TenantID,
 
 		resources:          features.NewResourcesClient(env.Environment(), subscriptionDoc.ID, fpAuth),
+		resourceGroups:     features.NewResourceGroupsClient(env.Environment(), subscriptionDoc.ID, fpAuth),
+		roleAssignments:    authorization.NewRoleAssignmentsClient(env.Environment(), subscriptionDoc.ID, fpAuth),
+		roleDefinitions:    authorization.NewRoleDefinitionsClient(env.Environment(), subscriptionDoc.ID, fpAuth),
 		resourceSkus:       compute.NewResourceSkusClient(env.Environment(), subscriptionDoc.ID, fpAuth),
 		virtualMachines:    compute.NewVirtualMachinesClient(env.Environment(), subscriptionDoc.ID, fpAuth),
 		virtualNetworks:    network.NewVirtualNetworksClient(env.Environment(), subscriptionDoc.ID, fpAuth),
@@ -92,7 +127,10 @@ func NewAzureActions(log *logrus.Entry, env env.Interface, oc *api.OpenShiftClus
 		storageAccounts:    storage.NewAccountsClient(env.Environment(), subscriptionDoc.ID, fpAuth),
 		networkInterfaces:  network.NewInterfacesClient(env.Environment(), subscriptionDoc.ID, fpAuth),
 		loadBalancers:      network.NewLoadBalancersClient(env.Environment(), subscriptionDoc.ID, fpAuth),
+		securityGroups:     network.NewSecurityGroupsClient(env.Environment(), subscriptionDoc.ID, fpAuth),
 		appLens:            appLensClient,
+		dns:                dns.NewManager(env, localFPAuthorizer),
+		storage:            utilstorage.NewManager(env, subscriptionDoc.ID, fpAuth),
 	}, nil
 }
 
@@ -0,0 +1,337 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+	"github.com/Azure/ARO-RP/pkg/util/clusterdelete"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// DeletePreview returns the objects which deleting the cluster would remove
+// or modify, in the order in which they are deleted, followed by the customer
+// network which is left in place.  docID is the ID of the cluster document.
+func (a *azureActions) DeletePreview(ctx context.Context, docID string) ([]clusterdelete.Item, error) {
+	resourceGroupID := a.oc.Properties.ClusterProfile.ResourceGroupID
+	resourceGroup := stringutils.LastTokenByte(resourceGroupID, '/')
+
+	var items []clusterdelete.Item
+
+	records, err := a.dns.ListRecords(ctx, a.oc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		items = append(items, clusterdelete.Item{
+			Kind:   clusterdelete.KindDNSRecord,
+			ID:     record + "." + a.env.Domain(),
+			Action: clusterdelete.ActionDelete,
+		})
+	}
+
+	items = append(items, clusterdelete.Item{
+		Kind:   clusterdelete.KindPrivateEndpoint,
+		ID:     fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateEndpoints/%s", a.env.SubscriptionID(), a.env.ResourceGroup(), env.RPPrivateEndpointPrefix+docID),
+		Type:   "Microsoft.Network/privateEndpoints",
+		Action: clusterdelete.ActionDelete,
+	})
+
+	roleItems, err := a.deletePreviewRoles(ctx, resourceGroup, resourceGroupID)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, roleItems...)
+
+	if a.oc.Properties.NetworkProfile.GatewayPrivateLinkID != "" {
+		items = append(items, clusterdelete.Item{
+			Kind:   clusterdelete.KindGatewayRecord,
+			ID:     a.oc.Properties.NetworkProfile.GatewayPrivateLinkID,
+			Action: clusterdelete.ActionDelete,
+		})
+	}
+
+	resourceItems, disconnected, err := a.deletePreviewResourceGroup(ctx, resourceGroup, resourceGroupID)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, resourceItems...)
+
+	if !a.env.FeatureIsSet(env.FeatureDisableSignedCertificates) {
+		managedDomain, err := dns.ManagedDomain(a.env, a.oc.Properties.ClusterProfile.Domain)
+		if err != nil {
+			return nil, err
+		}
+
+		if managedDomain != "" {
+			for _, name := range []string{docID + "-apiserver", docID + "-ingress"} {
+				items = append(items, clusterdelete.Item{
+					Kind:   clusterdelete.KindCertificate,
+					ID:     name,
+					Action: clusterdelete.ActionDelete,
+				})
+			}
+		}
+	}
+
+	if !a.env.IsLocalDevelopmentMode() {
+		r, err := azure.ParseResourceID(a.env.ACRResourceID())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rp := range a.oc.Properties.RegistryProfiles {
+			if rp.Name != fmt.Sprintf("%s.%s", r.ResourceName, a.env.Environment().ContainerRegistryDNSSuffix) {
+				continue
+			}
+
+			items = append(items, clusterdelete.Item{
+				Kind:   clusterdelete.KindACRToken,
+				ID:     a.env.ACRResourceID() + "/tokens/" + rp.Username,
+				Type:   "Microsoft.ContainerRegistry/registries/tokens",
+				Action: clusterdelete.ActionDelete,
+			})
+		}
+	}
+
+	if a.oc.Properties.HiveProfile.Namespace != "" {
+		item, err := a.deletePreviewHiveNamespace(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	items = append(items, a.deletePreviewCustomerNetwork(disconnected)...)
+
+	return items, nil
+}
+
+func (a *azureActions) deletePreviewRoles(ctx context.Context, resourceGroup, resourceGroupID string) ([]clusterdelete.Item, error) {
+	var items []clusterdelete.Item
+
+	roleAssignments, err := a.roleAssignments.ListForResourceGroup(ctx, resourceGroup, "")
+	if err != nil && !azureerrors.ResourceGroupNotFound(err) && !azureerrors.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	for _, assignment := range roleAssignments {
+		if !clusterdelete.IsClusterRoleAssignment(assignment, resourceGroupID) {
+			continue
+		}
+
+		items = append(items, clusterdelete.Item{
+			Kind:   clusterdelete.KindRoleAssignment,
+			ID:     *assignment.ID,
+			Type:   "Microsoft.Authorization/roleAssignments",
+			Action: clusterdelete.ActionDelete,
+		})
+	}
+
+	roleDefinitions, err := a.roleDefinitions.List(ctx, resourceGroupID, "")
+	if err != nil && !azureerrors.ResourceGroupNotFound(err) && !azureerrors.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	for _, definition := range roleDefinitions {
+		if !clusterdelete.IsClusterRoleDefinition(definition, resourceGroupID) {
+			continue
+		}
+
+		items = append(items, clusterdelete.Item{
+			Kind:    clusterdelete.KindRoleDefinition,
+			ID:      *definition.ID,
+			Type:    "Microsoft.Authorization/roleDefinitions",
+			Action:  clusterdelete.ActionDelete,
+			Message: *definition.RoleName,
+		})
+	}
+
+	return items, nil
+}
+
+// disconnectedSubnet is a subnet from which a network security group in the
+// cluster resource group will be disconnected
+type disconnectedSubnet struct {
+	subnetID string
+	nsgID    string
+}
+
+// deletePreviewResourceGroup returns the resources in the cluster resource
+// group and the subnets from which network security groups in the resource
+// group will be disconnected, keyed by lower case subnet ID
+func (a *azureActions) deletePreviewResourceGroup(ctx context.Context, resourceGroup, resourceGroupID string) ([]clusterdelete.Item, map[string]disconnectedSubnet, error) {
+	rg, err := a.resourceGroups.Get(ctx, resourceGroup)
+	if azureerrors.ResourceGroupNotFound(err) || azureerrors.IsNotFoundError(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rg.ManagedBy == nil || !strings.EqualFold(*rg.ManagedBy, a.oc.ID) {
+		return []clusterdelete.Item{
+			{
+				Kind:    clusterdelete.KindResourceGroup,
+				ID:      resourceGroupID,
+				Action:  clusterdelete.ActionRetain,
+				Message: "resource group is not managed by the cluster",
+			},
+		}, nil, nil
+	}
+
+	resources, err := a.resources.ListByResourceGroup(ctx, resourceGroup, "", "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []clusterdelete.Item
+	disconnected := map[string]disconnectedSubnet{}
+
+	for _, level := range clusterdelete.Levels(resources) {
+		for _, resource := range level {
+			items = append(items, clusterdelete.Item{
+				Kind:   clusterdelete.KindResource,
+				ID:     *resource.ID,
+				Type:   *resource.Type,
+				Action: clusterdelete.ActionDelete,
+			})
+
+			if !strings.EqualFold(*resource.Type, "Microsoft.Network/networkSecurityGroups") {
+				continue
+			}
+
+			nsg, err := a.securityGroups.Get(ctx, resourceGroup, *resource.Name, "")
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if nsg.SecurityGroupPropertiesFormat == nil || nsg.Subnets == nil {
+				continue
+			}
+
+			for _, s := range *nsg.Subnets {
+				if s.ID != nil {
+					disconnected[strings.ToLower(*s.ID)] = disconnectedSubnet{
+						subnetID: *s.ID,
+						nsgID:    *nsg.ID,
+					}
+				}
+			}
+		}
+	}
+
+	items = append(items, clusterdelete.Item{
+		Kind:   clusterdelete.KindResourceGroup,
+		ID:     resourceGroupID,
+		Action: clusterdelete.ActionDelete,
+	})
+
+	return items, disconnected, nil
+}
+
+func (a *azureActions) deletePreviewHiveNamespace(ctx context.Context) (clusterdelete.Item, error) {
+	item := clusterdelete.Item{
+		Kind:   clusterdelete.KindHiveNamespace,
+		ID:     a.oc.Properties.HiveProfile.Namespace,
+		Action: clusterdelete.ActionDelete,
+	}
+
+	installViaHive, err := a.env.LiveConfig().InstallViaHive(ctx)
+	if err != nil {
+		return item, err
+	}
+
+	adoptByHive, err := a.env.LiveConfig().AdoptByHive(ctx)
+	if err != nil {
+		return item, err
+	}
+
+	if !installViaHive && !adoptByHive {
+		item.Action = clusterdelete.ActionRetain
+		item.Message = "hive is disabled in this region"
+	}
+
+	return item, nil
+}
+
+// deletePreviewCustomerNetwork returns the customer virtual networks and
+// subnets used by the cluster, and any other subnets from which network
+// security groups in the cluster resource group will be disconnected
+func (a *azureActions) deletePreviewCustomerNetwork(disconnected map[string]disconnectedSubnet) []clusterdelete.Item {
+	subnetIDs := []string{a.oc.Properties.MasterProfile.SubnetID}
+	for _, wp := range a.oc.Properties.WorkerProfiles {
+		subnetIDs = append(subnetIDs, wp.SubnetID)
+	}
+
+	var items []clusterdelete.Item
+	seen := map[string]bool{}
+
+	for _, subnetID := range subnetIDs {
+		if subnetID == "" {
+			continue
+		}
+
+		vnetID, _, err := subnet.Split(subnetID)
+		if err == nil && !seen[strings.ToLower(vnetID)] {
+			seen[strings.ToLower(vnetID)] = true
+			items = append(items, clusterdelete.Item{
+				Kind:   clusterdelete.KindVirtualNetwork,
+				ID:     vnetID,
+				Type:   "Microsoft.Network/virtualNetworks",
+				Action: clusterdelete.ActionRetain,
+			})
+		}
+
+		if seen[strings.ToLower(subnetID)] {
+			continue
+		}
+		seen[strings.ToLower(subnetID)] = true
+
+		items = append(items, clusterdelete.Item{
+			Kind:   clusterdelete.KindSubnet,
+			ID:     subnetID,
+			Type:   "Microsoft.Network/virtualNetworks/subnets",
+			Action: clusterdelete.ActionRetain,
+		})
+	}
+
+	for i, item := range items {
+		if d, found := disconnected[strings.ToLower(item.ID)]; found {
+			items[i].Action = clusterdelete.ActionModify
+			items[i].Message = fmt.Sprintf("network security group %s will be disconnected", d.nsgID)
+			delete(disconnected, strings.ToLower(item.ID))
+		}
+	}
+
+	// network security groups may also be attached to subnets which the
+	// cluster does not use
+	others := make([]string, 0, len(disconnected))
+	for key := range disconnected {
+		others = append(others, key)
+	}
+	sort.Strings(others)
+
+	for _, key := range others {
+		items = append(items, clusterdelete.Item{
+			Kind:    clusterdelete.KindSubnet,
+			ID:      disconnected[key].subnetID,
+			Type:    "Microsoft.Network/virtualNetworks/subnets",
+			Action:  clusterdelete.ActionModify,
+			Message: fmt.Sprintf("network security group %s will be disconnected", disconnected[key].nsgID),
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,406 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/clusterdelete"
+	mock_authorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/authorization"
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	mock_dns "github.com/Azure/ARO-RP/pkg/util/mocks/dns"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	"github.com/Azure/ARO-RP/pkg/util/rbac"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	"github.com/Azure/ARO-RP/test/util/testliveconfig"
+)
+
+func TestDeletePreview(t *testing.T) {
+	ctx := context.Background()
+
+	docID := "11111111-1111-1111-1111-111111111111"
+	clusterID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster"
+	resourceGroupID := "/subscriptions/sub/resourceGroups/aro-cluster"
+	vnetID := "/subscriptions/sub/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet"
+	nsgID := resourceGroupID + "/providers/Microsoft.Network/networkSecurityGroups/cluster-nsg"
+
+	oc := &api.OpenShiftCluster{
+		ID: clusterID,
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain:          "cluster.location.aroapp.io",
+				ResourceGroupID: resourceGroupID,
+			},
+			MasterProfile: api.MasterProfile{
+				SubnetID: vnetID + "/subnets/master",
+			},
+			WorkerProfiles: []api.WorkerProfile{
+				{
+					SubnetID: vnetID + "/subnets/worker",
+				},
+				{
+					SubnetID: vnetID + "/subnets/worker",
+				},
+			},
+			RegistryProfiles: []*api.RegistryProfile{
+				{
+					Name:     "arointsvc.azurecr.io",
+					Username: "token-12345",
+				},
+			},
+			HiveProfile: api.HiveProfile{
+				Namespace: "aro-" + docID,
+			},
+		},
+	}
+
+	notFound := autorest.DetailedError{
+		StatusCode: http.StatusNotFound,
+	}
+
+	roleAssignment := mgmtauthorization.RoleAssignment{
+		ID: to.StringPtr(resourceGroupID + "/providers/Microsoft.Authorization/roleAssignments/assignment"),
+		RoleAssignmentPropertiesWithScope: &mgmtauthorization.RoleAssignmentPropertiesWithScope{
+			Scope:            to.StringPtr(resourceGroupID),
+			RoleDefinitionID: to.StringPtr("/subscriptions/sub/providers/Microsoft.Authorization/roleDefinitions/" + rbac.RoleContributor),
+		},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		mocks     func(*mock_features.MockResourceGroupsClient, *mock_features.MockResourcesClient, *mock_network.MockSecurityGroupsClient)
+		wantItems []clusterdelete.Item
+		wantErr   string
+	}{
+		{
+			name: "managed resource group",
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient, resources *mock_features.MockResourcesClient, securityGroups *mock_network.MockSecurityGroupsClient) {
+				resourceGroups.EXPECT().Get(gomock.Any(), "aro-cluster").Return(mgmtfeatures.ResourceGroup{
+					ManagedBy: to.StringPtr(clusterID),
+				}, nil)
+				resources.EXPECT().ListByResourceGroup(gomock.Any(), "aro-cluster", "", "", nil).Return([]mgmtfeatures.GenericResourceExpanded{
+					{
+						ID:   to.StringPtr(nsgID),
+						Name: to.StringPtr("cluster-nsg"),
+						Type: to.StringPtr("Microsoft.Network/networkSecurityGroups"),
+					},
+					{
+						ID:   to.StringPtr(resourceGroupID + "/providers/Microsoft.Compute/virtualMachines/master-0"),
+						Name: to.StringPtr("master-0"),
+						Type: to.StringPtr("Microsoft.Compute/virtualMachines"),
+					},
+				}, nil)
+				securityGroups.EXPECT().Get(gomock.Any(), "aro-cluster", "cluster-nsg", "").Return(mgmtnetwork.SecurityGroup{
+					ID: to.StringPtr(nsgID),
+					SecurityGroupPropertiesFormat: &mgmtnetwork.SecurityGroupPropertiesFormat{
+						Subnets: &[]mgmtnetwork.Subnet{
+							{
+								ID: to.StringPtr(vnetID + "/subnets/master"),
+							},
+							{
+								ID: to.StringPtr(vnetID + "/subnets/other"),
+							},
+						},
+					},
+				}, nil)
+			},
+			wantItems: []clusterdelete.Item{
+				{
+					Kind:   clusterdelete.KindDNSRecord,
+					ID:     "api.cluster.location.aroapp.io",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindPrivateEndpoint,
+					ID:     "/subscriptions/rpSubscription/resourceGroups/rpResourceGroup/providers/Microsoft.Network/privateEndpoints/rp-pe-" + docID,
+					Type:   "Microsoft.Network/privateEndpoints",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindRoleAssignment,
+					ID:     *roleAssignment.ID,
+					Type:   "Microsoft.Authorization/roleAssignments",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindResource,
+					ID:     resourceGroupID + "/providers/Microsoft.Compute/virtualMachines/master-0",
+					Type:   "Microsoft.Compute/virtualMachines",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindResource,
+					ID:     nsgID,
+					Type:   "Microsoft.Network/networkSecurityGroups",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindResourceGroup,
+					ID:     resourceGroupID,
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindCertificate,
+					ID:     docID + "-apiserver",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindCertificate,
+					ID:     docID + "-ingress",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindACRToken,
+					ID:     "/subscriptions/rpSubscription/resourceGroups/global/providers/Microsoft.ContainerRegistry/registries/arointsvc/tokens/token-12345",
+					Type:   "Microsoft.ContainerRegistry/registries/tokens",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:    clusterdelete.KindHiveNamespace,
+					ID:      "aro-" + docID,
+					Action:  clusterdelete.ActionRetain,
+					Message: "hive is disabled in this region",
+				},
+				{
+					Kind:   clusterdelete.KindVirtualNetwork,
+					ID:     vnetID,
+					Type:   "Microsoft.Network/virtualNetworks",
+					Action: clusterdelete.ActionRetain,
+				},
+				{
+					Kind:    clusterdelete.KindSubnet,
+					ID:      vnetID + "/subnets/master",
+					Type:    "Microsoft.Network/virtualNetworks/subnets",
+					Action:  clusterdelete.ActionModify,
+					Message: "network security group " + nsgID + " will be disconnected",
+				},
+				{
+					Kind:   clusterdelete.KindSubnet,
+					ID:     vnetID + "/subnets/worker",
+					Type:   "Microsoft.Network/virtualNetworks/subnets",
+					Action: clusterdelete.ActionRetain,
+				},
+				{
+					Kind:    clusterdelete.KindSubnet,
+					ID:      vnetID + "/subnets/other",
+					Type:    "Microsoft.Network/virtualNetworks/subnets",
+					Action:  clusterdelete.ActionModify,
+					Message: "network security group " + nsgID + " will be disconnected",
+				},
+			},
+		},
+		{
+			name: "resource group not managed by the cluster",
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient, resources *mock_features.MockResourcesClient, securityGroups *mock_network.MockSecurityGroupsClient) {
+				resourceGroups.EXPECT().Get(gomock.Any(), "aro-cluster").Return(mgmtfeatures.ResourceGroup{
+					ManagedBy: to.StringPtr("someone else"),
+				}, nil)
+			},
+			wantItems: []clusterdelete.Item{
+				{
+					Kind:   clusterdelete.KindDNSRecord,
+					ID:     "api.cluster.location.aroapp.io",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindPrivateEndpoint,
+					ID:     "/subscriptions/rpSubscription/resourceGroups/rpResourceGroup/providers/Microsoft.Network/privateEndpoints/rp-pe-" + docID,
+					Type:   "Microsoft.Network/privateEndpoints",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindRoleAssignment,
+					ID:     *roleAssignment.ID,
+					Type:   "Microsoft.Authorization/roleAssignments",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:    clusterdelete.KindResourceGroup,
+					ID:      resourceGroupID,
+					Action:  clusterdelete.ActionRetain,
+					Message: "resource group is not managed by the cluster",
+				},
+				{
+					Kind:   clusterdelete.KindCertificate,
+					ID:     docID + "-apiserver",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindCertificate,
+					ID:     docID + "-ingress",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindACRToken,
+					ID:     "/subscriptions/rpSubscription/resourceGroups/global/providers/Microsoft.ContainerRegistry/registries/arointsvc/tokens/token-12345",
+					Type:   "Microsoft.ContainerRegistry/registries/tokens",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:    clusterdelete.KindHiveNamespace,
+					ID:      "aro-" + docID,
+					Action:  clusterdelete.ActionRetain,
+					Message: "hive is disabled in this region",
+				},
+				{
+					Kind:   clusterdelete.KindVirtualNetwork,
+					ID:     vnetID,
+					Type:   "Microsoft.Network/virtualNetworks",
+					Action: clusterdelete.ActionRetain,
+				},
+				{
+					Kind:   clusterdelete.KindSubnet,
+					ID:     vnetID + "/subnets/master",
+					Type:   "Microsoft.Network/virtualNetworks/subnets",
+					Action: clusterdelete.ActionRetain,
+				},
+				{
+					Kind:   clusterdelete.KindSubnet,
+					ID:     vnetID + "/subnets/worker",
+					Type:   "Microsoft.Network/virtualNetworks/subnets",
+					Action: clusterdelete.ActionRetain,
+				},
+			},
+		},
+		{
+			name: "resource group not found",
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient, resources *mock_features.MockResourcesClient, securityGroups *mock_network.MockSecurityGroupsClient) {
+				resourceGroups.EXPECT().Get(gomock.Any(), "aro-cluster").Return(mgmtfeatures.ResourceGroup{}, notFound)
+			},
+			wantItems: []clusterdelete.Item{
+				{
+					Kind:   clusterdelete.KindDNSRecord,
+					ID:     "api.cluster.location.aroapp.io",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindPrivateEndpoint,
+					ID:     "/subscriptions/rpSubscription/resourceGroups/rpResourceGroup/providers/Microsoft.Network/privateEndpoints/rp-pe-" + docID,
+					Type:   "Microsoft.Network/privateEndpoints",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindRoleAssignment,
+					ID:     *roleAssignment.ID,
+					Type:   "Microsoft.Authorization/roleAssignments",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindCertificate,
+					ID:     docID + "-apiserver",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindCertificate,
+					ID:     docID + "-ingress",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:   clusterdelete.KindACRToken,
+					ID:     "/subscriptions/rpSubscription/resourceGroups/global/providers/Microsoft.ContainerRegistry/registries/arointsvc/tokens/token-12345",
+					Type:   "Microsoft.ContainerRegistry/registries/tokens",
+					Action: clusterdelete.ActionDelete,
+				},
+				{
+					Kind:    clusterdelete.KindHiveNamespace,
+					ID:      "aro-" + docID,
+					Action:  clusterdelete.ActionRetain,
+					Message: "hive is disabled in this region",
+				},
+				{
+					Kind:   clusterdelete.KindVirtualNetwork,
+					ID:     vnetID,
+					Type:   "Microsoft.Network/virtualNetworks",
+					Action: clusterdelete.ActionRetain,
+				},
+				{
+					Kind:   clusterdelete.KindSubnet,
+					ID:     vnetID + "/subnets/master",
+					Type:   "Microsoft.Network/virtualNetworks/subnets",
+					Action: clusterdelete.ActionRetain,
+				},
+				{
+					Kind:   clusterdelete.KindSubnet,
+					ID:     vnetID + "/subnets/worker",
+					Type:   "Microsoft.Network/virtualNetworks/subnets",
+					Action: clusterdelete.ActionRetain,
+				},
+			},
+		},
+		{
+			name: "resource group error",
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient, resources *mock_features.MockResourcesClient, securityGroups *mock_network.MockSecurityGroupsClient) {
+				resourceGroups.EXPECT().Get(gomock.Any(), "aro-cluster").Return(mgmtfeatures.ResourceGroup{}, autorest.DetailedError{
+					StatusCode: http.StatusForbidden,
+					Message:    "forbidden",
+				})
+			},
+			wantErr: "#: forbidden: StatusCode=403",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().Domain().AnyTimes().Return("location.aroapp.io")
+			_env.EXPECT().SubscriptionID().AnyTimes().Return("rpSubscription")
+			_env.EXPECT().ResourceGroup().AnyTimes().Return("rpResourceGroup")
+			_env.EXPECT().FeatureIsSet(env.FeatureDisableSignedCertificates).AnyTimes().Return(false)
+			_env.EXPECT().IsLocalDevelopmentMode().AnyTimes().Return(false)
+			_env.EXPECT().ACRResourceID().AnyTimes().Return("/subscriptions/rpSubscription/resourceGroups/global/providers/Microsoft.ContainerRegistry/registries/arointsvc")
+			_env.EXPECT().Environment().AnyTimes().Return(&azureclient.PublicCloud)
+			_env.EXPECT().LiveConfig().AnyTimes().Return(testliveconfig.NewTestLiveConfig(false, false, false))
+
+			dns := mock_dns.NewMockManager(controller)
+			dns.EXPECT().ListRecords(gomock.Any(), oc).Return([]string{"api.cluster"}, nil)
+
+			roleAssignments := mock_authorization.NewMockRoleAssignmentsClient(controller)
+			roleAssignments.EXPECT().ListForResourceGroup(gomock.Any(), "aro-cluster", "").Return([]mgmtauthorization.RoleAssignment{roleAssignment}, nil)
+
+			roleDefinitions := mock_authorization.NewMockRoleDefinitionsClient(controller)
+			roleDefinitions.EXPECT().List(gomock.Any(), resourceGroupID, "").Return(nil, notFound)
+
+			resourceGroups := mock_features.NewMockResourceGroupsClient(controller)
+			resources := mock_features.NewMockResourcesClient(controller)
+			securityGroups := mock_network.NewMockSecurityGroupsClient(controller)
+			tt.mocks(resourceGroups, resources, securityGroups)
+
+			a := &azureActions{
+				env:             _env,
+				oc:              oc,
+				resources:       resources,
+				resourceGroups:  resourceGroups,
+				roleAssignments: roleAssignments,
+				roleDefinitions: roleDefinitions,
+				securityGroups:  securityGroups,
+				dns:             dns,
+			}
+
+			items, err := a.DeletePreview(ctx, docID)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				for _, item := range items {
+					t.Logf("%#v", item)
+				}
+				t.Error("unexpected items")
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+
+	"github.com/Azure/ARO-RP/pkg/util/diagnostics"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// CollectDiagnostics gathers the resources and pod logs which o asks for into
+// b
+func (k *kubeActions) CollectDiagnostics(ctx context.Context, b *diagnostics.Bundle, o *diagnostics.Options) error {
+	return diagnostics.NewCollector(k.kubecli, k.dyn, o).Collect(ctx, b)
+}
+
+func (a *azureActions) diagnosticBundleContainer(ctx context.Context, p mgmtstorage.Permissions) (*azstorage.Container, error) {
+	clusterRGName := stringutils.LastTokenByte(a.oc.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	blobService, err := a.storage.BlobService(ctx, clusterRGName, "cluster"+a.oc.Properties.StorageSuffix, p, mgmtstorage.SignedResourceTypes("co"))
+	if err != nil {
+		return nil, err
+	}
+
+	return blobService.GetContainerReference(diagnostics.Container), nil
+}
+
+// DiagnosticBundleList returns the diagnostic bundles stored in the cluster
+// storage account, oldest first
+func (a *azureActions) DiagnosticBundleList(ctx context.Context) ([]azstorage.Blob, error) {
+	container, err := a.diagnosticBundleContainer(ctx, mgmtstorage.Permissions("l"))
+	if err != nil {
+		return nil, err
+	}
+
+	return diagnostics.List(container)
+}
+
+// DiagnosticBundleUpload stores an encrypted diagnostic bundle in the cluster
+// storage account and removes the bundles beyond the retention policy
+func (a *azureActions) DiagnosticBundleUpload(ctx context.Context, name string, data []byte) error {
+	container, err := a.diagnosticBundleContainer(ctx, mgmtstorage.Permissions("cwdl"))
+	if err != nil {
+		return err
+	}
+
+	_, err = container.CreateIfNotExists(nil)
+	if err != nil {
+		return err
+	}
+
+	err = container.GetBlobReference(name).CreateBlockBlobFromReader(bytes.NewReader(data), nil)
+	if err != nil {
+		return err
+	}
+
+	expired, err := diagnostics.Prune(container, time.Now())
+	for _, name := range expired {
+		a.log.Printf("removed expired diagnostic bundle %s", name)
+	}
+
+	return err
+}
+
+// DiagnosticBundleGet returns the given encrypted diagnostic bundle
+func (a *azureActions) DiagnosticBundleGet(ctx context.Context, name string) ([]byte, error) {
+	container, err := a.diagnosticBundleContainer(ctx, mgmtstorage.Permissions("r"))
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := container.GetBlobReference(name).Get(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,18 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+)
+
+func (a *azureActions) DNSRecordDrift(ctx context.Context) ([]dns.RecordDrift, error) {
+	return a.dns.CheckDrift(ctx, a.oc)
+}
+
+func (a *azureActions) DNSRepair(ctx context.Context) error {
+	return a.dns.Repair(ctx, a.oc)
+}
@@ -0,0 +1,62 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+
+	"github.com/Azure/ARO-RP/pkg/util/etcdsnapshot"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// EtcdSnapshotList returns the etcd snapshots stored in the cluster storage
+// account, oldest first
+func (a *azureActions) EtcdSnapshotList(ctx context.Context) ([]azstorage.Blob, error) {
+	clusterRGName := stringutils.LastTokenByte(a.oc.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	blobService, err := a.storage.BlobService(ctx, clusterRGName, "cluster"+a.oc.Properties.StorageSuffix, mgmtstorage.Permissions("l"), mgmtstorage.SignedResourceTypesC)
+	if err != nil {
+		return nil, err
+	}
+
+	container := blobService.GetContainerReference(etcdsnapshot.Container)
+
+	var snapshots []azstorage.Blob
+	params := azstorage.ListBlobsParameters{}
+	for {
+		res, err := container.ListBlobs(params)
+		if azErr, ok := err.(azstorage.AzureStorageServiceError); ok && azErr.StatusCode == http.StatusNotFound {
+			// no snapshot has been taken yet
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, blob := range res.Blobs {
+			if etcdsnapshot.IsBlobName(blob.Name) {
+				snapshots = append(snapshots, blob)
+			}
+		}
+
+		if res.NextMarker == "" {
+			break
+		}
+		params.Marker = res.NextMarker
+	}
+
+	return snapshots, nil
+}
+
+// EtcdSnapshotURL returns a URL from which the given etcd snapshot can be
+// downloaded
+func (a *azureActions) EtcdSnapshotURL(ctx context.Context, name string) (string, error) {
+	clusterRGName := stringutils.LastTokenByte(a.oc.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	return a.storage.BlobSASURL(ctx, clusterRGName, "cluster"+a.oc.Properties.StorageSuffix, etcdsnapshot.Container, name, mgmtstorage.Permissions("r"))
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/diagnostics"
 	"github.com/Azure/ARO-RP/pkg/util/restconfig"
 )
 
@@ -39,6 +40,7 @@ type KubeActions interface {
 	KubeGetPodLogs(ctx context.Context, namespace, name, containerName string) ([]byte, error)
 	// kubeWatch returns a watch object for the provided label selector key
 	KubeWatch(ctx context.Context, o *unstructured.Unstructured, label string) (watch.Interface, error)
+	CollectDiagnostics(ctx context.Context, b *diagnostics.Bundle, o *diagnostics.Options) error
 }
 
 type kubeActions struct {
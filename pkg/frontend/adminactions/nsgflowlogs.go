@@ -0,0 +1,123 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/jongio/azidext/go/azidext"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+	"github.com/Azure/ARO-RP/pkg/util/permissions"
+)
+
+// the actions which the cluster service principal needs to configure flow
+// logs with a network watcher and storage account
+var (
+	nsgFlowLogsNetworkWatcherActions = []string{
+		"Microsoft.Network/networkWatchers/read",
+		"Microsoft.Network/networkWatchers/flowLogs/read",
+		"Microsoft.Network/networkWatchers/flowLogs/write",
+		"Microsoft.Network/networkWatchers/flowLogs/delete",
+	}
+	nsgFlowLogsStorageAccountActions = []string{
+		"Microsoft.Storage/storageAccounts/read",
+		"Microsoft.Storage/storageAccounts/listKeys/action",
+		"Microsoft.Storage/storageAccounts/listServiceSas/action",
+		"Microsoft.Storage/storageAccounts/listAccountSas/action",
+	}
+)
+
+// ValidateNSGFlowLogs checks that the network watcher and storage account
+// referenced by a flow log configuration exist in the cluster's location and
+// that the cluster service principal, which the ARO operator uses to configure
+// the flow logs, is allowed to use them.
+func (a *azureActions) ValidateNSGFlowLogs(ctx context.Context, networkWatcherID, storageAccountID string) error {
+	err := a.initializeClusterSPClients()
+	if err != nil {
+		return err
+	}
+
+	err = a.validateNSGFlowLogsResource(ctx, networkWatcherID, "networkWatcherID", nsgFlowLogsNetworkWatcherActions)
+	if err != nil {
+		return err
+	}
+
+	return a.validateNSGFlowLogsResource(ctx, storageAccountID, "storageAccountResourceId", nsgFlowLogsStorageAccountActions)
+}
+
+func (a *azureActions) validateNSGFlowLogsResource(ctx context.Context, id, path string, actions []string) error {
+	r, err := azure.ParseResourceID(id)
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided resource ID '%s' is invalid.", id)
+	}
+
+	resource, err := a.spResources.GetByID(ctx, id, azureclient.APIVersion(r.Provider+"/"+r.ResourceType))
+	switch {
+	case azureerrors.IsNotFoundError(err):
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The resource '%s' could not be found.", id)
+	case azureerrors.HasAuthorizationFailedError(err):
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidServicePrincipalPermissions, path, "The cluster service principal does not have permission to read the resource '%s'.", id)
+	case err != nil:
+		return err
+	}
+
+	if resource.Location == nil || !strings.EqualFold(*resource.Location, a.oc.Location) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLocation, path, "The resource '%s' must be in the same location as the cluster.", id)
+	}
+
+	perms, err := a.spPermissions.ListForResource(ctx, r.ResourceGroup, r.Provider, "", r.ResourceType, r.ResourceName)
+	if err != nil {
+		return err
+	}
+
+	for _, action := range actions {
+		ok, err := permissions.CanDoAction(perms, action)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidServicePrincipalPermissions, path, "The cluster service principal does not have permission to perform action '%s' on the resource '%s'.", action, id)
+		}
+	}
+
+	return nil
+}
+
+// initializeClusterSPClients creates the clients which act as the cluster
+// service principal.  They are created on first use as most admin actions do
+// not need them.
+func (a *azureActions) initializeClusterSPClients() error {
+	if a.spResources != nil && a.spPermissions != nil {
+		return nil
+	}
+
+	r, err := azure.ParseResourceID(a.oc.ID)
+	if err != nil {
+		return err
+	}
+
+	spp := a.oc.Properties.ServicePrincipalProfile
+	options := a.env.Environment().ClientSecretCredentialOptions()
+	spTokenCredential, err := azidentity.NewClientSecretCredential(a.tenantID, spp.ClientID, string(spp.ClientSecret), options)
+	if err != nil {
+		return err
+	}
+
+	scopes := []string{a.env.Environment().ResourceManagerScope}
+	spAuthorizer := azidext.NewTokenCredentialAdapter(spTokenCredential, scopes)
+
+	a.spResources = features.NewResourcesClient(a.env.Environment(), r.SubscriptionID, spAuthorizer)
+	a.spPermissions = authorization.NewPermissionsClient(a.env.Environment(), r.SubscriptionID, spAuthorizer)
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_authorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/authorization"
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateNSGFlowLogs(t *testing.T) {
+	ctx := context.Background()
+	subscription := "00000000-0000-0000-0000-000000000000"
+	location := "eastus"
+	networkWatcherID := "/subscriptions/" + subscription + "/resourceGroups/NetworkWatcherRG/providers/Microsoft.Network/networkWatchers/NetworkWatcher_eastus"
+	storageAccountID := "/subscriptions/" + subscription + "/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/flowlogs"
+
+	allowed := []mgmtauthorization.Permission{
+		{
+			Actions:    &[]string{"*"},
+			NotActions: &[]string{},
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_features.MockResourcesClient, *mock_authorization.MockPermissionsClient)
+		wantErr string
+	}{
+		{
+			name: "valid",
+			mocks: func(resources *mock_features.MockResourcesClient, permissions *mock_authorization.MockPermissionsClient) {
+				resources.EXPECT().GetByID(gomock.Any(), networkWatcherID, "2020-08-01").Return(mgmtfeatures.GenericResource{Location: to.StringPtr(location)}, nil)
+				permissions.EXPECT().ListForResource(gomock.Any(), "NetworkWatcherRG", "Microsoft.Network", "", "networkWatchers", "NetworkWatcher_eastus").Return(allowed, nil)
+				resources.EXPECT().GetByID(gomock.Any(), storageAccountID, "2019-06-01").Return(mgmtfeatures.GenericResource{Location: to.StringPtr(location)}, nil)
+				permissions.EXPECT().ListForResource(gomock.Any(), "rg", "Microsoft.Storage", "", "storageAccounts", "flowlogs").Return(allowed, nil)
+			},
+		},
+		{
+			name: "network watcher not found",
+			mocks: func(resources *mock_features.MockResourcesClient, permissions *mock_authorization.MockPermissionsClient) {
+				resources.EXPECT().GetByID(gomock.Any(), networkWatcherID, "2020-08-01").Return(mgmtfeatures.GenericResource{}, autorest.DetailedError{StatusCode: http.StatusNotFound})
+			},
+			wantErr: "400: InvalidParameter: networkWatcherID: The resource '" + networkWatcherID + "' could not be found.",
+		},
+		{
+			name: "network watcher in another location",
+			mocks: func(resources *mock_features.MockResourcesClient, permissions *mock_authorization.MockPermissionsClient) {
+				resources.EXPECT().GetByID(gomock.Any(), networkWatcherID, "2020-08-01").Return(mgmtfeatures.GenericResource{Location: to.StringPtr("westus")}, nil)
+			},
+			wantErr: "400: InvalidLocation: networkWatcherID: The resource '" + networkWatcherID + "' must be in the same location as the cluster.",
+		},
+		{
+			name: "missing permission on storage account",
+			mocks: func(resources *mock_features.MockResourcesClient, permissions *mock_authorization.MockPermissionsClient) {
+				resources.EXPECT().GetByID(gomock.Any(), networkWatcherID, "2020-08-01").Return(mgmtfeatures.GenericResource{Location: to.StringPtr(location)}, nil)
+				permissions.EXPECT().ListForResource(gomock.Any(), "NetworkWatcherRG", "Microsoft.Network", "", "networkWatchers", "NetworkWatcher_eastus").Return(allowed, nil)
+				resources.EXPECT().GetByID(gomock.Any(), storageAccountID, "2019-06-01").Return(mgmtfeatures.GenericResource{Location: to.StringPtr(location)}, nil)
+				permissions.EXPECT().ListForResource(gomock.Any(), "rg", "Microsoft.Storage", "", "storageAccounts", "flowlogs").Return([]mgmtauthorization.Permission{
+					{
+						Actions:    &[]string{"*"},
+						NotActions: &[]string{"Microsoft.Storage/storageAccounts/listKeys/action"},
+					},
+				}, nil)
+			},
+			wantErr: "400: InvalidServicePrincipalPermissions: storageAccountResourceId: The cluster service principal does not have permission to perform action 'Microsoft.Storage/storageAccounts/listKeys/action' on the resource '" + storageAccountID + "'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			resources := mock_features.NewMockResourcesClient(controller)
+			permissions := mock_authorization.NewMockPermissionsClient(controller)
+			tt.mocks(resources, permissions)
+
+			a := azureActions{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				oc: &api.OpenShiftCluster{
+					Location: location,
+				},
+				spResources:   resources,
+				spPermissions: permissions,
+			}
+
+			err := a.ValidateNSGFlowLogs(ctx, networkWatcherID, storageAccountID)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -101,6 +101,44 @@ func TestGetAsyncOperationsStatus(t *testing.T) {
 				StartTime:         mockOpStartTime,
 			},
 		},
+		{
+			name: "operation in progress reports its progress",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					ID:                  mockOpID,
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resource1")),
+					AsyncOperation: &api.AsyncOperation{
+						ID:                       "fakeoppath",
+						Name:                     mockOpID,
+						InitialProvisioningState: api.ProvisioningStateCreating,
+						ProvisioningState:        api.ProvisioningStateCreating,
+						StartTime:                mockOpStartTime,
+						PercentComplete:          42,
+						Properties: &api.AsyncOperationProperties{
+							Phase: "Bootstrap",
+							Step:  "ensureGraph",
+						},
+					},
+				})
+
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:              strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resource1")),
+					AsyncOperationID: mockOpID,
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.AsyncOperation{
+				ID:                "fakeoppath",
+				Name:              mockOpID,
+				ProvisioningState: api.ProvisioningStateCreating,
+				StartTime:         mockOpStartTime,
+				PercentComplete:   42,
+				Properties: &api.AsyncOperationProperties{
+					Phase: "Bootstrap",
+					Step:  "ensureGraph",
+				},
+			},
+		},
 		{
 			name:           "operation not found in db",
 			wantStatusCode: http.StatusNotFound,
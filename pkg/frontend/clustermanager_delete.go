@@ -50,12 +50,21 @@ func (f *frontend) _deleteClusterManagerConfigurationDocument(ctx context.Contex
 	doc, err := f.dbClusterManagerConfiguration.Get(ctx, r.URL.Path)
 	switch {
 	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		err = checkPreconditions(r.Header, "")
+		if err != nil {
+			return err
+		}
 		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s/%s/%s' under resource group '%s' was not found.",
 			resourceType, resourceName, ocmResourceType, ocmResourceName, resourceGroupName)
 	case err != nil:
 		return err
 	}
 
+	err = checkPreconditions(r.Header, doc.ETag)
+	if err != nil {
+		return err
+	}
+
 	// Right now we are going to assume that the backend will delete the document, we will just mark for deletion.
 	doc.Deleting = true
 	err = cosmosdb.RetryOnPreconditionFailed(func() error {
@@ -23,8 +23,9 @@ func (f *frontend) getClusterManagerConfiguration(w http.ResponseWriter, r *http
 	ocmResourceType := chi.URLParam(r, "ocmResourceType")
 
 	var (
-		b   []byte
-		err error
+		header http.Header
+		b      []byte
+		err    error
 	)
 
 	apiVersion := r.URL.Query().Get(api.APIVersionKey)
@@ -37,60 +38,68 @@ func (f *frontend) getClusterManagerConfiguration(w http.ResponseWriter, r *http
 
 	switch ocmResourceType {
 	case "syncset":
-		b, err = f._getSyncSetConfiguration(ctx, log, r, f.apis[apiVersion].SyncSetConverter)
+		b, err = f._getSyncSetConfiguration(ctx, log, r, &header, f.apis[apiVersion].SyncSetConverter)
 	case "machinepool":
-		b, err = f._getMachinePoolConfiguration(ctx, log, r, f.apis[apiVersion].MachinePoolConverter)
+		b, err = f._getMachinePoolConfiguration(ctx, log, r, &header, f.apis[apiVersion].MachinePoolConverter)
 	case "syncidentityprovider":
-		b, err = f._getSyncIdentityProviderConfiguration(ctx, log, r, f.apis[apiVersion].SyncIdentityProviderConverter)
+		b, err = f._getSyncIdentityProviderConfiguration(ctx, log, r, &header, f.apis[apiVersion].SyncIdentityProviderConverter)
 	case "secret":
-		b, err = f._getSecretConfiguration(ctx, log, r, f.apis[apiVersion].SecretConverter)
+		b, err = f._getSecretConfiguration(ctx, log, r, &header, f.apis[apiVersion].SecretConverter)
 	default:
 		return
 	}
 
-	reply(log, w, nil, b, err)
+	reply(log, w, header, b, err)
 }
 
-func (f *frontend) _getSyncSetConfiguration(ctx context.Context, log *logrus.Entry, r *http.Request, converter api.SyncSetConverter) ([]byte, error) {
+func (f *frontend) _getSyncSetConfiguration(ctx context.Context, log *logrus.Entry, r *http.Request, header *http.Header, converter api.SyncSetConverter) ([]byte, error) {
 	resType, resName, ocmResType, ocmResName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "ocmResourceType"), chi.URLParam(r, "ocmResourceName"), chi.URLParam(r, "resourceGroupName")
 	doc, err := f.validateResourceForGet(ctx, resType, resName, ocmResType, ocmResName, resGroupName, r.URL.Path, r)
 	if err != nil {
 		return nil, err
 	}
 
+	setETagHeader(header, doc.ETag)
+
 	ext := converter.ToExternal(doc.SyncSet)
 	return json.MarshalIndent(ext, "", "    ")
 }
 
-func (f *frontend) _getMachinePoolConfiguration(ctx context.Context, log *logrus.Entry, r *http.Request, converter api.MachinePoolConverter) ([]byte, error) {
+func (f *frontend) _getMachinePoolConfiguration(ctx context.Context, log *logrus.Entry, r *http.Request, header *http.Header, converter api.MachinePoolConverter) ([]byte, error) {
 	resType, resName, ocmResType, ocmResName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "ocmResourceType"), chi.URLParam(r, "ocmResourceName"), chi.URLParam(r, "resourceGroupName")
 	doc, err := f.validateResourceForGet(ctx, resType, resName, ocmResType, ocmResName, resGroupName, r.URL.Path, r)
 	if err != nil {
 		return nil, err
 	}
 
+	setETagHeader(header, doc.ETag)
+
 	ext := converter.ToExternal(doc.MachinePool)
 	return json.MarshalIndent(ext, "", "    ")
 }
 
-func (f *frontend) _getSyncIdentityProviderConfiguration(ctx context.Context, log *logrus.Entry, r *http.Request, converter api.SyncIdentityProviderConverter) ([]byte, error) {
+func (f *frontend) _getSyncIdentityProviderConfiguration(ctx context.Context, log *logrus.Entry, r *http.Request, header *http.Header, converter api.SyncIdentityProviderConverter) ([]byte, error) {
 	resType, resName, ocmResType, ocmResName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "ocmResourceType"), chi.URLParam(r, "ocmResourceName"), chi.URLParam(r, "resourceGroupName")
 	doc, err := f.validateResourceForGet(ctx, resType, resName, ocmResType, ocmResName, resGroupName, r.URL.Path, r)
 	if err != nil {
 		return nil, err
 	}
 
+	setETagHeader(header, doc.ETag)
+
 	ext := converter.ToExternal(doc.SyncIdentityProvider)
 	return json.MarshalIndent(ext, "", "    ")
 }
 
-func (f *frontend) _getSecretConfiguration(ctx context.Context, log *logrus.Entry, r *http.Request, converter api.SecretConverter) ([]byte, error) {
+func (f *frontend) _getSecretConfiguration(ctx context.Context, log *logrus.Entry, r *http.Request, header *http.Header, converter api.SecretConverter) ([]byte, error) {
 	resType, resName, ocmResType, ocmResName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "ocmResourceType"), chi.URLParam(r, "ocmResourceName"), chi.URLParam(r, "resourceGroupName")
 	doc, err := f.validateResourceForGet(ctx, resType, resName, ocmResType, ocmResName, resGroupName, r.URL.Path, r)
 	if err != nil {
 		return nil, err
 	}
 
+	setETagHeader(header, doc.ETag)
+
 	ext := converter.ToExternal(doc.Secret)
 	return json.MarshalIndent(ext, "", "    ")
 }
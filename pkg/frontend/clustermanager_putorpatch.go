@@ -72,6 +72,16 @@ func (f *frontend) _putOrPatchSyncSet(ctx context.Context, log *logrus.Entry, r
 			resType, resName, ocmResourceType, ocmResourceName, resGroupName)
 	}
 
+	var etag string
+	if ocmdoc != nil {
+		etag = ocmdoc.ETag
+	}
+
+	err = checkPreconditions(r.Header, etag)
+	if err != nil {
+		return nil, err
+	}
+
 	var resources string
 	err = json.Unmarshal(body, &resources)
 	if err != nil {
@@ -120,6 +130,8 @@ func (f *frontend) _putOrPatchSyncSet(ctx context.Context, log *logrus.Entry, r
 		return nil, err
 	}
 
+	setETagHeader(header, ocmdoc.ETag)
+
 	ext := converter.ToExternal(ocmdoc.SyncSet)
 	b, err := json.MarshalIndent(ext, "", "  ")
 	return b, err
@@ -145,6 +157,16 @@ func (f *frontend) _putOrPatchMachinePool(ctx context.Context, log *logrus.Entry
 			resType, resName, ocmResourceType, ocmResourceName, resGroupName)
 	}
 
+	var etag string
+	if ocmdoc != nil {
+		etag = ocmdoc.ETag
+	}
+
+	err = checkPreconditions(r.Header, etag)
+	if err != nil {
+		return nil, err
+	}
+
 	var resources string
 	err = json.Unmarshal(body, &resources)
 	if err != nil {
@@ -193,6 +215,8 @@ func (f *frontend) _putOrPatchMachinePool(ctx context.Context, log *logrus.Entry
 		return nil, err
 	}
 
+	setETagHeader(header, ocmdoc.ETag)
+
 	ext := converter.ToExternal(ocmdoc.MachinePool)
 	b, err := json.MarshalIndent(ext, "", "  ")
 	return b, err
@@ -218,6 +242,16 @@ func (f *frontend) _putOrPatchSyncIdentityProvider(ctx context.Context, log *log
 			resType, resName, ocmResourceType, ocmResourceName, resGroupName)
 	}
 
+	var etag string
+	if ocmdoc != nil {
+		etag = ocmdoc.ETag
+	}
+
+	err = checkPreconditions(r.Header, etag)
+	if err != nil {
+		return nil, err
+	}
+
 	var resources string
 	err = json.Unmarshal(body, &resources)
 	if err != nil {
@@ -266,6 +300,8 @@ func (f *frontend) _putOrPatchSyncIdentityProvider(ctx context.Context, log *log
 		return nil, err
 	}
 
+	setETagHeader(header, ocmdoc.ETag)
+
 	ext := converter.ToExternal(ocmdoc.SyncIdentityProvider)
 	b, err := json.MarshalIndent(ext, "", "  ")
 	return b, err
@@ -291,6 +327,16 @@ func (f *frontend) _putOrPatchSecret(ctx context.Context, log *logrus.Entry, r *
 			resType, resName, ocmResourceType, ocmResourceName, resGroupName)
 	}
 
+	var etag string
+	if ocmdoc != nil {
+		etag = ocmdoc.ETag
+	}
+
+	err = checkPreconditions(r.Header, etag)
+	if err != nil {
+		return nil, err
+	}
+
 	var resources string
 	err = json.Unmarshal(body, &resources)
 	if err != nil {
@@ -339,6 +385,8 @@ func (f *frontend) _putOrPatchSecret(ctx context.Context, log *logrus.Entry, r *
 		return nil, err
 	}
 
+	setETagHeader(header, ocmdoc.ETag)
+
 	ext := converter.ToExternal(ocmdoc.Secret)
 	b, err := json.MarshalIndent(ext, "", "  ")
 	return b, err
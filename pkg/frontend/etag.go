@@ -0,0 +1,63 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// checkPreconditions evaluates the If-Match and If-None-Match headers of a
+// request against the ETag of the current document, which is empty if the
+// document does not exist.  "If-None-Match: *" therefore gives create-only
+// semantics.
+func checkPreconditions(requestHeader http.Header, etag string) error {
+	if ifMatch := strings.Join(requestHeader.Values("If-Match"), ","); ifMatch != "" {
+		if etag == "" || !etagMatches(ifMatch, etag) {
+			return api.NewCloudError(http.StatusPreconditionFailed, api.CloudErrorCodePreconditionFailed, "", "The condition specified in the If-Match header was not met.")
+		}
+	}
+
+	if ifNoneMatch := strings.Join(requestHeader.Values("If-None-Match"), ","); ifNoneMatch != "" {
+		if etag != "" && etagMatches(ifNoneMatch, etag) {
+			return api.NewCloudError(http.StatusPreconditionFailed, api.CloudErrorCodePreconditionFailed, "", "The condition specified in the If-None-Match header was not met.")
+		}
+	}
+
+	return nil
+}
+
+// etagMatches returns true if a comma separated If-Match or If-None-Match
+// header value matches the given document ETag
+func etagMatches(value, etag string) bool {
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || v == quoteETag(etag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setETagHeader sets the ETag response header from a document ETag
+func setETagHeader(header *http.Header, etag string) {
+	if *header == nil {
+		*header = http.Header{}
+	}
+
+	header.Set("ETag", quoteETag(etag))
+}
+
+// quoteETag returns a document ETag as an HTTP entity tag.  Cosmos DB ETags
+// are already quoted.
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) {
+		return etag
+	}
+
+	return `"` + etag + `"`
+}
@@ -0,0 +1,98 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestCheckPreconditions(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		header  http.Header
+		etag    string
+		wantErr string
+	}{
+		{
+			name: "no preconditions",
+			etag: `"1"`,
+		},
+		{
+			name: "no preconditions, document does not exist",
+		},
+		{
+			name: "If-Match matches",
+			header: http.Header{
+				"If-Match": []string{`"1"`},
+			},
+			etag: `"1"`,
+		},
+		{
+			name: "If-Match matches unquoted document etag",
+			header: http.Header{
+				"If-Match": []string{`"0", W/"1"`},
+			},
+			etag: "1",
+		},
+		{
+			name: "If-Match wildcard",
+			header: http.Header{
+				"If-Match": []string{"*"},
+			},
+			etag: `"1"`,
+		},
+		{
+			name: "If-Match does not match",
+			header: http.Header{
+				"If-Match": []string{`"0"`},
+			},
+			etag:    `"1"`,
+			wantErr: "412: PreconditionFailed: : The condition specified in the If-Match header was not met.",
+		},
+		{
+			name: "If-Match wildcard, document does not exist",
+			header: http.Header{
+				"If-Match": []string{"*"},
+			},
+			wantErr: "412: PreconditionFailed: : The condition specified in the If-Match header was not met.",
+		},
+		{
+			name: "If-None-Match wildcard, document does not exist",
+			header: http.Header{
+				"If-None-Match": []string{"*"},
+			},
+		},
+		{
+			name: "If-None-Match wildcard, document exists",
+			header: http.Header{
+				"If-None-Match": []string{"*"},
+			},
+			etag:    `"1"`,
+			wantErr: "412: PreconditionFailed: : The condition specified in the If-None-Match header was not met.",
+		},
+		{
+			name: "If-None-Match does not match",
+			header: http.Header{
+				"If-None-Match": []string{`"0"`},
+			},
+			etag: `"1"`,
+		},
+		{
+			name: "If-None-Match matches",
+			header: http.Header{
+				"If-None-Match": []string{`"1"`},
+			},
+			etag:    `"1"`,
+			wantErr: "412: PreconditionFailed: : The condition specified in the If-None-Match header was not met.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPreconditions(tt.header, tt.etag)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
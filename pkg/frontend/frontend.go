@@ -56,6 +56,7 @@ type frontend struct {
 	authMiddleware        middleware.AuthMiddleware
 	apiVersionMiddleware  middleware.ApiVersionValidator
 	maintenanceMiddleware middleware.MaintenanceMiddleware
+	throttleMiddleware    *middleware.ThrottleMiddleware
 
 	dbAsyncOperations             database.AsyncOperations
 	dbClusterManagerConfiguration database.ClusterManagerConfigurations
@@ -126,6 +127,11 @@ func NewFrontend(ctx context.Context,
 	azureActionsFactory azureActionsFactory,
 	enricher clusterdata.BestEffortEnricher,
 ) (*frontend, error) {
+	throttleLimits, err := middleware.NewThrottleLimitsFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
 	f := &frontend{
 		logMiddleware: middleware.LogMiddleware{
 			EnvironmentName: _env.Environment().Name,
@@ -156,6 +162,7 @@ func NewFrontend(ctx context.Context,
 		apis:                          apis,
 		m:                             middleware.MetricsMiddleware{Emitter: m},
 		maintenanceMiddleware:         middleware.MaintenanceMiddleware{Emitter: clusterm},
+		throttleMiddleware:            middleware.NewThrottleMiddleware(m, throttleLimits),
 		aead:                          aead,
 		hiveClusterManager:            hiveClusterManager,
 		kubeActionsFactory:            kubeActionsFactory,
@@ -224,7 +231,7 @@ func (f *frontend) chiUnauthenticatedRoutes(router chi.Router) {
 }
 
 func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
-	r := router.With(f.authMiddleware.Authenticate)
+	r := router.With(f.authMiddleware.Authenticate, f.throttleMiddleware.Throttle)
 
 	r.Route("/subscriptions/{subscriptionId}", func(r chi.Router) {
 		r.Route("/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}", func(r chi.Router) {
@@ -288,6 +295,7 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 			r.Put("/", f.putAdminOpenShiftVersion)
 		})
 		r.Get("/supportedvmsizes", f.supportedvmsizes)
+		r.Get("/maintenancetasks", f.getAdminMaintenanceTasks)
 
 		r.Route("/subscriptions/{subscriptionId}", func(r chi.Router) {
 			r.Route("/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}", func(r chi.Router) {
@@ -323,6 +331,29 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/reconcilefailednic", f.postAdminReconcileFailedNIC)
 
+				// The maintenance signal is set by the admin update which runs the operation
+				r.Get("/rollingnodeoperation", f.getAdminOpenShiftClusterRollingNodeOperation)
+				r.Post("/rollingnodeoperation", f.postAdminOpenShiftClusterRollingNodeOperation)
+				r.Delete("/rollingnodeoperation", f.deleteAdminOpenShiftClusterRollingNodeOperation)
+
+				// Customer impacting maintenance tasks set the maintenance signal when invoked
+				r.Post("/maintenancetasks/{maintenanceTask}", f.postAdminOpenShiftClusterMaintenanceTask)
+
+				r.Get("/dnsdrift", f.getAdminOpenShiftClusterDNSDrift)
+				r.Post("/repairdns", f.postAdminOpenShiftClusterRepairDNS)
+
+				r.Get("/deletepreview", f.getAdminOpenShiftClusterDeletePreview)
+
+				r.Get("/nsgflowlogs", f.getAdminOpenShiftClusterNSGFlowLogs)
+				r.Post("/nsgflowlogs", f.postAdminOpenShiftClusterNSGFlowLogs)
+
+				r.Get("/diagnosticbundles", f.getAdminOpenShiftClusterDiagnosticBundles)
+				r.Post("/diagnosticbundles", f.postAdminOpenShiftClusterDiagnosticBundles)
+				r.Get("/diagnosticbundle", f.getAdminOpenShiftClusterDiagnosticBundle)
+
+				r.Get("/etcdsnapshots", f.getAdminOpenShiftClusterEtcdSnapshots)
+				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/etcdrestore", f.postAdminOpenShiftClusterEtcdRestore)
+
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/cordonnode", f.postAdminOpenShiftClusterCordonNode)
 
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/drainnode", f.postAdminOpenShiftClusterDrainNode)
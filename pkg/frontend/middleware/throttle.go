@@ -0,0 +1,229 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+// ThrottleClass is a class of routes which are throttled together
+type ThrottleClass string
+
+// The throttle classes also name the x-ms-ratelimit-remaining-* response
+// header and, upper cased, the FRONTEND_THROTTLE_* environment variable which
+// configures the limit of the class
+const (
+	ThrottleClassReads       ThrottleClass = "subscription-reads"
+	ThrottleClassWrites      ThrottleClass = "subscription-writes"
+	ThrottleClassCredentials ThrottleClass = "subscription-credentials"
+	ThrottleClassAdmin       ThrottleClass = "admin-requests"
+)
+
+// throttleSweepInterval is how often idle buckets are removed
+const throttleSweepInterval = 10 * time.Minute
+
+// ThrottleLimit allows Requests requests per Period, in bursts of up to
+// Requests requests
+type ThrottleLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+var defaultThrottleLimits = map[ThrottleClass]ThrottleLimit{
+	ThrottleClassReads:       {Requests: 12000, Period: time.Hour},
+	ThrottleClassWrites:      {Requests: 1200, Period: time.Hour},
+	ThrottleClassCredentials: {Requests: 100, Period: time.Hour},
+	ThrottleClassAdmin:       {Requests: 600, Period: 10 * time.Minute},
+}
+
+// NewThrottleLimitsFromEnvironment returns the default throttle limits,
+// overridden by any FRONTEND_THROTTLE_<CLASS> environment variables.  These
+// have the form <requests>/<period>, e.g. "1200/1h".  A limit of zero requests
+// disables throttling of the class.
+func NewThrottleLimitsFromEnvironment() (map[ThrottleClass]ThrottleLimit, error) {
+	limits := map[ThrottleClass]ThrottleLimit{}
+
+	for class, limit := range defaultThrottleLimits {
+		key := throttleEnvironmentVariable(class)
+
+		if v := os.Getenv(key); v != "" {
+			var err error
+			limit, err = parseThrottleLimit(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+		}
+
+		limits[class] = limit
+	}
+
+	return limits, nil
+}
+
+func throttleEnvironmentVariable(class ThrottleClass) string {
+	return "FRONTEND_THROTTLE_" + strings.ToUpper(strings.ReplaceAll(string(class), "-", "_"))
+}
+
+func parseThrottleLimit(v string) (ThrottleLimit, error) {
+	requests, period, found := strings.Cut(v, "/")
+	if !found {
+		return ThrottleLimit{}, fmt.Errorf("%q is not of the form <requests>/<period>", v)
+	}
+
+	n, err := strconv.Atoi(requests)
+	if err != nil || n < 0 {
+		return ThrottleLimit{}, fmt.Errorf("%q is not a valid number of requests", requests)
+	}
+
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return ThrottleLimit{}, fmt.Errorf("%q is not a valid period", period)
+	}
+
+	return ThrottleLimit{Requests: n, Period: d}, nil
+}
+
+type ThrottleMiddleware struct {
+	metrics.Emitter
+
+	limits map[ThrottleClass]ThrottleLimit
+	now    func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*rate.Limiter
+	lastSweep time.Time
+}
+
+func NewThrottleMiddleware(m metrics.Emitter, limits map[ThrottleClass]ThrottleLimit) *ThrottleMiddleware {
+	return &ThrottleMiddleware{
+		Emitter: m,
+		limits:  limits,
+		now:     time.Now,
+		buckets: map[string]*rate.Limiter{},
+	}
+}
+
+// Throttle rate limits requests with a token bucket per route class,
+// subscription and caller.  Requests over the limit are rejected with an
+// ARM-style 429 response.
+func (tm *ThrottleMiddleware) Throttle(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class := throttleClass(r)
+
+		limit, found := tm.limits[class]
+		if !found || limit.Requests == 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		subscriptionID := throttleSubscriptionID(r)
+		remaining, retryAfter := tm.take(class, limit, strings.Join([]string{string(class), subscriptionID, throttleCaller(r)}, "|"))
+
+		w.Header().Set("X-Ms-Ratelimit-Remaining-"+string(class), strconv.Itoa(remaining))
+
+		if retryAfter > 0 {
+			tm.EmitGauge("frontend.throttled", 1, map[string]string{
+				"class":          string(class),
+				"subscriptionId": subscriptionID,
+			})
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			api.WriteError(w, http.StatusTooManyRequests, api.CloudErrorCodeTooManyRequests, "", "The request was throttled. Retry after %d seconds.", int(math.Ceil(retryAfter.Seconds())))
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// take takes a token from the bucket with the given key.  It returns the
+// number of tokens remaining and, if no token was available, the time after
+// which one will be.
+func (tm *ThrottleMiddleware) take(class ThrottleClass, limit ThrottleLimit, key string) (int, time.Duration) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	now := tm.now()
+	tm.sweep(now)
+
+	bucket, found := tm.buckets[key]
+	if !found {
+		bucket = rate.NewLimiter(rate.Every(limit.Period/time.Duration(limit.Requests)), limit.Requests)
+		tm.buckets[key] = bucket
+	}
+
+	reservation := bucket.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return 0, delay
+	}
+
+	return int(bucket.TokensAt(now)), 0
+}
+
+// sweep removes buckets which have refilled, so that the buckets of
+// subscriptions and callers which are no longer active don't accumulate
+func (tm *ThrottleMiddleware) sweep(now time.Time) {
+	if now.Sub(tm.lastSweep) < throttleSweepInterval {
+		return
+	}
+	tm.lastSweep = now
+
+	for key, bucket := range tm.buckets {
+		if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+			delete(tm.buckets, key)
+		}
+	}
+}
+
+func throttleClass(r *http.Request) ThrottleClass {
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/admin"):
+		return ThrottleClassAdmin
+	case strings.HasSuffix(r.URL.Path, "/listcredentials"),
+		strings.HasSuffix(r.URL.Path, "/listadmincredentials"):
+		return ThrottleClassCredentials
+	case r.Method == http.MethodGet:
+		return ThrottleClassReads
+	default:
+		return ThrottleClassWrites
+	}
+}
+
+// throttleSubscriptionID returns the subscription in the request path, if any
+func throttleSubscriptionID(r *http.Request) string {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/admin"), "/")
+	if len(parts) > 2 && parts[1] == "subscriptions" {
+		return parts[2]
+	}
+
+	return ""
+}
+
+// throttleCaller returns the identity of the caller.  Requests from ARM carry
+// the principal on whose behalf ARM is calling; otherwise the caller is
+// identified by its client certificate.
+func throttleCaller(r *http.Request) string {
+	if objectID := r.Header.Get("X-Ms-Client-Object-Id"); objectID != "" {
+		return objectID
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates[0].Subject.CommonName
+	}
+
+	return ""
+}
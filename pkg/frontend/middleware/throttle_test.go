@@ -0,0 +1,247 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestNewThrottleLimitsFromEnvironment(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		env     map[string]string
+		want    map[ThrottleClass]ThrottleLimit
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: defaultThrottleLimits,
+		},
+		{
+			name: "overridden",
+			env: map[string]string{
+				"FRONTEND_THROTTLE_SUBSCRIPTION_CREDENTIALS": "10/1m",
+				"FRONTEND_THROTTLE_ADMIN_REQUESTS":           "0/1h",
+			},
+			want: map[ThrottleClass]ThrottleLimit{
+				ThrottleClassReads:       {Requests: 12000, Period: time.Hour},
+				ThrottleClassWrites:      {Requests: 1200, Period: time.Hour},
+				ThrottleClassCredentials: {Requests: 10, Period: time.Minute},
+				ThrottleClassAdmin:       {Requests: 0, Period: time.Hour},
+			},
+		},
+		{
+			name: "missing period",
+			env: map[string]string{
+				"FRONTEND_THROTTLE_SUBSCRIPTION_READS": "10",
+			},
+			wantErr: `invalid FRONTEND_THROTTLE_SUBSCRIPTION_READS: "10" is not of the form <requests>/<period>`,
+		},
+		{
+			name: "invalid period",
+			env: map[string]string{
+				"FRONTEND_THROTTLE_SUBSCRIPTION_WRITES": "10/0s",
+			},
+			wantErr: `invalid FRONTEND_THROTTLE_SUBSCRIPTION_WRITES: "0s" is not a valid period`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			limits, err := NewThrottleLimitsFromEnvironment()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr == "" && !reflect.DeepEqual(limits, tt.want) {
+				t.Error(limits)
+			}
+		})
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type request struct {
+		method         string
+		path           string
+		objectID       string
+		wantStatusCode int
+		wantRemaining  string
+		wantRetryAfter string
+	}
+
+	for _, tt := range []struct {
+		name        string
+		requests    []request
+		wantMetrics []map[string]string
+	}{
+		{
+			name: "requests over the limit are throttled",
+			requests: []request{
+				{
+					method:         http.MethodPut,
+					path:           "/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster",
+					objectID:       "caller",
+					wantStatusCode: http.StatusOK,
+					wantRemaining:  "1",
+				},
+				{
+					method:         http.MethodPatch,
+					path:           "/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster",
+					objectID:       "caller",
+					wantStatusCode: http.StatusOK,
+					wantRemaining:  "0",
+				},
+				{
+					method:         http.MethodDelete,
+					path:           "/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster",
+					objectID:       "caller",
+					wantStatusCode: http.StatusTooManyRequests,
+					wantRemaining:  "0",
+					wantRetryAfter: "1800",
+				},
+			},
+			wantMetrics: []map[string]string{
+				{
+					"class":          "subscription-writes",
+					"subscriptionId": "sub1",
+				},
+			},
+		},
+		{
+			name: "buckets are per class, subscription and caller",
+			requests: []request{
+				{
+					method:         http.MethodPut,
+					path:           "/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster",
+					objectID:       "caller",
+					wantStatusCode: http.StatusOK,
+					wantRemaining:  "1",
+				},
+				{
+					method:         http.MethodPut,
+					path:           "/subscriptions/sub2/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster",
+					objectID:       "caller",
+					wantStatusCode: http.StatusOK,
+					wantRemaining:  "1",
+				},
+				{
+					method:         http.MethodPut,
+					path:           "/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster",
+					objectID:       "other",
+					wantStatusCode: http.StatusOK,
+					wantRemaining:  "1",
+				},
+				{
+					method:         http.MethodPost,
+					path:           "/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster/listcredentials",
+					objectID:       "caller",
+					wantStatusCode: http.StatusOK,
+					wantRemaining:  "0",
+				},
+				{
+					method:         http.MethodGet,
+					path:           "/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster",
+					objectID:       "caller",
+					wantStatusCode: http.StatusOK,
+					wantRemaining:  "9",
+				},
+			},
+		},
+		{
+			name: "unlimited classes are not throttled",
+			requests: []request{
+				{
+					method:         http.MethodGet,
+					path:           "/admin/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster/kubernetesobjects",
+					wantStatusCode: http.StatusOK,
+				},
+				{
+					method:         http.MethodGet,
+					path:           "/admin/subscriptions/sub1/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster/kubernetesobjects",
+					wantStatusCode: http.StatusOK,
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			for _, dims := range tt.wantMetrics {
+				m.EXPECT().EmitGauge("frontend.throttled", int64(1), dims)
+			}
+
+			tm := NewThrottleMiddleware(m, map[ThrottleClass]ThrottleLimit{
+				ThrottleClassReads:       {Requests: 10, Period: time.Hour},
+				ThrottleClassWrites:      {Requests: 2, Period: time.Hour},
+				ThrottleClassCredentials: {Requests: 1, Period: time.Hour},
+				ThrottleClassAdmin:       {Requests: 0, Period: time.Hour},
+			})
+			tm.now = func() time.Time { return now }
+
+			handler := tm.Throttle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			for i, req := range tt.requests {
+				r, err := http.NewRequest(req.method, req.path, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				r.Header.Set("X-Ms-Client-Object-Id", req.objectID)
+
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, r)
+
+				if w.Code != req.wantStatusCode {
+					t.Errorf("request %d: got status code %d, want %d", i, w.Code, req.wantStatusCode)
+				}
+
+				if req.wantRemaining != "" {
+					remaining := w.Header().Get("X-Ms-Ratelimit-Remaining-" + string(throttleClass(r)))
+					if remaining != req.wantRemaining {
+						t.Errorf("request %d: got remaining %q, want %q", i, remaining, req.wantRemaining)
+					}
+				}
+
+				if w.Header().Get("Retry-After") != req.wantRetryAfter {
+					t.Errorf("request %d: got Retry-After %q, want %q", i, w.Header().Get("Retry-After"), req.wantRetryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestThrottleSweep(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tm := NewThrottleMiddleware(nil, nil)
+	tm.now = func() time.Time { return now }
+
+	limit := ThrottleLimit{Requests: 2, Period: time.Minute}
+
+	tm.take(ThrottleClassWrites, limit, "idle")
+	tm.take(ThrottleClassWrites, limit, "busy")
+
+	now = now.Add(throttleSweepInterval)
+	tm.take(ThrottleClassWrites, limit, "busy")
+
+	if _, found := tm.buckets["idle"]; found {
+		t.Error("idle bucket was not removed")
+	}
+	if _, found := tm.buckets["busy"]; !found {
+		t.Error("busy bucket was removed")
+	}
+}
@@ -26,7 +26,10 @@ func (f *frontend) deleteOpenShiftCluster(w http.ResponseWriter, r *http.Request
 	})
 	switch {
 	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
-		err = statusCodeError(http.StatusNoContent)
+		err = checkPreconditions(r.Header, "")
+		if err == nil {
+			err = statusCodeError(http.StatusNoContent)
+		}
 	case err == nil:
 		err = statusCodeError(http.StatusAccepted)
 	}
@@ -38,7 +41,12 @@ func (f *frontend) deleteOpenShiftCluster(w http.ResponseWriter, r *http.Request
 func (f *frontend) _deleteOpenShiftCluster(ctx context.Context, r *http.Request, header *http.Header, doc *api.OpenShiftClusterDocument) error {
 	correlationData := r.Context().Value(middleware.ContextKeyCorrelationData).(*api.CorrelationData)
 
-	_, err := f.validateSubscriptionState(ctx, doc.Key, api.SubscriptionStateRegistered, api.SubscriptionStateWarned, api.SubscriptionStateSuspended)
+	err := checkPreconditions(r.Header, doc.ETag)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.validateSubscriptionState(ctx, doc.Key, api.SubscriptionStateRegistered, api.SubscriptionStateWarned, api.SubscriptionStateSuspended)
 	if err != nil {
 		return err
 	}
@@ -25,6 +25,7 @@ func TestDeleteOpenShiftCluster(t *testing.T) {
 		name           string
 		resourceID     string
 		fixture        func(*testdatabase.Fixture)
+		header         http.Header
 		dbError        error
 		wantDocuments  func(*testdatabase.Checker)
 		wantStatusCode int
@@ -83,11 +84,64 @@ func TestDeleteOpenShiftCluster(t *testing.T) {
 			wantStatusCode: http.StatusAccepted,
 			wantAsync:      true,
 		},
+		{
+			name:       "cluster exists in db, If-Match does not match",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+						},
+					},
+				})
+			},
+			header: http.Header{
+				"If-Match": []string{`"1"`},
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantError:      "412: PreconditionFailed: : The condition specified in the If-Match header was not met.",
+		},
 		{
 			name:           "cluster not found in db",
 			resourceID:     testdatabase.GetResourcePath(mockSubID, "resourceName"),
 			wantStatusCode: http.StatusNoContent,
 		},
+		{
+			name:       "cluster not found in db, If-Match set",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			header: http.Header{
+				"If-Match": []string{"*"},
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantError:      "412: PreconditionFailed: : The condition specified in the If-Match header was not met.",
+		},
 		{
 			name:           "internal error",
 			resourceID:     testdatabase.GetResourcePath(mockSubID, "resourceName"),
@@ -123,7 +177,7 @@ func TestDeleteOpenShiftCluster(t *testing.T) {
 
 			resp, b, err := ti.request(http.MethodDelete,
 				"https://server"+tt.resourceID+"?api-version=2020-04-30",
-				nil, nil)
+				tt.header, nil)
 			if err != nil {
 				t.Error(err)
 			}
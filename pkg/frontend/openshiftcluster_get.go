@@ -21,13 +21,14 @@ func (f *frontend) getOpenShiftCluster(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
 
-	b, err := f._getOpenShiftCluster(ctx, log, r, f.apis[r.URL.Query().Get(api.APIVersionKey)].OpenShiftClusterConverter)
+	var header http.Header
+	b, err := f._getOpenShiftCluster(ctx, log, r, &header, f.apis[r.URL.Query().Get(api.APIVersionKey)].OpenShiftClusterConverter)
 
 	frontendOperationResultLog(log, r.Method, err)
-	reply(log, w, nil, b, err)
+	reply(log, w, header, b, err)
 }
 
-func (f *frontend) _getOpenShiftCluster(ctx context.Context, log *logrus.Entry, r *http.Request, converter api.OpenShiftClusterConverter) ([]byte, error) {
+func (f *frontend) _getOpenShiftCluster(ctx context.Context, log *logrus.Entry, r *http.Request, header *http.Header, converter api.OpenShiftClusterConverter) ([]byte, error) {
 	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
 
 	doc, err := f.dbOpenShiftClusters.Get(ctx, r.URL.Path)
@@ -46,5 +47,7 @@ func (f *frontend) _getOpenShiftCluster(ctx context.Context, log *logrus.Entry,
 	doc.OpenShiftCluster.Properties.ClusterProfile.PullSecret = ""
 	doc.OpenShiftCluster.Properties.ServicePrincipalProfile.ClientSecret = ""
 
+	setETagHeader(header, doc.ETag)
+
 	return json.MarshalIndent(converter.ToExternal(doc.OpenShiftCluster), "", "    ")
 }
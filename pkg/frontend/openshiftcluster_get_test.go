@@ -27,6 +27,7 @@ func TestGetOpenShiftCluster(t *testing.T) {
 		wantEnriched   []string
 		wantStatusCode int
 		wantResponse   func(*test) *v20200430.OpenShiftCluster
+		wantETag       string
 		wantError      string
 	}
 
@@ -64,6 +65,7 @@ func TestGetOpenShiftCluster(t *testing.T) {
 					Type: "Microsoft.RedHatOpenShift/openshiftClusters",
 				}
 			},
+			wantETag: `"0"`,
 		},
 		{
 			name:           "cluster not found in db",
@@ -115,6 +117,10 @@ func TestGetOpenShiftCluster(t *testing.T) {
 			if err != nil {
 				t.Error(err)
 			}
+
+			if resp.Header.Get("ETag") != tt.wantETag {
+				t.Error(resp.Header.Get("ETag"))
+			}
 		})
 	}
 }
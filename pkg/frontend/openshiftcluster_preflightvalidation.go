@@ -92,9 +92,7 @@ func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry,
 	if err := staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), resourceID); err != nil {
 		return api.ValidationResult{
 			Status: api.ValidationStatusFailed,
-			Error: &api.ManagementErrorWithDetails{
-				Message: to.StringPtr(err.Error()),
-			},
+			Error:  managementErrorWithDetails(err),
 		}
 	}
 
@@ -111,6 +109,42 @@ func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry,
 	return validationSuccess
 }
 
+// managementErrorWithDetails converts a validation error to the preflight
+// error format, preserving the details of errors which aggregate multiple
+// validation findings
+func managementErrorWithDetails(err error) *api.ManagementErrorWithDetails {
+	e := &api.ManagementErrorWithDetails{
+		Message: to.StringPtr(err.Error()),
+	}
+
+	cloudErr, ok := err.(*api.CloudError)
+	if !ok || cloudErr.CloudErrorBody == nil {
+		return e
+	}
+
+	e.Code = to.StringPtr(cloudErr.Code)
+	if cloudErr.Target != "" {
+		e.Target = to.StringPtr(cloudErr.Target)
+	}
+
+	if len(cloudErr.Details) > 0 {
+		details := make([]api.ManagementErrorWithDetails, 0, len(cloudErr.Details))
+		for _, detail := range cloudErr.Details {
+			d := api.ManagementErrorWithDetails{
+				Code:    to.StringPtr(detail.Code),
+				Message: to.StringPtr(detail.Message),
+			}
+			if detail.Target != "" {
+				d.Target = to.StringPtr(detail.Target)
+			}
+			details = append(details, d)
+		}
+		e.Details = &details
+	}
+
+	return e
+}
+
 func unmarshalRequest(body []byte) (*api.PreflightRequest, error) {
 	preflightRequest := &api.PreflightRequest{}
 	if err := json.Unmarshal(body, preflightRequest); err != nil {
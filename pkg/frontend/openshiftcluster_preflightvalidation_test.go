@@ -12,6 +12,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20231122"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
 )
@@ -104,6 +105,95 @@ func TestPreflightValidation(t *testing.T) {
 				Status: api.ValidationStatusSucceeded,
 			},
 		},
+		{
+			name: "Failed Preflight Static with multiple errors",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+			},
+			preflightRequest: func() *api.PreflightRequest {
+				return &api.PreflightRequest{
+					Resources: []json.RawMessage{
+						[]byte(`
+								{
+									"apiVersion": "2023-11-22",
+									"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourcename/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName",
+									"name": "resourceName",
+									"type": "microsoft.redhatopenshift/openshiftclusters",
+									"location": "eastus",
+									"properties": {
+										"clusterProfile": {
+										  "domain": "example.aroapp.io",
+										  "resourceGroupId": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourcenameTest",
+										  "fipsValidatedModules": "Enabled"
+										},
+										"consoleProfile": {},
+										"servicePrincipalProfile": {
+										  "clientId": "invalid",
+										  "clientSecret": "00000000-0000-0000-0000-000000000000"
+										},
+										"networkProfile": {
+										  "podCidr": "invalid",
+										  "serviceCidr": "172.30.0.0/16"
+										},
+										"masterProfile": {
+										  "vmSize": "Standard_D32s_v3",
+										  "subnetId": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/ms-eastus/providers/Microsoft.Network/virtualNetworks/dev-vnet/subnets/CARO2-master",
+										  "encryptionAtHost": "Enabled"
+										},
+										"workerProfiles": [
+										  {
+											"name": "worker",
+											"vmSize": "Standard_D32s_v3",
+											"diskSizeGB": 128,
+											"subnetId": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/ms-eastus/providers/Microsoft.Network/virtualNetworks/dev-vnet/subnets/CARO2-worker",
+											"count": 3,
+											"encryptionAtHost": "Enabled"
+										  }
+										],
+										"apiserverProfile": {
+										  "visibility": "Public"
+										},
+										"ingressProfiles": [
+										  {
+											"name": "default",
+											"visibility": "Public"
+										  }
+										]
+									  }
+								}
+						`),
+					},
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.ValidationResult{
+				Status: api.ValidationStatusFailed,
+				Error: &api.ManagementErrorWithDetails{
+					Code:    to.StringPtr(api.CloudErrorCodeMultipleErrorsOccurred),
+					Message: to.StringPtr("400: MultipleErrorsOccurred: : Multiple validation errors occurred. Please see details for more information. Details: InvalidParameter: properties.servicePrincipalProfile.clientId: The provided client ID 'invalid' is invalid., InvalidParameter: properties.networkProfile.podCidr: The provided pod CIDR 'invalid' is invalid: 'invalid CIDR address: invalid'."),
+					Details: &[]api.ManagementErrorWithDetails{
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("The provided client ID 'invalid' is invalid."),
+							Target:  to.StringPtr("properties.servicePrincipalProfile.clientId"),
+						},
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("The provided pod CIDR 'invalid' is invalid: 'invalid CIDR address: invalid'."),
+							Target:  to.StringPtr("properties.networkProfile.podCidr"),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Failed Preflight Static",
 			fixture: func(f *testdatabase.Fixture) {
@@ -143,7 +233,45 @@ func TestPreflightValidation(t *testing.T) {
 			wantResponse: &api.ValidationResult{
 				Status: api.ValidationStatusFailed,
 				Error: &api.ManagementErrorWithDetails{
-					Message: to.StringPtr("400: InvalidParameter: properties.clusterProfile.resourceGroupId: The provided resource group '/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/resourcenameTest' is invalid: must be in same subscription as cluster."),
+					Code:    to.StringPtr(api.CloudErrorCodeMultipleErrorsOccurred),
+					Message: to.StringPtr("400: MultipleErrorsOccurred: : Multiple validation errors occurred. Please see details for more information. Details: InvalidParameter: properties.clusterProfile.resourceGroupId: The provided resource group '/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/resourcenameTest' is invalid: must be in same subscription as cluster., InvalidParameter: properties.servicePrincipalProfile.clientId: The provided client ID '' is invalid., InvalidParameter: properties.networkProfile.podCidr: The provided pod CIDR '' is invalid: 'invalid CIDR address: '., InvalidParameter: properties.masterProfile.vmSize: The provided master VM size '' is invalid., InvalidParameter: properties.apiserverProfile.visibility: The provided visibility '' is invalid., InvalidParameter: properties.workerProfiles: There should be exactly one worker profile., InvalidParameter: properties.ingressProfiles: There should be exactly one ingress profile."),
+					Details: &[]api.ManagementErrorWithDetails{
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("The provided resource group '/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/resourcenameTest' is invalid: must be in same subscription as cluster."),
+							Target:  to.StringPtr("properties.clusterProfile.resourceGroupId"),
+						},
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("The provided client ID '' is invalid."),
+							Target:  to.StringPtr("properties.servicePrincipalProfile.clientId"),
+						},
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("The provided pod CIDR '' is invalid: 'invalid CIDR address: '."),
+							Target:  to.StringPtr("properties.networkProfile.podCidr"),
+						},
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("The provided master VM size '' is invalid."),
+							Target:  to.StringPtr("properties.masterProfile.vmSize"),
+						},
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("The provided visibility '' is invalid."),
+							Target:  to.StringPtr("properties.apiserverProfile.visibility"),
+						},
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("There should be exactly one worker profile."),
+							Target:  to.StringPtr("properties.workerProfiles"),
+						},
+						{
+							Code:    to.StringPtr(api.CloudErrorCodeInvalidParameter),
+							Message: to.StringPtr("There should be exactly one ingress profile."),
+							Target:  to.StringPtr("properties.ingressProfiles"),
+						},
+					},
 				},
 			},
 		},
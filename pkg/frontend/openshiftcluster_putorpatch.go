@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 	"github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/util/adminupdate"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
@@ -44,7 +45,7 @@ func (f *frontend) putOrPatchOpenShiftCluster(w http.ResponseWriter, r *http.Req
 	apiVersion := r.URL.Query().Get(api.APIVersionKey)
 	err := cosmosdb.RetryOnPreconditionFailed(func() error {
 		var err error
-		b, err = f._putOrPatchOpenShiftCluster(ctx, log, body, correlationData, systemData, r.URL.Path, originalPath, r.Method, referer, &header, f.apis[apiVersion].OpenShiftClusterConverter, f.apis[apiVersion].OpenShiftClusterStaticValidator, subId, resourceProviderNamespace, apiVersion)
+		b, err = f._putOrPatchOpenShiftCluster(ctx, log, body, correlationData, systemData, r.URL.Path, originalPath, r.Method, referer, r.Header, &header, f.apis[apiVersion].OpenShiftClusterConverter, f.apis[apiVersion].OpenShiftClusterStaticValidator, subId, resourceProviderNamespace, apiVersion)
 		return err
 	})
 
@@ -52,7 +53,7 @@ func (f *frontend) putOrPatchOpenShiftCluster(w http.ResponseWriter, r *http.Req
 	reply(log, w, header, b, err)
 }
 
-func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.Entry, body []byte, correlationData *api.CorrelationData, systemData *api.SystemData, path, originalPath, method, referer string, header *http.Header, converter api.OpenShiftClusterConverter, staticValidator api.OpenShiftClusterStaticValidator, subId, resourceProviderNamespace string, apiVersion string) ([]byte, error) {
+func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.Entry, body []byte, correlationData *api.CorrelationData, systemData *api.SystemData, path, originalPath, method, referer string, requestHeader http.Header, header *http.Header, converter api.OpenShiftClusterConverter, staticValidator api.OpenShiftClusterStaticValidator, subId, resourceProviderNamespace string, apiVersion string) ([]byte, error) {
 	subscription, err := f.validateSubscriptionState(ctx, path, api.SubscriptionStateRegistered)
 	if err != nil {
 		return nil, err
@@ -64,6 +65,18 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 	}
 	isCreate := doc == nil
 
+	// the document ETag is kept on update, so a concurrent modification
+	// after this check also fails the precondition
+	var etag string
+	if !isCreate {
+		etag = doc.ETag
+	}
+
+	err = checkPreconditions(requestHeader, etag)
+	if err != nil {
+		return nil, err
+	}
+
 	if isCreate {
 		originalR, err := azure.ParseResourceID(originalPath)
 		if err != nil {
@@ -197,7 +210,18 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 			return nil, err
 		}
 	} else {
-		setUpdateProvisioningState(doc, apiVersion)
+		// maintenance tasks invoked by an admin update have the same
+		// preconditions as when they are invoked by name
+		if apiVersion == admin.APIVersion {
+			if def := api.GetMaintenanceTaskDefinition(doc.OpenShiftCluster.Properties.MaintenanceTask); def != nil {
+				err = validateMaintenanceTaskPreconditions(doc, def)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		setUpdateProvisioningState(doc, apiVersion, f.now())
 	}
 
 	// SetDefaults will set defaults on cluster document
@@ -231,6 +255,8 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 		return nil, err
 	}
 
+	setETagHeader(header, doc.ETag)
+
 	// We remove sensitive data from document to prevent sensitive data being
 	// returned to the customer.
 	doc.OpenShiftCluster.Properties.ClusterProfile.PullSecret = ""
@@ -301,10 +327,10 @@ func (f *frontend) ValidateNewCluster(ctx context.Context, subscription *api.Sub
 }
 
 // setUpdateProvisioningState Sets either the admin update or update provisioning state
-func setUpdateProvisioningState(doc *api.OpenShiftClusterDocument, apiVersion string) {
+func setUpdateProvisioningState(doc *api.OpenShiftClusterDocument, apiVersion string, now time.Time) {
 	switch apiVersion {
 	case admin.APIVersion:
-		adminUpdateProvisioningState(doc)
+		adminUpdateProvisioningState(doc, now)
 	default:
 		updateProvisioningState(doc)
 	}
@@ -318,26 +344,40 @@ func updateProvisioningState(doc *api.OpenShiftClusterDocument) {
 }
 
 // Admin update (ex: cluster maintenance)
-func adminUpdateProvisioningState(doc *api.OpenShiftClusterDocument) {
-	if doc.OpenShiftCluster.Properties.MaintenanceTask.IsMaintenanceOngoingTask() {
-		doc.OpenShiftCluster.Properties.LastProvisioningState = doc.OpenShiftCluster.Properties.ProvisioningState
-		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
-		doc.OpenShiftCluster.Properties.LastAdminUpdateError = ""
-		doc.Dequeues = 0
+func adminUpdateProvisioningState(doc *api.OpenShiftClusterDocument, now time.Time) {
+	switch {
+	case doc.OpenShiftCluster.Properties.MaintenanceTask.IsMaintenanceOngoingTask():
+		// Planned maintenance only starts inside the customer's maintenance
+		// windows.  Outside them the cluster is left as it is, so that the
+		// customer can still operate on it, and the master monitor queues the
+		// update when a window next opens.
+		if adminupdate.MustDefer(doc, now) {
+			task := doc.OpenShiftCluster.Properties.MaintenanceTask
+			if task == "" {
+				task = api.MaintenanceTaskEverything
+			}
+
+			doc.OpenShiftCluster.Properties.DeferredMaintenanceTask = task
+			doc.OpenShiftCluster.Properties.MaintenanceTask = ""
+			return
+		}
 
 		// Set the maintenance to ongoing so we emit the appropriate signal to customerss
-		if doc.OpenShiftCluster.Properties.MaintenanceState == api.MaintenanceStatePending {
-			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStatePlanned
-		} else {
-			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateUnplanned
-		}
-	} else {
+		adminupdate.QueueMaintenance(doc, doc.OpenShiftCluster.Properties.MaintenanceTask)
+
+	case api.GetMaintenanceTaskDefinition(doc.OpenShiftCluster.Properties.MaintenanceTask) != nil:
+		// Like the tasks queued by the monitor, tasks which don't impact the
+		// customer run straight away and leave the maintenance signal alone
+		adminupdate.Queue(doc, doc.OpenShiftCluster.Properties.MaintenanceTask)
+
+	default:
 		// No default needed since we're using an enum
 		switch doc.OpenShiftCluster.Properties.MaintenanceTask {
 		case api.MaintenanceTaskPending:
 			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStatePending
 		case api.MaintenanceTaskNone:
 			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateNone
+			doc.OpenShiftCluster.Properties.DeferredMaintenanceTask = ""
 		case api.MaintenanceTaskCustomerActionNeeded:
 			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateCustomerActionNeeded
 		}
@@ -47,6 +47,19 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 
 	mockSubID := "00000000-0000-0000-0000-000000000000"
 
+	// a Wednesday
+	mockCurrentTime := time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC)
+	thursdayMaintenanceWindowProfile := &api.MaintenanceWindowProfile{
+		TimeZone: "UTC",
+		Windows: []api.MaintenanceWindow{
+			{
+				DayOfWeek:     "Thursday",
+				StartTime:     "02:00",
+				DurationHours: 4,
+			},
+		},
+	}
+
 	type test struct {
 		name                   string
 		request                func(*admin.OpenShiftCluster)
@@ -180,6 +193,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 						Tags: map[string]string{"tag": "will-be-kept"},
 						Properties: api.OpenShiftClusterProperties{
 							ClusterProfile: api.ClusterProfile{
+								Version:              "4.10.0",
 								FipsValidatedModules: api.FipsValidatedModulesDisabled,
 							},
 							ProvisioningState: api.ProvisioningStateSucceeded,
@@ -209,6 +223,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 							LastProvisioningState: api.ProvisioningStateSucceeded,
 							MaintenanceTask:       api.MaintenanceTaskOperator,
 							ClusterProfile: api.ClusterProfile{
+								Version:              "4.10.0",
 								FipsValidatedModules: api.FipsValidatedModulesDisabled,
 							},
 							NetworkProfile: api.NetworkProfile{
@@ -248,6 +263,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 						},
 					},
 					ClusterProfile: admin.ClusterProfile{
+						Version:              "4.10.0",
 						FipsValidatedModules: admin.FipsValidatedModulesDisabled,
 					},
 					MasterProfile: admin.MasterProfile{
@@ -375,6 +391,9 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
 						Tags: map[string]string{"tag": "will-be-kept"},
 						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Version: "4.10.0",
+							},
 							ProvisioningState: api.ProvisioningStateSucceeded,
 						},
 					},
@@ -400,6 +419,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 							ProvisioningState:     api.ProvisioningStateAdminUpdating,
 							LastProvisioningState: api.ProvisioningStateSucceeded,
 							ClusterProfile: api.ClusterProfile{
+								Version:              "4.10.0",
 								FipsValidatedModules: api.FipsValidatedModulesDisabled,
 							},
 							MaintenanceTask: api.MaintenanceTaskOperator,
@@ -431,6 +451,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 					ProvisioningState:     admin.ProvisioningStateAdminUpdating,
 					LastProvisioningState: admin.ProvisioningStateSucceeded,
 					ClusterProfile: admin.ClusterProfile{
+						Version:              "4.10.0",
 						FipsValidatedModules: admin.FipsValidatedModulesDisabled,
 					},
 					MaintenanceTask: admin.MaintenanceTaskOperator,
@@ -470,6 +491,9 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
 						Tags: map[string]string{"tag": "will-be-kept"},
 						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Version: "4.10.0",
+							},
 							ProvisioningState: api.ProvisioningStateSucceeded,
 							MaintenanceTask:   api.MaintenanceTaskEverything,
 							OperatorFlags:     api.OperatorFlags{"testFlag": "true"},
@@ -498,6 +522,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 							ProvisioningState:     api.ProvisioningStateAdminUpdating,
 							LastProvisioningState: api.ProvisioningStateSucceeded,
 							ClusterProfile: api.ClusterProfile{
+								Version:              "4.10.0",
 								FipsValidatedModules: api.FipsValidatedModulesDisabled,
 							},
 							MaintenanceTask: api.MaintenanceTaskOperator,
@@ -529,6 +554,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 					ProvisioningState:     admin.ProvisioningStateAdminUpdating,
 					LastProvisioningState: admin.ProvisioningStateSucceeded,
 					ClusterProfile: admin.ClusterProfile{
+						Version:              "4.10.0",
 						FipsValidatedModules: admin.FipsValidatedModulesDisabled,
 					},
 					MaintenanceTask: admin.MaintenanceTaskOperator,
@@ -548,6 +574,147 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "patch with operator update request is rejected on clusters the operator does not support",
+			request: func(oc *admin.OpenShiftCluster) {
+				oc.Properties.MaintenanceTask = admin.MaintenanceTaskOperator
+			},
+			isPatch: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Version: "4.6.62",
+							},
+							ProvisioningState: api.ProvisioningStateSucceeded,
+						},
+					},
+				})
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Version: "4.6.62",
+							},
+							ProvisioningState: api.ProvisioningStateSucceeded,
+						},
+					},
+				})
+			},
+			wantSystemDataEnriched: true,
+			wantEnriched:           []string{testdatabase.GetResourcePath(mockSubID, "resourceName")},
+			wantStatusCode:         http.StatusConflict,
+			wantError:              "409: RequestNotAllowed: : The maintenance task 'OperatorUpdate' requires a cluster version supported by the ARO operator, but the cluster version is '4.6.62'.",
+		},
+		{
+			name: "patch with a task which is not customer impacting leaves the maintenance signal alone",
+			request: func(oc *admin.OpenShiftCluster) {
+				oc.Properties.MaintenanceTask = admin.MaintenanceTaskFixMCSUserData
+			},
+			isPatch: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							OperatorFlags:     api.OperatorFlags{"testFlag": "true"},
+							MaintenanceState:  api.MaintenanceStatePending,
+						},
+					},
+				})
+			},
+			wantSystemDataEnriched: true,
+			wantEnriched:           []string{testdatabase.GetResourcePath(mockSubID, "resourceName")},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					AsyncOperation: &api.AsyncOperation{
+						InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+						ProvisioningState:        api.ProvisioningStateAdminUpdating,
+					},
+				})
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateAdminUpdating,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							ClusterProfile: api.ClusterProfile{
+								FipsValidatedModules: api.FipsValidatedModulesDisabled,
+							},
+							MaintenanceTask: api.MaintenanceTaskFixMCSUserData,
+							NetworkProfile: api.NetworkProfile{
+								OutboundType:     api.OutboundTypeLoadbalancer,
+								PreconfiguredNSG: api.PreconfiguredNSGDisabled,
+								LoadBalancerProfile: &api.LoadBalancerProfile{
+									ManagedOutboundIPs: &api.ManagedOutboundIPs{
+										Count: 1,
+									},
+								},
+							},
+							MasterProfile: api.MasterProfile{
+								EncryptionAtHost: api.EncryptionAtHostDisabled,
+							},
+							OperatorFlags:    api.OperatorFlags{"testFlag": "true"},
+							MaintenanceState: api.MaintenanceStatePending,
+						},
+					},
+				})
+			},
+			wantAsync:      true,
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftCluster{
+				ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+				Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+				Properties: admin.OpenShiftClusterProperties{
+					ProvisioningState:     admin.ProvisioningStateAdminUpdating,
+					LastProvisioningState: admin.ProvisioningStateSucceeded,
+					ClusterProfile: admin.ClusterProfile{
+						FipsValidatedModules: admin.FipsValidatedModulesDisabled,
+					},
+					MaintenanceTask: admin.MaintenanceTaskFixMCSUserData,
+					NetworkProfile: admin.NetworkProfile{
+						OutboundType: admin.OutboundTypeLoadbalancer,
+						LoadBalancerProfile: &admin.LoadBalancerProfile{
+							ManagedOutboundIPs: &admin.ManagedOutboundIPs{
+								Count: 1,
+							},
+						},
+					},
+					MasterProfile: admin.MasterProfile{
+						EncryptionAtHost: admin.EncryptionAtHostDisabled,
+					},
+					OperatorFlags:    admin.OperatorFlags{"testFlag": "true"},
+					MaintenanceState: admin.MaintenanceStatePending,
+				},
+			},
+		},
 		{
 			name: "patch a cluster with registry profile should fail",
 			request: func(oc *admin.OpenShiftCluster) {
@@ -921,6 +1088,117 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "patch a maintenance state pending cluster with planned maintenance outside its maintenance window",
+			request: func(oc *admin.OpenShiftCluster) {
+				oc.Properties.MaintenanceTask = admin.MaintenanceTaskEverything
+			},
+			isPatch: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Tags: map[string]string{"tag": "will-be-kept"},
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:        api.ProvisioningStateSucceeded,
+							LastProvisioningState:    api.ProvisioningStateSucceeded,
+							MaintenanceState:         api.MaintenanceStatePending,
+							MaintenanceWindowProfile: thursdayMaintenanceWindowProfile,
+						},
+					},
+				})
+			},
+			wantSystemDataEnriched: true,
+			wantEnriched:           []string{testdatabase.GetResourcePath(mockSubID, "resourceName")},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					AsyncOperation: &api.AsyncOperation{
+						InitialProvisioningState: api.ProvisioningStateSucceeded,
+						ProvisioningState:        api.ProvisioningStateSucceeded,
+					},
+				})
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Tags: map[string]string{"tag": "will-be-kept"},
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateSucceeded,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							ClusterProfile: api.ClusterProfile{
+								FipsValidatedModules: api.FipsValidatedModulesDisabled,
+							},
+							MaintenanceTask: "",
+							NetworkProfile: api.NetworkProfile{
+								OutboundType:     api.OutboundTypeLoadbalancer,
+								PreconfiguredNSG: api.PreconfiguredNSGDisabled,
+								LoadBalancerProfile: &api.LoadBalancerProfile{
+									ManagedOutboundIPs: &api.ManagedOutboundIPs{
+										Count: 1,
+									},
+								},
+							},
+							MasterProfile: api.MasterProfile{
+								EncryptionAtHost: api.EncryptionAtHostDisabled,
+							},
+							OperatorFlags:            operator.DefaultOperatorFlags(),
+							MaintenanceState:         api.MaintenanceStatePending,
+							MaintenanceWindowProfile: thursdayMaintenanceWindowProfile,
+							DeferredMaintenanceTask:  api.MaintenanceTaskEverything,
+						},
+					},
+				})
+			},
+			wantAsync:      true,
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftCluster{
+				ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+				Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+				Tags: map[string]string{"tag": "will-be-kept"},
+				Properties: admin.OpenShiftClusterProperties{
+					ProvisioningState:     admin.ProvisioningStateSucceeded,
+					LastProvisioningState: admin.ProvisioningStateSucceeded,
+					ClusterProfile: admin.ClusterProfile{
+						FipsValidatedModules: admin.FipsValidatedModulesDisabled,
+					},
+					MaintenanceTask: "",
+					NetworkProfile: admin.NetworkProfile{
+						OutboundType: admin.OutboundTypeLoadbalancer,
+						LoadBalancerProfile: &admin.LoadBalancerProfile{
+							ManagedOutboundIPs: &admin.ManagedOutboundIPs{
+								Count: 1,
+							},
+						},
+					},
+					MasterProfile: admin.MasterProfile{
+						EncryptionAtHost: admin.EncryptionAtHostDisabled,
+					},
+					OperatorFlags:    admin.OperatorFlags(operator.DefaultOperatorFlags()),
+					MaintenanceState: admin.MaintenanceStatePending,
+					MaintenanceWindowProfile: &admin.MaintenanceWindowProfile{
+						TimeZone: "UTC",
+						Windows: []admin.MaintenanceWindow{
+							{
+								DayOfWeek:     "Thursday",
+								StartTime:     "02:00",
+								DurationHours: 4,
+							},
+						},
+					},
+					DeferredMaintenanceTask: admin.MaintenanceTaskEverything,
+				},
+			},
+		},
 		{
 			name: "patch a planned maintenance ongoing cluster with maintenance none request",
 			request: func(oc *admin.OpenShiftCluster) {
@@ -1732,6 +2010,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 				t.Fatal(err)
 			}
 			f.bucketAllocator = bucket.Fixed(1)
+			f.now = func() time.Time { return mockCurrentTime }
 
 			var systemDataClusterDocEnricherCalled bool
 			f.systemDataClusterDocEnricher = func(doc *api.OpenShiftClusterDocument, systemData *api.SystemData) {
@@ -1828,12 +2107,14 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 		quotaValidatorError     error
 		skuValidatorError       error
 		providersValidatorError error
+		requestHeader           http.Header
 		wantEnriched            []string
 		wantSystemDataEnriched  bool
 		wantDocuments           func(*testdatabase.Checker)
 		wantStatusCode          int
 		wantResponse            *v20200430.OpenShiftCluster
 		wantAsync               bool
+		wantETag                string
 		wantError               string
 	}
 
@@ -1904,6 +2185,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 			wantEnriched:   []string{},
 			wantAsync:      true,
 			wantStatusCode: http.StatusCreated,
+			wantETag:       `"0"`,
 			wantResponse: &v20200430.OpenShiftCluster{
 				ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
 				Name: "resourceName",
@@ -2675,6 +2957,73 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 			wantStatusCode: http.StatusBadRequest,
 			wantError:      "400: RequestNotAllowed: : Request is not allowed on cluster whose deletion failed. Delete the cluster.",
 		},
+		{
+			name: "update a cluster with a stale If-Match header",
+			request: func(oc *v20200430.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "changed"
+			},
+			isPatch: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+						},
+					},
+				})
+			},
+			requestHeader: http.Header{
+				"If-Match": []string{`"1"`},
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantError:      "412: PreconditionFailed: : The condition specified in the If-Match header was not met.",
+		},
+		{
+			name: "create a cluster with If-None-Match when the cluster exists",
+			request: func(oc *v20200430.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Version = defaultVersion
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+						},
+					},
+				})
+			},
+			requestHeader: http.Header{
+				"If-None-Match": []string{"*"},
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantError:      "412: PreconditionFailed: : The condition specified in the If-None-Match header was not met.",
+		},
 		{
 			name: "creating cluster failing when provided cluster resource group already contains a cluster",
 			request: func(oc *v20200430.OpenShiftCluster) {
@@ -2831,15 +3180,24 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 				method = http.MethodPatch
 			}
 
+			header := http.Header{
+				"Content-Type": []string{"application/json"},
+			}
+			for k, v := range tt.requestHeader {
+				header[k] = v
+			}
+
 			resp, b, err := ti.request(method,
 				"https://server"+testdatabase.GetResourcePath(mockSubID, "resourceName")+"?api-version=2020-04-30",
-				http.Header{
-					"Content-Type": []string{"application/json"},
-				}, oc)
+				header, oc)
 			if err != nil {
 				t.Error(err)
 			}
 
+			if tt.wantETag != "" && resp.Header.Get("ETag") != tt.wantETag {
+				t.Error(resp.Header.Get("ETag"))
+			}
+
 			azureAsyncOperation := resp.Header.Get("Azure-AsyncOperation")
 			if tt.wantAsync {
 				if !strings.HasPrefix(azureAsyncOperation, fmt.Sprintf("https://localhost:8443/subscriptions/%s/providers/microsoft.redhatopenshift/locations/%s/operationsstatus/", mockSubID, ti.env.Location())) {
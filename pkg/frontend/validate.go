@@ -13,9 +13,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
 	"github.com/Azure/ARO-RP/pkg/api/validate"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	utilnamespace "github.com/Azure/ARO-RP/pkg/util/namespace"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
 func validateTerminalProvisioningState(state api.ProvisioningState) error {
@@ -200,6 +202,104 @@ func validateAdminMasterVMSize(vmSize string) error {
 	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided vmSize '%s' is unsupported for master.", vmSize)
 }
 
+func validateAdminRollingNodeOperation(operation *admin.RollingNodeOperation) error {
+	switch operation.Action {
+	case admin.RollingNodeOperationActionResize:
+		err := validateAdminMasterVMSize(string(operation.VMSize))
+		if err != nil {
+			return err
+		}
+	case admin.RollingNodeOperationActionRedeploy, admin.RollingNodeOperationActionReboot:
+		if operation.VMSize != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "vmSize", "The provided vmSize '%s' is only valid for the Resize action.", operation.VMSize)
+		}
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "action", "The provided action '%s' is invalid.", operation.Action)
+	}
+
+	if len(operation.Nodes) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "nodes", "At least one node must be provided.")
+	}
+
+	nodes := map[string]struct{}{}
+	for _, node := range operation.Nodes {
+		err := validateAdminVMName(node.Name)
+		if err != nil {
+			return err
+		}
+
+		if _, found := nodes[node.Name]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "nodes", "The node '%s' is provided more than once.", node.Name)
+		}
+		nodes[node.Name] = struct{}{}
+
+		// as with the resize action, only masters are resized; workers are
+		// resized via their MachineSets
+		if operation.Action == admin.RollingNodeOperationActionResize && !nodeIsMaster(node.Name) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "nodes", "The node '%s' cannot be resized. It is either not a master node or not adhering to the standard naming convention.", node.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateAdminNSGFlowLogs validates a request to enable NSG flow logs.  The
+// referenced resources must be in the cluster's subscription, as they are
+// accessed as the cluster service principal.
+func validateAdminNSGFlowLogs(flowLogs *admin.NSGFlowLogs, subscriptionID string) error {
+	if !flowLogs.Enabled {
+		return nil
+	}
+
+	for _, r := range []struct {
+		path         string
+		id           string
+		resourceType string
+	}{
+		{
+			path:         "networkWatcherID",
+			id:           flowLogs.NetworkWatcherID,
+			resourceType: "Microsoft.Network/networkWatchers",
+		},
+		{
+			path:         "storageAccountResourceId",
+			id:           flowLogs.StorageAccountResourceID,
+			resourceType: "Microsoft.Storage/storageAccounts",
+		},
+	} {
+		resource, err := azure.ParseResourceID(r.id)
+		if err != nil || !strings.EqualFold(resource.Provider+"/"+resource.ResourceType, r.resourceType) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, r.path, "The provided %s '%s' is invalid.", r.path, r.id)
+		}
+
+		if !strings.EqualFold(resource.SubscriptionID, subscriptionID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, r.path, "The provided %s '%s' must be in the same subscription as the cluster.", r.path, r.id)
+		}
+	}
+
+	switch flowLogs.Version {
+	case 0, 1, 2:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "version", "The provided version '%d' is invalid.", flowLogs.Version)
+	}
+
+	if flowLogs.RetentionDays < 0 || flowLogs.RetentionDays > 365 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "retentionDays", "The provided retentionDays '%d' is invalid.", flowLogs.RetentionDays)
+	}
+
+	if flowLogs.TrafficAnalyticsLogAnalyticsWorkspaceID != "" && !uuid.IsValid(flowLogs.TrafficAnalyticsLogAnalyticsWorkspaceID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "trafficAnalyticsLogAnalyticsWorkspaceId", "The provided trafficAnalyticsLogAnalyticsWorkspaceId '%s' is invalid.", flowLogs.TrafficAnalyticsLogAnalyticsWorkspaceID)
+	}
+
+	switch flowLogs.TrafficAnalyticsInterval {
+	case "", "10m", "60m":
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "trafficAnalyticsInterval", "The provided trafficAnalyticsInterval '%s' is invalid.", flowLogs.TrafficAnalyticsInterval)
+	}
+
+	return nil
+}
+
 // validateInstallVersion validates the install version set in the clusterprofile.version
 // TODO convert this into static validation instead of this receiver function in the validation for frontend.
 func (f *frontend) validateInstallVersion(ctx context.Context, oc *api.OpenShiftCluster) error {
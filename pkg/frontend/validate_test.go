@@ -10,6 +10,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/Azure/ARO-RP/pkg/api/admin"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
@@ -265,3 +266,93 @@ func TestValidateAdminMasterVMSize(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAdminNSGFlowLogs(t *testing.T) {
+	subscriptionID := "00000000-0000-0000-0000-000000000000"
+	networkWatcherID := "/subscriptions/" + subscriptionID + "/resourceGroups/NetworkWatcherRG/providers/Microsoft.Network/networkWatchers/NetworkWatcher_eastus"
+	storageAccountID := "/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/flowlogs"
+
+	for _, tt := range []struct {
+		test    string
+		modify  func(*admin.NSGFlowLogs)
+		wantErr string
+	}{
+		{
+			test: "valid",
+		},
+		{
+			test: "disabling is not validated",
+			modify: func(flowLogs *admin.NSGFlowLogs) {
+				flowLogs.Enabled = false
+				flowLogs.NetworkWatcherID = "invalid"
+			},
+		},
+		{
+			test: "invalid network watcher ID",
+			modify: func(flowLogs *admin.NSGFlowLogs) {
+				flowLogs.NetworkWatcherID = "invalid"
+			},
+			wantErr: "400: InvalidParameter: networkWatcherID: The provided networkWatcherID 'invalid' is invalid.",
+		},
+		{
+			test: "network watcher ID is a storage account",
+			modify: func(flowLogs *admin.NSGFlowLogs) {
+				flowLogs.NetworkWatcherID = storageAccountID
+			},
+			wantErr: "400: InvalidParameter: networkWatcherID: The provided networkWatcherID '" + storageAccountID + "' is invalid.",
+		},
+		{
+			test: "storage account in another subscription",
+			modify: func(flowLogs *admin.NSGFlowLogs) {
+				flowLogs.StorageAccountResourceID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/flowlogs"
+			},
+			wantErr: "400: InvalidParameter: storageAccountResourceId: The provided storageAccountResourceId '/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/flowlogs' must be in the same subscription as the cluster.",
+		},
+		{
+			test: "invalid version",
+			modify: func(flowLogs *admin.NSGFlowLogs) {
+				flowLogs.Version = 3
+			},
+			wantErr: "400: InvalidParameter: version: The provided version '3' is invalid.",
+		},
+		{
+			test: "invalid retention",
+			modify: func(flowLogs *admin.NSGFlowLogs) {
+				flowLogs.RetentionDays = 366
+			},
+			wantErr: "400: InvalidParameter: retentionDays: The provided retentionDays '366' is invalid.",
+		},
+		{
+			test: "invalid workspace ID",
+			modify: func(flowLogs *admin.NSGFlowLogs) {
+				flowLogs.TrafficAnalyticsLogAnalyticsWorkspaceID = "workspace"
+			},
+			wantErr: "400: InvalidParameter: trafficAnalyticsLogAnalyticsWorkspaceId: The provided trafficAnalyticsLogAnalyticsWorkspaceId 'workspace' is invalid.",
+		},
+		{
+			test: "invalid traffic analytics interval",
+			modify: func(flowLogs *admin.NSGFlowLogs) {
+				flowLogs.TrafficAnalyticsInterval = "30m"
+			},
+			wantErr: "400: InvalidParameter: trafficAnalyticsInterval: The provided trafficAnalyticsInterval '30m' is invalid.",
+		},
+	} {
+		t.Run(tt.test, func(t *testing.T) {
+			flowLogs := &admin.NSGFlowLogs{
+				Enabled:                                 true,
+				Version:                                 2,
+				NetworkWatcherID:                        networkWatcherID,
+				StorageAccountResourceID:                storageAccountID,
+				RetentionDays:                           90,
+				TrafficAnalyticsLogAnalyticsWorkspaceID: "11111111-1111-1111-1111-111111111111",
+				TrafficAnalyticsInterval:                "10m",
+			}
+			if tt.modify != nil {
+				tt.modify(flowLogs)
+			}
+
+			err := validateAdminNSGFlowLogs(flowLogs, subscriptionID)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
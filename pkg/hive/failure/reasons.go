@@ -3,36 +3,15 @@ package failure
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
-import "regexp"
+import "github.com/Azure/ARO-RP/pkg/util/installfailure"
 
-type InstallFailingReason struct {
-	Name          string
-	Reason        string
-	Message       string
-	SearchRegexes []*regexp.Regexp
-}
+type InstallFailingReason = installfailure.Rule
 
-var Reasons = []InstallFailingReason{
-	// Order within this array determines precedence. Earlier entries will take
-	// priority over later ones.
-	AzureRequestDisallowedByPolicy,
-	AzureInvalidTemplateDeployment,
-}
+// Reasons are the install failing reasons configured in Hive. They are
+// defined by the shared install failure rule set, whose order determines
+// precedence.
+var Reasons = installfailure.Default().Rules
 
-var AzureRequestDisallowedByPolicy = InstallFailingReason{
-	Name:    "AzureRequestDisallowedByPolicy",
-	Reason:  "AzureRequestDisallowedByPolicy",
-	Message: "Deployment failed due to RequestDisallowedByPolicy. Please see details for more information.",
-	SearchRegexes: []*regexp.Regexp{
-		regexp.MustCompile(`"code":\w?"InvalidTemplateDeployment".*"code":\w?"RequestDisallowedByPolicy"`),
-	},
-}
+var AzureRequestDisallowedByPolicy = *installfailure.Default().Lookup("AzureRequestDisallowedByPolicy")
 
-var AzureInvalidTemplateDeployment = InstallFailingReason{
-	Name:    "AzureInvalidTemplateDeployment",
-	Reason:  "AzureInvalidTemplateDeployment",
-	Message: "Deployment failed. Please see details for more information.",
-	SearchRegexes: []*regexp.Regexp{
-		regexp.MustCompile(`"code":\w?"InvalidTemplateDeployment"`),
-	},
-}
+var AzureInvalidTemplateDeployment = *installfailure.Default().Lookup("AzureInvalidTemplateDeployment")
@@ -20,6 +20,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/hive/failure"
 	"github.com/Azure/ARO-RP/pkg/util/cmp"
+	"github.com/Azure/ARO-RP/pkg/util/installfailure"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 	uuidfake "github.com/Azure/ARO-RP/pkg/util/uuid/fake"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
@@ -314,34 +315,37 @@ func TestIsClusterInstallationComplete(t *testing.T) {
 			level=info msg=deploying resources template
 			level=error msg=step [AuthorizationRefreshingAction [Action github.com/Azure/ARO-RP/pkg/installer.(*manager).deployResourceTemplate-fm]] encountered error: 400: DeploymentFailed: : Deployment failed. Details: : : {"code": "InvalidTemplateDeployment","message": "The template deployment failed with multiple errors. Please see details for more information.","target": null,"details": [{"code": "RequestDisallowedByPolicy","message": "Resource 'aro-test-aaaaa-bootstrap' was disallowed by policy.","target": "aro-test-aaaaa-bootstrap"},{"code": "RequestDisallowedByPolicy","message": "Resource 'aro-test-aaaaa-master-0' was disallowed by policy.","target": "aro-test-aaaaa-master-0"},{"code": "RequestDisallowedByPolicy","message": "Resource 'aro-test-aaaaa-master-1' was disallowed by policy.","target": "aro-test-aaaaa-master-1"},{"code": "RequestDisallowedByPolicy","message": "Resource 'aro-test-aaaaa-master-2' was disallowed by policy.","target": "aro-test-aaaaa-master-2"}]}
 			level=error msg=400: DeploymentFailed: : Deployment failed. Details: : : {"code": "InvalidTemplateDeployment","message": "The template deployment failed with multiple errors. Please see details for more information.","target": null,"details": [{"code": "RequestDisallowedByPolicy","message": "Resource 'aro-test-aaaaa-bootstrap' was disallowed by policy.","target": "aro-test-aaaaa-bootstrap"},{"code": "RequestDisallowedByPolicy","message": "Resource 'aro-test-aaaaa-master-0' was disallowed by policy.","target": "aro-test-aaaaa-master-0"},{"code": "RequestDisallowedByPolicy","message": "Resource 'aro-test-aaaaa-master-1' was disallowed by policy.","target": "aro-test-aaaaa-master-1"},{"code": "RequestDisallowedByPolicy","message": "Resource 'aro-test-aaaaa-master-2' was disallowed by policy.","target": "aro-test-aaaaa-master-2"}]}`),
-			wantErr: &api.CloudError{
-				StatusCode: http.StatusBadRequest,
-				CloudErrorBody: &api.CloudErrorBody{
-					Code:    api.CloudErrorCodeDeploymentFailed,
-					Message: "Deployment failed due to RequestDisallowedByPolicy. Please see details for more information.",
-					Details: []api.CloudErrorBody{
-						{
-							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
-							Message: "Resource 'aro-test-aaaaa-bootstrap' was disallowed by policy.",
-							Target:  "aro-test-aaaaa-bootstrap",
-						},
-						{
-							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
-							Message: "Resource 'aro-test-aaaaa-master-0' was disallowed by policy.",
-							Target:  "aro-test-aaaaa-master-0",
-						},
-						{
-							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
-							Message: "Resource 'aro-test-aaaaa-master-1' was disallowed by policy.",
-							Target:  "aro-test-aaaaa-master-1",
-						},
-						{
-							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
-							Message: "Resource 'aro-test-aaaaa-master-2' was disallowed by policy.",
-							Target:  "aro-test-aaaaa-master-2",
+			wantErr: &installfailure.Error{
+				CloudError: &api.CloudError{
+					StatusCode: http.StatusBadRequest,
+					CloudErrorBody: &api.CloudErrorBody{
+						Code:    api.CloudErrorCodeDeploymentFailed,
+						Message: "Deployment failed due to RequestDisallowedByPolicy. Please see details for more information.",
+						Details: []api.CloudErrorBody{
+							{
+								Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+								Message: "Resource 'aro-test-aaaaa-bootstrap' was disallowed by policy.",
+								Target:  "aro-test-aaaaa-bootstrap",
+							},
+							{
+								Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+								Message: "Resource 'aro-test-aaaaa-master-0' was disallowed by policy.",
+								Target:  "aro-test-aaaaa-master-0",
+							},
+							{
+								Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+								Message: "Resource 'aro-test-aaaaa-master-1' was disallowed by policy.",
+								Target:  "aro-test-aaaaa-master-1",
+							},
+							{
+								Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+								Message: "Resource 'aro-test-aaaaa-master-2' was disallowed by policy.",
+								Target:  "aro-test-aaaaa-master-2",
+							},
 						},
 					},
 				},
+				Reason: failure.AzureRequestDisallowedByPolicy.Reason,
 			},
 			wantResult: false,
 		},
@@ -374,13 +378,16 @@ func TestIsClusterInstallationComplete(t *testing.T) {
 			level=info msg=deploying resources template
 			level=error msg=step [AuthorizationRefreshingAction [Action github.com/Azure/ARO-RP/pkg/installer.(*manager).deployResourceTemplate-fm]] encountered error: 400: DeploymentFailed: : Deployment failed. Details: : : {"code": "InvalidTemplateDeployment","message": "The template deployment failed with multiple errors. Please see details for more information.","target": null,"details": []}
 			level=error msg=400: DeploymentFailed: : Deployment failed. Details: : : {"code": "InvalidTemplateDeployment","message": "The template deployment failed with multiple errors. Please see details for more information.","target": null,"details": []}`),
-			wantErr: &api.CloudError{
-				StatusCode: http.StatusBadRequest,
-				CloudErrorBody: &api.CloudErrorBody{
-					Code:    api.CloudErrorCodeDeploymentFailed,
-					Message: "Deployment failed. Please see details for more information.",
-					Details: []api.CloudErrorBody{},
+			wantErr: &installfailure.Error{
+				CloudError: &api.CloudError{
+					StatusCode: http.StatusBadRequest,
+					CloudErrorBody: &api.CloudErrorBody{
+						Code:    api.CloudErrorCodeDeploymentFailed,
+						Message: "Deployment failed. Please see details for more information.",
+						Details: []api.CloudErrorBody{},
+					},
 				},
+				Reason: failure.AzureInvalidTemplateDeployment.Reason,
 			},
 			wantResult: false,
 		},
@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/containers/image/v5/docker"
 	"github.com/containers/image/v5/pkg/blobinfocache/memory"
 	"github.com/containers/image/v5/types"
 	imagev1 "github.com/openshift/api/image/v1"
@@ -24,7 +23,7 @@ func getReleaseImageStream(ctx context.Context, reference string, auth *types.Do
 		DockerAuthConfig: auth,
 	}
 
-	ref, err := docker.ParseReference("//" + reference)
+	ref, err := parseReference(reference)
 	if err != nil {
 		return nil, err
 	}
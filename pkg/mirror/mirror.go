@@ -5,40 +5,75 @@ package mirror
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/containers/image/v5/copy"
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/oci/layout"
 	"github.com/containers/image/v5/signature"
 	"github.com/containers/image/v5/types"
+	"github.com/docker/distribution/registry/api/errcode"
+	v2 "github.com/docker/distribution/registry/api/v2"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 )
 
+// ociPrefix marks a reference as pointing to a local OCI layout directory
+// rather than to a registry, e.g. oci:/path/to/layout:image:tag
+const ociPrefix = "oci:"
+
+// copyBackoff determines how failed copies are retried
+var copyBackoff = wait.Backoff{
+	Steps:    6,
+	Duration: 10 * time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Cap:      2 * time.Minute,
+}
+
+func parseReference(reference string) (types.ImageReference, error) {
+	if strings.HasPrefix(reference, ociPrefix) {
+		return layout.ParseReference(strings.TrimPrefix(reference, ociPrefix))
+	}
+
+	return docker.ParseReference("//" + reference)
+}
+
 func Copy(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) error {
+	_, err := copyImage(ctx, dstreference, srcreference, dstauth, srcauth)
+	return err
+}
+
+// copyImage copies srcreference to dstreference and returns the digest of the
+// manifest written to the destination
+func copyImage(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) (string, error) {
 	policyctx, err := signature.NewPolicyContext(&signature.Policy{
 		Default: signature.PolicyRequirements{
 			signature.NewPRInsecureAcceptAnything(),
 		},
 	})
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	src, err := docker.ParseReference("//" + srcreference)
+	src, err := parseReference(srcreference)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	dst, err := docker.ParseReference("//" + dstreference)
+	dst, err := parseReference(dstreference)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = copy.Image(ctx, policyctx, dst, src, &copy.Options{
+	b, err := copy.Image(ctx, policyctx, dst, src, &copy.Options{
 		SourceCtx: &types.SystemContext{
 			DockerAuthConfig: srcauth,
 		},
@@ -51,8 +86,116 @@ func Copy(ctx context.Context, dstreference, srcreference string, dstauth, srcau
 		// they're all already there)
 		OptimizeDestinationImageAlreadyExists: true,
 	})
+	if err != nil {
+		return "", err
+	}
 
-	return err
+	d, err := manifest.Digest(b)
+	if err != nil {
+		return "", err
+	}
+
+	return d.String(), nil
+}
+
+// manifestDigest returns the digest of the manifest that reference points to
+func manifestDigest(ctx context.Context, reference string, auth *types.DockerAuthConfig) (string, error) {
+	ref, err := parseReference(reference)
+	if err != nil {
+		return "", err
+	}
+
+	src, err := ref.NewImageSource(ctx, &types.SystemContext{
+		DockerAuthConfig: auth,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	b, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	d, err := manifest.Digest(b)
+	if err != nil {
+		return "", err
+	}
+
+	return d.String(), nil
+}
+
+// isRetryable returns false for errors that will not go away by retrying the
+// copy, e.g. authentication failures or missing source images
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.As(err, &docker.ErrUnauthorizedForCredentials{}) {
+		return false
+	}
+
+	if errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+
+	var ec errcode.ErrorCoder
+	if errors.As(err, &ec) {
+		switch ec.ErrorCode() {
+		case errcode.ErrorCodeUnauthorized,
+			errcode.ErrorCodeDenied,
+			v2.ErrorCodeNameInvalid,
+			v2.ErrorCodeNameUnknown,
+			v2.ErrorCodeTagInvalid,
+			v2.ErrorCodeManifestUnknown,
+			v2.ErrorCodeManifestInvalid:
+			return false
+		}
+	}
+
+	var ecs errcode.Errors
+	if errors.As(err, &ecs) {
+		for _, e := range ecs {
+			if !isRetryable(e) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// trimHost returns reference without its registry host
+// Ex:
+// reference azurecr.io/some/path/to/image:tag
+// returns:  some/path/to/image:tag
+func trimHost(reference string) string {
+	return reference[strings.IndexByte(reference, '/')+1:]
+}
+
+// source returns the reference to copy an image of the release from. If the
+// release is read from an OCI layout, images are expected in the same layout,
+// named by their reference without the registry host.
+func source(srcrelease, reference string) string {
+	if !strings.HasPrefix(srcrelease, ociPrefix) {
+		return reference
+	}
+
+	dir := strings.SplitN(strings.TrimPrefix(srcrelease, ociPrefix), ":", 2)[0]
+	return ociPrefix + dir + ":" + trimHost(reference)
+}
+
+// destination returns the reference to copy an image to. For OCI layout
+// destinations, images are named by their reference without the registry
+// host.
+func destination(dstrepo, reference string) string {
+	if strings.HasPrefix(dstrepo, ociPrefix) {
+		return dstrepo + ":" + trimHost(reference)
+	}
+
+	return Dest(dstrepo, reference)
 }
 
 // This will return repo and image name, preserving path
@@ -73,11 +216,27 @@ func DestLastIndex(repo, reference string) string {
 	return repo + reference[strings.LastIndex(reference, "/"):]
 }
 
-func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig) error {
+// releaseReference returns the reference the release image is mirrored
+// under. Images in OCI layouts are named without their registry host, so a
+// placeholder host is used.
+func releaseReference(srcrelease string) string {
+	if !strings.HasPrefix(srcrelease, ociPrefix) {
+		return srcrelease
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(srcrelease, ociPrefix), ":", 2)
+	if len(parts) < 2 || parts[1] == "" {
+		return "localhost/release"
+	}
+
+	return "localhost/" + parts[1]
+}
+
+func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig) (*Report, error) {
 	log.Printf("reading imagestream from %s", srcrelease)
 	is, err := getReleaseImageStream(ctx, srcrelease, srcauth)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	type work struct {
@@ -90,26 +249,15 @@ func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string,
 
 	ch := make(chan *work)
 	wg := &sync.WaitGroup{}
-	var errorOccurred atomic.Value
-	errorOccurred.Store(false)
+	report := &Report{
+		Release: srcrelease,
+	}
 
 	for i := 0; i < 10; i++ {
 		wg.Add(1)
 		go func() {
 			for w := range ch {
-				log.Printf("mirroring %s", w.tag)
-				var err error
-				for retry := 0; retry < 6; retry++ {
-					err = Copy(ctx, w.dstreference, w.srcreference, w.dstauth, w.srcauth)
-					if err == nil {
-						break
-					}
-					time.Sleep(10 * time.Second)
-				}
-				if err != nil {
-					log.Errorf("%s: %s\n", w.tag, err)
-					errorOccurred.Store(true)
-				}
+				report.add(mirrorImage(ctx, log, w.tag, w.dstreference, w.srcreference, w.dstauth, w.srcauth))
 			}
 			wg.Done()
 		}()
@@ -119,7 +267,7 @@ func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string,
 
 	ch <- &work{
 		tag:          "release",
-		dstreference: Dest(dstrepo, srcrelease),
+		dstreference: destination(dstrepo, releaseReference(srcrelease)),
 		srcreference: srcrelease,
 		dstauth:      dstauth,
 		srcauth:      srcauth,
@@ -128,8 +276,8 @@ func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string,
 	for _, tag := range is.Spec.Tags {
 		ch <- &work{
 			tag:          tag.Name,
-			dstreference: Dest(dstrepo, tag.From.Name),
-			srcreference: tag.From.Name,
+			dstreference: destination(dstrepo, tag.From.Name),
+			srcreference: source(srcrelease, tag.From.Name),
 			dstauth:      dstauth,
 			srcauth:      srcauth,
 		}
@@ -138,9 +286,57 @@ func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string,
 	close(ch)
 	wg.Wait()
 
-	if errorOccurred.Load().(bool) {
-		return fmt.Errorf("an error occurred")
+	report.sort()
+
+	log.Printf("mirrored release %s: %d copied, %d skipped, %d failed", srcrelease, report.Copied, report.Skipped, report.Failed)
+
+	if report.Failed > 0 {
+		return report, fmt.Errorf("failed to mirror %d image(s)", report.Failed)
+	}
+
+	return report, nil
+}
+
+// mirrorImage copies a single image unless the destination already has a
+// manifest with the same digest, retrying retryable errors with backoff
+func mirrorImage(ctx context.Context, log *logrus.Entry, tag, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) ReportEntry {
+	entry := ReportEntry{
+		Tag:         tag,
+		Source:      srcreference,
+		Destination: dstreference,
+	}
+
+	srcDigest, err := manifestDigest(ctx, srcreference, srcauth)
+	if err == nil {
+		dstDigest, err := manifestDigest(ctx, dstreference, dstauth)
+		if err == nil && dstDigest == srcDigest {
+			log.Debugf("skipping %s: %s already present at destination", tag, srcDigest)
+			entry.Status = ReportStatusSkipped
+			entry.Digest = srcDigest
+			return entry
+		}
+	}
+
+	log.Printf("mirroring %s", tag)
+	err = retry.OnError(copyBackoff, func(err error) bool {
+		retryable := isRetryable(err)
+		if retryable {
+			log.Warnf("%s: retrying after error: %s", tag, err)
+		}
+		return retryable
+	}, func() error {
+		entry.Attempts++
+		var err error
+		entry.Digest, err = copyImage(ctx, dstreference, srcreference, dstauth, srcauth)
+		return err
+	})
+	if err != nil {
+		log.Errorf("%s: %s\n", tag, err)
+		entry.Status = ReportStatusFailed
+		entry.Error = err.Error()
+		return entry
 	}
 
-	return nil
+	entry.Status = ReportStatusCopied
+	return entry
 }
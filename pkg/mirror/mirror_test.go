@@ -4,8 +4,25 @@ package mirror
 // Licensed under the Apache License 2.0.
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/docker/distribution/registry/api/errcode"
+	v2 "github.com/docker/distribution/registry/api/v2"
+	"github.com/opencontainers/go-digest"
+	imgspec "github.com/opencontainers/image-spec/specs-go"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 func TestDestLastIndex(t *testing.T) {
@@ -71,3 +88,228 @@ func TestDest(t *testing.T) {
 		})
 	}
 }
+
+func TestDestination(t *testing.T) {
+	tests := []struct {
+		name                string
+		repo                string
+		reference           string
+		expectedDestination string
+	}{
+		{
+			name:                "registry destination keeps path",
+			repo:                "destrepo.io",
+			reference:           "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:abcd",
+			expectedDestination: "destrepo.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:abcd",
+		},
+		{
+			name:                "oci destination names image without host",
+			repo:                "oci:/tmp/layout",
+			reference:           "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:abcd",
+			expectedDestination: "oci:/tmp/layout:openshift-release-dev/ocp-v4.0-art-dev@sha256:abcd",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := destination(test.repo, test.reference)
+
+			if got != test.expectedDestination {
+				t.Error(fmt.Errorf("got != want: %s != %s", got, test.expectedDestination))
+			}
+		})
+	}
+}
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		release        string
+		reference      string
+		expectedSource string
+	}{
+		{
+			name:           "registry release uses reference",
+			release:        "quay.io/openshift-release-dev/ocp-release:4.14.1-x86_64",
+			reference:      "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:abcd",
+			expectedSource: "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:abcd",
+		},
+		{
+			name:           "oci release uses same layout",
+			release:        "oci:/tmp/layout:openshift-release-dev/ocp-release:4.14.1-x86_64",
+			reference:      "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:abcd",
+			expectedSource: "oci:/tmp/layout:openshift-release-dev/ocp-v4.0-art-dev@sha256:abcd",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := source(test.release, test.reference)
+
+			if got != test.expectedSource {
+				t.Error(fmt.Errorf("got != want: %s != %s", got, test.expectedSource))
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "generic error",
+			err:  errors.New("connection reset by peer"),
+			want: true,
+		},
+		{
+			name: "unauthorized",
+			err:  fmt.Errorf("copying: %w", docker.ErrUnauthorizedForCredentials{Err: errors.New("401")}),
+		},
+		{
+			name: "manifest unknown",
+			err:  v2.ErrorCodeManifestUnknown.WithMessage("manifest unknown"),
+		},
+		{
+			name: "denied in list",
+			err:  errcode.Errors{errcode.ErrorCodeDenied.WithMessage("denied")},
+		},
+		{
+			name: "too many requests",
+			err:  errcode.ErrorCodeTooManyRequests.WithMessage("slow down"),
+			want: true,
+		},
+		{
+			name: "context cancelled",
+			err:  context.Canceled,
+		},
+		{
+			name: "missing oci layout",
+			err:  fmt.Errorf("opening: %w", os.ErrNotExist),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := isRetryable(test.err)
+
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMirrorImageOCILayout(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+
+	oldCopyBackoff := copyBackoff
+	t.Cleanup(func() { copyBackoff = oldCopyBackoff })
+	copyBackoff = wait.Backoff{Steps: 1}
+
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	writeOCILayout(t, srcDir, "openshift-release-dev/ocp-v4.0-art-dev:test")
+
+	src := "oci:" + srcDir + ":openshift-release-dev/ocp-v4.0-art-dev:test"
+	dst := destination("oci:"+dstDir, "quay.io/openshift-release-dev/ocp-v4.0-art-dev:test")
+
+	entry := mirrorImage(ctx, log, "test", dst, src, nil, nil)
+	if entry.Status != ReportStatusCopied {
+		t.Fatalf("got status %s (%s), want %s", entry.Status, entry.Error, ReportStatusCopied)
+	}
+	if entry.Attempts != 1 {
+		t.Errorf("got %d attempts, want 1", entry.Attempts)
+	}
+
+	entry = mirrorImage(ctx, log, "test", dst, src, nil, nil)
+	if entry.Status != ReportStatusSkipped {
+		t.Fatalf("got status %s (%s), want %s", entry.Status, entry.Error, ReportStatusSkipped)
+	}
+
+	entry = mirrorImage(ctx, log, "missing", dst, "oci:"+srcDir+":missing", nil, nil)
+	if entry.Status != ReportStatusFailed {
+		t.Fatalf("got status %s, want %s", entry.Status, ReportStatusFailed)
+	}
+}
+
+// writeOCILayout writes a minimal single image OCI layout to dir
+func writeOCILayout(t *testing.T, dir, refName string) {
+	writeBlob := func(b []byte) imgspecv1.Descriptor {
+		d := digest.FromBytes(b)
+
+		err := os.MkdirAll(filepath.Join(dir, "blobs", d.Algorithm().String()), 0777)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(filepath.Join(dir, "blobs", d.Algorithm().String(), d.Encoded()), b, 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return imgspecv1.Descriptor{
+			Digest: d,
+			Size:   int64(len(b)),
+		}
+	}
+
+	marshal := func(v interface{}) []byte {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	config := writeBlob(marshal(imgspecv1.Image{
+		Architecture: "amd64",
+		OS:           "linux",
+		RootFS: imgspecv1.RootFS{
+			Type: "layers",
+		},
+	}))
+	config.MediaType = imgspecv1.MediaTypeImageConfig
+
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	err := tar.NewWriter(gw).Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = gw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layer := writeBlob(buf.Bytes())
+	layer.MediaType = imgspecv1.MediaTypeImageLayerGzip
+
+	m := writeBlob(marshal(imgspecv1.Manifest{
+		Versioned: imgspec.Versioned{SchemaVersion: 2},
+		MediaType: imgspecv1.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []imgspecv1.Descriptor{layer},
+	}))
+	m.MediaType = imgspecv1.MediaTypeImageManifest
+	m.Annotations = map[string]string{
+		imgspecv1.AnnotationRefName: refName,
+	}
+
+	err = os.WriteFile(filepath.Join(dir, imgspecv1.ImageLayoutFile), marshal(imgspecv1.ImageLayout{Version: imgspecv1.ImageLayoutVersion}), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "index.json"), marshal(imgspecv1.Index{
+		Versioned: imgspec.Versioned{SchemaVersion: 2},
+		Manifests: []imgspecv1.Descriptor{m},
+	}), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
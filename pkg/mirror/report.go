@@ -0,0 +1,64 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sort"
+	"sync"
+)
+
+type ReportStatus string
+
+const (
+	ReportStatusCopied  ReportStatus = "Copied"
+	ReportStatusSkipped ReportStatus = "Skipped"
+	ReportStatusFailed  ReportStatus = "Failed"
+)
+
+// Report records the outcome of mirroring each image of a release
+type Report struct {
+	mu sync.Mutex
+
+	Release string        `json:"release,omitempty"`
+	Copied  int           `json:"copied"`
+	Skipped int           `json:"skipped"`
+	Failed  int           `json:"failed"`
+	Entries []ReportEntry `json:"entries,omitempty"`
+}
+
+// ReportEntry records the outcome of mirroring a single image
+type ReportEntry struct {
+	Tag         string       `json:"tag,omitempty"`
+	Source      string       `json:"source,omitempty"`
+	Destination string       `json:"destination,omitempty"`
+	Status      ReportStatus `json:"status,omitempty"`
+	Digest      string       `json:"digest,omitempty"`
+	Attempts    int          `json:"attempts,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+func (r *Report) add(entry ReportEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch entry.Status {
+	case ReportStatusCopied:
+		r.Copied++
+	case ReportStatusSkipped:
+		r.Skipped++
+	case ReportStatusFailed:
+		r.Failed++
+	}
+
+	r.Entries = append(r.Entries, entry)
+}
+
+func (r *Report) sort() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sort.Slice(r.Entries, func(i, j int) bool {
+		return r.Entries[i].Tag < r.Entries[j].Tag
+	})
+}
@@ -0,0 +1,127 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/adminupdate"
+)
+
+const (
+	acrTokenRotationSweepInterval = time.Hour
+
+	// maxACRTokenRotationsPerSweep bounds the number of admin updates queued
+	// by a single sweep, so that clusters whose password creation time was
+	// never recorded are not all rotated at once
+	maxACRTokenRotationsPerSweep = 20
+)
+
+var errACRTokenRotationNotDue = errors.New("ACR token rotation is not due")
+
+// queueACRTokenRotations queues an ACRTokenRotation admin update for each
+// cluster whose ACR token password is due to be rotated.  It runs every hour
+// on the master monitor only, and queries only the clusters whose password is
+// older than the rotation interval.
+func (mon *monitor) queueACRTokenRotations(ctx context.Context) error {
+	if !mon.isMaster || !mon.acrTokenRotationPolicy.Enabled() ||
+		time.Since(mon.lastACRTokenRotationSweep) < acrTokenRotationSweepInterval {
+		return nil
+	}
+	mon.lastACRTokenRotationSweep = time.Now()
+
+	now := mon.now()
+
+	docs, err := mon.dbOpenShiftClusters.ListWithACRTokenPasswordCreatedBefore(ctx, mon.acrRegistryProfileName, now.Add(-mon.acrTokenRotationPolicy.Interval))
+	if err != nil {
+		return err
+	}
+
+	var due, queued, overdue int
+	for _, doc := range docs.OpenShiftClusterDocuments {
+		if mon.acrTokenRotationPolicy.Overdue(mon.acrRegistryProfile(doc), now) {
+			overdue++
+		}
+
+		if !mon.acrTokenRotationDue(doc, now) {
+			continue
+		}
+
+		due++
+		if queued >= maxACRTokenRotationsPerSweep {
+			continue
+		}
+
+		var lastAdminUpdateError string
+		_, err = mon.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+			if !mon.acrTokenRotationDue(doc, now) {
+				return errACRTokenRotationNotDue
+			}
+
+			lastAdminUpdateError = doc.OpenShiftCluster.Properties.LastAdminUpdateError
+			adminupdate.Queue(doc, api.MaintenanceTaskACRTokenRotation)
+			return nil
+		})
+		if err == errACRTokenRotationNotDue {
+			continue
+		}
+		if err != nil {
+			mon.baseLog.Errorf("queueing ACR token rotation for %s: %s", doc.OpenShiftCluster.ID, err)
+			continue
+		}
+
+		if lastAdminUpdateError != "" {
+			mon.baseLog.Warnf("queued ACR token rotation for %s, replacing last admin update error: %s", doc.OpenShiftCluster.ID, lastAdminUpdateError)
+		} else {
+			mon.baseLog.Printf("queued ACR token rotation for %s", doc.OpenShiftCluster.ID)
+		}
+		queued++
+	}
+
+	mon.m.EmitGauge("monitor.acrtokenrotation.due", int64(due), nil)
+	mon.m.EmitGauge("monitor.acrtokenrotation.queued", int64(queued), nil)
+	mon.m.EmitGauge("monitor.acrtokenrotation.overdue", int64(overdue), nil)
+
+	return nil
+}
+
+// acrRegistryProfile returns the registry profile of the RP's container
+// registry of the cluster, or nil if it has none
+func (mon *monitor) acrRegistryProfile(doc *api.OpenShiftClusterDocument) *api.RegistryProfile {
+	for _, rp := range doc.OpenShiftCluster.Properties.RegistryProfiles {
+		if rp.Name == mon.acrRegistryProfileName {
+			return rp
+		}
+	}
+
+	return nil
+}
+
+// acrTokenRotationDue returns true if the cluster's ACR token password is due
+// to be rotated and an admin update can be queued.  Rotation is not customer
+// impacting, so unlike other scheduled admin updates it is not held back by a
+// failed admin update or a maintenance signal: a password which is never
+// rotated is a credential which is never renewed.
+func (mon *monitor) acrTokenRotationDue(doc *api.OpenShiftClusterDocument, now time.Time) bool {
+	props := &doc.OpenShiftCluster.Properties
+
+	switch {
+	case props.ProvisioningState == api.ProvisioningStateSucceeded:
+	case props.ProvisioningState == api.ProvisioningStateFailed &&
+		props.FailedProvisioningState == api.ProvisioningStateUpdating:
+	default:
+		// the cluster is busy, or failed to install or delete
+		return false
+	}
+
+	rp := mon.acrRegistryProfile(doc)
+	if rp == nil || rp.Password == "" {
+		return false
+	}
+
+	return mon.acrTokenRotationPolicy.Due(rp, now)
+}
@@ -0,0 +1,201 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/acrtoken"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestQueueACRTokenRotations(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-31 * 24 * time.Hour)
+	recent := now.Add(-24 * time.Hour)
+	overdue := now.Add(-61 * 24 * time.Hour)
+
+	clusterDoc := func(name string, provisioningState api.ProvisioningState, passwordCreationTime *time.Time) *api.OpenShiftClusterDocument {
+		resourceID := fmt.Sprintf("/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/%s", name)
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   resourceID,
+				Name: name,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: provisioningState,
+					RegistryProfiles: []*api.RegistryProfile{
+						{
+							Name:                 "arosvc.azurecr.io",
+							Username:             "token-" + name,
+							Password:             "password",
+							PasswordCreationTime: passwordCreationTime,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	queued := func(doc *api.OpenShiftClusterDocument) *api.OpenShiftClusterDocument {
+		doc.OpenShiftCluster.Properties.LastProvisioningState = doc.OpenShiftCluster.Properties.ProvisioningState
+		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+		doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskACRTokenRotation
+		return doc
+	}
+
+	for _, tt := range []struct {
+		name        string
+		notMaster   bool
+		fixture     func(*testdatabase.Fixture)
+		checker     func(*testdatabase.Checker)
+		wantDue     int64
+		wantQueued  int64
+		wantOverdue int64
+	}{
+		{
+			name: "due clusters are queued for rotation",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("old", api.ProvisioningStateSucceeded, &old),
+					clusterDoc("recent", api.ProvisioningStateSucceeded, &recent),
+					clusterDoc("unrecorded", api.ProvisioningStateSucceeded, nil),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					queued(clusterDoc("old", api.ProvisioningStateSucceeded, &old)),
+					clusterDoc("recent", api.ProvisioningStateSucceeded, &recent),
+					queued(clusterDoc("unrecorded", api.ProvisioningStateSucceeded, nil)),
+				)
+			},
+			wantDue:    2,
+			wantQueued: 2,
+		},
+		{
+			name: "failed admin updates and maintenance signals do not hold back rotation",
+			fixture: func(f *testdatabase.Fixture) {
+				adminUpdateFailed := clusterDoc("adminupdatefailed", api.ProvisioningStateSucceeded, &old)
+				adminUpdateFailed.OpenShiftCluster.Properties.LastAdminUpdateError = "error"
+				pending := clusterDoc("pending", api.ProvisioningStateSucceeded, &old)
+				pending.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStatePending
+				updateFailed := clusterDoc("updatefailed", api.ProvisioningStateFailed, &old)
+				updateFailed.OpenShiftCluster.Properties.FailedProvisioningState = api.ProvisioningStateUpdating
+
+				f.AddOpenShiftClusterDocuments(adminUpdateFailed, pending, updateFailed)
+			},
+			checker: func(c *testdatabase.Checker) {
+				adminUpdateFailed := queued(clusterDoc("adminupdatefailed", api.ProvisioningStateSucceeded, &old))
+				pending := queued(clusterDoc("pending", api.ProvisioningStateSucceeded, &old))
+				pending.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStatePending
+				updateFailed := queued(clusterDoc("updatefailed", api.ProvisioningStateFailed, &old))
+				updateFailed.OpenShiftCluster.Properties.FailedProvisioningState = api.ProvisioningStateUpdating
+
+				c.AddOpenShiftClusterDocuments(adminUpdateFailed, pending, updateFailed)
+			},
+			wantDue:    3,
+			wantQueued: 3,
+		},
+		{
+			name: "busy clusters and failed installs are skipped",
+			fixture: func(f *testdatabase.Fixture) {
+				updating := clusterDoc("updating", api.ProvisioningStateUpdating, &old)
+				installFailed := clusterDoc("installfailed", api.ProvisioningStateFailed, &old)
+				installFailed.OpenShiftCluster.Properties.FailedProvisioningState = api.ProvisioningStateCreating
+
+				f.AddOpenShiftClusterDocuments(installFailed, updating)
+			},
+			checker: func(c *testdatabase.Checker) {
+				updating := clusterDoc("updating", api.ProvisioningStateUpdating, &old)
+				installFailed := clusterDoc("installfailed", api.ProvisioningStateFailed, &old)
+				installFailed.OpenShiftCluster.Properties.FailedProvisioningState = api.ProvisioningStateCreating
+
+				c.AddOpenShiftClusterDocuments(installFailed, updating)
+			},
+		},
+		{
+			name: "clusters which missed a whole rotation interval are reported overdue",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("overdue", api.ProvisioningStateUpdating, &overdue),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					clusterDoc("overdue", api.ProvisioningStateUpdating, &overdue),
+				)
+			},
+			wantOverdue: 1,
+		},
+		{
+			name:      "only the master monitor queues",
+			notMaster: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("old", api.ProvisioningStateSucceeded, &old),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					clusterDoc("old", api.ProvisioningStateSucceeded, &old),
+				)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dbOpenShiftClusters, clientOpenShiftClusters := testdatabase.NewFakeOpenShiftClusters()
+
+			f := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+			tt.fixture(f)
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if !tt.notMaster {
+				m.EXPECT().EmitGauge("monitor.acrtokenrotation.due", tt.wantDue, nil)
+				m.EXPECT().EmitGauge("monitor.acrtokenrotation.queued", tt.wantQueued, nil)
+				m.EXPECT().EmitGauge("monitor.acrtokenrotation.overdue", tt.wantOverdue, nil)
+			}
+
+			mon := &monitor{
+				baseLog:             logrus.NewEntry(logrus.StandardLogger()),
+				dbOpenShiftClusters: dbOpenShiftClusters,
+				m:                   m,
+				isMaster:            !tt.notMaster,
+				now:                 func() time.Time { return now },
+
+				acrTokenRotationPolicy: &acrtoken.RotationPolicy{Interval: 30 * 24 * time.Hour},
+				acrRegistryProfileName: "arosvc.azurecr.io",
+			}
+
+			err = mon.queueACRTokenRotations(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := testdatabase.NewChecker()
+			tt.checker(c)
+
+			errs := c.CheckOpenShiftClusters(clientOpenShiftClusters)
+			for _, err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
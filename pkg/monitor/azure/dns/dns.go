@@ -0,0 +1,81 @@
+package dns
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	"github.com/Azure/ARO-RP/pkg/monitor/emitter"
+	"github.com/Azure/ARO-RP/pkg/monitor/monitoring"
+	utildns "github.com/Azure/ARO-RP/pkg/util/dns"
+)
+
+const (
+	MetricRecordDrift            = "monitor.dns.recorddrift"
+	MetricRecordDriftCheckFailed = "monitor.dns.recorddriftcheckfailed"
+	MetricDanglingRecords        = "monitor.dns.danglingrecords"
+)
+
+var _ monitoring.Monitor = (*DNSMonitor)(nil)
+
+// DNSMonitor compares the records of a cluster's managed domain with the
+// cluster document
+type DNSMonitor struct {
+	log     *logrus.Entry
+	emitter metrics.Emitter
+	oc      *api.OpenShiftCluster
+
+	wg *sync.WaitGroup
+
+	dns  utildns.Manager
+	dims map[string]string
+}
+
+func NewDNSMonitor(log *logrus.Entry, oc *api.OpenShiftCluster, subscriptionID string, dns utildns.Manager, emitter metrics.Emitter, wg *sync.WaitGroup) *DNSMonitor {
+	return &DNSMonitor{
+		log:     log,
+		emitter: emitter,
+		oc:      oc,
+
+		dns: dns,
+		wg:  wg,
+
+		dims: map[string]string{
+			dimension.ResourceID:     oc.ID,
+			dimension.SubscriptionID: subscriptionID,
+			dimension.Location:       oc.Location,
+		},
+	}
+}
+
+// Monitor emits a metric for each record of the cluster's managed domain which
+// has drifted from the cluster document.  Drift can be repaired with the
+// repairdns admin action.
+func (d *DNSMonitor) Monitor(ctx context.Context) []error {
+	defer d.wg.Done()
+
+	drift, err := d.dns.CheckDrift(ctx, d.oc)
+	if err != nil {
+		d.log.Errorf("error while checking DNS records. %s", err)
+		emitter.EmitGauge(d.emitter, MetricRecordDriftCheckFailed, int64(1), d.dims, nil)
+		return []error{err}
+	}
+
+	for _, r := range drift {
+		emitter.EmitGauge(d.emitter, MetricRecordDrift, int64(1), d.dims, map[string]string{
+			"record":   r.Name,
+			"expected": r.Expected,
+			"actual":   strings.Join(r.Actual, ","),
+		})
+	}
+
+	return nil
+}
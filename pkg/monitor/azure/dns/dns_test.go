@@ -0,0 +1,100 @@
+package dns
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	utildns "github.com/Azure/ARO-RP/pkg/util/dns"
+	mock_dns "github.com/Azure/ARO-RP/pkg/util/mocks/dns"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	subscriptionID := "0000-0000-0000-0000"
+	oc := &api.OpenShiftCluster{
+		ID:       "id",
+		Location: "eastus",
+	}
+	dims := map[string]string{
+		dimension.ResourceID:     oc.ID,
+		dimension.SubscriptionID: subscriptionID,
+		dimension.Location:       oc.Location,
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_dns.MockManager, *mock_metrics.MockEmitter)
+		wantErr string
+	}{
+		{
+			name: "no drift",
+			mocks: func(dns *mock_dns.MockManager, emitter *mock_metrics.MockEmitter) {
+				dns.EXPECT().CheckDrift(ctx, oc).Return(nil, nil)
+			},
+		},
+		{
+			name: "drift",
+			mocks: func(dns *mock_dns.MockManager, emitter *mock_metrics.MockEmitter) {
+				dns.EXPECT().CheckDrift(ctx, oc).Return([]utildns.RecordDrift{
+					{
+						Name:     "api.cluster",
+						Expected: "1.2.3.4",
+						Actual:   []string{"5.6.7.8", "9.9.9.9"},
+					},
+				}, nil)
+				emitter.EXPECT().EmitGauge(MetricRecordDrift, int64(1), map[string]string{
+					dimension.ResourceID:     oc.ID,
+					dimension.SubscriptionID: subscriptionID,
+					dimension.Location:       oc.Location,
+					"record":                 "api.cluster",
+					"expected":               "1.2.3.4",
+					"actual":                 "5.6.7.8,9.9.9.9",
+				})
+			},
+		},
+		{
+			name: "error",
+			mocks: func(dns *mock_dns.MockManager, emitter *mock_metrics.MockEmitter) {
+				dns.EXPECT().CheckDrift(ctx, oc).Return(nil, fmt.Errorf("random error"))
+				emitter.EXPECT().EmitGauge(MetricRecordDriftCheckFailed, int64(1), dims)
+			},
+			wantErr: "random error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dns := mock_dns.NewMockManager(controller)
+			emitter := mock_metrics.NewMockEmitter(controller)
+			tt.mocks(dns, emitter)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			d := NewDNSMonitor(logrus.NewEntry(logrus.New()), oc, subscriptionID, dns, emitter, &wg)
+
+			errs := d.Monitor(ctx)
+			wg.Wait()
+
+			var err error
+			if len(errs) > 0 {
+				err = errs[0]
+			}
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
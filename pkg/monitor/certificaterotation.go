@@ -0,0 +1,159 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/jongio/azidext/go/azidext"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/adminupdate"
+	keyvaultclient "github.com/Azure/ARO-RP/pkg/util/azureclient/keyvault"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+)
+
+const certificateRotationSweepInterval = time.Hour
+
+var errCertificateRotationNotDue = errors.New("certificate rotation is not due")
+
+// queueCertificateRotations queues a CustomerCertificatesRotation admin update
+// for each idle cluster which serves a customer supplied certificate that is
+// not the version referenced by its secret ID, e.g. because the customer added
+// a new version of the secret.  It runs hourly on the master monitor only.
+func (mon *monitor) queueCertificateRotations(ctx context.Context) error {
+	if !mon.isMaster || time.Since(mon.lastCertificateRotationSweep) < certificateRotationSweepInterval {
+		return nil
+	}
+	mon.lastCertificateRotationSweep = time.Now()
+
+	docs, err := mon.dbOpenShiftClusters.ListWithCustomerCertificates(ctx)
+	if err != nil {
+		return err
+	}
+
+	var due, queued int
+	for _, doc := range docs.OpenShiftClusterDocuments {
+		if !adminupdate.Idle(doc) {
+			continue
+		}
+
+		rotate, err := mon.certificateRotationDue(ctx, doc)
+		if err != nil {
+			mon.baseLog.Errorf("checking certificate secrets for %s: %s", doc.OpenShiftCluster.ID, err)
+			continue
+		}
+		if !rotate {
+			continue
+		}
+
+		due++
+
+		_, err = mon.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+			if !adminupdate.Idle(doc) {
+				return errCertificateRotationNotDue
+			}
+
+			adminupdate.Queue(doc, api.MaintenanceTaskCustomerCertificates)
+			return nil
+		})
+		if err == errCertificateRotationNotDue {
+			continue
+		}
+		if err != nil {
+			mon.baseLog.Errorf("queueing certificate rotation for %s: %s", doc.OpenShiftCluster.ID, err)
+			continue
+		}
+
+		mon.baseLog.Printf("queued certificate rotation for %s", doc.OpenShiftCluster.ID)
+		queued++
+	}
+
+	mon.m.EmitGauge("monitor.certificaterotation.due", int64(due), nil)
+	mon.m.EmitGauge("monitor.certificaterotation.queued", int64(queued), nil)
+
+	return nil
+}
+
+// certificateRotationDue returns true if the version of a customer supplied
+// certificate secret referenced by the cluster differs from the version which
+// the cluster serves
+func (mon *monitor) certificateRotationDue(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error) {
+	props := &doc.OpenShiftCluster.Properties
+
+	refs := []struct {
+		secretID      string
+		servedVersion string
+	}{
+		{
+			secretID:      props.APIServerProfile.CertificateSecretID,
+			servedVersion: props.APIServerCertificateSecretVersion,
+		},
+	}
+	for _, p := range props.IngressProfiles {
+		if p.Name == "default" {
+			refs = append(refs, struct {
+				secretID      string
+				servedVersion string
+			}{
+				secretID:      p.CertificateSecretID,
+				servedVersion: props.IngressCertificateSecretVersion,
+			})
+		}
+	}
+
+	r, err := azure.ParseResourceID(doc.OpenShiftCluster.ID)
+	if err != nil {
+		return false, err
+	}
+
+	mon.mu.RLock()
+	sub := mon.subs[r.SubscriptionID]
+	mon.mu.RUnlock()
+
+	if sub == nil {
+		return false, fmt.Errorf("subscription %s not found", r.SubscriptionID)
+	}
+
+	kv, err := mon.clusterSPKeyvault(sub.Subscription.Properties.TenantID, &props.ServicePrincipalProfile)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ref := range refs {
+		if ref.secretID == "" {
+			continue
+		}
+
+		version, err := keyvault.GetSecretVersionByID(ctx, kv, ref.secretID)
+		if err != nil {
+			return false, err
+		}
+
+		if version != ref.servedVersion {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// newClusterSPKeyvault returns a client which reads customer key vaults as the
+// cluster service principal
+func (mon *monitor) newClusterSPKeyvault(tenantID string, spp *api.ServicePrincipalProfile) (keyvaultclient.BaseClient, error) {
+	options := mon.env.Environment().ClientSecretCredentialOptions()
+	spTokenCredential, err := azidentity.NewClientSecretCredential(
+		tenantID, spp.ClientID, string(spp.ClientSecret), options)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := []string{mon.env.Environment().KeyVaultScope}
+	return keyvaultclient.New(azidext.NewTokenCredentialAdapter(spTokenCredential, scopes)), nil
+}
@@ -0,0 +1,173 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	keyvaultclient "github.com/Azure/ARO-RP/pkg/util/azureclient/keyvault"
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/keyvault"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestQueueCertificateRotations(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		subscriptionID = "00000000-0000-0000-0000-000000000000"
+		tenantID       = "11111111-1111-1111-1111-111111111111"
+		secretID       = "https://myvault.vault.azure.net/secrets/mycert"
+		oldVersion     = "00000000000000000000000000000000"
+		newVersion     = "11111111111111111111111111111111"
+	)
+
+	clusterDoc := func(name string, provisioningState api.ProvisioningState, apiServerSecretID, servedVersion string) *api.OpenShiftClusterDocument {
+		resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/%s", subscriptionID, name)
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   resourceID,
+				Name: name,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: provisioningState,
+					APIServerProfile: api.APIServerProfile{
+						CertificateSecretID: apiServerSecretID,
+					},
+					APIServerCertificateSecretVersion: servedVersion,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name       string
+		fixture    func(*testdatabase.Fixture)
+		mocks      func(*mock_keyvault.MockBaseClient)
+		checker    func(*testdatabase.Checker)
+		wantDue    int64
+		wantQueued int64
+	}{
+		{
+			name: "new secret version is queued",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc("rotate", api.ProvisioningStateSucceeded, secretID, oldVersion))
+			},
+			mocks: func(kv *mock_keyvault.MockBaseClient) {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{ID: to.StringPtr(secretID + "/" + newVersion)}, nil)
+			},
+			checker: func(c *testdatabase.Checker) {
+				doc := clusterDoc("rotate", api.ProvisioningStateAdminUpdating, secretID, oldVersion)
+				doc.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskCustomerCertificates
+				c.AddOpenShiftClusterDocuments(doc)
+			},
+			wantDue:    1,
+			wantQueued: 1,
+		},
+		{
+			name: "served secret version is left alone",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("latest", api.ProvisioningStateSucceeded, secretID, newVersion),
+					clusterDoc("pinned", api.ProvisioningStateSucceeded, secretID+"/"+oldVersion, oldVersion),
+				)
+			},
+			mocks: func(kv *mock_keyvault.MockBaseClient) {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{ID: to.StringPtr(secretID + "/" + newVersion)}, nil)
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					clusterDoc("latest", api.ProvisioningStateSucceeded, secretID, newVersion),
+					clusterDoc("pinned", api.ProvisioningStateSucceeded, secretID+"/"+oldVersion, oldVersion),
+				)
+			},
+		},
+		{
+			name: "busy clusters and clusters without customer certificates are not checked",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("busy", api.ProvisioningStateUpdating, secretID, oldVersion),
+					clusterDoc("managed", api.ProvisioningStateSucceeded, "", ""),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					clusterDoc("busy", api.ProvisioningStateUpdating, secretID, oldVersion),
+					clusterDoc("managed", api.ProvisioningStateSucceeded, "", ""),
+				)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dbOpenShiftClusters, clientOpenShiftClusters := testdatabase.NewFakeOpenShiftClusters()
+
+			f := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+			tt.fixture(f)
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			kv := mock_keyvault.NewMockBaseClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(kv)
+			}
+
+			m := mock_metrics.NewMockEmitter(controller)
+			m.EXPECT().EmitGauge("monitor.certificaterotation.due", tt.wantDue, nil)
+			m.EXPECT().EmitGauge("monitor.certificaterotation.queued", tt.wantQueued, nil)
+
+			mon := &monitor{
+				baseLog:             logrus.NewEntry(logrus.StandardLogger()),
+				dbOpenShiftClusters: dbOpenShiftClusters,
+				m:                   m,
+				isMaster:            true,
+				subs: map[string]*api.SubscriptionDocument{
+					subscriptionID: {
+						ID: subscriptionID,
+						Subscription: &api.Subscription{
+							Properties: &api.SubscriptionProperties{
+								TenantID: tenantID,
+							},
+						},
+					},
+				},
+				clusterSPKeyvault: func(gotTenantID string, spp *api.ServicePrincipalProfile) (keyvaultclient.BaseClient, error) {
+					if gotTenantID != tenantID {
+						t.Errorf("got tenant %s", gotTenantID)
+					}
+					return kv, nil
+				},
+			}
+
+			err = mon.queueCertificateRotations(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := testdatabase.NewChecker()
+			tt.checker(c)
+
+			errs := c.CheckOpenShiftClusters(clientOpenShiftClusters)
+			for _, err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
+)
+
+const acrPullSecretLaggingMetricsTopic = "cluster.pullsecret.acrtoken.lagging"
+
+// emitACRPullSecretLag emits the age in minutes of the current ACR token
+// password of each registry profile which the cluster's pull secret does not
+// yet contain.  The password in a lagging pull secret is regenerated, and so
+// invalidated, by the next rotation, which would stop the cluster pulling
+// images from ACR.
+func (mon *Monitor) emitACRPullSecretLag(ctx context.Context) error {
+	var secret *corev1.Secret
+
+	for _, rp := range mon.oc.Properties.RegistryProfiles {
+		// only passwords generated since rotation was introduced have their
+		// creation time recorded
+		if rp.PasswordCreationTime == nil || rp.Password == "" {
+			continue
+		}
+
+		if secret == nil {
+			var err error
+			secret, err = mon.cli.CoreV1().Secrets("openshift-config").Get(ctx, "pull-secret", metav1.GetOptions{})
+			if kerrors.IsNotFound(err) {
+				secret = &corev1.Secret{}
+			} else if err != nil {
+				return err
+			}
+		}
+
+		auths, err := pullsecret.UnmarshalSecretData(secret)
+		if err != nil {
+			return err
+		}
+
+		if auths[rp.Name] == base64.StdEncoding.EncodeToString([]byte(rp.Username+":"+string(rp.Password))) {
+			continue
+		}
+
+		mon.emitGauge(acrPullSecretLaggingMetricsTopic, int64(time.Since(*rp.PasswordCreationTime).Minutes()), map[string]string{
+			"registry": rp.Name,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEmitACRPullSecretLag(t *testing.T) {
+	created := time.Now().Add(-2 * time.Hour)
+
+	current := &api.RegistryProfile{
+		Name:                 "arosvc.azurecr.io",
+		Username:             "token",
+		Password:             "current",
+		PasswordCreationTime: &created,
+	}
+
+	pullSecret := func(password string) *corev1.Secret {
+		ps, _, err := pullsecret.SetRegistryProfiles("", &api.RegistryProfile{
+			Name:     "arosvc.azurecr.io",
+			Username: "token",
+			Password: api.SecureString(password),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pull-secret",
+				Namespace: "openshift-config",
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(ps),
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name        string
+		rps         []*api.RegistryProfile
+		objects     []runtime.Object
+		wantLagging bool
+		wantErr     string
+	}{
+		{
+			name:    "pull secret contains current password",
+			rps:     []*api.RegistryProfile{current},
+			objects: []runtime.Object{pullSecret("current")},
+		},
+		{
+			name:        "pull secret contains previous password",
+			rps:         []*api.RegistryProfile{current},
+			objects:     []runtime.Object{pullSecret("previous")},
+			wantLagging: true,
+		},
+		{
+			name:        "pull secret missing",
+			rps:         []*api.RegistryProfile{current},
+			wantLagging: true,
+		},
+		{
+			name: "password creation time not recorded",
+			rps: []*api.RegistryProfile{
+				{
+					Name:     "arosvc.azurecr.io",
+					Username: "token",
+					Password: "current",
+				},
+			},
+			objects: []runtime.Object{pullSecret("previous")},
+		},
+		{
+			name: "invalid pull secret",
+			rps:  []*api.RegistryProfile{current},
+			objects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pull-secret",
+						Namespace: "openshift-config",
+					},
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: []byte("invalid"),
+					},
+				},
+			},
+			wantErr: "invalid character 'i' looking for beginning of value",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.wantLagging {
+				m.EXPECT().EmitGauge(acrPullSecretLaggingMetricsTopic, gomock.Any(), map[string]string{
+					"registry": "arosvc.azurecr.io",
+				}).Do(func(_ string, value int64, _ map[string]string) {
+					if value < 119 || value > 121 {
+						t.Error(value)
+					}
+				})
+			}
+
+			mon := &Monitor{
+				cli: fake.NewSimpleClientset(tt.objects...),
+				m:   m,
+				oc: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						RegistryProfiles: tt.rps,
+					},
+				},
+			}
+
+			err := mon.emitACRPullSecretLag(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
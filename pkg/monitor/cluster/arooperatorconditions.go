@@ -19,11 +19,14 @@ const (
 )
 
 var aroOperatorConditionsExpected = map[string]operatorv1.ConditionStatus{
-	arov1alpha1.InternetReachableFromMaster: operatorv1.ConditionTrue,
-	arov1alpha1.InternetReachableFromWorker: operatorv1.ConditionTrue,
-	arov1alpha1.ServicePrincipalValid:       operatorv1.ConditionTrue,
-	arov1alpha1.DefaultIngressCertificate:   operatorv1.ConditionTrue,
-	arov1alpha1.MachineValid:                operatorv1.ConditionTrue,
+	arov1alpha1.InternetReachableFromMaster:       operatorv1.ConditionTrue,
+	arov1alpha1.InternetReachableFromWorker:       operatorv1.ConditionTrue,
+	arov1alpha1.ServicePrincipalValid:             operatorv1.ConditionTrue,
+	arov1alpha1.DefaultIngressCertificate:         operatorv1.ConditionTrue,
+	arov1alpha1.MachineValid:                      operatorv1.ConditionTrue,
+	arov1alpha1.CriticalAlertsFiring:              operatorv1.ConditionFalse,
+	arov1alpha1.GuardRailsPolicyViolations:        operatorv1.ConditionFalse,
+	arov1alpha1.WorkerAutoscalingPolicyViolations: operatorv1.ConditionFalse,
 }
 
 func (mon *Monitor) emitAroOperatorConditions(ctx context.Context) error {
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -25,13 +26,15 @@ import (
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 const (
-	certificateExpirationMetricName = "certificate.expirationdate"
-	secretMissingMetricName         = "certificate.secretnotfound"
-	ingressNamespace                = "openshift-ingress-operator"
-	ingressName                     = "default"
+	certificateExpirationMetricName         = "certificate.expirationdate"
+	customerCertificateExpirationMetricName = "certificate.customer.expirationdate"
+	secretMissingMetricName                 = "certificate.secretnotfound"
+	ingressNamespace                        = "openshift-ingress-operator"
+	ingressName                             = "default"
 )
 
-// report NotAfter dates for Ingress and API (on managed domains), and Geneva (always)
+// report NotAfter dates for Ingress and API (on managed domains, or when
+// supplied by the customer), and Geneva (always)
 func (mon *Monitor) emitCertificateExpirationStatuses(ctx context.Context) error {
 	mdsdCert, err := mon.getCertificate(ctx, operator.Namespace, operator.SecretName, genevalogging.GenevaCertName)
 	if kerrors.IsNotFound(err) {
@@ -84,6 +87,66 @@ func (mon *Monitor) emitCertificateExpirationStatuses(ctx context.Context) error
 				})
 			}
 		}
+
+		return nil
+	}
+
+	return mon.emitCustomerCertificateExpirationStatuses(ctx)
+}
+
+// emitCustomerCertificateExpirationStatuses reports NotAfter dates for the
+// certificates supplied by the customer.  These have their own metric as it is
+// the customer who must renew them.
+func (mon *Monitor) emitCustomerCertificateExpirationStatuses(ctx context.Context) error {
+	var secretNames []string
+
+	if mon.oc.Properties.APIServerProfile.CertificateSecretID != "" {
+		apiserver := &configv1.APIServer{}
+		err := mon.ocpclientset.Get(ctx, client.ObjectKey{Name: "cluster"}, apiserver)
+		if err != nil {
+			return err
+		}
+
+		// secret with customer certificates is uuid + "-ingress" or "-apiserver"
+		for _, nc := range apiserver.Spec.ServingCerts.NamedCertificates {
+			if strings.HasSuffix(nc.ServingCertificate.Name, "-apiserver") {
+				secretNames = append(secretNames, nc.ServingCertificate.Name)
+			}
+		}
+	}
+
+	for _, p := range mon.oc.Properties.IngressProfiles {
+		if p.Name != ingressName || p.CertificateSecretID == "" {
+			continue
+		}
+
+		ic := &operatorv1.IngressController{}
+		err := mon.ocpclientset.Get(ctx, client.ObjectKey{
+			Namespace: ingressNamespace,
+			Name:      ingressName,
+		}, ic)
+		if err != nil {
+			return err
+		}
+
+		if ic.Spec.DefaultCertificate != nil && strings.HasSuffix(ic.Spec.DefaultCertificate.Name, "-ingress") {
+			secretNames = append(secretNames, ic.Spec.DefaultCertificate.Name)
+		}
+	}
+
+	for _, secretName := range secretNames {
+		certificate, err := mon.getCertificate(ctx, operator.Namespace, secretName, corev1.TLSCertKey)
+		if kerrors.IsNotFound(err) {
+			mon.emitGauge(secretMissingMetricName, int64(1), secretMissingMetric(operator.Namespace, secretName))
+		} else if err != nil {
+			return err
+		} else {
+			mon.emitGauge(customerCertificateExpirationMetricName, int64(utilcert.DaysUntilExpiration(certificate)), map[string]string{
+				"subject":   certificate.Subject.CommonName,
+				"name":      secretName,
+				"namespace": operator.Namespace,
+			})
+		}
 	}
 
 	return nil
@@ -206,6 +206,147 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 	})
 }
 
+func TestEmitCustomerCertificateExpirationStatuses(t *testing.T) {
+	ctx := context.Background()
+	expiration := time.Now().Add(time.Hour * 24 * 5)
+	daysUntilExpiration := 4
+	clusterID := "00000000-0000-0000-0000-000000000000"
+
+	for _, tt := range []struct {
+		name                    string
+		apiserverSecretID       string
+		ingressSecretID         string
+		certsPresent            []certInfo
+		wantCustomerExpirations []map[string]string
+		wantWarning             []map[string]string
+	}{
+		{
+			name:              "emits API and ingress status for customer certificates",
+			apiserverSecretID: "https://myvault.vault.azure.net/secrets/apiserver",
+			ingressSecretID:   "https://myvault.vault.azure.net/secrets/ingress",
+			certsPresent: []certInfo{
+				{"cluster", "geneva.certificate"},
+				{clusterID + "-ingress", "*.apps." + unmanagedDomainName},
+				{clusterID + "-apiserver", "api." + unmanagedDomainName},
+			},
+			wantCustomerExpirations: []map[string]string{
+				{
+					"subject":   "api.aro.contoso.com",
+					"name":      clusterID + "-apiserver",
+					"namespace": "openshift-azure-operator",
+				},
+				{
+					"subject":   "*.apps.aro.contoso.com",
+					"name":      clusterID + "-ingress",
+					"namespace": "openshift-azure-operator",
+				},
+			},
+		},
+		{
+			name:              "emits only API status when only the API certificate is supplied",
+			apiserverSecretID: "https://myvault.vault.azure.net/secrets/apiserver",
+			certsPresent: []certInfo{
+				{"cluster", "geneva.certificate"},
+				{clusterID + "-apiserver", "api." + unmanagedDomainName},
+			},
+			wantCustomerExpirations: []map[string]string{
+				{
+					"subject":   "api.aro.contoso.com",
+					"name":      clusterID + "-apiserver",
+					"namespace": "openshift-azure-operator",
+				},
+			},
+		},
+		{
+			name:            "emits warning metric when customer certificate secret has been deleted",
+			ingressSecretID: "https://myvault.vault.azure.net/secrets/ingress",
+			certsPresent: []certInfo{
+				{"cluster", "geneva.certificate"},
+			},
+			wantWarning: []map[string]string{
+				{
+					"namespace": "openshift-azure-operator",
+					"name":      clusterID + "-ingress",
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets, err := generateTestSecrets(tt.certsPresent, tweakTemplateFn(expiration))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := mock_metrics.NewMockEmitter(gomock.NewController(t))
+			m.EXPECT().EmitGauge(certificateExpirationMetricName, int64(daysUntilExpiration), map[string]string{
+				"subject":   "geneva.certificate",
+				"name":      "cluster",
+				"namespace": "openshift-azure-operator",
+			})
+			for _, w := range tt.wantWarning {
+				m.EXPECT().EmitGauge(secretMissingMetricName, int64(1), w)
+			}
+			for _, g := range tt.wantCustomerExpirations {
+				m.EXPECT().EmitGauge(customerCertificateExpirationMetricName, int64(daysUntilExpiration), g)
+			}
+
+			apiserver := &configv1.APIServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+			}
+			if tt.apiserverSecretID != "" {
+				apiserver.Spec.ServingCerts.NamedCertificates = []configv1.APIServerNamedServingCert{
+					{
+						Names:              []string{"api." + unmanagedDomainName},
+						ServingCertificate: configv1.SecretNameReference{Name: clusterID + "-apiserver"},
+					},
+				}
+			}
+
+			ingressController := &operatorv1.IngressController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "default",
+					Namespace: "openshift-ingress-operator",
+				},
+			}
+			if tt.ingressSecretID != "" {
+				ingressController.Spec.DefaultCertificate = &corev1.LocalObjectReference{
+					Name: clusterID + "-ingress",
+				}
+			}
+
+			mon := &Monitor{
+				ocpclientset: fake.
+					NewClientBuilder().
+					WithObjects(apiserver, ingressController).
+					WithObjects(secrets...).
+					Build(),
+				m: m,
+				oc: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						APIServerProfile: api.APIServerProfile{
+							URL:                 unmanagedDomainApiURL,
+							CertificateSecretID: tt.apiserverSecretID,
+						},
+						IngressProfiles: []api.IngressProfile{
+							{
+								Name:                "default",
+								CertificateSecretID: tt.ingressSecretID,
+							},
+						},
+					},
+				},
+			}
+
+			err = mon.emitCertificateExpirationStatuses(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 func tweakTemplateFn(expiration time.Time) func(*x509.Certificate) {
 	return func(template *x509.Certificate) {
 		template.NotAfter = expiration
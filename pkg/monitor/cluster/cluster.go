@@ -200,6 +200,7 @@ func (mon *Monitor) Monitor(ctx context.Context) (errs []error) {
 		mon.emitMachineConfigPoolConditions,
 		mon.emitMachineConfigPoolUnmanagedNodeCounts,
 		mon.emitNodeConditions,
+		mon.emitNodeDrainsStuck,
 		mon.emitPodConditions,
 		mon.emitDebugPodsCount,
 		mon.detectQuotaFailure,
@@ -210,8 +211,11 @@ func (mon *Monitor) Monitor(ctx context.Context) (errs []error) {
 		mon.emitHiveRegistrationStatus,
 		mon.emitOperatorFlagsAndSupportBanner,
 		mon.emitMaintenanceState,
+		mon.emitACRPullSecretLag,
+		mon.emitEtcdSnapshotAge,
 		mon.emitCertificateExpirationStatuses,
 		mon.emitEtcdCertificateExpiry,
+		mon.emitGuardRailsViolations,
 		mon.emitPrometheusAlerts, // at the end for now because it's the slowest/least reliable
 	} {
 		err = f(ctx)
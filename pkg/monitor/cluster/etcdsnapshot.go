@@ -0,0 +1,27 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+)
+
+const etcdSnapshotAgeMetricsTopic = "etcd.snapshot.age"
+
+// emitEtcdSnapshotAge emits the age in minutes of the cluster's latest etcd
+// snapshot if scheduled snapshots are enabled.  The interval is emitted
+// alongside so that alerts can fire when snapshots are overdue.
+func (mon *Monitor) emitEtcdSnapshotAge(ctx context.Context) error {
+	p := mon.oc.Properties.EtcdSnapshotProfile
+	if p == nil || p.Interval == "" || p.LastSnapshotTime == nil {
+		return nil
+	}
+
+	mon.emitGauge(etcdSnapshotAgeMetricsTopic, int64(time.Since(*p.LastSnapshotTime).Minutes()), map[string]string{
+		"interval": p.Interval,
+	})
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitEtcdSnapshotAge(t *testing.T) {
+	last := time.Now().Add(-90 * time.Minute)
+
+	for _, tt := range []struct {
+		name    string
+		profile *api.EtcdSnapshotProfile
+		wantAge bool
+	}{
+		{
+			name: "no profile",
+		},
+		{
+			name: "scheduled snapshots disabled",
+			profile: &api.EtcdSnapshotProfile{
+				LastSnapshotTime: &last,
+			},
+		},
+		{
+			name: "no snapshot taken",
+			profile: &api.EtcdSnapshotProfile{
+				Interval: "24h",
+			},
+		},
+		{
+			name: "snapshot taken",
+			profile: &api.EtcdSnapshotProfile{
+				Interval:         "24h",
+				LastSnapshotTime: &last,
+			},
+			wantAge: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.wantAge {
+				m.EXPECT().EmitGauge(etcdSnapshotAgeMetricsTopic, gomock.Any(), map[string]string{
+					"interval": "24h",
+				}).Do(func(_ string, value int64, _ map[string]string) {
+					if value < 89 || value > 91 {
+						t.Error(value)
+					}
+				})
+			}
+
+			mon := &Monitor{
+				m: m,
+				oc: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						EtcdSnapshotProfile: tt.profile,
+					},
+				},
+			}
+
+			err := mon.emitEtcdSnapshotAge(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
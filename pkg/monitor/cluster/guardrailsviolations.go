@@ -0,0 +1,29 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/guardrails"
+)
+
+// emitGuardRailsViolations emits the number of violations of each Gatekeeper
+// constraint found by the latest audit
+func (mon *Monitor) emitGuardRailsViolations(ctx context.Context) error {
+	results, err := guardrails.GetConstraintViolations(ctx, mon.ocpclientset)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		mon.emitGauge("guardrails.violations", result.TotalViolations, map[string]string{
+			"kind":              result.Kind,
+			"name":              result.Name,
+			"enforcementAction": result.EnforcementAction,
+		})
+	}
+
+	return nil
+}
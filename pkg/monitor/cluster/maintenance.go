@@ -5,8 +5,10 @@ package cluster
 
 import (
 	"context"
+	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/maintenancewindow"
 )
 
 /**************************************************************
@@ -27,6 +29,11 @@ import (
 
 	(4) No ongoinig or scheduled maintenance
 		- Emit the none signal.
+
+	If the customer has set maintenance windows, additionally emit whether
+	planned maintenance may currently start and, if not, how many minutes
+	remain until it may, so that maintenance stuck outside the customer's
+	windows can be alerted on.
 **************************************************************/
 
 type maintenanceState string
@@ -49,9 +56,40 @@ func (mon *Monitor) emitMaintenanceState(ctx context.Context) error {
 		"state": state.String(),
 	})
 
+	window, minutes, ok := getMaintenanceWindow(mon.oc.Properties.MaintenanceWindowProfile, time.Now())
+	if ok {
+		mon.emitGauge("cluster.maintenance.window", minutes, map[string]string{
+			"state":  state.String(),
+			"window": window,
+		})
+	}
+
 	return nil
 }
 
+// getMaintenanceWindow returns whether the customer's maintenance window is
+// "open" or "closed" at now, and the number of minutes until it next opens.  A
+// window which does not open within the search horizon (e.g. because of
+// blackout dates) is "unavailable".  ok is false if the cluster has no valid
+// maintenance windows.
+func getMaintenanceWindow(p *api.MaintenanceWindowProfile, now time.Time) (window string, minutes int64, ok bool) {
+	s, err := maintenancewindow.New(p)
+	if err != nil || s == nil {
+		return "", 0, false
+	}
+
+	if s.Open(now) {
+		return "open", 0, true
+	}
+
+	next := s.Next(now)
+	if next.IsZero() {
+		return "unavailable", 0, true
+	}
+
+	return "closed", int64(next.Sub(now).Minutes()), true
+}
+
 func getMaintenanceState(clusterProperties api.OpenShiftClusterProperties) maintenanceState {
 	switch clusterProperties.MaintenanceState {
 	case api.MaintenanceStatePending:
@@ -6,6 +6,7 @@ package cluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
@@ -88,3 +89,67 @@ func TestEmitMaintenanceState(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMaintenanceWindow(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name        string
+		profile     *api.MaintenanceWindowProfile
+		wantWindow  string
+		wantMinutes int64
+		wantOK      bool
+	}{
+		{
+			name: "no profile",
+		},
+		{
+			name:    "no windows",
+			profile: &api.MaintenanceWindowProfile{TimeZone: "UTC"},
+		},
+		{
+			name: "invalid profile",
+			profile: &api.MaintenanceWindowProfile{
+				TimeZone: "Invalid/Zone",
+				Windows:  []api.MaintenanceWindow{{DayOfWeek: "Wednesday", StartTime: "10:00", DurationHours: 4}},
+			},
+		},
+		{
+			name: "open",
+			profile: &api.MaintenanceWindowProfile{
+				TimeZone: "UTC",
+				Windows:  []api.MaintenanceWindow{{DayOfWeek: "Wednesday", StartTime: "10:00", DurationHours: 4}},
+			},
+			wantWindow: "open",
+			wantOK:     true,
+		},
+		{
+			name: "closed",
+			profile: &api.MaintenanceWindowProfile{
+				TimeZone: "UTC",
+				Windows:  []api.MaintenanceWindow{{DayOfWeek: "Thursday", StartTime: "02:00", DurationHours: 4}},
+			},
+			wantWindow:  "closed",
+			wantMinutes: 14 * 60,
+			wantOK:      true,
+		},
+		{
+			name: "blacked out",
+			profile: &api.MaintenanceWindowProfile{
+				TimeZone:      "UTC",
+				Windows:       []api.MaintenanceWindow{{DayOfWeek: "Wednesday", StartTime: "10:00", DurationHours: 4}},
+				BlackoutDates: []api.MaintenanceBlackout{{StartDate: "2024-01-01", EndDate: "2026-12-31"}},
+			},
+			wantWindow: "unavailable",
+			wantOK:     true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			window, minutes, ok := getMaintenanceWindow(tt.profile, now)
+			if window != tt.wantWindow || minutes != tt.wantMinutes || ok != tt.wantOK {
+				t.Errorf("got %q, %d, %v", window, minutes, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/node"
+)
+
+const nodeDrainStuckMetricsTopic = "node.drain.stuck"
+
+// emitNodeDrainsStuck emits the number of minutes that nodes have been
+// draining for, for drains recorded by the ARO operator which have taken
+// longer than the cluster's drain policy stuck threshold
+func (mon *Monitor) emitNodeDrainsStuck(ctx context.Context) error {
+	cluster, err := mon.arocli.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	policy := node.GetDrainPolicy(cluster)
+
+	ns, err := mon.listNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, n := range ns.Items {
+		t, err := time.Parse(time.RFC3339, n.Annotations[node.AnnotationDrainStartTime])
+		if err != nil {
+			continue
+		}
+
+		duration := now.Sub(t)
+		if duration < policy.StuckThreshold {
+			continue
+		}
+
+		mon.emitGauge(nodeDrainStuckMetricsTopic, int64(duration.Minutes()), map[string]string{
+			"nodeName":      n.Name,
+			"podsRemaining": n.Annotations[node.AnnotationDrainPodsRemaining],
+			"blockingPDBs":  n.Annotations[node.AnnotationDrainBlockingPDBs],
+		})
+
+		if mon.hourlyRun {
+			mon.log.WithFields(logrus.Fields{
+				"metric":        nodeDrainStuckMetricsTopic,
+				"name":          n.Name,
+				"startTime":     t,
+				"podsRemaining": n.Annotations[node.AnnotationDrainPodsRemaining],
+				"blockingPDBs":  n.Annotations[node.AnnotationDrainBlockingPDBs],
+			}).Print()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	arofake "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned/fake"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/node"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitNodeDrainsStuck(t *testing.T) {
+	ctx := context.Background()
+
+	startTime := time.Now().Add(-3 * time.Hour).UTC().Format(time.RFC3339)
+
+	for _, tt := range []struct {
+		name        string
+		drainPolicy *arov1alpha1.NodeDrainPolicy
+		wantMetric  bool
+	}{
+		{
+			name:       "drain beyond the default threshold is emitted",
+			wantMetric: true,
+		},
+		{
+			name: "drain within the policy threshold is ignored",
+			drainPolicy: &arov1alpha1.NodeDrainPolicy{
+				StuckThreshold: &metav1.Duration{Duration: 4 * time.Hour},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			cli := fake.NewSimpleClientset(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "aro-worker-0",
+					Annotations: map[string]string{
+						node.AnnotationDrainStartTime:     startTime,
+						node.AnnotationDrainPodsRemaining: "1",
+						node.AnnotationDrainBlockingPDBs:  "app/app",
+					},
+				},
+			}, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "aro-worker-1",
+				},
+			})
+
+			arocli := arofake.NewSimpleClientset(&arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					NodeDrainPolicy: tt.drainPolicy,
+				},
+			})
+
+			m := mock_metrics.NewMockEmitter(controller)
+
+			mon := &Monitor{
+				cli:    cli,
+				arocli: arocli,
+				m:      m,
+			}
+
+			if tt.wantMetric {
+				m.EXPECT().EmitGauge(nodeDrainStuckMetricsTopic, int64(180), map[string]string{
+					"nodeName":      "aro-worker-0",
+					"podsRemaining": "1",
+					"blockingPDBs":  "app/app",
+				})
+			}
+
+			err := mon.emitNodeDrainsStuck(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
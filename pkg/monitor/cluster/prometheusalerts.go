@@ -9,10 +9,13 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/prometheus/common/model"
 
+	pkgoperator "github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/alertwebhook"
 	"github.com/Azure/ARO-RP/pkg/util/namespace"
 	"github.com/Azure/ARO-RP/pkg/util/portforward"
 )
@@ -23,6 +26,76 @@ var ignoredAlerts = map[string]struct{}{
 }
 
 func (mon *Monitor) emitPrometheusAlerts(ctx context.Context) error {
+	summary, err := mon.getOperatorAlertSummary(ctx)
+	if err != nil {
+		// older operators do not run the receiver, and the receiver does not
+		// know which alerts are firing until Alertmanager first notifies it
+		mon.log.Debugf("falling back to Alertmanager: %s", err)
+
+		summary, err = mon.getAlertmanagerAlertSummary(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	m := map[string]struct {
+		count    int64
+		severity string
+	}{}
+
+	mon.emitGauge("prometheus.alerts.count", summary.Count, nil)
+
+	for _, g := range summary.Groups {
+		if !namespace.IsOpenShiftNamespace(g.Namespace) {
+			continue
+		}
+
+		if alertIsIgnored(g.Name) {
+			continue
+		}
+
+		a := m[g.Name]
+
+		a.severity = g.Severity
+		a.count += g.Count
+
+		m[g.Name] = a
+	}
+
+	for alertName, a := range m {
+		mon.emitGauge("prometheus.alerts", a.count, map[string]string{
+			"alert":    alertName,
+			"severity": a.severity,
+		})
+	}
+
+	return nil
+}
+
+// getOperatorAlertSummary fetches the alerts aggregated by the ARO operator's
+// Alertmanager webhook receiver via the API server service proxy
+func (mon *Monitor) getOperatorAlertSummary(ctx context.Context) (*alertwebhook.AlertSummary, error) {
+	b, err := mon.cli.CoreV1().Services(pkgoperator.Namespace).ProxyGet("http", "aro-operator-master", strconv.Itoa(alertwebhook.ReceiverPort), alertwebhook.AlertsPath, nil).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary *alertwebhook.AlertSummary
+	err = json.Unmarshal(b, &summary)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary == nil {
+		return nil, fmt.Errorf("empty alert summary")
+	}
+
+	return summary, nil
+}
+
+// getAlertmanagerAlertSummary fetches the alerts directly from Alertmanager
+// via a port-forward
+func (mon *Monitor) getAlertmanagerAlertSummary(ctx context.Context) (*alertwebhook.AlertSummary, error) {
 	var resp *http.Response
 	var err error
 
@@ -50,7 +123,7 @@ func (mon *Monitor) emitPrometheusAlerts(ctx context.Context) error {
 		var req *http.Request
 		req, err = http.NewRequestWithContext(ctx, http.MethodGet, "http://alertmanager-main.openshift-monitoring.svc:9093/api/v2/alerts", nil)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		resp, err = hc.Do(req)
@@ -59,52 +132,21 @@ func (mon *Monitor) emitPrometheusAlerts(ctx context.Context) error {
 		}
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
 	}
 
 	var alerts []model.Alert
 	err = json.NewDecoder(resp.Body).Decode(&alerts)
 	if err != nil {
-		return err
-	}
-
-	m := map[string]struct {
-		count    int64
-		severity string
-	}{}
-
-	mon.emitGauge("prometheus.alerts.count", int64(len(alerts)), nil)
-
-	for _, alert := range alerts {
-		if !namespace.IsOpenShiftNamespace(string(alert.Labels["namespace"])) {
-			continue
-		}
-
-		if alertIsIgnored(alert.Name()) {
-			continue
-		}
-
-		a := m[alert.Name()]
-
-		a.severity = string(alert.Labels["severity"])
-		a.count++
-
-		m[alert.Name()] = a
+		return nil, err
 	}
 
-	for alertName, a := range m {
-		mon.emitGauge("prometheus.alerts", a.count, map[string]string{
-			"alert":    alertName,
-			"severity": a.severity,
-		})
-	}
-
-	return nil
+	return alertwebhook.Summarize(alerts), nil
 }
 
 func alertIsIgnored(alertName string) bool {
@@ -0,0 +1,74 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	ktesting "k8s.io/client-go/testing"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+type fakeResponseWrapper []byte
+
+func (b fakeResponseWrapper) DoRaw(context.Context) ([]byte, error) {
+	return b, nil
+}
+
+func (b fakeResponseWrapper) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func TestEmitPrometheusAlerts(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cli := fake.NewSimpleClientset()
+	cli.PrependProxyReactor("services", func(action ktesting.Action) (bool, restclient.ResponseWrapper, error) {
+		a := action.(ktesting.ProxyGetAction)
+		if a.GetNamespace() != "openshift-azure-operator" || a.GetName() != "aro-operator-master" ||
+			a.GetPort() != "8081" || a.GetPath() != "/alerts" {
+			t.Errorf("unexpected proxy action %#v", a)
+		}
+
+		return true, fakeResponseWrapper(`{
+			"count": 5,
+			"groups": [
+				{"name": "KubePodCrashLooping", "severity": "warning", "namespace": "openshift-dns", "count": 2},
+				{"name": "KubePodCrashLooping", "severity": "warning", "namespace": "openshift-ingress", "count": 1},
+				{"name": "KubePodCrashLooping", "severity": "warning", "namespace": "customer", "count": 1},
+				{"name": "InsightsDisabled", "severity": "info", "namespace": "openshift-insights", "count": 1}
+			]
+		}`), nil
+	})
+
+	m := mock_metrics.NewMockEmitter(controller)
+
+	mon := &Monitor{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		cli: cli,
+		m:   m,
+	}
+
+	m.EXPECT().EmitGauge("prometheus.alerts.count", int64(5), map[string]string{})
+	m.EXPECT().EmitGauge("prometheus.alerts", int64(3), map[string]string{
+		"alert":    "KubePodCrashLooping",
+		"severity": "warning",
+	})
+
+	err := mon.emitPrometheusAlerts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/monitor/azure/dns"
+)
+
+// checkDanglingDNSRecords emits the number of records in the managed domain
+// zone which belong to clusters which no longer exist.  It runs hourly on the
+// master monitor only, as it lists the whole zone.
+func (mon *monitor) checkDanglingDNSRecords(ctx context.Context) error {
+	if !mon.isMaster || time.Since(mon.lastDanglingDNSCheck) < time.Hour {
+		return nil
+	}
+	mon.lastDanglingDNSCheck = time.Now()
+
+	dangling, err := mon.dns.ListDangling(ctx, func(ctx context.Context, resourceID string) (bool, error) {
+		_, err := mon.dbOpenShiftClusters.Get(ctx, strings.ToLower(resourceID))
+		if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range dangling {
+		mon.baseLog.Warnf("dangling DNS record %s", name)
+	}
+
+	mon.m.EmitGauge(dns.MetricDanglingRecords, int64(len(dangling)), nil)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/adminupdate"
+	"github.com/Azure/ARO-RP/pkg/util/etcdsnapshot"
+)
+
+const (
+	etcdSnapshotSweepInterval = 10 * time.Minute
+
+	// maxEtcdSnapshotsPerSweep bounds the number of admin updates queued by a
+	// single sweep, so that enabling snapshots on many clusters at once does
+	// not flood the backend
+	maxEtcdSnapshotsPerSweep = 20
+)
+
+var errEtcdSnapshotNotDue = errors.New("etcd snapshot is not due")
+
+// queueEtcdSnapshots queues an EtcdSnapshot admin update for each cluster
+// whose next scheduled etcd snapshot is due.  It runs every ten minutes on the
+// master monitor only, so that the schedule is swept once per region.
+func (mon *monitor) queueEtcdSnapshots(ctx context.Context) error {
+	if !mon.isMaster || time.Since(mon.lastEtcdSnapshotSweep) < etcdSnapshotSweepInterval {
+		return nil
+	}
+	mon.lastEtcdSnapshotSweep = time.Now()
+
+	docs, err := mon.dbOpenShiftClusters.ListWithEtcdSnapshotSchedule(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := mon.now()
+
+	var due, queued int
+	for _, doc := range docs.OpenShiftClusterDocuments {
+		if !etcdSnapshotDue(doc, now) {
+			continue
+		}
+
+		due++
+		if queued >= maxEtcdSnapshotsPerSweep {
+			continue
+		}
+
+		_, err = mon.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+			if !etcdSnapshotDue(doc, now) {
+				return errEtcdSnapshotNotDue
+			}
+
+			adminupdate.Queue(doc, api.MaintenanceTaskEtcdSnapshot)
+			return nil
+		})
+		if err == errEtcdSnapshotNotDue {
+			continue
+		}
+		if err != nil {
+			mon.baseLog.Errorf("queueing etcd snapshot for %s: %s", doc.OpenShiftCluster.ID, err)
+			continue
+		}
+
+		mon.baseLog.Printf("queued etcd snapshot for %s", doc.OpenShiftCluster.ID)
+		queued++
+	}
+
+	mon.m.EmitGauge("monitor.etcdsnapshot.due", int64(due), nil)
+	mon.m.EmitGauge("monitor.etcdsnapshot.queued", int64(queued), nil)
+
+	return nil
+}
+
+// etcdSnapshotDue returns true if the cluster's next scheduled etcd snapshot is
+// due and the cluster is idle
+func etcdSnapshotDue(doc *api.OpenShiftClusterDocument, now time.Time) bool {
+	return adminupdate.Idle(doc) &&
+		etcdsnapshot.Due(doc.OpenShiftCluster.Properties.EtcdSnapshotProfile, now)
+}
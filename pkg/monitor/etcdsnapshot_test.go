@@ -0,0 +1,166 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestQueueEtcdSnapshots(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-25 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	clusterDoc := func(name string, provisioningState api.ProvisioningState, profile *api.EtcdSnapshotProfile) *api.OpenShiftClusterDocument {
+		resourceID := fmt.Sprintf("/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/%s", name)
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   resourceID,
+				Name: name,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState:   provisioningState,
+					EtcdSnapshotProfile: profile,
+				},
+			},
+		}
+	}
+
+	daily := func(lastSnapshotTime *time.Time) *api.EtcdSnapshotProfile {
+		return &api.EtcdSnapshotProfile{
+			Interval:         "24h",
+			LastSnapshotTime: lastSnapshotTime,
+		}
+	}
+
+	queued := func(doc *api.OpenShiftClusterDocument) *api.OpenShiftClusterDocument {
+		doc.OpenShiftCluster.Properties.LastProvisioningState = doc.OpenShiftCluster.Properties.ProvisioningState
+		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+		doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEtcdSnapshot
+		return doc
+	}
+
+	for _, tt := range []struct {
+		name       string
+		notMaster  bool
+		fixture    func(*testdatabase.Fixture)
+		checker    func(*testdatabase.Checker)
+		wantDue    int64
+		wantQueued int64
+	}{
+		{
+			name: "due clusters are queued for a snapshot",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("disabled", api.ProvisioningStateSucceeded, &api.EtcdSnapshotProfile{LastSnapshotTime: &old}),
+					clusterDoc("never", api.ProvisioningStateSucceeded, daily(nil)),
+					clusterDoc("old", api.ProvisioningStateSucceeded, daily(&old)),
+					clusterDoc("recent", api.ProvisioningStateSucceeded, daily(&recent)),
+					clusterDoc("unconfigured", api.ProvisioningStateSucceeded, nil),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					clusterDoc("disabled", api.ProvisioningStateSucceeded, &api.EtcdSnapshotProfile{LastSnapshotTime: &old}),
+					queued(clusterDoc("never", api.ProvisioningStateSucceeded, daily(nil))),
+					queued(clusterDoc("old", api.ProvisioningStateSucceeded, daily(&old))),
+					clusterDoc("recent", api.ProvisioningStateSucceeded, daily(&recent)),
+					clusterDoc("unconfigured", api.ProvisioningStateSucceeded, nil),
+				)
+			},
+			wantDue:    2,
+			wantQueued: 2,
+		},
+		{
+			name: "busy and failed clusters are skipped",
+			fixture: func(f *testdatabase.Fixture) {
+				adminUpdateFailed := clusterDoc("adminupdatefailed", api.ProvisioningStateSucceeded, daily(&old))
+				adminUpdateFailed.OpenShiftCluster.Properties.LastAdminUpdateError = "error"
+
+				f.AddOpenShiftClusterDocuments(
+					adminUpdateFailed,
+					clusterDoc("failed", api.ProvisioningStateFailed, daily(&old)),
+					clusterDoc("updating", api.ProvisioningStateUpdating, daily(&old)),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				adminUpdateFailed := clusterDoc("adminupdatefailed", api.ProvisioningStateSucceeded, daily(&old))
+				adminUpdateFailed.OpenShiftCluster.Properties.LastAdminUpdateError = "error"
+
+				c.AddOpenShiftClusterDocuments(
+					adminUpdateFailed,
+					clusterDoc("failed", api.ProvisioningStateFailed, daily(&old)),
+					clusterDoc("updating", api.ProvisioningStateUpdating, daily(&old)),
+				)
+			},
+		},
+		{
+			name:      "only the master monitor queues snapshots",
+			notMaster: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("old", api.ProvisioningStateSucceeded, daily(&old)),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					clusterDoc("old", api.ProvisioningStateSucceeded, daily(&old)),
+				)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dbOpenShiftClusters, clientOpenShiftClusters := testdatabase.NewFakeOpenShiftClusters()
+
+			f := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+			tt.fixture(f)
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if !tt.notMaster {
+				m.EXPECT().EmitGauge("monitor.etcdsnapshot.due", tt.wantDue, nil)
+				m.EXPECT().EmitGauge("monitor.etcdsnapshot.queued", tt.wantQueued, nil)
+			}
+
+			mon := &monitor{
+				baseLog:             logrus.NewEntry(logrus.StandardLogger()),
+				dbOpenShiftClusters: dbOpenShiftClusters,
+				m:                   m,
+				isMaster:            !tt.notMaster,
+				now:                 func() time.Time { return now },
+			}
+
+			err = mon.queueEtcdSnapshots(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := testdatabase.NewChecker()
+			tt.checker(c)
+
+			errs := c.CheckOpenShiftClusters(clientOpenShiftClusters)
+			for _, err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/adminupdate"
+)
+
+const deferredMaintenanceSweepInterval = 5 * time.Minute
+
+var errDeferredMaintenanceNotDue = errors.New("deferred maintenance is not due")
+
+// queueDeferredMaintenance queues an admin update for each cluster whose
+// planned maintenance was deferred and whose maintenance window has opened.  It
+// runs every five minutes on the master monitor only, so that each update is
+// queued once per region.
+func (mon *monitor) queueDeferredMaintenance(ctx context.Context) error {
+	if !mon.isMaster || time.Since(mon.lastDeferredMaintenanceSweep) < deferredMaintenanceSweepInterval {
+		return nil
+	}
+	mon.lastDeferredMaintenanceSweep = time.Now()
+
+	docs, err := mon.dbOpenShiftClusters.ListWithDeferredMaintenanceTask(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := mon.now()
+
+	var queued int
+	for _, doc := range docs.OpenShiftClusterDocuments {
+		if !deferredMaintenanceDue(doc, now) {
+			continue
+		}
+
+		_, err = mon.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+			if !deferredMaintenanceDue(doc, now) {
+				return errDeferredMaintenanceNotDue
+			}
+
+			task := doc.OpenShiftCluster.Properties.DeferredMaintenanceTask
+			doc.OpenShiftCluster.Properties.DeferredMaintenanceTask = ""
+			adminupdate.QueueMaintenance(doc, task)
+			return nil
+		})
+		if err == errDeferredMaintenanceNotDue {
+			continue
+		}
+		if err != nil {
+			mon.baseLog.Errorf("queueing deferred maintenance for %s: %s", doc.OpenShiftCluster.ID, err)
+			continue
+		}
+
+		mon.baseLog.Printf("queued deferred maintenance for %s", doc.OpenShiftCluster.ID)
+		queued++
+	}
+
+	mon.m.EmitGauge("monitor.deferredmaintenance.deferred", int64(len(docs.OpenShiftClusterDocuments)-queued), nil)
+	mon.m.EmitGauge("monitor.deferredmaintenance.queued", int64(queued), nil)
+
+	return nil
+}
+
+// deferredMaintenanceDue returns true if the cluster has deferred maintenance
+// which may now start.  Clusters which are busy with a customer operation are
+// left until it finishes.
+func deferredMaintenanceDue(doc *api.OpenShiftClusterDocument, now time.Time) bool {
+	props := &doc.OpenShiftCluster.Properties
+
+	return props.DeferredMaintenanceTask != "" &&
+		props.ProvisioningState.IsTerminal() &&
+		!adminupdate.MustDefer(doc, now)
+}
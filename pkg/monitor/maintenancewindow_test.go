@@ -0,0 +1,142 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestQueueDeferredMaintenance(t *testing.T) {
+	ctx := context.Background()
+
+	// a Wednesday
+	now := time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC)
+
+	window := func(dayOfWeek string) *api.MaintenanceWindowProfile {
+		return &api.MaintenanceWindowProfile{
+			TimeZone: "UTC",
+			Windows: []api.MaintenanceWindow{
+				{
+					DayOfWeek:     dayOfWeek,
+					StartTime:     "10:00",
+					DurationHours: 4,
+				},
+			},
+		}
+	}
+
+	clusterDoc := func(name string, provisioningState api.ProvisioningState, profile *api.MaintenanceWindowProfile) *api.OpenShiftClusterDocument {
+		resourceID := fmt.Sprintf("/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/%s", name)
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   resourceID,
+				Name: name,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState:        provisioningState,
+					MaintenanceState:         api.MaintenanceStatePending,
+					MaintenanceWindowProfile: profile,
+					DeferredMaintenanceTask:  api.MaintenanceTaskOperator,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name         string
+		fixture      func(*testdatabase.Fixture)
+		checker      func(*testdatabase.Checker)
+		wantDeferred int64
+		wantQueued   int64
+	}{
+		{
+			name: "deferred maintenance is queued when the window opens",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("open", api.ProvisioningStateSucceeded, window("Wednesday")),
+					clusterDoc("closed", api.ProvisioningStateSucceeded, window("Thursday")),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				open := clusterDoc("open", api.ProvisioningStateSucceeded, window("Wednesday"))
+				open.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+				open.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				open.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskOperator
+				open.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStatePlanned
+				open.OpenShiftCluster.Properties.DeferredMaintenanceTask = ""
+
+				c.AddOpenShiftClusterDocuments(
+					clusterDoc("closed", api.ProvisioningStateSucceeded, window("Thursday")),
+					open,
+				)
+			},
+			wantDeferred: 1,
+			wantQueued:   1,
+		},
+		{
+			name: "clusters busy with a customer operation are left until it finishes",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("updating", api.ProvisioningStateUpdating, window("Wednesday")),
+				)
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					clusterDoc("updating", api.ProvisioningStateUpdating, window("Wednesday")),
+				)
+			},
+			wantDeferred: 1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dbOpenShiftClusters, clientOpenShiftClusters := testdatabase.NewFakeOpenShiftClusters()
+
+			f := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+			tt.fixture(f)
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := mock_metrics.NewMockEmitter(controller)
+			m.EXPECT().EmitGauge("monitor.deferredmaintenance.deferred", tt.wantDeferred, nil)
+			m.EXPECT().EmitGauge("monitor.deferredmaintenance.queued", tt.wantQueued, nil)
+
+			mon := &monitor{
+				baseLog:             logrus.NewEntry(logrus.StandardLogger()),
+				dbOpenShiftClusters: dbOpenShiftClusters,
+				m:                   m,
+				isMaster:            true,
+				now:                 func() time.Time { return now },
+			}
+
+			err = mon.queueDeferredMaintenance(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := testdatabase.NewChecker()
+			tt.checker(c)
+
+			errs := c.CheckOpenShiftClusters(clientOpenShiftClusters)
+			for _, err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
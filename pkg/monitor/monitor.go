@@ -19,9 +19,13 @@ import (
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/proxy"
+	"github.com/Azure/ARO-RP/pkg/util/acrtoken"
+	keyvaultclient "github.com/Azure/ARO-RP/pkg/util/azureclient/keyvault"
 	"github.com/Azure/ARO-RP/pkg/util/bucket"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
 	"github.com/Azure/ARO-RP/pkg/util/heartbeat"
 	"github.com/Azure/ARO-RP/pkg/util/liveconfig"
+	"github.com/Azure/ARO-RP/pkg/util/reseal"
 )
 
 type monitor struct {
@@ -50,14 +54,32 @@ type monitor struct {
 	liveConfig       liveconfig.Manager
 	hiveShardConfigs map[int]*rest.Config
 	shardMutex       sync.RWMutex
+
+	dns                  dns.Manager
+	lastDanglingDNSCheck time.Time
+
+	lastEtcdSnapshotSweep        time.Time
+	lastDeferredMaintenanceSweep time.Time
+	lastCertificateRotationSweep time.Time
+
+	acrTokenRotationPolicy    *acrtoken.RotationPolicy
+	acrRegistryProfileName    string
+	lastACRTokenRotationSweep time.Time
+
+	resealSweeper   reseal.Sweeper
+	lastResealSweep time.Time
+
+	clusterSPKeyvault func(string, *api.ServicePrincipalProfile) (keyvaultclient.BaseClient, error)
+
+	now func() time.Time
 }
 
 type Runnable interface {
 	Run(context.Context) error
 }
 
-func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Monitors, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, m, clusterm metrics.Emitter, liveConfig liveconfig.Manager, e env.Interface) Runnable {
-	return &monitor{
+func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Monitors, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, m, clusterm metrics.Emitter, liveConfig liveconfig.Manager, e env.Interface, resealSweeper reseal.Sweeper) Runnable {
+	mon := &monitor{
 		baseLog: log,
 		dialer:  dialer,
 
@@ -79,7 +101,15 @@ func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Moni
 		liveConfig: liveConfig,
 
 		hiveShardConfigs: map[int]*rest.Config{},
+
+		resealSweeper: resealSweeper,
+
+		now: time.Now,
 	}
+
+	mon.clusterSPKeyvault = mon.newClusterSPKeyvault
+
+	return mon
 }
 
 func (mon *monitor) Run(ctx context.Context) error {
@@ -90,6 +120,27 @@ func (mon *monitor) Run(ctx context.Context) error {
 		return err
 	}
 
+	localFPAuthorizer, err := mon.env.FPAuthorizer(mon.env.TenantID(), mon.env.Environment().ResourceManagerScope)
+	if err != nil {
+		return err
+	}
+	mon.dns = dns.NewManager(mon.env, localFPAuthorizer)
+
+	// we do not want to rotate tokens in local development
+	if !mon.env.IsLocalDevelopmentMode() {
+		mon.acrTokenRotationPolicy, err = acrtoken.NewRotationPolicyFromEnvironment()
+		if err != nil {
+			return err
+		}
+	}
+
+	if mon.acrTokenRotationPolicy.Enabled() {
+		mon.acrRegistryProfileName, err = acrtoken.RegistryProfileName(mon.env)
+		if err != nil {
+			return err
+		}
+	}
+
 	// fill the cache from the database change feed
 	go mon.changefeed(ctx, mon.baseLog.WithField("component", "changefeed"), nil)
 
@@ -111,6 +162,39 @@ func (mon *monitor) Run(ctx context.Context) error {
 			mon.baseLog.Error(err)
 		}
 
+		// check for DNS records left behind by deleted clusters
+		err = mon.checkDanglingDNSRecords(ctx)
+		if err != nil {
+			mon.baseLog.Error(err)
+		}
+
+		// queue the scheduled etcd snapshots which are due
+		err = mon.queueEtcdSnapshots(ctx)
+		if err != nil {
+			mon.baseLog.Error(err)
+		}
+
+		// queue the planned maintenance whose maintenance window has opened
+		err = mon.queueDeferredMaintenance(ctx)
+		if err != nil {
+			mon.baseLog.Error(err)
+		}
+
+		// queue the rotation of ACR token passwords which are due
+		err = mon.queueACRTokenRotations(ctx)
+		if err != nil {
+			mon.baseLog.Error(err)
+		}
+
+		// queue the rotation of customer certificates whose secrets changed
+		err = mon.queueCertificateRotations(ctx)
+		if err != nil {
+			mon.baseLog.Error(err)
+		}
+
+		// report the documents which were not sealed with the current key
+		mon.startReseal(ctx)
+
 		// read our bucket allocation from the master
 		err = mon.listBuckets(ctx)
 		if err != nil {
@@ -0,0 +1,58 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const resealInterval = 24 * time.Hour
+
+// startReseal starts a sweep, once a day on the master monitor only, which
+// reports documents whose secure fields were not sealed as the RP currently
+// seals them, so that old keys can be retired.  The sweep reads every document,
+// so it runs in the background rather than holding up the master's lease.
+// Documents are only resealed on demand by `aro reseal fix`, so that rewriting
+// them is never done while RPs which cannot open the result are still running.
+func (mon *monitor) startReseal(ctx context.Context) {
+	if !mon.isMaster || mon.resealSweeper == nil ||
+		time.Since(mon.lastResealSweep) < resealInterval {
+		return
+	}
+	mon.lastResealSweep = time.Now()
+
+	go func() {
+		defer recover.Panic(mon.baseLog)
+
+		err := mon.reseal(ctx)
+		if err != nil {
+			mon.baseLog.Error(err)
+		}
+	}()
+}
+
+func (mon *monitor) reseal(ctx context.Context) error {
+	report, err := mon.resealSweeper.Sweep(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range report.Collections {
+		dims := map[string]string{
+			"collection": c.Collection,
+		}
+
+		mon.m.EmitGauge("monitor.reseal.stale", int64(c.Stale), dims)
+		mon.m.EmitGauge("monitor.reseal.errors", int64(len(c.Errors)), dims)
+	}
+
+	mon.m.EmitGauge("monitor.reseal.duration", report.EndTime.Sub(report.StartTime).Milliseconds(), nil)
+
+	mon.baseLog.Printf("reseal: %d stale documents", report.Stale())
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	mock_reseal "github.com/Azure/ARO-RP/pkg/util/mocks/reseal"
+	"github.com/Azure/ARO-RP/pkg/util/reseal"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReseal(t *testing.T) {
+	ctx := context.Background()
+	startTime := time.Unix(1700000000, 0)
+
+	for _, tt := range []struct {
+		name    string
+		report  *reseal.Report
+		err     error
+		mocks   func(*mock_metrics.MockEmitter)
+		wantErr string
+	}{
+		{
+			name: "results are emitted by collection",
+			report: &reseal.Report{
+				StartTime: startTime,
+				EndTime:   startTime.Add(3 * time.Second),
+				Collections: []*reseal.CollectionReport{
+					{Collection: "OpenShiftClusters", Documents: 10, Stale: 3, Errors: []string{"error"}},
+					{Collection: "AsyncOperations", Documents: 5},
+				},
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("monitor.reseal.stale", int64(3), map[string]string{"collection": "OpenShiftClusters"})
+				m.EXPECT().EmitGauge("monitor.reseal.errors", int64(1), map[string]string{"collection": "OpenShiftClusters"})
+				m.EXPECT().EmitGauge("monitor.reseal.stale", int64(0), map[string]string{"collection": "AsyncOperations"})
+				m.EXPECT().EmitGauge("monitor.reseal.errors", int64(0), map[string]string{"collection": "AsyncOperations"})
+				m.EXPECT().EmitGauge("monitor.reseal.duration", int64(3000), nil)
+			},
+		},
+		{
+			name:    "sweep error",
+			err:     errors.New("random error"),
+			mocks:   func(m *mock_metrics.MockEmitter) {},
+			wantErr: "random error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			sweeper := mock_reseal.NewMockSweeper(controller)
+			sweeper.EXPECT().Sweep(gomock.Any(), false).Return(tt.report, tt.err)
+
+			m := mock_metrics.NewMockEmitter(controller)
+			tt.mocks(m)
+
+			mon := &monitor{
+				baseLog:       logrus.NewEntry(logrus.StandardLogger()),
+				m:             m,
+				resealSweeper: sweeper,
+			}
+
+			err := mon.reseal(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestStartReseal(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name            string
+		notMaster       bool
+		lastResealSweep time.Time
+	}{
+		{
+			name:      "only the master monitor sweeps",
+			notMaster: true,
+		},
+		{
+			name:            "sweeps at most once a day",
+			lastResealSweep: time.Now().Add(-time.Hour),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			// the sweeper has no expectations: it must not be called
+			sweeper := mock_reseal.NewMockSweeper(controller)
+
+			mon := &monitor{
+				baseLog:         logrus.NewEntry(logrus.StandardLogger()),
+				isMaster:        !tt.notMaster,
+				resealSweeper:   sweeper,
+				lastResealSweep: tt.lastResealSweep,
+			}
+
+			mon.startReseal(ctx)
+
+			if mon.lastResealSweep != tt.lastResealSweep {
+				t.Error(mon.lastResealSweep)
+			}
+		})
+	}
+}
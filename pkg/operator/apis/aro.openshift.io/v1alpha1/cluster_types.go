@@ -29,6 +29,15 @@ const (
 	DefaultIngressCertificate = "DefaultIngressCertificate"
 	DefaultClusterDNS         = "DefaultClusterDNS"
 	GuardRailsStatus          = "GuardRailsStatus"
+
+	// GuardRailsPolicyViolations records the Gatekeeper audit results
+	GuardRailsPolicyViolations = "GuardRailsPolicyViolations"
+
+	// CriticalAlertsFiring is set by the Alertmanager webhook receiver
+	CriticalAlertsFiring = "CriticalAlertsFiring"
+
+	// WorkerAutoscalingPolicyViolations is set by the machineset controller
+	WorkerAutoscalingPolicyViolations = "WorkerAutoscalingPolicyViolations"
 )
 
 // AllConditionTypes is a operator conditions currently in use, any condition not in this list is not
@@ -43,6 +52,9 @@ func AllConditionTypes() []string {
 		DefaultIngressCertificate,
 		DefaultClusterDNS,
 		GuardRailsStatus,
+		GuardRailsPolicyViolations,
+		CriticalAlertsFiring,
+		WorkerAutoscalingPolicyViolations,
 	}
 }
 
@@ -116,6 +128,16 @@ type ClusterSpec struct {
 	Banner                   Banner              `json:"banner,omitempty"`
 	ServiceSubnets           []string            `json:"serviceSubnets,omitempty"`
 
+	// WorkerAutoscalingPolicy defines the guardrails within which worker
+	// MachineSets may be scaled.  It is not set by the RP and is preserved
+	// across operator updates
+	WorkerAutoscalingPolicy *WorkerAutoscalingPolicy `json:"workerAutoscalingPolicy,omitempty"`
+
+	// NodeDrainPolicy defines how the node controller drains worker nodes
+	// whose upgrade is stuck draining.  It is not set by the RP and is
+	// preserved across operator updates
+	NodeDrainPolicy *NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
+
 	// OperatorFlags defines feature gates for the ARO Operator
 	OperatorFlags OperatorFlags `json:"operatorflags,omitempty"`
 }
@@ -125,6 +147,65 @@ type Banner struct {
 	Content BannerContent `json:"content,omitempty"`
 }
 
+// WorkerAutoscalingPolicy is enforced by the machineset controller against
+// changes made by customers and by the cluster autoscaler.  The minimum and
+// maximum sizes of MachineSets targeted by a MachineAutoscaler are clamped to
+// the policy
+type WorkerAutoscalingPolicy struct {
+	// MinReplicasPerZone is the minimum number of worker replicas in each
+	// availability zone
+	// +kubebuilder:validation:Minimum=0
+	MinReplicasPerZone *int32 `json:"minReplicasPerZone,omitempty"`
+	// MaxReplicasPerZone is the maximum number of worker replicas in each
+	// availability zone
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicasPerZone *int32 `json:"maxReplicasPerZone,omitempty"`
+	// AllowedVMSizes lists the VM sizes worker MachineSets may use.  Any size
+	// is allowed if it is empty.  Disallowed VM sizes are reverted to the last
+	// allowed VM size of the MachineSet
+	AllowedVMSizes []string `json:"allowedVMSizes,omitempty"`
+	// ScaleDownProtectionWindows are recurring windows during which worker
+	// replicas may not be reduced
+	ScaleDownProtectionWindows []ScaleDownProtectionWindow `json:"scaleDownProtectionWindows,omitempty"`
+}
+
+// ScaleDownProtectionWindow is a recurring window, in UTC
+type ScaleDownProtectionWindow struct {
+	// Days are the days of the week on which the window starts, e.g. Monday.
+	// The window starts every day if it is empty
+	Days []string `json:"days,omitempty"`
+	// Start is the time of day at which the window starts, as HH:MM
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration is the length of the window, e.g. 8h
+	Duration metav1.Duration `json:"duration"`
+}
+
+// NodeDrainPolicy configures the node controller, which drains worker nodes
+// that the machine config daemon has failed to drain, deleting pods without
+// regard to their PodDisruptionBudgets
+type NodeDrainPolicy struct {
+	// GracePeriod is how long the machine config daemon is given to drain a
+	// node before the node controller drains it, default 1h
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+	// Timeout is how long the node controller waits for the pods of a node to
+	// be deleted before retrying, default 60s
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// PodGracePeriodSeconds is the termination grace period given to deleted
+	// pods.  The grace period of each pod is used if it is unset
+	// +kubebuilder:validation:Minimum=0
+	PodGracePeriodSeconds *int32 `json:"podGracePeriodSeconds,omitempty"`
+	// DeleteEmptyDirData allows pods using emptyDir volumes to be deleted,
+	// losing their data, default true
+	DeleteEmptyDirData *bool `json:"deleteEmptyDirData,omitempty"`
+	// IgnoreDaemonSets skips pods managed by DaemonSets, default true.  A
+	// node running DaemonSet pods cannot be drained if it is false
+	IgnoreDaemonSets *bool `json:"ignoreDaemonSets,omitempty"`
+	// StuckThreshold is how long a node may be draining before the drain is
+	// reported as stuck by the monitor, default 2h
+	StuckThreshold *metav1.Duration `json:"stuckThreshold,omitempty"`
+}
+
 // ClusterStatus defines the observed state of Cluster
 type ClusterStatus struct {
 	OperatorVersion   string                         `json:"operatorVersion,omitempty"`
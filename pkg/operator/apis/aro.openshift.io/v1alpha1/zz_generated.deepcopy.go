@@ -7,6 +7,7 @@ package v1alpha1
 
 import (
 	v1 "github.com/openshift/api/operator/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -100,6 +101,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.WorkerAutoscalingPolicy != nil {
+		in, out := &in.WorkerAutoscalingPolicy, &out.WorkerAutoscalingPolicy
+		*out = new(WorkerAutoscalingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeDrainPolicy != nil {
+		in, out := &in.NodeDrainPolicy, &out.NodeDrainPolicy
+		*out = new(NodeDrainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.OperatorFlags != nil {
 		in, out := &in.OperatorFlags, &out.OperatorFlags
 		*out = make(OperatorFlags, len(*in))
@@ -181,6 +192,51 @@ func (in *InternetCheckerSpec) DeepCopy() *InternetCheckerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainPolicy) DeepCopyInto(out *NodeDrainPolicy) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PodGracePeriodSeconds != nil {
+		in, out := &in.PodGracePeriodSeconds, &out.PodGracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeleteEmptyDirData != nil {
+		in, out := &in.DeleteEmptyDirData, &out.DeleteEmptyDirData
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreDaemonSets != nil {
+		in, out := &in.IgnoreDaemonSets, &out.IgnoreDaemonSets
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StuckThreshold != nil {
+		in, out := &in.StuckThreshold, &out.StuckThreshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainPolicy.
+func (in *NodeDrainPolicy) DeepCopy() *NodeDrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in OperatorFlags) DeepCopyInto(out *OperatorFlags) {
 	{
@@ -201,3 +257,61 @@ func (in OperatorFlags) DeepCopy() OperatorFlags {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDownProtectionWindow) DeepCopyInto(out *ScaleDownProtectionWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDownProtectionWindow.
+func (in *ScaleDownProtectionWindow) DeepCopy() *ScaleDownProtectionWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDownProtectionWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerAutoscalingPolicy) DeepCopyInto(out *WorkerAutoscalingPolicy) {
+	*out = *in
+	if in.MinReplicasPerZone != nil {
+		in, out := &in.MinReplicasPerZone, &out.MinReplicasPerZone
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicasPerZone != nil {
+		in, out := &in.MaxReplicasPerZone, &out.MaxReplicasPerZone
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedVMSizes != nil {
+		in, out := &in.AllowedVMSizes, &out.AllowedVMSizes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaleDownProtectionWindows != nil {
+		in, out := &in.ScaleDownProtectionWindows, &out.ScaleDownProtectionWindows
+		*out = make([]ScaleDownProtectionWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerAutoscalingPolicy.
+func (in *WorkerAutoscalingPolicy) DeepCopy() *WorkerAutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerAutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -53,10 +53,30 @@ type PreviewFeatureSpec struct {
 	NSGFlowLogs *NSGFlowLogs `json:"nsgFlowLogs,omitempty"`
 }
 
+// NSGFlowLogStatus reports the flow log applied to a network security group
+type NSGFlowLogStatus struct {
+	// NetworkSecurityGroupID is the ID of the network security group.
+	NetworkSecurityGroupID string `json:"networkSecurityGroupID"`
+
+	// FlowLogID is the ID of the flow log configured for the network
+	// security group.
+	FlowLogID string `json:"flowLogID,omitempty"`
+
+	// Enabled is true when the flow log has been applied.
+	Enabled bool `json:"enabled"`
+
+	// Error is the last error encountered applying or removing the flow log.
+	Error string `json:"error,omitempty"`
+}
+
 // PreviewFeatureStatus defines the observed state of PreviewFeature
 type PreviewFeatureStatus struct {
 	OperatorVersion string                         `json:"operatorVersion,omitempty"`
 	Conditions      []operatorv1.OperatorCondition `json:"conditions,omitempty"`
+
+	// NSGFlowLogs reports the flow logs applied to each network security
+	// group of the cluster.
+	NSGFlowLogs []NSGFlowLogStatus `json:"nsgFlowLogs,omitempty"`
 }
 
 // PreviewFeature is the Schema for the preview feature API
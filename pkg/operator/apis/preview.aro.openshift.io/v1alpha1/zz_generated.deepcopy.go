@@ -26,6 +26,21 @@ func (in *NSGFlowLogs) DeepCopy() *NSGFlowLogs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSGFlowLogStatus) DeepCopyInto(out *NSGFlowLogStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSGFlowLogStatus.
+func (in *NSGFlowLogStatus) DeepCopy() *NSGFlowLogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NSGFlowLogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PreviewFeature) DeepCopyInto(out *PreviewFeature) {
 	*out = *in
@@ -115,6 +130,11 @@ func (in *PreviewFeatureStatus) DeepCopyInto(out *PreviewFeatureStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NSGFlowLogs != nil {
+		in, out := &in.NSGFlowLogs, &out.NSGFlowLogs
+		*out = make([]NSGFlowLogStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewFeatureStatus.
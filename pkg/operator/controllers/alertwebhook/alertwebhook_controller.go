@@ -5,6 +5,7 @@ package alertwebhook
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	"github.com/ghodss/yaml"
@@ -41,7 +42,8 @@ func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
 	}
 }
 
-// Reconcile makes sure that the Alertmanager default webhook is set.
+// Reconcile makes sure that the Alertmanager default webhook is set to the
+// Receiver.
 func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	instance := &arov1alpha1.Cluster{}
 	err := r.client.Get(ctx, types.NamespacedName{Name: arov1alpha1.SingletonClusterName}, instance)
@@ -55,11 +57,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 	}
 
 	r.log.Debug("running")
-	return reconcile.Result{}, r.setAlertManagerWebhook(ctx, "http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8080/healthz/ready")
+	return reconcile.Result{}, r.setAlertManagerWebhook(ctx, fmt.Sprintf("http://aro-operator-master.openshift-azure-operator.svc.cluster.local:%d%s", ReceiverPort, WebhookPath))
 }
 
-// setAlertManagerWebhook is a hack to disable the
-// AlertmanagerReceiversNotConfigured warning added in 4.3.8.
+// setAlertManagerWebhook points the default Alertmanager receivers at the
+// Receiver.  This also disables the AlertmanagerReceiversNotConfigured warning
+// added in 4.3.8.  Every other receiver also sends to the Receiver, because
+// Alertmanager only notifies the receiver of the first route which matches an
+// alert, and the Receiver must see every alert, e.g. critical alerts routed to
+// the Critical receiver.
 func (r *Reconciler) setAlertManagerWebhook(ctx context.Context, addr string) error {
 	s := &corev1.Secret{}
 	err := r.client.Get(ctx, alertManagerName, s)
@@ -85,16 +91,28 @@ func (r *Reconciler) setAlertManagerWebhook(ctx context.Context, addr string) er
 			continue
 		}
 
-		if name, ok := r["name"].(string); !ok || (name != "null" && name != "Default") {
+		name, ok := r["name"].(string)
+		if !ok {
 			continue
 		}
 
-		webhookConfigs := []interface{}{
-			map[string]interface{}{"url": addr},
+		if name == "null" || name == "Default" {
+			webhookConfigs := []interface{}{
+				map[string]interface{}{"url": addr},
+			}
+
+			if !reflect.DeepEqual(r["webhook_configs"], webhookConfigs) {
+				r["webhook_configs"] = webhookConfigs
+				changed = true
+			}
+
+			continue
 		}
 
-		if !reflect.DeepEqual(r["webhook_configs"], webhookConfigs) {
-			r["webhook_configs"] = webhookConfigs
+		// keep the customer's webhooks on other receivers
+		webhookConfigs, _ := r["webhook_configs"].([]interface{})
+		if !hasWebhook(webhookConfigs, addr) {
+			r["webhook_configs"] = append(webhookConfigs, map[string]interface{}{"url": addr})
 			changed = true
 		}
 	}
@@ -111,6 +129,16 @@ func (r *Reconciler) setAlertManagerWebhook(ctx context.Context, addr string) er
 	return r.client.Update(ctx, s)
 }
 
+func hasWebhook(webhookConfigs []interface{}, addr string) bool {
+	for _, wc := range webhookConfigs {
+		if wc, ok := wc.(map[string]interface{}); ok && wc["url"] == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
 // SetupWithManager setup our manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	isAlertManagerPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
@@ -6,8 +6,10 @@ package alertwebhook
 import (
 	"bytes"
 	"context"
+	"reflect"
 	"testing"
 
+	"github.com/ghodss/yaml"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,7 +47,7 @@ global:
 receivers:
 - name: "null"
   webhook_configs:
-  - url: http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8080/healthz/ready
+  - url: http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8081/alertmanager/webhook
 route:
   group_by:
   - namespace
@@ -118,9 +120,13 @@ inhibit_rules:
 receivers:
 - name: Default
   webhook_configs:
-  - url: http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8080/healthz/ready
+  - url: http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8081/alertmanager/webhook
 - name: Watchdog
+  webhook_configs:
+  - url: http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8081/alertmanager/webhook
 - name: Critical
+  webhook_configs:
+  - url: http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8081/alertmanager/webhook
 route:
   group_by:
   - namespace
@@ -136,6 +142,37 @@ route:
       severity: critical
     receiver: Critical
 `)
+
+	initialCustomer = []byte(`
+"receivers":
+- "name": "Default"
+- "name": "Critical"
+  "webhook_configs":
+  - "url": "https://pager.example.com/"
+"route":
+  "receiver": "Default"
+  "routes":
+  - "match":
+      "severity": "critical"
+    "receiver": "Critical"
+`)
+
+	wantCustomer = []byte(`
+receivers:
+- name: Default
+  webhook_configs:
+  - url: http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8081/alertmanager/webhook
+- name: Critical
+  webhook_configs:
+  - url: https://pager.example.com/
+  - url: http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8081/alertmanager/webhook
+route:
+  receiver: Default
+  routes:
+  - match:
+      severity: critical
+    receiver: Critical
+`)
 )
 
 func TestSetAlertManagerWebhook(t *testing.T) {
@@ -159,6 +196,24 @@ func TestSetAlertManagerWebhook(t *testing.T) {
 			controllerEnabled: true,
 			want:              wantNew,
 		},
+		{
+			name:              "old cluster, already set",
+			alertmanagerYaml:  wantOld,
+			controllerEnabled: true,
+			want:              wantOld,
+		},
+		{
+			name:              "new cluster, already set",
+			alertmanagerYaml:  wantNew,
+			controllerEnabled: true,
+			want:              wantNew,
+		},
+		{
+			name:              "new cluster, customer webhook",
+			alertmanagerYaml:  initialCustomer,
+			controllerEnabled: true,
+			want:              wantCustomer,
+		},
 		{
 			name:              "old cluster, disabled",
 			alertmanagerYaml:  initialOld,
@@ -222,3 +277,89 @@ func TestSetAlertManagerWebhook(t *testing.T) {
 		})
 	}
 }
+
+// routeAlert returns the receivers which Alertmanager sends an alert with the
+// given labels to, following its routing rules: child routes are tried in
+// order, only exact matchers are supported, matching stops at the first
+// matching route unless it continues, and the parent's receiver is used if no
+// child route matches.
+func routeAlert(route map[string]interface{}, labels map[string]string) (receivers []string, matched bool) {
+	if match, ok := route["match"].(map[string]interface{}); ok {
+		for k, v := range match {
+			if labels[k] != v {
+				return nil, false
+			}
+		}
+	}
+
+	routes, _ := route["routes"].([]interface{})
+	var childMatched bool
+	for _, child := range routes {
+		r, m := routeAlert(child.(map[string]interface{}), labels)
+		if !m {
+			continue
+		}
+
+		receivers = append(receivers, r...)
+		childMatched = true
+
+		if cont, _ := child.(map[string]interface{})["continue"].(bool); !cont {
+			break
+		}
+	}
+
+	if !childMatched {
+		receivers = append(receivers, route["receiver"].(string))
+	}
+
+	return receivers, true
+}
+
+func TestAlertRouting(t *testing.T) {
+	var am map[string]interface{}
+	err := yaml.Unmarshal(wantNew, &am)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webhooks := map[string][]interface{}{}
+	for _, r := range am["receivers"].([]interface{}) {
+		r := r.(map[string]interface{})
+		webhooks[r["name"].(string)], _ = r["webhook_configs"].([]interface{})
+	}
+
+	for _, tt := range []struct {
+		name   string
+		labels map[string]string
+		want   []string
+	}{
+		{
+			name:   "critical alert",
+			labels: map[string]string{"alertname": "KubeAPIDown", "severity": "critical"},
+			want:   []string{"Critical"},
+		},
+		{
+			name:   "warning alert",
+			labels: map[string]string{"alertname": "KubePodCrashLooping", "severity": "warning"},
+			want:   []string{"Default"},
+		},
+		{
+			name:   "watchdog",
+			labels: map[string]string{"alertname": "Watchdog", "severity": "none"},
+			want:   []string{"Watchdog"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			receivers, _ := routeAlert(am["route"].(map[string]interface{}), tt.labels)
+			if !reflect.DeepEqual(receivers, tt.want) {
+				t.Fatal(receivers)
+			}
+
+			for _, r := range receivers {
+				if !hasWebhook(webhooks[r], "http://aro-operator-master.openshift-azure-operator.svc.cluster.local:8081/alertmanager/webhook") {
+					t.Errorf("receiver %s does not send to the Receiver", r)
+				}
+			}
+		})
+	}
+}
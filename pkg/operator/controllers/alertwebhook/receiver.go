@@ -0,0 +1,289 @@
+package alertwebhook
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/conditions"
+	"github.com/Azure/ARO-RP/pkg/util/namespace"
+)
+
+const (
+	// ReceiverPort is the port on which the receiver listens; it is exposed
+	// by the aro-operator-master service
+	ReceiverPort = 8081
+
+	// WebhookPath receives notifications from Alertmanager
+	WebhookPath = "/alertmanager/webhook"
+
+	// AlertsPath serves an AlertSummary of the firing alerts
+	AlertsPath = "/alerts"
+
+	maxNotificationSize = 10 << 20
+)
+
+// webhookMessage is the payload Alertmanager POSTs to webhook receivers.  Only
+// the fields used by the receiver are decoded.
+type webhookMessage struct {
+	GroupKey string         `json:"groupKey"`
+	Alerts   []webhookAlert `json:"alerts"`
+}
+
+type webhookAlert struct {
+	Status       string         `json:"status"`
+	Labels       model.LabelSet `json:"labels"`
+	Annotations  model.LabelSet `json:"annotations"`
+	StartsAt     time.Time      `json:"startsAt"`
+	EndsAt       time.Time      `json:"endsAt"`
+	GeneratorURL string         `json:"generatorURL"`
+}
+
+// AlertSummary aggregates the firing alerts known to Alertmanager
+type AlertSummary struct {
+	// Count is the number of distinct firing alerts
+	Count int64 `json:"count"`
+
+	Groups []AlertGroup `json:"groups,omitempty"`
+}
+
+// AlertGroup counts the firing alerts sharing a name, severity and namespace
+type AlertGroup struct {
+	Name      string `json:"name"`
+	Severity  string `json:"severity,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Count     int64  `json:"count"`
+}
+
+// Summarize aggregates alerts, counting alerts with identical labels once
+func Summarize(alerts []model.Alert) *AlertSummary {
+	seen := map[model.Fingerprint]struct{}{}
+	m := map[AlertGroup]int64{}
+
+	for _, alert := range alerts {
+		fp := alert.Labels.Fingerprint()
+		if _, found := seen[fp]; found {
+			continue
+		}
+		seen[fp] = struct{}{}
+
+		m[AlertGroup{
+			Name:      alert.Name(),
+			Severity:  string(alert.Labels["severity"]),
+			Namespace: string(alert.Labels["namespace"]),
+		}]++
+	}
+
+	summary := &AlertSummary{
+		Count: int64(len(seen)),
+	}
+
+	for g, count := range m {
+		g.Count = count
+		summary.Groups = append(summary.Groups, g)
+	}
+
+	sort.Slice(summary.Groups, func(i, j int) bool {
+		a, b := summary.Groups[i], summary.Groups[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Severity < b.Severity
+	})
+
+	return summary
+}
+
+// Receiver is the Alertmanager webhook receiver configured by the Reconciler.
+// Alertmanager sends the full state of an alert group with every
+// notification, so the receiver keeps the firing alerts of the latest
+// notification for each group.  It records firing critical alerts in
+// OpenShift namespaces as the CriticalAlertsFiring condition, and serves an
+// AlertSummary to the RP monitor.
+//
+// Alerts are only held in memory.  Until the first notification after the
+// operator starts, the receiver does not know which alerts are firing: it
+// serves 503 and leaves the condition untouched.
+type Receiver struct {
+	log *logrus.Entry
+
+	client client.Client
+
+	mu       sync.Mutex
+	groups   map[string][]model.Alert
+	received bool
+	message  *string
+}
+
+func NewReceiver(log *logrus.Entry, client client.Client) *Receiver {
+	return &Receiver{
+		log:    log,
+		client: client,
+		groups: map[string][]model.Alert{},
+	}
+}
+
+// Start implements manager.Runnable
+func (r *Receiver) Start(ctx context.Context) error {
+	s := &http.Server{
+		Addr:              fmt.Sprintf(":%d", ReceiverPort),
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = s.Shutdown(context.Background())
+	}()
+
+	err := s.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.Path == WebhookPath && req.Method == http.MethodPost:
+		r.handleWebhook(w, req)
+	case req.URL.Path == AlertsPath && req.Method == http.MethodGet:
+		r.handleAlerts(w, req)
+	case req.URL.Path == WebhookPath || req.URL.Path == AlertsPath:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (r *Receiver) handleWebhook(w http.ResponseWriter, req *http.Request) {
+	var msg webhookMessage
+	err := json.NewDecoder(io.LimitReader(req.Body, maxNotificationSize)).Decode(&msg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var firing []model.Alert
+	for _, a := range msg.Alerts {
+		if a.Status != string(model.AlertFiring) {
+			continue
+		}
+
+		firing = append(firing, model.Alert{
+			Labels:       a.Labels,
+			Annotations:  a.Annotations,
+			StartsAt:     a.StartsAt,
+			EndsAt:       a.EndsAt,
+			GeneratorURL: a.GeneratorURL,
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(firing) > 0 {
+		r.groups[msg.GroupKey] = firing
+	} else {
+		delete(r.groups, msg.GroupKey)
+	}
+	r.received = true
+
+	// a failure to record the condition is returned to Alertmanager, which
+	// will retry the notification
+	err = r.setCondition(req.Context())
+	if err != nil {
+		r.log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *Receiver) handleAlerts(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	if !r.received {
+		r.mu.Unlock()
+		http.Error(w, "no notification received from Alertmanager", http.StatusServiceUnavailable)
+		return
+	}
+	summary := Summarize(r.alerts())
+	r.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(summary)
+}
+
+// alerts returns the firing alerts of every group.  Callers must hold r.mu.
+func (r *Receiver) alerts() []model.Alert {
+	var alerts []model.Alert
+	for _, group := range r.groups {
+		alerts = append(alerts, group...)
+	}
+	return alerts
+}
+
+// setCondition records the firing critical alerts, if they have changed since
+// they were last recorded.  Callers must hold r.mu.
+func (r *Receiver) setCondition(ctx context.Context) error {
+	names := map[string]struct{}{}
+	for _, alert := range r.alerts() {
+		if alert.Labels["severity"] != "critical" ||
+			!namespace.IsOpenShiftNamespace(string(alert.Labels["namespace"])) {
+			continue
+		}
+		names[alert.Name()] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	condition := &operatorv1.OperatorCondition{
+		Type:    arov1alpha1.CriticalAlertsFiring,
+		Status:  operatorv1.ConditionFalse,
+		Message: "No critical alerts are firing",
+		Reason:  "NoAlertsFiring",
+	}
+	if len(sorted) > 0 {
+		condition.Status = operatorv1.ConditionTrue
+		condition.Message = "Critical alerts are firing: " + strings.Join(sorted, ", ")
+		condition.Reason = "AlertsFiring"
+	}
+
+	if r.message != nil && *r.message == condition.Message {
+		return nil
+	}
+
+	err := conditions.SetCondition(ctx, r.client, condition, operator.RoleMaster)
+	if err != nil {
+		return err
+	}
+
+	r.message = &condition.Message
+	return nil
+}
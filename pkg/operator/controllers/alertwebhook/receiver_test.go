@@ -0,0 +1,176 @@
+package alertwebhook
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+)
+
+func TestSummarize(t *testing.T) {
+	alert := func(name, namespace, severity, pod string) model.Alert {
+		return model.Alert{
+			Labels: model.LabelSet{
+				model.AlertNameLabel: model.LabelValue(name),
+				"namespace":          model.LabelValue(namespace),
+				"severity":           model.LabelValue(severity),
+				"pod":                model.LabelValue(pod),
+			},
+		}
+	}
+
+	summary := Summarize([]model.Alert{
+		alert("KubePodCrashLooping", "openshift-dns", "warning", "a"),
+		alert("KubePodCrashLooping", "openshift-dns", "warning", "b"),
+		alert("KubePodCrashLooping", "openshift-dns", "warning", "a"),
+		alert("KubePodCrashLooping", "customer", "warning", "c"),
+		alert("EtcdMembersDown", "openshift-etcd", "critical", "d"),
+	})
+
+	want := &AlertSummary{
+		Count: 4,
+		Groups: []AlertGroup{
+			{Name: "EtcdMembersDown", Severity: "critical", Namespace: "openshift-etcd", Count: 1},
+			{Name: "KubePodCrashLooping", Severity: "warning", Namespace: "customer", Count: 1},
+			{Name: "KubePodCrashLooping", Severity: "warning", Namespace: "openshift-dns", Count: 2},
+		},
+	}
+
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("got %#v, want %#v", summary, want)
+	}
+}
+
+func TestReceiver(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &arov1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: arov1alpha1.SingletonClusterName,
+		},
+	}
+
+	client := ctrlfake.NewClientBuilder().WithObjects(cluster).Build()
+	r := NewReceiver(logrus.NewEntry(logrus.StandardLogger()), client)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	getCondition := func() *operatorv1.OperatorCondition {
+		cluster := &arov1alpha1.Cluster{}
+		err := client.Get(ctx, types.NamespacedName{Name: arov1alpha1.SingletonClusterName}, cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range cluster.Status.Conditions {
+			if c.Type == arov1alpha1.CriticalAlertsFiring {
+				return &c
+			}
+		}
+		return nil
+	}
+
+	getSummary := func() *AlertSummary {
+		w := do(http.MethodGet, AlertsPath, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("got status %d", w.Code)
+		}
+
+		var summary *AlertSummary
+		err := json.Unmarshal(w.Body.Bytes(), &summary)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return summary
+	}
+
+	// until the first notification, the firing alerts are unknown
+	if w := do(http.MethodGet, AlertsPath, ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d", w.Code)
+	}
+	if c := getCondition(); c != nil {
+		t.Errorf("unexpected condition %#v", c)
+	}
+
+	if w := do(http.MethodGet, WebhookPath, ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("got status %d", w.Code)
+	}
+
+	if w := do(http.MethodPost, WebhookPath, "not json"); w.Code != http.StatusBadRequest {
+		t.Errorf("got status %d", w.Code)
+	}
+
+	// a critical alert in one group and a warning in another; the watchdog
+	// alert is repeated in both groups and is only counted once
+	if w := do(http.MethodPost, WebhookPath, `{
+		"groupKey": "etcd",
+		"alerts": [
+			{"status": "firing", "labels": {"alertname": "EtcdMembersDown", "namespace": "openshift-etcd", "severity": "critical"}},
+			{"status": "resolved", "labels": {"alertname": "EtcdNoLeader", "namespace": "openshift-etcd", "severity": "critical"}},
+			{"status": "firing", "labels": {"alertname": "Watchdog", "namespace": "openshift-monitoring", "severity": "none"}}
+		]
+	}`); w.Code != http.StatusOK {
+		t.Fatalf("got status %d", w.Code)
+	}
+	if w := do(http.MethodPost, WebhookPath, `{
+		"groupKey": "dns",
+		"alerts": [
+			{"status": "firing", "labels": {"alertname": "KubePodCrashLooping", "namespace": "openshift-dns", "severity": "warning"}},
+			{"status": "firing", "labels": {"alertname": "CustomerAlert", "namespace": "customer", "severity": "critical"}},
+			{"status": "firing", "labels": {"alertname": "Watchdog", "namespace": "openshift-monitoring", "severity": "none"}}
+		]
+	}`); w.Code != http.StatusOK {
+		t.Fatalf("got status %d", w.Code)
+	}
+
+	summary := getSummary()
+	if summary.Count != 4 || len(summary.Groups) != 4 {
+		t.Errorf("unexpected summary %#v", summary)
+	}
+
+	c := getCondition()
+	if c == nil || c.Status != operatorv1.ConditionTrue || c.Message != "Critical alerts are firing: EtcdMembersDown" {
+		t.Errorf("unexpected condition %#v", c)
+	}
+
+	// resolving the etcd group clears the condition
+	if w := do(http.MethodPost, WebhookPath, `{
+		"groupKey": "etcd",
+		"alerts": [
+			{"status": "resolved", "labels": {"alertname": "EtcdMembersDown", "namespace": "openshift-etcd", "severity": "critical"}}
+		]
+	}`); w.Code != http.StatusOK {
+		t.Fatalf("got status %d", w.Code)
+	}
+
+	summary = getSummary()
+	if summary.Count != 3 {
+		t.Errorf("unexpected summary %#v", summary)
+	}
+
+	c = getCondition()
+	if c == nil || c.Status != operatorv1.ConditionFalse || c.Reason != "NoAlertsFiring" {
+		t.Errorf("unexpected condition %#v", c)
+	}
+}
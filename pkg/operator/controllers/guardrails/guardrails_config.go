@@ -55,6 +55,11 @@ const (
 
 	defaultReconciliationMinutes = "60"
 
+	policyEnforcementDeny    = "deny"
+	policyEnforcementWarn    = "warn"
+	policyEnforcementDryRun  = "dryrun"
+	defaultPolicyEnforcement = policyEnforcementDryRun
+
 	defaultValidatingWebhookFailurePolicy = "Ignore"
 	defaultValidatingWebhookTimeout       = "3"
 	defaultMutatingWebhookFailurePolicy   = "Ignore"
@@ -123,6 +123,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 			if err != nil {
 				return reconcile.Result{}, err
 			}
+
+			// Record the latest Gatekeeper audit results
+			err = r.setViolationsCondition(ctx)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
 		}
 
 		// start a ticker to re-enforce gatekeeper policies periodically
@@ -153,6 +159,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 			if err != nil {
 				r.log.Warnf("failed to remove ConstraintTemplates with error %s", err.Error())
 			}
+
+			err = r.clearViolationsCondition(ctx)
+			if err != nil {
+				r.log.Warnf("failed to clear policy violations condition with error %s", err.Error())
+			}
 		}
 		err = r.deployer.Remove(ctx, config.GuardRailsDeploymentConfig{Namespace: r.namespace})
 		if err != nil {
@@ -32,14 +32,28 @@ func (r *Reconciler) getPolicyConfig(ctx context.Context, instance *arov1alpha1.
 	name := parts[0]
 
 	managedPath := fmt.Sprintf(controllerPolicyManagedTemplate, name)
-	managed := instance.Spec.OperatorFlags.GetWithDefault(managedPath, "false")
+	managed := strings.ToLower(instance.Spec.OperatorFlags.GetWithDefault(managedPath, "false"))
 
 	enforcementPath := fmt.Sprintf(controllerPolicyEnforcementTemplate, name)
-	enforcement := instance.Spec.OperatorFlags.GetWithDefault(enforcementPath, "dryrun")
+	enforcement := strings.ToLower(instance.Spec.OperatorFlags.GetWithDefault(enforcementPath, defaultPolicyEnforcement))
+	if !isValidEnforcement(enforcement) {
+		r.log.Warnf("invalid enforcement %q for policy %s, using %s", enforcement, name, defaultPolicyEnforcement)
+		enforcement = defaultPolicyEnforcement
+	}
 
 	return managed, enforcement, nil
 }
 
+// isValidEnforcement returns true if enforcement is a Gatekeeper enforcement
+// action
+func isValidEnforcement(enforcement string) bool {
+	switch enforcement {
+	case policyEnforcementDeny, policyEnforcementWarn, policyEnforcementDryRun:
+		return true
+	}
+	return false
+}
+
 func (r *Reconciler) ensurePolicy(ctx context.Context, fs embed.FS, path string) error {
 	template, err := template.ParseFS(fs, filepath.Join(path, "*"))
 	if err != nil {
@@ -62,6 +76,7 @@ func (r *Reconciler) ensurePolicy(ctx context.Context, fs embed.FS, path string)
 		policyConfig := &config.GuardRailsPolicyConfig{
 			Enforcement: enforcement,
 		}
+		buffer.Reset()
 		err = templ.Execute(buffer, policyConfig)
 		if err != nil {
 			return err
@@ -97,6 +112,7 @@ func (r *Reconciler) removePolicy(ctx context.Context, fs embed.FS, path string)
 	}
 	buffer := new(bytes.Buffer)
 	for _, templ := range template.Templates() {
+		buffer.Reset()
 		err := templ.Execute(buffer, nil)
 		if err != nil {
 			return err
@@ -141,6 +157,11 @@ func (r *Reconciler) policyTicker(ctx context.Context, instance *arov1alpha1.Clu
 			if err != nil {
 				r.log.Errorf("policyTicker ensurePolicy error %s", err.Error())
 			}
+
+			err = r.setViolationsCondition(ctx)
+			if err != nil {
+				r.log.Errorf("policyTicker setViolationsCondition error %s", err.Error())
+			}
 		}
 	}
 }
@@ -0,0 +1,117 @@
+package guardrails
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	mock_dynamichelper "github.com/Azure/ARO-RP/pkg/util/mocks/dynamichelper"
+)
+
+func TestEnsurePolicy(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		flags       arov1alpha1.OperatorFlags
+		wantEnsured map[string]string
+	}{
+		{
+			name: "no policies managed",
+		},
+		{
+			name: "policies managed individually",
+			flags: arov1alpha1.OperatorFlags{
+				"aro.guardrails.policies.aro-machines-deny.managed":        "true",
+				"aro.guardrails.policies.aro-machines-deny.enforcement":    "deny",
+				"aro.guardrails.policies.aro-pull-secret-deny.managed":     "True",
+				"aro.guardrails.policies.aro-pull-secret-deny.enforcement": "Warn",
+				"aro.guardrails.policies.aro-machine-config-deny.managed":  "true",
+				// aro-machine-config-deny enforcement defaults to dryrun
+				"aro.guardrails.policies.aro-master-toleration-pod-deny.managed":     "true",
+				"aro.guardrails.policies.aro-master-toleration-pod-deny.enforcement": "block",
+				"aro.guardrails.policies.aro-privileged-namespace-deny.managed":      "false",
+			},
+			wantEnsured: map[string]string{
+				"aro-machines-deny":              "deny",
+				"aro-pull-secret-deny":           "warn",
+				"aro-machine-config-deny":        "dryrun",
+				"aro-master-toleration-pod-deny": "dryrun",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			cluster := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: tt.flags,
+				},
+			}
+
+			dh := mock_dynamichelper.NewMockInterface(controller)
+
+			var deleted []string
+			dh.EXPECT().EnsureDeletedGVR(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Do(func(ctx context.Context, groupKind, namespace, name, version string) {
+					deleted = append(deleted, name)
+				}).Return(nil).AnyTimes()
+
+			ensured := map[string]string{}
+			dh.EXPECT().Ensure(gomock.Any(), gomock.Any()).
+				Do(func(ctx context.Context, objs ...kruntime.Object) {
+					for _, o := range objs {
+						uns := o.(*unstructured.Unstructured)
+						ensured[uns.GetName()], _, _ = unstructured.NestedString(uns.Object, "spec", "enforcementAction")
+					}
+				}).Return(nil)
+
+			r := &Reconciler{
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				client: ctrlfake.NewClientBuilder().WithObjects(cluster).Build(),
+				dh:     dh,
+			}
+
+			err := r.ensurePolicy(context.Background(), gkPolicyConstraints, gkConstraintsPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantEnsured == nil {
+				tt.wantEnsured = map[string]string{}
+			}
+			for _, diff := range deep.Equal(ensured, tt.wantEnsured) {
+				t.Error(diff)
+			}
+
+			// every policy which is not managed is removed
+			wantDeleted := []string{}
+			for _, name := range []string{"aro-machine-config-deny", "aro-machines-deny", "aro-master-toleration-pod-deny", "aro-privileged-namespace-deny", "aro-pull-secret-deny"} {
+				if _, found := tt.wantEnsured[name]; !found {
+					wantDeleted = append(wantDeleted, name)
+				}
+			}
+			sort.Strings(deleted)
+			if deleted == nil {
+				deleted = []string{}
+			}
+			for _, diff := range deep.Equal(deleted, wantDeleted) {
+				t.Error(diff)
+			}
+		})
+	}
+}
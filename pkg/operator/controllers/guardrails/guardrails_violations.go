@@ -0,0 +1,128 @@
+package guardrails
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/conditions"
+)
+
+var (
+	constraintTemplateListGVK = schema.GroupVersionKind{Group: "templates.gatekeeper.sh", Version: "v1", Kind: "ConstraintTemplateList"}
+	constraintGV              = schema.GroupVersion{Group: "constraints.gatekeeper.sh", Version: "v1beta1"}
+)
+
+// ConstraintViolations is the result of the latest Gatekeeper audit of a
+// constraint
+type ConstraintViolations struct {
+	Kind              string
+	Name              string
+	EnforcementAction string
+	TotalViolations   int64
+}
+
+// GetConstraintViolations returns the audit results of the constraints of
+// every ConstraintTemplate on the cluster, sorted by name.  It returns no
+// results if Gatekeeper is not installed.
+func GetConstraintViolations(ctx context.Context, c client.Client) ([]ConstraintViolations, error) {
+	templates := &unstructured.UnstructuredList{}
+	templates.SetGroupVersionKind(constraintTemplateListGVK)
+
+	err := c.List(ctx, templates)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ConstraintViolations
+	for _, template := range templates.Items {
+		kind, _, _ := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind")
+		if kind == "" {
+			continue
+		}
+
+		constraints := &unstructured.UnstructuredList{}
+		constraints.SetGroupVersionKind(constraintGV.WithKind(kind + "List"))
+
+		err = c.List(ctx, constraints)
+		if meta.IsNoMatchError(err) {
+			// Gatekeeper has not yet created the constraint CRD
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, constraint := range constraints.Items {
+			enforcementAction, _, _ := unstructured.NestedString(constraint.Object, "spec", "enforcementAction")
+			totalViolations, _, _ := unstructured.NestedInt64(constraint.Object, "status", "totalViolations")
+
+			results = append(results, ConstraintViolations{
+				Kind:              kind,
+				Name:              constraint.GetName(),
+				EnforcementAction: enforcementAction,
+				TotalViolations:   totalViolations,
+			})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	return results, nil
+}
+
+// setViolationsCondition records the constraints with audit violations
+func (r *Reconciler) setViolationsCondition(ctx context.Context) error {
+	results, err := GetConstraintViolations(ctx, r.client)
+	if err != nil {
+		return err
+	}
+
+	var violations []string
+	for _, result := range results {
+		if result.TotalViolations > 0 {
+			violations = append(violations, fmt.Sprintf("%s (%s): %d", result.Name, result.EnforcementAction, result.TotalViolations))
+		}
+	}
+
+	condition := &operatorv1.OperatorCondition{
+		Type:    arov1alpha1.GuardRailsPolicyViolations,
+		Status:  operatorv1.ConditionFalse,
+		Message: "No policy violations found by the Gatekeeper audit",
+		Reason:  "NoViolations",
+	}
+	if len(violations) > 0 {
+		condition.Status = operatorv1.ConditionTrue
+		condition.Message = "Policy violations found by the Gatekeeper audit: " + strings.Join(violations, ", ")
+		condition.Reason = "ViolationsFound"
+	}
+
+	return conditions.SetCondition(ctx, r.client, condition, operator.RoleMaster)
+}
+
+// clearViolationsCondition records that policies are no longer audited.  The
+// condition is False, as the monitor expects, because undeployed policies
+// cannot be violated.
+func (r *Reconciler) clearViolationsCondition(ctx context.Context) error {
+	return conditions.SetCondition(ctx, r.client, &operatorv1.OperatorCondition{
+		Type:    arov1alpha1.GuardRailsPolicyViolations,
+		Status:  operatorv1.ConditionFalse,
+		Message: "Guardrails policies are not deployed",
+		Reason:  "NotDeployed",
+	}, operator.RoleMaster)
+}
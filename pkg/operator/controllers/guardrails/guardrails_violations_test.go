@@ -0,0 +1,185 @@
+package guardrails
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+)
+
+func TestSetViolationsCondition(t *testing.T) {
+	ctx := context.Background()
+
+	template := func(name, kind string) client.Object {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "templates.gatekeeper.sh/v1",
+				"kind":       "ConstraintTemplate",
+				"metadata": map[string]interface{}{
+					"name": name,
+				},
+				"spec": map[string]interface{}{
+					"crd": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"names": map[string]interface{}{
+								"kind": kind,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	constraint := func(kind, name, enforcementAction string, totalViolations int64) client.Object {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "constraints.gatekeeper.sh/v1beta1",
+				"kind":       kind,
+				"metadata": map[string]interface{}{
+					"name": name,
+				},
+				"spec": map[string]interface{}{
+					"enforcementAction": enforcementAction,
+				},
+				"status": map[string]interface{}{
+					"totalViolations": totalViolations,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		objects        []client.Object
+		wantViolations []ConstraintViolations
+		wantCondition  operatorv1.OperatorCondition
+	}{
+		{
+			name: "gatekeeper not installed",
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.GuardRailsPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "No policy violations found by the Gatekeeper audit",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name: "violations found",
+			objects: []client.Object{
+				template("arodenylabels", "ARODenyLabels"),
+				template("arodenydeletepullsecret", "ARODenyDeletePullSecret"),
+				constraint("ARODenyLabels", "aro-machines-deny", "deny", 2),
+				constraint("ARODenyDeletePullSecret", "aro-pull-secret-deny", "dryrun", 0),
+			},
+			wantViolations: []ConstraintViolations{
+				{Kind: "ARODenyLabels", Name: "aro-machines-deny", EnforcementAction: "deny", TotalViolations: 2},
+				{Kind: "ARODenyDeletePullSecret", Name: "aro-pull-secret-deny", EnforcementAction: "dryrun", TotalViolations: 0},
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.GuardRailsPolicyViolations,
+				Status:  operatorv1.ConditionTrue,
+				Message: "Policy violations found by the Gatekeeper audit: aro-machines-deny (deny): 2",
+				Reason:  "ViolationsFound",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+			}
+
+			client := ctrlfake.NewClientBuilder().WithObjects(append(tt.objects, cluster)...).Build()
+
+			violations, err := GetConstraintViolations(ctx, client)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(violations, tt.wantViolations) {
+				t.Errorf("got violations %#v, want %#v", violations, tt.wantViolations)
+			}
+
+			r := &Reconciler{
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				client: client,
+			}
+
+			err = r.setViolationsCondition(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = client.Get(ctx, types.NamespacedName{Name: arov1alpha1.SingletonClusterName}, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(cluster.Status.Conditions) != 1 {
+				t.Fatalf("got %d conditions", len(cluster.Status.Conditions))
+			}
+
+			c := cluster.Status.Conditions[0]
+			c.LastTransitionTime = metav1.Time{}
+			if !reflect.DeepEqual(c, tt.wantCondition) {
+				t.Errorf("got condition %#v, want %#v", c, tt.wantCondition)
+			}
+		})
+	}
+}
+
+func TestClearViolationsCondition(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &arov1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: arov1alpha1.SingletonClusterName,
+		},
+	}
+
+	r := &Reconciler{
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		client: ctrlfake.NewClientBuilder().WithObjects(cluster).Build(),
+	}
+
+	err := r.clearViolationsCondition(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.client.Get(ctx, types.NamespacedName{Name: arov1alpha1.SingletonClusterName}, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cluster.Status.Conditions) != 1 {
+		t.Fatalf("got %d conditions", len(cluster.Status.Conditions))
+	}
+
+	c := cluster.Status.Conditions[0]
+	c.LastTransitionTime = metav1.Time{}
+	want := operatorv1.OperatorCondition{
+		Type:    arov1alpha1.GuardRailsPolicyViolations,
+		Status:  operatorv1.ConditionFalse,
+		Message: "Guardrails policies are not deployed",
+		Reason:  "NotDeployed",
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("got condition %#v, want %#v", c, want)
+	}
+}
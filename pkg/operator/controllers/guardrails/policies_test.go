@@ -0,0 +1,284 @@
+package guardrails
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	templatesv1 "github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1"
+	"github.com/open-policy-agent/frameworks/constraint/pkg/client/drivers/local"
+	"github.com/open-policy-agent/frameworks/constraint/pkg/core/templates"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/guardrails/config"
+)
+
+// The tests in this file are a harness for the policies under policies/.  They
+// check that the generated ConstraintTemplates are up to date with their Rego
+// sources and that every Constraint refers to a ConstraintTemplate, and they
+// evaluate the gator suite of each ConstraintTemplate against its fixture
+// objects without a cluster, using the constraint framework's local driver as
+// Gatekeeper does.
+
+const (
+	templateSrcPath = "policies/gktemplates-src"
+	libraryPath     = "policies/gktemplates-src/library"
+
+	// gatekeeperTarget is the target of Gatekeeper's admission webhook
+	gatekeeperTarget = "admission.k8s.gatekeeper.sh"
+)
+
+// gatorSuite is the subset of the gator Suite format used by the policies
+type gatorSuite struct {
+	Tests []struct {
+		Name       string `json:"name"`
+		Template   string `json:"template"`
+		Constraint string `json:"constraint"`
+		Cases      []struct {
+			Name       string `json:"name"`
+			Object     string `json:"object"`
+			Assertions []struct {
+				Violations interface{} `json:"violations"`
+				Message    *string     `json:"message"`
+			} `json:"assertions"`
+		} `json:"cases"`
+	} `json:"tests"`
+}
+
+func readConstraintTemplate(t *testing.T, path string) *templatesv1.ConstraintTemplate {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ct := &templatesv1.ConstraintTemplate{}
+	err = yaml.Unmarshal(b, ct)
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+
+	if len(ct.Spec.Targets) != 1 {
+		t.Fatalf("%s: expected 1 target, got %d", path, len(ct.Spec.Targets))
+	}
+
+	return ct
+}
+
+// readConstraint renders a constraint as the operator does, with the given
+// enforcement action
+func readConstraint(t *testing.T, path, enforcement string) *unstructured.Unstructured {
+	tmpl, err := template.ParseFiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = tmpl.Execute(buf, &config.GuardRailsPolicyConfig{Enforcement: enforcement})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := &unstructured.Unstructured{}
+	err = yaml.Unmarshal(buf.Bytes(), &o.Object)
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+
+	return o
+}
+
+func policySourceDirs(t *testing.T) []string {
+	dirs, err := filepath.Glob(filepath.Join(templateSrcPath, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var policies []string
+	for _, dir := range dirs {
+		if dir != libraryPath {
+			policies = append(policies, dir)
+		}
+	}
+
+	return policies
+}
+
+func TestPolicySources(t *testing.T) {
+	library, err := os.ReadFile(filepath.Join(libraryPath, "common.rego"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kinds := map[string]struct{}{}
+
+	for _, dir := range policySourceDirs(t) {
+		name := filepath.Base(dir)
+
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join(dir, "src.rego"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, file := range []string{"src_test.rego", "suite.yaml", name + ".tmpl"} {
+				_, err = os.Stat(filepath.Join(dir, file))
+				if err != nil {
+					t.Error(err)
+				}
+			}
+
+			ct := readConstraintTemplate(t, filepath.Join(gkTemplatePath, name+".yaml"))
+			kinds[ct.Spec.CRD.Spec.Names.Kind] = struct{}{}
+
+			target := ct.Spec.Targets[0]
+			if strings.TrimSpace(target.Rego) != strings.TrimSpace(string(src)) {
+				t.Errorf("%s is out of date with src.rego: run make generate-guardrails", name)
+			}
+
+			for _, lib := range target.Libs {
+				if strings.TrimSpace(lib) != strings.TrimSpace(string(library)) {
+					t.Errorf("%s is out of date with common.rego: run make generate-guardrails", name)
+				}
+			}
+		})
+	}
+
+	constraints, err := filepath.Glob(filepath.Join(gkConstraintsPath, "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range constraints {
+		for _, enforcement := range []string{policyEnforcementDeny, policyEnforcementWarn, policyEnforcementDryRun} {
+			o := readConstraint(t, path, enforcement)
+
+			if _, found := kinds[o.GetKind()]; !found {
+				t.Errorf("%s: no ConstraintTemplate for kind %s", path, o.GetKind())
+			}
+
+			action, _, _ := unstructured.NestedString(o.Object, "spec", "enforcementAction")
+			if action != enforcement {
+				t.Errorf("%s: got enforcementAction %q, want %q", path, action, enforcement)
+			}
+
+			if o.GetName()+".yaml" != filepath.Base(path) {
+				t.Errorf("%s: name %s does not match the file name, so cannot be toggled", path, o.GetName())
+			}
+		}
+	}
+}
+
+func TestPolicySuites(t *testing.T) {
+	ctx := context.Background()
+
+	for _, dir := range policySourceDirs(t) {
+		b, err := os.ReadFile(filepath.Join(dir, "suite.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var suite gatorSuite
+		err = yaml.Unmarshal(b, &suite)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, test := range suite.Tests {
+			driver, err := local.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ct := &templates.ConstraintTemplate{}
+			err = templatesv1.Convert_v1_ConstraintTemplate_To_templates_ConstraintTemplate(readConstraintTemplate(t, filepath.Join(dir, test.Template)), ct, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = driver.AddTemplate(ctx, ct)
+			if err != nil {
+				t.Fatalf("%s: %v", test.Template, err)
+			}
+
+			// suites refer to the constraints which test.sh expands under
+			// gkconstraints-test; read and expand them directly instead
+			constraint := readConstraint(t, filepath.Join(gkConstraintsPath, filepath.Base(test.Constraint)), policyEnforcementDeny)
+
+			err = driver.AddConstraint(ctx, constraint)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, c := range test.Cases {
+				t.Run(filepath.Base(dir)+"/"+test.Name+"/"+c.Name, func(t *testing.T) {
+					msgs := evaluatePolicy(ctx, t, driver, constraint, filepath.Join(dir, c.Object))
+
+					for _, a := range c.Assertions {
+						var matching int
+						for _, msg := range msgs {
+							if a.Message == nil || regexp.MustCompile(*a.Message).MatchString(msg) {
+								matching++
+							}
+						}
+
+						switch v := a.Violations.(type) {
+						case bool:
+							if v != (matching > 0) {
+								t.Errorf("got violations %q, want violations: %v", msgs, v)
+							}
+						case float64:
+							if int(v) != matching {
+								t.Errorf("got violations %q, want %d", msgs, int(v))
+							}
+						default:
+							t.Fatalf("unsupported assertion %#v", a.Violations)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// evaluatePolicy evaluates constraint against the admission request in path,
+// as Gatekeeper does, returning the violation messages
+func evaluatePolicy(ctx context.Context, t *testing.T, driver *local.Driver, constraint *unstructured.Unstructured, path string) []string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var review struct {
+		Request map[string]interface{} `json:"request"`
+	}
+	err = yaml.Unmarshal(b, &review)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Gatekeeper reviews the old object of a DELETE request
+	if review.Request["object"] == nil {
+		review.Request["object"] = review.Request["oldObject"]
+	}
+
+	results, _, err := driver.Query(ctx, gatekeeperTarget, []*unstructured.Unstructured{constraint}, review.Request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var msgs []string
+	for _, result := range results {
+		msgs = append(msgs, result.Msg)
+	}
+
+	return msgs
+}
@@ -6,4 +6,14 @@ package machineset
 const (
 	machineSetsNamespace = "openshift-machine-api"
 	minSupportedReplicas = 2
+
+	// the cluster autoscaler operator sets these annotations on the
+	// MachineSets targeted by a MachineAutoscaler
+	autoscalerMinSizeAnnotation = "machine.openshift.io/cluster-api-autoscaler-node-group-min-size"
+	autoscalerMaxSizeAnnotation = "machine.openshift.io/cluster-api-autoscaler-node-group-max-size"
+
+	// allowedVMSizeAnnotation records the last VM size of a MachineSet which
+	// the worker autoscaling policy allowed, which disallowed VM sizes are
+	// reverted to
+	allowedVMSizeAnnotation = "aro.openshift.io/allowed-vm-size"
 )
@@ -6,20 +6,26 @@ package machineset
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
 )
 
 const (
@@ -28,16 +34,24 @@ const (
 
 type Reconciler struct {
 	base.AROController
+
+	recorder record.EventRecorder
+	now      func() time.Time
 }
 
 // MachineSet reconciler watches MachineSet objects for changes, evaluates total worker replica count, and reverts changes if needed.
-func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+// If the cluster defines a worker autoscaling policy, changes which violate the policy are also reverted, the sizes of
+// MachineSets owned by the cluster autoscaler are clamped to the policy, and violations are reported as events and in the
+// WorkerAutoscalingPolicyViolations condition.
+func NewReconciler(log *logrus.Entry, client client.Client, recorder record.EventRecorder) *Reconciler {
 	return &Reconciler{
 		AROController: base.AROController{
 			Log:    log,
 			Client: client,
 			Name:   ControllerName,
 		},
+		recorder: recorder,
+		now:      time.Now,
 	}
 }
 
@@ -78,22 +92,86 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 
 	// Count amount of total current worker replicas
 	replicaCount := 0
+	customMachineSets := false
 	for _, machineset := range machinesets.Items {
-		// If there are any custom machinesets in the list, bail and don't requeue
+		// If there are any custom machinesets in the list, leave the total
+		// worker replica count to the customer.  The worker autoscaling policy
+		// still applies to them.
 		if !strings.Contains(machineset.Name, instance.Spec.InfraID) {
-			r.ClearDegraded(ctx)
-
-			return reconcile.Result{}, nil
+			customMachineSets = true
 		}
 		if machineset.Spec.Replicas != nil {
 			replicaCount += int(*machineset.Spec.Replicas)
 		}
 	}
 
-	if replicaCount < minSupportedReplicas {
+	var currentReplicas int32
+	if modifiedMachineset.Spec.Replicas != nil {
+		currentReplicas = *modifiedMachineset.Spec.Replicas
+	}
+	replicas := currentReplicas
+
+	if !customMachineSets && replicaCount < minSupportedReplicas {
 		r.Log.Infof("Found less than %v worker replicas. The MachineSet controller will attempt scaling.", minSupportedReplicas)
-		// Add replicas to the object, and call Update
-		modifiedMachineset.Spec.Replicas = to.Int32Ptr(int32(minSupportedReplicas-replicaCount) + *modifiedMachineset.Spec.Replicas)
+		// Add replicas to the object
+		replicas += int32(minSupportedReplicas - replicaCount)
+	}
+
+	policy := instance.Spec.WorkerAutoscalingPolicy
+
+	var changed bool
+	var workerMachineSets []*workerMachineSet
+	var result reconcile.Result
+	if policy != nil {
+		for i := range machinesets.Items {
+			ms, err := newWorkerMachineSet(&machinesets.Items[i])
+			if err != nil {
+				r.Log.Error(err)
+				r.SetDegraded(ctx, err)
+
+				return reconcile.Result{}, err
+			}
+			workerMachineSets = append(workerMachineSets, ms)
+		}
+
+		replicas, changed, err = r.enforceWorkerAutoscalingPolicy(policy, modifiedMachineset, workerMachineSets, replicas)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+
+			return reconcile.Result{}, err
+		}
+
+		vmSizeChanged, err := r.enforceAllowedVMSize(policy, modifiedMachineset)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+
+			return reconcile.Result{}, err
+		}
+		changed = changed || vmSizeChanged
+
+		// reconcile again when a scale-down protection window starts or ends
+		now := r.now()
+		next, err := nextScaleDownProtectionWindowBoundary(policy.ScaleDownProtectionWindows, now)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+
+			return reconcile.Result{}, err
+		}
+		if !next.IsZero() {
+			result.RequeueAfter = next.Sub(now)
+		}
+	}
+
+	if replicas != currentReplicas {
+		r.Log.Infof("Scaling MachineSet %s to %d replicas", modifiedMachineset.Name, replicas)
+		modifiedMachineset.Spec.Replicas = to.Int32Ptr(replicas)
+		changed = true
+	}
+
+	if changed {
 		err := r.Client.Update(ctx, modifiedMachineset)
 		if err != nil {
 			r.Log.Error(err)
@@ -101,10 +179,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 
 			return reconcile.Result{}, err
 		}
+
+		ms, err := newWorkerMachineSet(modifiedMachineset)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+
+			return reconcile.Result{}, err
+		}
+
+		for i := range workerMachineSets {
+			if workerMachineSets[i].name == modifiedMachineset.Name {
+				workerMachineSets[i] = ms
+			}
+		}
 	}
 
+	r.SetConditions(ctx, workerAutoscalingPolicyCondition(policy, workerMachineSets))
 	r.ClearConditions(ctx)
-	return reconcile.Result{}, nil
+	return result, nil
 }
 
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -113,8 +206,39 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return strings.EqualFold("worker", role)
 	})
 
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&machinev1beta1.MachineSet{}, builder.WithPredicates(machineSetPredicate)).
+		Watches(
+			&source.Kind{Type: &arov1alpha1.Cluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.workerMachineSetRequests),
+			builder.WithPredicates(aroClusterPredicate, predicate.GenerationChangedPredicate{}), // to enforce changes to the worker autoscaling policy
+		).
 		Named(ControllerName).
 		Complete(r)
 }
+
+// workerMachineSetRequests returns a request for each worker MachineSet
+func (r *Reconciler) workerMachineSetRequests(o client.Object) []reconcile.Request {
+	machinesets := &machinev1beta1.MachineSetList{}
+	err := r.Client.List(context.Background(), machinesets,
+		client.InNamespace(machineSetsNamespace),
+		client.MatchingLabels{"machine.openshift.io/cluster-api-machine-role": "worker"},
+	)
+	if err != nil {
+		r.Log.Error(err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(machinesets.Items))
+	for _, machineset := range machinesets.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: machineset.Name, Namespace: machineset.Namespace},
+		})
+	}
+
+	return requests
+}
@@ -5,6 +5,7 @@ package machineset
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -14,10 +15,13 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/Azure/ARO-RP/pkg/operator"
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
@@ -216,7 +220,7 @@ func TestReconciler(t *testing.T) {
 				WithObjects(tt.machinesets...).
 				Build()
 
-			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake, record.NewFakeRecorder(10))
 
 			request := ctrl.Request{}
 			request.Name = tt.objectName
@@ -241,3 +245,421 @@ func TestReconciler(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilerWorkerAutoscalingPolicy(t *testing.T) {
+	now := time.Date(2023, time.January, 2, 10, 0, 0, 0, time.UTC) // a Monday
+
+	fakeMachineSet := func(name, zone, vmSize string, replicas, statusReplicas int32) *machinev1beta1.MachineSet {
+		return &machinev1beta1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: machineSetsNamespace,
+				Labels: map[string]string{
+					"machine.openshift.io/cluster-api-machine-role": "worker",
+				},
+			},
+			Spec: machinev1beta1.MachineSetSpec{
+				Replicas: to.Int32Ptr(replicas),
+				Template: machinev1beta1.MachineTemplateSpec{
+					Spec: machinev1beta1.MachineSpec{
+						ProviderSpec: machinev1beta1.ProviderSpec{
+							Value: &kruntime.RawExtension{
+								Raw: []byte(fmt.Sprintf(`{
+"apiVersion": "machine.openshift.io/v1beta1",
+"kind": "AzureMachineProviderSpec",
+"vmSize": %q,
+"zone": %q
+}`, vmSize, zone)),
+							},
+						},
+					},
+				},
+			},
+			Status: machinev1beta1.MachineSetStatus{
+				Replicas: statusReplicas,
+			},
+		}
+	}
+
+	fakeMachineSets := func(replicas0, statusReplicas0 int32, vmSize0 string) []client.Object {
+		return []client.Object{
+			fakeMachineSet("aro-fake-machineset-0", "1", vmSize0, replicas0, statusReplicas0),
+			fakeMachineSet("aro-fake-machineset-1", "2", "Standard_D4s_v3", 1, 1),
+			fakeMachineSet("aro-fake-machineset-2", "3", "Standard_D4s_v3", 1, 1),
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		machinesets     []client.Object
+		policy          *arov1alpha1.WorkerAutoscalingPolicy
+		annotations     map[string]string
+		wantReplicas    int32
+		wantVMSize      string
+		wantAnnotations map[string]string
+		wantEvents      []string
+		wantResult      reconcile.Result
+		wantCondition   operatorv1.OperatorCondition
+		wantErr         string
+	}{
+		{
+			name:         "no policy",
+			machinesets:  fakeMachineSets(5, 5, "Standard_D4s_v3"),
+			wantReplicas: 5,
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "No worker autoscaling policy is defined",
+				Reason:  "NoPolicy",
+			},
+		},
+		{
+			name:        "scale up within policy",
+			machinesets: fakeMachineSets(3, 1, "Standard_D4s_v3"),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				MinReplicasPerZone: to.Int32Ptr(1),
+				MaxReplicasPerZone: to.Int32Ptr(3),
+				AllowedVMSizes:     []string{"Standard_D4s_v3"},
+			},
+			wantReplicas: 3,
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "scale up above maximum is reverted",
+			machinesets: fakeMachineSets(5, 2, "Standard_D4s_v3"),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				MaxReplicasPerZone: to.Int32Ptr(3),
+			},
+			wantReplicas: 3,
+			wantEvents: []string{
+				`Warning AboveMaxReplicasPerZone Zone "1" has 5 worker replicas, scaling down to the maximum of 3`,
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "scale down below minimum is reverted",
+			machinesets: fakeMachineSets(0, 2, "Standard_D4s_v3"),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				MinReplicasPerZone: to.Int32Ptr(2),
+			},
+			wantReplicas: 2,
+			wantEvents: []string{
+				`Warning BelowMinReplicasPerZone Zone "1" has 0 worker replicas, scaling up to the minimum of 2`,
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionTrue,
+				Message: `Worker MachineSets violate the worker autoscaling policy: zone "2" has 1 replicas, below the minimum of 2, zone "3" has 1 replicas, below the minimum of 2`,
+				Reason:  "ViolationsFound",
+			},
+		},
+		{
+			name:        "scale down during protection window is reverted",
+			machinesets: fakeMachineSets(1, 3, "Standard_D4s_v3"),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				ScaleDownProtectionWindows: []arov1alpha1.ScaleDownProtectionWindow{
+					{
+						Days:     []string{"Monday"},
+						Start:    "09:00",
+						Duration: metav1.Duration{Duration: 8 * time.Hour},
+					},
+				},
+			},
+			wantReplicas: 3,
+			wantResult:   reconcile.Result{RequeueAfter: 7 * time.Hour},
+			wantEvents: []string{
+				"Warning ScaleDownProtected Scaling down from 3 to 1 replicas is not allowed during a scale-down protection window",
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "scale down outside protection window",
+			machinesets: fakeMachineSets(1, 3, "Standard_D4s_v3"),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				ScaleDownProtectionWindows: []arov1alpha1.ScaleDownProtectionWindow{
+					{
+						Days:     []string{"Tuesday"},
+						Start:    "09:00",
+						Duration: metav1.Duration{Duration: 8 * time.Hour},
+					},
+				},
+			},
+			wantReplicas: 1,
+			wantResult:   reconcile.Result{RequeueAfter: 23 * time.Hour},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "VM size not allowed",
+			machinesets: fakeMachineSets(1, 1, "Standard_D16s_v3"),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				AllowedVMSizes: []string{"Standard_D4s_v3", "Standard_D8s_v3"},
+			},
+			wantReplicas: 1,
+			wantEvents: []string{
+				"Warning VMSizeNotAllowed VM size Standard_D16s_v3 is not allowed by the worker autoscaling policy",
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionTrue,
+				Message: "Worker MachineSets violate the worker autoscaling policy: machineset aro-fake-machineset-0 uses VM size Standard_D16s_v3",
+				Reason:  "ViolationsFound",
+			},
+		},
+		{
+			name:        "scale down of autoscaled machineset during protection window is reverted",
+			machinesets: fakeMachineSets(1, 3, "Standard_D4s_v3"),
+			annotations: map[string]string{
+				autoscalerMinSizeAnnotation: "1",
+				autoscalerMaxSizeAnnotation: "3",
+			},
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				ScaleDownProtectionWindows: []arov1alpha1.ScaleDownProtectionWindow{
+					{
+						Days:     []string{"Monday"},
+						Start:    "09:00",
+						Duration: metav1.Duration{Duration: 8 * time.Hour},
+					},
+				},
+			},
+			wantReplicas: 3,
+			wantResult:   reconcile.Result{RequeueAfter: 7 * time.Hour},
+			wantEvents: []string{
+				"Warning ScaleDownProtected Scaling down from 3 to 1 replicas is not allowed during a scale-down protection window",
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "autoscaler maximum size above maximum is clamped",
+			machinesets: fakeMachineSets(5, 2, "Standard_D4s_v3"),
+			annotations: map[string]string{
+				autoscalerMinSizeAnnotation: "1",
+				autoscalerMaxSizeAnnotation: "5",
+			},
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				MaxReplicasPerZone: to.Int32Ptr(3),
+			},
+			wantReplicas: 3,
+			wantAnnotations: map[string]string{
+				autoscalerMinSizeAnnotation: "1",
+				autoscalerMaxSizeAnnotation: "3",
+			},
+			wantEvents: []string{
+				`Warning AutoscalerMaxSizeClamped Setting the autoscaler maximum size to 3 to comply with the worker autoscaling policy in zone "1"`,
+				"Warning AboveAutoscalerMaxSize MachineSet has 5 replicas, scaling down to the autoscaler maximum size of 3",
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "autoscaler minimum size below minimum is clamped",
+			machinesets: fakeMachineSets(1, 1, "Standard_D4s_v3"),
+			annotations: map[string]string{
+				autoscalerMinSizeAnnotation: "0",
+				autoscalerMaxSizeAnnotation: "4",
+			},
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				MinReplicasPerZone: to.Int32Ptr(2),
+				MaxReplicasPerZone: to.Int32Ptr(4),
+			},
+			wantReplicas: 2,
+			wantAnnotations: map[string]string{
+				autoscalerMinSizeAnnotation: "2",
+				autoscalerMaxSizeAnnotation: "4",
+			},
+			wantEvents: []string{
+				`Warning AutoscalerMinSizeClamped Setting the autoscaler minimum size to 2 to comply with the worker autoscaling policy in zone "1"`,
+				"Warning BelowAutoscalerMinSize MachineSet has 1 replicas, scaling up to the autoscaler minimum size of 2",
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionTrue,
+				Message: `Worker MachineSets violate the worker autoscaling policy: zone "2" has 1 replicas, below the minimum of 2, zone "3" has 1 replicas, below the minimum of 2`,
+				Reason:  "ViolationsFound",
+			},
+		},
+		{
+			name: "custom machineset is enforced",
+			machinesets: append(
+				fakeMachineSets(1, 1, "Standard_D4s_v3"),
+				fakeMachineSet("custom-machineset", "1", "Standard_D4s_v3", 1, 1),
+			),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				MaxReplicasPerZone: to.Int32Ptr(1),
+			},
+			wantReplicas: 0,
+			wantEvents: []string{
+				`Warning AboveMaxReplicasPerZone Zone "1" has 2 worker replicas, scaling down to the maximum of 1`,
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "allowed VM size is recorded",
+			machinesets: fakeMachineSets(1, 1, "Standard_D8s_v3"),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				AllowedVMSizes: []string{"Standard_D4s_v3", "Standard_D8s_v3"},
+			},
+			wantReplicas: 1,
+			wantVMSize:   "Standard_D8s_v3",
+			wantAnnotations: map[string]string{
+				allowedVMSizeAnnotation: "Standard_D8s_v3",
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "VM size not allowed is reverted",
+			machinesets: fakeMachineSets(1, 1, "Standard_D16s_v3"),
+			annotations: map[string]string{
+				allowedVMSizeAnnotation: "Standard_D4s_v3",
+			},
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				AllowedVMSizes: []string{"Standard_D4s_v3", "Standard_D8s_v3"},
+			},
+			wantReplicas: 1,
+			wantVMSize:   "Standard_D4s_v3",
+			wantAnnotations: map[string]string{
+				allowedVMSizeAnnotation: "Standard_D4s_v3",
+			},
+			wantEvents: []string{
+				"Warning VMSizeNotAllowed VM size Standard_D16s_v3 is not allowed by the worker autoscaling policy, reverting to Standard_D4s_v3",
+			},
+			wantCondition: operatorv1.OperatorCondition{
+				Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+				Status:  operatorv1.ConditionFalse,
+				Message: "Worker MachineSets comply with the worker autoscaling policy",
+				Reason:  "NoViolations",
+			},
+		},
+		{
+			name:        "invalid protection window",
+			machinesets: fakeMachineSets(1, 1, "Standard_D4s_v3"),
+			policy: &arov1alpha1.WorkerAutoscalingPolicy{
+				ScaleDownProtectionWindows: []arov1alpha1.ScaleDownProtectionWindow{
+					{
+						Days:     []string{"Someday"},
+						Start:    "09:00",
+						Duration: metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			wantReplicas: 1,
+			wantErr:      `invalid scale-down protection window day "Someday"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+				Spec: arov1alpha1.ClusterSpec{
+					InfraID: "aro-fake",
+					OperatorFlags: arov1alpha1.OperatorFlags{
+						operator.MachineSetEnabled: "true",
+					},
+					WorkerAutoscalingPolicy: tt.policy,
+				},
+			}
+
+			tt.machinesets[0].SetAnnotations(tt.annotations)
+
+			clientFake := ctrlfake.NewClientBuilder().
+				WithObjects(instance).
+				WithObjects(tt.machinesets...).
+				Build()
+
+			recorder := record.NewFakeRecorder(10)
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake, recorder)
+			r.now = func() time.Time { return now }
+
+			request := ctrl.Request{}
+			request.Name = "aro-fake-machineset-0"
+			request.Namespace = machineSetsNamespace
+			ctx := context.Background()
+
+			result, err := r.Reconcile(ctx, request)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if result != tt.wantResult {
+				t.Error(cmp.Diff(result, tt.wantResult))
+			}
+
+			modifiedMachineset := &machinev1beta1.MachineSet{}
+			err = r.Client.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: machineSetsNamespace}, modifiedMachineset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *modifiedMachineset.Spec.Replicas != tt.wantReplicas {
+				t.Error(cmp.Diff(*modifiedMachineset.Spec.Replicas, tt.wantReplicas))
+			}
+
+			if tt.wantVMSize != "" {
+				ms, err := newWorkerMachineSet(modifiedMachineset)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if ms.vmSize != tt.wantVMSize {
+					t.Errorf("got VM size %s, want %s", ms.vmSize, tt.wantVMSize)
+				}
+			}
+
+			for k, v := range tt.wantAnnotations {
+				if modifiedMachineset.Annotations[k] != v {
+					t.Errorf("got annotation %s=%q, want %q", k, modifiedMachineset.Annotations[k], v)
+				}
+			}
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			if diff := cmp.Diff(tt.wantEvents, events); diff != "" {
+				t.Error(diff)
+			}
+
+			if tt.wantErr == "" {
+				tt.wantCondition.LastTransitionTime = metav1.Now()
+				utilconditions.AssertControllerConditions(t, ctx, clientFake, []operatorv1.OperatorCondition{tt.wantCondition})
+			}
+		})
+	}
+}
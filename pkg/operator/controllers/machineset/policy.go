@@ -0,0 +1,402 @@
+package machineset
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+)
+
+// workerMachineSet holds the fields of a worker MachineSet which the worker
+// autoscaling policy is evaluated against
+type workerMachineSet struct {
+	name       string
+	zone       string
+	vmSize     string
+	replicas   int32
+	autoscaled bool
+}
+
+func newWorkerMachineSet(machineset *machinev1beta1.MachineSet) (*workerMachineSet, error) {
+	ms := &workerMachineSet{
+		name:       machineset.Name,
+		autoscaled: isAutoscaled(machineset),
+	}
+
+	if machineset.Spec.Replicas != nil {
+		ms.replicas = *machineset.Spec.Replicas
+	}
+
+	if machineset.Spec.Template.Spec.ProviderSpec.Value == nil {
+		return ms, nil
+	}
+
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(machineset.Spec.Template.Spec.ProviderSpec.Value.Raw, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	machineProviderSpec, ok := obj.(*machinev1beta1.AzureMachineProviderSpec)
+	if !ok {
+		return nil, fmt.Errorf("machineset %s: failed to read provider spec: %T", machineset.Name, obj)
+	}
+
+	if machineProviderSpec.Zone != nil {
+		ms.zone = *machineProviderSpec.Zone
+	}
+	ms.vmSize = machineProviderSpec.VMSize
+
+	return ms, nil
+}
+
+// isAutoscaled returns true if a MachineAutoscaler targets the MachineSet, in
+// which case the cluster autoscaler owns its replicas
+func isAutoscaled(machineset *machinev1beta1.MachineSet) bool {
+	_, hasMin := machineset.Annotations[autoscalerMinSizeAnnotation]
+	_, hasMax := machineset.Annotations[autoscalerMaxSizeAnnotation]
+
+	return hasMin || hasMax
+}
+
+// enforceWorkerAutoscalingPolicy returns the number of replicas which the
+// modified MachineSet must have to comply with the policy, given that it
+// currently requests the given number of replicas.  An event is recorded on the
+// MachineSet for each change made.  The replicas of MachineSets owned by the
+// cluster autoscaler are bounded by its minimum and maximum size annotations,
+// so those are clamped to the policy first, rather than fighting the autoscaler
+// on every scaling decision.  It returns true if the annotations of the
+// MachineSet were changed.
+func (r *Reconciler) enforceWorkerAutoscalingPolicy(policy *arov1alpha1.WorkerAutoscalingPolicy, modified *machinev1beta1.MachineSet, machinesets []*workerMachineSet, replicas int32) (int32, bool, error) {
+	protected, err := inScaleDownProtectionWindow(policy.ScaleDownProtectionWindows, r.now())
+	if err != nil {
+		return 0, false, err
+	}
+
+	if protected && replicas < modified.Status.Replicas {
+		r.recorder.Eventf(modified, corev1.EventTypeWarning, "ScaleDownProtected", "Scaling down from %d to %d replicas is not allowed during a scale-down protection window", modified.Status.Replicas, replicas)
+		replicas = modified.Status.Replicas
+	}
+
+	current := &workerMachineSet{}
+	for _, ms := range machinesets {
+		if ms.name == modified.Name {
+			current = ms
+		}
+	}
+	zone := current.zone
+
+	var otherZoneReplicas int32
+	for _, ms := range machinesets {
+		if ms.name != modified.Name && ms.zone == zone {
+			otherZoneReplicas += ms.replicas
+		}
+	}
+
+	if isAutoscaled(modified) {
+		minSize, maxSize, changed := r.clampAutoscalerSizes(policy, modified, zone, otherZoneReplicas)
+
+		switch {
+		case replicas < minSize:
+			r.recorder.Eventf(modified, corev1.EventTypeWarning, "BelowAutoscalerMinSize", "MachineSet has %d replicas, scaling up to the autoscaler minimum size of %d", replicas, minSize)
+			replicas = minSize
+		case maxSize >= 0 && replicas > maxSize:
+			r.recorder.Eventf(modified, corev1.EventTypeWarning, "AboveAutoscalerMaxSize", "MachineSet has %d replicas, scaling down to the autoscaler maximum size of %d", replicas, maxSize)
+			replicas = maxSize
+		}
+
+		return replicas, changed, nil
+	}
+
+	zoneReplicas := replicas + otherZoneReplicas
+
+	if policy.MinReplicasPerZone != nil && zoneReplicas < *policy.MinReplicasPerZone {
+		r.recorder.Eventf(modified, corev1.EventTypeWarning, "BelowMinReplicasPerZone", "Zone %q has %d worker replicas, scaling up to the minimum of %d", zone, zoneReplicas, *policy.MinReplicasPerZone)
+		replicas += *policy.MinReplicasPerZone - zoneReplicas
+	} else if policy.MaxReplicasPerZone != nil && zoneReplicas > *policy.MaxReplicasPerZone {
+		excess := zoneReplicas - *policy.MaxReplicasPerZone
+		if excess > replicas {
+			excess = replicas
+		}
+		r.recorder.Eventf(modified, corev1.EventTypeWarning, "AboveMaxReplicasPerZone", "Zone %q has %d worker replicas, scaling down to the maximum of %d", zone, zoneReplicas, *policy.MaxReplicasPerZone)
+		replicas -= excess
+	}
+
+	return replicas, false, nil
+}
+
+// clampAutoscalerSizes clamps the minimum and maximum size annotations of an
+// autoscaled MachineSet to the replicas which the policy allows it, given the
+// replicas of the other MachineSets in its zone.  It returns the resulting
+// sizes, with a maximum size of -1 if there is none, and true if the
+// annotations were changed.  The cluster autoscaler operator may overwrite the
+// annotations from the MachineAutoscaler, in which case the MachineSet is
+// reconciled and they are clamped again.
+func (r *Reconciler) clampAutoscalerSizes(policy *arov1alpha1.WorkerAutoscalingPolicy, modified *machinev1beta1.MachineSet, zone string, otherZoneReplicas int32) (int32, int32, bool) {
+	minSize, maxSize := int32(0), int32(-1)
+	if size, err := strconv.ParseInt(modified.Annotations[autoscalerMinSizeAnnotation], 10, 32); err == nil {
+		minSize = int32(size)
+	}
+	if size, err := strconv.ParseInt(modified.Annotations[autoscalerMaxSizeAnnotation], 10, 32); err == nil {
+		maxSize = int32(size)
+	}
+
+	var minChanged, maxChanged bool
+
+	if policy.MinReplicasPerZone != nil && minSize < *policy.MinReplicasPerZone-otherZoneReplicas {
+		minSize = *policy.MinReplicasPerZone - otherZoneReplicas
+		minChanged = true
+	}
+
+	if policy.MaxReplicasPerZone != nil {
+		limit := *policy.MaxReplicasPerZone - otherZoneReplicas
+		if limit < 0 {
+			limit = 0
+		}
+
+		if maxSize < 0 || maxSize > limit {
+			maxSize = limit
+			maxChanged = true
+		}
+	}
+
+	if maxSize >= 0 && minSize > maxSize {
+		minSize = maxSize
+		minChanged = true
+	}
+
+	if minChanged {
+		r.recorder.Eventf(modified, corev1.EventTypeWarning, "AutoscalerMinSizeClamped", "Setting the autoscaler minimum size to %d to comply with the worker autoscaling policy in zone %q", minSize, zone)
+		modified.Annotations[autoscalerMinSizeAnnotation] = strconv.Itoa(int(minSize))
+	}
+
+	if maxChanged {
+		r.recorder.Eventf(modified, corev1.EventTypeWarning, "AutoscalerMaxSizeClamped", "Setting the autoscaler maximum size to %d to comply with the worker autoscaling policy in zone %q", maxSize, zone)
+		modified.Annotations[autoscalerMaxSizeAnnotation] = strconv.Itoa(int(maxSize))
+	}
+
+	return minSize, maxSize, minChanged || maxChanged
+}
+
+// enforceAllowedVMSize reverts the VM size of the modified MachineSet to the
+// last VM size which the policy allowed, if its current VM size is not allowed.
+// Otherwise it records the current VM size as the one to revert to.  It
+// returns true if the MachineSet was changed.  Only Machines created
+// afterwards are affected.
+func (r *Reconciler) enforceAllowedVMSize(policy *arov1alpha1.WorkerAutoscalingPolicy, modified *machinev1beta1.MachineSet) (bool, error) {
+	if modified.Spec.Template.Spec.ProviderSpec.Value == nil {
+		return false, nil
+	}
+
+	// edit the raw provider spec to preserve any fields unknown to the API
+	var providerSpec map[string]interface{}
+	err := json.Unmarshal(modified.Spec.Template.Spec.ProviderSpec.Value.Raw, &providerSpec)
+	if err != nil {
+		return false, err
+	}
+
+	vmSize, _ := providerSpec["vmSize"].(string)
+	if vmSize == "" {
+		return false, nil
+	}
+
+	if vmSizeIsAllowed(policy, vmSize) {
+		if modified.Annotations[allowedVMSizeAnnotation] == vmSize {
+			return false, nil
+		}
+
+		if modified.Annotations == nil {
+			modified.Annotations = map[string]string{}
+		}
+		modified.Annotations[allowedVMSizeAnnotation] = vmSize
+
+		return true, nil
+	}
+
+	allowedVMSize := modified.Annotations[allowedVMSizeAnnotation]
+	if allowedVMSize == "" || !vmSizeIsAllowed(policy, allowedVMSize) {
+		r.recorder.Eventf(modified, corev1.EventTypeWarning, "VMSizeNotAllowed", "VM size %s is not allowed by the worker autoscaling policy", vmSize)
+		return false, nil
+	}
+
+	r.recorder.Eventf(modified, corev1.EventTypeWarning, "VMSizeNotAllowed", "VM size %s is not allowed by the worker autoscaling policy, reverting to %s", vmSize, allowedVMSize)
+
+	providerSpec["vmSize"] = allowedVMSize
+	modified.Spec.Template.Spec.ProviderSpec.Value.Raw, err = json.Marshal(providerSpec)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// workerAutoscalingPolicyCondition reports the violations of the policy which
+// remain across all of the worker MachineSets
+func workerAutoscalingPolicyCondition(policy *arov1alpha1.WorkerAutoscalingPolicy, machinesets []*workerMachineSet) *operatorv1.OperatorCondition {
+	if policy == nil {
+		return &operatorv1.OperatorCondition{
+			Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+			Status:  operatorv1.ConditionFalse,
+			Message: "No worker autoscaling policy is defined",
+			Reason:  "NoPolicy",
+		}
+	}
+
+	var violations []string
+
+	zoneReplicas := map[string]int32{}
+	for _, ms := range machinesets {
+		zoneReplicas[ms.zone] += ms.replicas
+
+		if ms.vmSize != "" && !vmSizeIsAllowed(policy, ms.vmSize) {
+			violations = append(violations, fmt.Sprintf("machineset %s uses VM size %s", ms.name, ms.vmSize))
+		}
+	}
+
+	zones := make([]string, 0, len(zoneReplicas))
+	for zone := range zoneReplicas {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		switch {
+		case policy.MinReplicasPerZone != nil && zoneReplicas[zone] < *policy.MinReplicasPerZone:
+			violations = append(violations, fmt.Sprintf("zone %q has %d replicas, below the minimum of %d", zone, zoneReplicas[zone], *policy.MinReplicasPerZone))
+		case policy.MaxReplicasPerZone != nil && zoneReplicas[zone] > *policy.MaxReplicasPerZone:
+			violations = append(violations, fmt.Sprintf("zone %q has %d replicas, above the maximum of %d", zone, zoneReplicas[zone], *policy.MaxReplicasPerZone))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &operatorv1.OperatorCondition{
+			Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+			Status:  operatorv1.ConditionTrue,
+			Message: "Worker MachineSets violate the worker autoscaling policy: " + strings.Join(violations, ", "),
+			Reason:  "ViolationsFound",
+		}
+	}
+
+	return &operatorv1.OperatorCondition{
+		Type:    arov1alpha1.WorkerAutoscalingPolicyViolations,
+		Status:  operatorv1.ConditionFalse,
+		Message: "Worker MachineSets comply with the worker autoscaling policy",
+		Reason:  "NoViolations",
+	}
+}
+
+func vmSizeIsAllowed(policy *arov1alpha1.WorkerAutoscalingPolicy, vmSize string) bool {
+	if len(policy.AllowedVMSizes) == 0 {
+		return true
+	}
+
+	for _, allowed := range policy.AllowedVMSizes {
+		if strings.EqualFold(allowed, vmSize) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inScaleDownProtectionWindow returns true if t falls within any of the
+// windows.  Windows may span midnight, so windows starting on previous days are
+// considered as well as the window starting on the day of t.
+func inScaleDownProtectionWindow(windows []arov1alpha1.ScaleDownProtectionWindow, t time.Time) (bool, error) {
+	t = t.UTC()
+
+	for _, w := range windows {
+		start, days, err := parseScaleDownProtectionWindow(w)
+		if err != nil {
+			return false, err
+		}
+
+		for i := 0; i <= int(w.Duration.Duration/(24*time.Hour))+1; i++ {
+			day := t.AddDate(0, 0, -i)
+			windowStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+
+			if len(days) > 0 && !days[windowStart.Weekday()] {
+				continue
+			}
+
+			if !t.Before(windowStart) && t.Before(windowStart.Add(w.Duration.Duration)) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// nextScaleDownProtectionWindowBoundary returns the first start or end of any
+// of the windows after t, or the zero time if there are no windows.  Windows
+// which started on previous days may end after t, and the window on each day of
+// the following week may start after t.
+func nextScaleDownProtectionWindowBoundary(windows []arov1alpha1.ScaleDownProtectionWindow, t time.Time) (time.Time, error) {
+	t = t.UTC()
+
+	var next time.Time
+	for _, w := range windows {
+		start, days, err := parseScaleDownProtectionWindow(w)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		for i := -int(w.Duration.Duration/(24*time.Hour)) - 1; i <= 7; i++ {
+			day := t.AddDate(0, 0, i)
+			windowStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+
+			if len(days) > 0 && !days[windowStart.Weekday()] {
+				continue
+			}
+
+			for _, boundary := range []time.Time{windowStart, windowStart.Add(w.Duration.Duration)} {
+				if boundary.After(t) && (next.IsZero() || boundary.Before(next)) {
+					next = boundary
+				}
+			}
+		}
+	}
+
+	return next, nil
+}
+
+// parseScaleDownProtectionWindow returns the start time of day of the window
+// and the days on which it starts, which are empty if it starts every day
+func parseScaleDownProtectionWindow(w arov1alpha1.ScaleDownProtectionWindow) (time.Time, map[time.Weekday]bool, error) {
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("invalid scale-down protection window start %q", w.Start)
+	}
+
+	days := map[time.Weekday]bool{}
+	for _, day := range w.Days {
+		weekday, err := parseWeekday(day)
+		if err != nil {
+			return time.Time{}, nil, err
+		}
+		days[weekday] = true
+	}
+
+	return start, days, nil
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(day, weekday.String()) {
+			return weekday, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid scale-down protection window day %q", day)
+}
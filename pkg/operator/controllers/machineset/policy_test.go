@@ -0,0 +1,208 @@
+package machineset
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestInScaleDownProtectionWindow(t *testing.T) {
+	// Saturday 22:00 to Monday 06:00
+	weekend := arov1alpha1.ScaleDownProtectionWindow{
+		Days:     []string{"saturday"},
+		Start:    "22:00",
+		Duration: metav1.Duration{Duration: 32 * time.Hour},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		windows []arov1alpha1.ScaleDownProtectionWindow
+		t       time.Time
+		want    bool
+		wantErr string
+	}{
+		{
+			name: "no windows",
+			t:    time.Date(2023, time.January, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "daily window",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				{
+					Start:    "09:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			t:    time.Date(2023, time.January, 4, 9, 30, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "end of daily window",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				{
+					Start:    "09:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			t: time.Date(2023, time.January, 4, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "window spanning midnight",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				{
+					Start:    "23:00",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+				},
+			},
+			t:    time.Date(2023, time.January, 4, 0, 30, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name:    "window spanning days, start",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{weekend},
+			t:       time.Date(2022, time.December, 31, 22, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "window spanning days, middle",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{weekend},
+			t:       time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "window spanning days, after",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{weekend},
+			t:       time.Date(2023, time.January, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "time converted to UTC",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{weekend},
+			t:       time.Date(2022, time.December, 31, 23, 30, 0, 0, time.FixedZone("CET", 3600)),
+			want:    true,
+		},
+		{
+			name: "invalid start",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				{
+					Start:    "9am",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantErr: `invalid scale-down protection window start "9am"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := inScaleDownProtectionWindow(tt.windows, tt.t)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextScaleDownProtectionWindowBoundary(t *testing.T) {
+	// Saturday 22:00 to Monday 06:00
+	weekend := arov1alpha1.ScaleDownProtectionWindow{
+		Days:     []string{"saturday"},
+		Start:    "22:00",
+		Duration: metav1.Duration{Duration: 32 * time.Hour},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		windows []arov1alpha1.ScaleDownProtectionWindow
+		t       time.Time
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "no windows",
+			t:    time.Date(2023, time.January, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "before daily window",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				{
+					Start:    "09:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			t:    time.Date(2023, time.January, 2, 8, 0, 0, 0, time.UTC),
+			want: time.Date(2023, time.January, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "during daily window",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				{
+					Start:    "09:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			t:    time.Date(2023, time.January, 2, 9, 0, 0, 0, time.UTC),
+			want: time.Date(2023, time.January, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "after daily window",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				{
+					Start:    "09:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			t:    time.Date(2023, time.January, 2, 10, 0, 0, 0, time.UTC),
+			want: time.Date(2023, time.January, 3, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "window spanning days, middle",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{weekend},
+			t:       time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC), // Sunday
+			want:    time.Date(2023, time.January, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "window spanning days, after",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{weekend},
+			t:       time.Date(2023, time.January, 2, 6, 0, 0, 0, time.UTC), // Monday
+			want:    time.Date(2023, time.January, 7, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "earliest of several windows",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				weekend,
+				{
+					Start:    "09:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			t:    time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC), // Sunday
+			want: time.Date(2023, time.January, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "invalid start",
+			windows: []arov1alpha1.ScaleDownProtectionWindow{
+				{
+					Start:    "9am",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantErr: `invalid scale-down protection window start "9am"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextScaleDownProtectionWindowBoundary(tt.windows, tt.t)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,12 +8,24 @@ import (
 )
 
 const (
-	annotationCurrentConfig  = "machineconfiguration.openshift.io/currentConfig"
-	annotationDesiredConfig  = "machineconfiguration.openshift.io/desiredConfig"
-	annotationReason         = "machineconfiguration.openshift.io/reason"
-	annotationState          = "machineconfiguration.openshift.io/state"
-	annotationDrainStartTime = "aro.openshift.io/drainStartTime"
-	stateDegraded            = "Degraded"
-	stateWorking             = "Working"
-	gracePeriod              = time.Hour
+	annotationCurrentConfig = "machineconfiguration.openshift.io/currentConfig"
+	annotationDesiredConfig = "machineconfiguration.openshift.io/desiredConfig"
+	annotationReason        = "machineconfiguration.openshift.io/reason"
+	annotationState         = "machineconfiguration.openshift.io/state"
+	stateDegraded           = "Degraded"
+	stateWorking            = "Working"
+
+	// AnnotationDrainStartTime, AnnotationDrainPodsRemaining and
+	// AnnotationDrainBlockingPDBs record the progress of a drain on the node
+	AnnotationDrainStartTime     = "aro.openshift.io/drainStartTime"
+	AnnotationDrainPodsRemaining = "aro.openshift.io/drainPodsRemaining"
+	AnnotationDrainBlockingPDBs  = "aro.openshift.io/drainBlockingPodDisruptionBudgets"
+
+	defaultGracePeriod    = time.Hour
+	defaultTimeout        = 60 * time.Second
+	defaultStuckThreshold = 2 * time.Hour
+
+	// progressInterval is how often the progress of a drain is recorded
+	// while the machine config daemon is draining the node
+	progressInterval = 5 * time.Minute
 )
@@ -6,14 +6,18 @@ package node
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/kubectl/pkg/drain"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,13 +34,16 @@ const (
 
 // Reconciler spots nodes that look like they're stuck upgrading.  When this
 // happens, it tries to drain them disabling eviction (so PDBs don't count).
+// While the machine config daemon is draining a node, the pods remaining on it
+// and the PDBs blocking their eviction are recorded as annotations and events.
 type Reconciler struct {
 	base.AROController
 
 	kubernetescli kubernetes.Interface
+	recorder      record.EventRecorder
 }
 
-func NewReconciler(log *logrus.Entry, client client.Client, kubernetescli kubernetes.Interface) *Reconciler {
+func NewReconciler(log *logrus.Entry, client client.Client, kubernetescli kubernetes.Interface, recorder record.EventRecorder) *Reconciler {
 	return &Reconciler{
 		AROController: base.AROController{
 			Log:    log,
@@ -45,6 +52,7 @@ func NewReconciler(log *logrus.Entry, client client.Client, kubernetescli kubern
 		},
 
 		kubernetescli: kubernetescli,
+		recorder:      recorder,
 	}
 }
 
@@ -79,14 +87,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 	}
 
 	if !isDraining(node) {
-		// we're not draining: ensure our annotation is not set and return
-		if getAnnotation(&node.ObjectMeta, annotationDrainStartTime) == "" {
+		// we're not draining: ensure our annotations are not set and return
+		if !removeDrainAnnotations(node) {
 			r.ClearConditions(ctx)
 			return reconcile.Result{}, nil
 		}
 
-		delete(node.Annotations, annotationDrainStartTime)
-
 		err = r.Client.Update(ctx, node)
 		if err != nil {
 			r.Log.Error(err)
@@ -96,12 +102,30 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return reconcile.Result{}, err
 	}
 
-	// we're draining: ensure our annotation is set
-	t, err := time.Parse(time.RFC3339, getAnnotation(&node.ObjectMeta, annotationDrainStartTime))
+	policy := GetDrainPolicy(instance)
+	helper := r.drainHelper(ctx, policy, node)
+
+	// we're draining: ensure our annotations are set and up to date
+	t, err := time.Parse(time.RFC3339, getAnnotation(&node.ObjectMeta, AnnotationDrainStartTime))
 	if err != nil {
 		t = time.Now().UTC()
-		setAnnotation(&node.ObjectMeta, annotationDrainStartTime, t.Format(time.RFC3339))
+		setAnnotation(&node.ObjectMeta, AnnotationDrainStartTime, t.Format(time.RFC3339))
+		r.recorder.Event(node, corev1.EventTypeNormal, "DrainStarted", "Node drain started")
+	}
 
+	podsRemaining, blockingPDBs, err := r.drainProgress(ctx, helper, node.Name)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+
+		return reconcile.Result{}, err
+	}
+
+	if len(blockingPDBs) > 0 && getAnnotation(&node.ObjectMeta, AnnotationDrainBlockingPDBs) != strings.Join(blockingPDBs, ",") {
+		r.recorder.Eventf(node, corev1.EventTypeWarning, "DrainBlocked", "Node drain is blocked by PodDisruptionBudgets %s", strings.Join(blockingPDBs, ", "))
+	}
+
+	if setDrainProgress(node, podsRemaining, blockingPDBs) {
 		err = r.Client.Update(ctx, node)
 		if err != nil {
 			r.Log.Error(err)
@@ -112,38 +136,31 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 	}
 
 	// if our deadline hasn't expired, requeue ourselves and return
-	deadline := t.Add(gracePeriod)
+	deadline := t.Add(policy.GracePeriod)
 	now := time.Now()
 	if deadline.After(now) {
 		r.SetProgressing(ctx, fmt.Sprintf("Draining node %s", request.Name))
 
-		return reconcile.Result{RequeueAfter: deadline.Sub(now)}, nil
+		requeueAfter := deadline.Sub(now)
+		if requeueAfter > progressInterval {
+			requeueAfter = progressInterval
+		}
+
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	// drain the node disabling eviction
-	err = drain.RunNodeDrain(&drain.Helper{
-		Client:              r.kubernetescli,
-		Force:               true,
-		GracePeriodSeconds:  -1,
-		IgnoreAllDaemonSets: true,
-		Timeout:             60 * time.Second,
-		DeleteEmptyDirData:  true,
-		DisableEviction:     true,
-		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
-			r.Log.Printf("deleted pod %s/%s", pod.Namespace, pod.Name)
-		},
-		Out:    r.Log.Writer(),
-		ErrOut: r.Log.Writer(),
-	}, request.Name)
+	err = drain.RunNodeDrain(helper, request.Name)
 	if err != nil {
+		r.recorder.Eventf(node, corev1.EventTypeWarning, "DrainFailed", "Node drain failed: %v", err)
 		r.Log.Error(err)
 		r.SetDegraded(ctx, err)
 
 		return reconcile.Result{}, err
 	}
 
-	// ensure our annotation is not set and return
-	delete(node.Annotations, annotationDrainStartTime)
+	// ensure our annotations are not set and return
+	removeDrainAnnotations(node)
 
 	err = r.Client.Update(ctx, node)
 	if err != nil {
@@ -153,10 +170,113 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return reconcile.Result{}, err
 	}
 
+	r.recorder.Event(node, corev1.EventTypeNormal, "Drained", "Node drained")
+
 	r.ClearConditions(ctx)
 	return reconcile.Result{}, nil
 }
 
+func (r *Reconciler) drainHelper(ctx context.Context, policy *DrainPolicy, node *corev1.Node) *drain.Helper {
+	return &drain.Helper{
+		Ctx:                 ctx,
+		Client:              r.kubernetescli,
+		Force:               true,
+		GracePeriodSeconds:  policy.PodGracePeriodSeconds,
+		IgnoreAllDaemonSets: policy.IgnoreDaemonSets,
+		Timeout:             policy.Timeout,
+		DeleteEmptyDirData:  policy.DeleteEmptyDirData,
+		DisableEviction:     true,
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			r.Log.Printf("deleted pod %s/%s", pod.Namespace, pod.Name)
+			r.recorder.Eventf(node, corev1.EventTypeNormal, "PodDeleted", "Deleted pod %s/%s", pod.Namespace, pod.Name)
+		},
+		Out:    r.Log.Writer(),
+		ErrOut: r.Log.Writer(),
+	}
+}
+
+// drainProgress returns the number of pods remaining to be drained from the
+// node and the PDBs which currently block the eviction of any of them
+func (r *Reconciler) drainProgress(ctx context.Context, helper *drain.Helper, nodeName string) (int, []string, error) {
+	list, errs := helper.GetPodsForDeletion(nodeName)
+	if list == nil {
+		// the pods could not be listed
+		return 0, nil, errs[0]
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range list.Pods() {
+		if pod.DeletionTimestamp == nil {
+			pods = append(pods, pod)
+		}
+	}
+
+	if len(pods) == 0 {
+		return 0, nil, nil
+	}
+
+	pdbs, err := r.kubernetescli.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var blockingPDBs []string
+	for _, pdb := range pdbs.Items {
+		if pdb.Status.DisruptionsAllowed > 0 {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+
+		for _, pod := range pods {
+			if pod.Namespace == pdb.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+				blockingPDBs = append(blockingPDBs, pdb.Namespace+"/"+pdb.Name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(blockingPDBs)
+
+	return len(pods), blockingPDBs, nil
+}
+
+// setDrainProgress records the progress of a drain in the node's annotations
+// and returns true if they have changed
+func setDrainProgress(node *corev1.Node, podsRemaining int, blockingPDBs []string) bool {
+	changed := getAnnotation(&node.ObjectMeta, AnnotationDrainPodsRemaining) != strconv.Itoa(podsRemaining)
+	setAnnotation(&node.ObjectMeta, AnnotationDrainPodsRemaining, strconv.Itoa(podsRemaining))
+
+	if len(blockingPDBs) == 0 {
+		if _, ok := node.Annotations[AnnotationDrainBlockingPDBs]; ok {
+			delete(node.Annotations, AnnotationDrainBlockingPDBs)
+			changed = true
+		}
+	} else if getAnnotation(&node.ObjectMeta, AnnotationDrainBlockingPDBs) != strings.Join(blockingPDBs, ",") {
+		setAnnotation(&node.ObjectMeta, AnnotationDrainBlockingPDBs, strings.Join(blockingPDBs, ","))
+		changed = true
+	}
+
+	return changed
+}
+
+// removeDrainAnnotations removes our drain annotations from the node and
+// returns true if any were set
+func removeDrainAnnotations(node *corev1.Node) bool {
+	var changed bool
+	for _, k := range []string{AnnotationDrainStartTime, AnnotationDrainPodsRemaining, AnnotationDrainBlockingPDBs} {
+		if _, ok := node.Annotations[k]; ok {
+			delete(node.Annotations, k)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
 // SetupWithManager setup our mananger
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -13,8 +13,12 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -37,11 +41,15 @@ func TestReconciler(t *testing.T) {
 		name            string
 		nodeName        string
 		nodeObject      *corev1.Node
+		kubeObjects     []kruntime.Object
+		drainPolicy     *arov1alpha1.NodeDrainPolicy
 		clusterNotFound bool
 		featureFlag     bool
 		wantErr         string
 		startConditions []operatorv1.OperatorCondition
 		wantConditions  []operatorv1.OperatorCondition
+		wantAnnotations map[string]string
+		wantEvents      []string
 	}{
 		{
 			name:     "node is a master, don't touch it",
@@ -101,7 +109,7 @@ func TestReconciler(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "aro-fake-node-0",
 					Annotations: map[string]string{
-						annotationDrainStartTime: "",
+						AnnotationDrainStartTime: "",
 					},
 				},
 			},
@@ -117,7 +125,7 @@ func TestReconciler(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "aro-fake-node-0",
 					Annotations: map[string]string{
-						annotationDrainStartTime: "some-start-time",
+						AnnotationDrainStartTime: "some-start-time",
 					},
 				},
 			},
@@ -376,7 +384,7 @@ func TestReconciler(t *testing.T) {
 						annotationDesiredConfig:  "config-2",
 						annotationState:          stateDegraded,
 						annotationReason:         "failed to drain node",
-						annotationDrainStartTime: "2006-01-02T15:04:05Z",
+						AnnotationDrainStartTime: "2006-01-02T15:04:05Z",
 					},
 				},
 				Spec: corev1.NodeSpec{
@@ -405,6 +413,184 @@ func TestReconciler(t *testing.T) {
 			},
 			wantConditions: []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded},
 		},
+		{
+			name:     "isDraining true, record drain progress",
+			nodeName: "aro-fake-node-0",
+			nodeObject: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "aro-fake-node-0",
+					Annotations: map[string]string{
+						annotationCurrentConfig: "config",
+						annotationDesiredConfig: "config-2",
+						annotationState:         stateWorking,
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Unschedulable: true,
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:   corev1.NodeReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			kubeObjects: []kruntime.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "app-0",
+						Namespace: "app",
+						Labels: map[string]string{
+							"app": "app",
+						},
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "aro-fake-node-0",
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other-0",
+						Namespace: "other",
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "aro-fake-node-0",
+					},
+				},
+				&policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "app",
+						Namespace: "app",
+					},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "app",
+							},
+						},
+					},
+				},
+				&policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "allowed",
+						Namespace: "app",
+					},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "app",
+							},
+						},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{
+						DisruptionsAllowed: 1,
+					},
+				},
+				&policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "app",
+						Namespace: "other",
+					},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "app",
+							},
+						},
+					},
+				},
+			},
+			featureFlag:     true,
+			wantErr:         "",
+			startConditions: defaultConditions,
+			wantConditions: []operatorv1.OperatorCondition{
+				defaultAvailable,
+				{
+					Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeProgressing,
+					Status:             operatorv1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+					Message:            `Draining node aro-fake-node-0`,
+				},
+				defaultDegraded,
+			},
+			wantAnnotations: map[string]string{
+				AnnotationDrainPodsRemaining: "2",
+				AnnotationDrainBlockingPDBs:  "app/app",
+			},
+			wantEvents: []string{
+				"Normal DrainStarted Node drain started",
+				"Warning DrainBlocked Node drain is blocked by PodDisruptionBudgets app/app",
+			},
+		},
+		{
+			name:     "node is draining, deadline extended by drain policy",
+			nodeName: "aro-fake-node-0",
+			nodeObject: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "aro-fake-node-0",
+					Annotations: map[string]string{
+						annotationCurrentConfig:      "config",
+						annotationDesiredConfig:      "config-2",
+						annotationState:              stateDegraded,
+						annotationReason:             "failed to drain node",
+						AnnotationDrainStartTime:     time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+						AnnotationDrainPodsRemaining: "1",
+						AnnotationDrainBlockingPDBs:  "app/app",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Unschedulable: true,
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:   corev1.NodeReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			drainPolicy: &arov1alpha1.NodeDrainPolicy{
+				GracePeriod: &metav1.Duration{Duration: 3 * time.Hour},
+			},
+			featureFlag:     true,
+			wantErr:         "",
+			startConditions: defaultConditions,
+			wantConditions: []operatorv1.OperatorCondition{
+				defaultAvailable,
+				{
+					Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeProgressing,
+					Status:             operatorv1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+					Message:            `Draining node aro-fake-node-0`,
+				},
+				defaultDegraded,
+			},
+			wantAnnotations: map[string]string{
+				AnnotationDrainPodsRemaining: "0",
+			},
+		},
+		{
+			name:     "isDraining false, remove drain annotations",
+			nodeName: "aro-fake-node-0",
+			nodeObject: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "aro-fake-node-0",
+					Annotations: map[string]string{
+						AnnotationDrainStartTime:     "2006-01-02T15:04:05Z",
+						AnnotationDrainPodsRemaining: "1",
+						AnnotationDrainBlockingPDBs:  "app/app",
+					},
+				},
+			},
+			featureFlag:     true,
+			wantErr:         "",
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+			wantAnnotations: map[string]string{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -418,6 +604,7 @@ func TestReconciler(t *testing.T) {
 						OperatorFlags: arov1alpha1.OperatorFlags{
 							operator.NodeDrainerEnabled: strconv.FormatBool(tt.featureFlag),
 						},
+						NodeDrainPolicy: tt.drainPolicy,
 					},
 				}
 				if len(tt.startConditions) > 0 {
@@ -432,7 +619,9 @@ func TestReconciler(t *testing.T) {
 
 			client := clientBuilder.Build()
 
-			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), client, fake.NewSimpleClientset(tt.nodeObject))
+			recorder := record.NewFakeRecorder(10)
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), client, fake.NewSimpleClientset(append(tt.kubeObjects, tt.nodeObject)...), recorder)
 
 			request := ctrl.Request{}
 			request.Name = tt.nodeName
@@ -442,6 +631,34 @@ func TestReconciler(t *testing.T) {
 			_, err := r.Reconcile(ctx, request)
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 			utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+
+			if tt.wantAnnotations != nil {
+				node := &corev1.Node{}
+				err = client.Get(ctx, types.NamespacedName{Name: tt.nodeName}, node)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, k := range []string{AnnotationDrainPodsRemaining, AnnotationDrainBlockingPDBs} {
+					if node.Annotations[k] != tt.wantAnnotations[k] {
+						t.Errorf("%s: got %q, want %q", k, node.Annotations[k], tt.wantAnnotations[k])
+					}
+				}
+
+				if len(tt.wantAnnotations) == 0 && node.Annotations[AnnotationDrainStartTime] != "" {
+					t.Errorf("%s: unexpected %q", AnnotationDrainStartTime, node.Annotations[AnnotationDrainStartTime])
+				}
+			}
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+
+			if tt.wantEvents != nil && !reflect.DeepEqual(events, tt.wantEvents) {
+				t.Error(cmp.Diff(events, tt.wantEvents))
+			}
 		})
 	}
 }
@@ -454,7 +671,7 @@ func TestSetAnnotation(t *testing.T) {
 		annotationValue string
 	}{
 		{
-			// This test case is still necessary for coverage, because Reconcile only uses setAnnotaion() to set annotationDrainStartTime and never sets nil k/v.
+			// This test case is still necessary for coverage, because Reconcile only uses setAnnotaion() to set AnnotationDrainStartTime and never sets nil k/v.
 			name: "ensure an empty map is returned when k and v are nil",
 			node: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,59 @@
+package node
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+)
+
+// DrainPolicy is a NodeDrainPolicy with its defaults applied
+type DrainPolicy struct {
+	GracePeriod           time.Duration
+	Timeout               time.Duration
+	PodGracePeriodSeconds int
+	DeleteEmptyDirData    bool
+	IgnoreDaemonSets      bool
+	StuckThreshold        time.Duration
+}
+
+// GetDrainPolicy returns the node drain policy of the cluster
+func GetDrainPolicy(instance *arov1alpha1.Cluster) *DrainPolicy {
+	p := &DrainPolicy{
+		GracePeriod: defaultGracePeriod,
+		Timeout:     defaultTimeout,
+		// use the termination grace period of each pod
+		PodGracePeriodSeconds: -1,
+		DeleteEmptyDirData:    true,
+		IgnoreDaemonSets:      true,
+		StuckThreshold:        defaultStuckThreshold,
+	}
+
+	policy := instance.Spec.NodeDrainPolicy
+	if policy == nil {
+		return p
+	}
+
+	if policy.GracePeriod != nil {
+		p.GracePeriod = policy.GracePeriod.Duration
+	}
+	if policy.Timeout != nil && policy.Timeout.Duration > 0 {
+		p.Timeout = policy.Timeout.Duration
+	}
+	if policy.PodGracePeriodSeconds != nil {
+		p.PodGracePeriodSeconds = int(*policy.PodGracePeriodSeconds)
+	}
+	if policy.DeleteEmptyDirData != nil {
+		p.DeleteEmptyDirData = *policy.DeleteEmptyDirData
+	}
+	if policy.IgnoreDaemonSets != nil {
+		p.IgnoreDaemonSets = *policy.IgnoreDaemonSets
+	}
+	if policy.StuckThreshold != nil && policy.StuckThreshold.Duration > 0 {
+		p.StuckThreshold = policy.StuckThreshold.Duration
+	}
+
+	return p
+}
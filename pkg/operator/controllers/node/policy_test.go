@@ -0,0 +1,83 @@
+package node
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/cmp"
+)
+
+func TestGetDrainPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		policy *arov1alpha1.NodeDrainPolicy
+		want   *DrainPolicy
+	}{
+		{
+			name: "defaults",
+			want: &DrainPolicy{
+				GracePeriod:           time.Hour,
+				Timeout:               60 * time.Second,
+				PodGracePeriodSeconds: -1,
+				DeleteEmptyDirData:    true,
+				IgnoreDaemonSets:      true,
+				StuckThreshold:        2 * time.Hour,
+			},
+		},
+		{
+			name: "overridden",
+			policy: &arov1alpha1.NodeDrainPolicy{
+				GracePeriod:           &metav1.Duration{},
+				Timeout:               &metav1.Duration{Duration: 5 * time.Minute},
+				StuckThreshold:        &metav1.Duration{Duration: 6 * time.Hour},
+				PodGracePeriodSeconds: to.Int32Ptr(30),
+				DeleteEmptyDirData:    to.BoolPtr(false),
+				IgnoreDaemonSets:      to.BoolPtr(false),
+			},
+			want: &DrainPolicy{
+				GracePeriod:           0,
+				Timeout:               5 * time.Minute,
+				PodGracePeriodSeconds: 30,
+				DeleteEmptyDirData:    false,
+				IgnoreDaemonSets:      false,
+				StuckThreshold:        6 * time.Hour,
+			},
+		},
+		{
+			name: "zero timeout and stuck threshold are defaulted",
+			policy: &arov1alpha1.NodeDrainPolicy{
+				Timeout:        &metav1.Duration{},
+				StuckThreshold: &metav1.Duration{},
+			},
+			want: &DrainPolicy{
+				GracePeriod:           time.Hour,
+				Timeout:               60 * time.Second,
+				PodGracePeriodSeconds: -1,
+				DeleteEmptyDirData:    true,
+				IgnoreDaemonSets:      true,
+				StuckThreshold:        2 * time.Hour,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &arov1alpha1.Cluster{
+				Spec: arov1alpha1.ClusterSpec{
+					NodeDrainPolicy: tt.policy,
+				},
+			}
+
+			got := GetDrainPolicy(instance)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
@@ -5,6 +5,9 @@ package nsgflowlogs
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
@@ -13,6 +16,7 @@ import (
 
 	aropreviewv1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/preview.aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
 	"github.com/Azure/ARO-RP/pkg/util/subnet"
 )
 
@@ -48,30 +52,75 @@ func (n *nsgFlowLogsFeature) Reconcile(ctx context.Context, instance *aropreview
 	return n.Enable(ctx, instance)
 }
 
+// Enable creates or updates the flow log of each NSG of the cluster and
+// removes the flow logs of NSGs which are no longer attached to the cluster's
+// subnets.  The outcome for each NSG is recorded in the instance status.
 func (n *nsgFlowLogsFeature) Enable(ctx context.Context, instance *aropreviewv1alpha1.PreviewFeature) error {
+	networkWatcherResource, err := azure.ParseResourceID(instance.Spec.NSGFlowLogs.NetworkWatcherID)
+	if err != nil {
+		return err
+	}
+
 	nsgs, err := n.getNSGs(ctx)
 	if err != nil {
 		return err
 	}
 
+	nsgIDs := make([]string, 0, len(nsgs))
 	for nsgID := range nsgs {
-		networkWatcherResource, err := azure.ParseResourceID(instance.Spec.NSGFlowLogs.NetworkWatcherID)
-		if err != nil {
-			return err
+		nsgIDs = append(nsgIDs, nsgID)
+	}
+	sort.Strings(nsgIDs)
+
+	var statuses []aropreviewv1alpha1.NSGFlowLogStatus
+	for _, nsgID := range nsgIDs {
+		status := aropreviewv1alpha1.NSGFlowLogStatus{
+			NetworkSecurityGroupID: nsgID,
 		}
 
-		flowLog := n.newFlowLog(instance, nsgID)
+		thisErr := n.enableOne(ctx, instance, networkWatcherResource, nsgID, &status)
+		if thisErr != nil {
+			status.Error = thisErr.Error()
+			err = thisErr
+		}
 
-		res, err := azure.ParseResourceID(nsgID)
-		if err != nil {
-			return err
+		statuses = append(statuses, status)
+	}
+
+	for _, status := range instance.Status.NSGFlowLogs {
+		if _, ok := nsgs[status.NetworkSecurityGroupID]; ok {
+			continue
 		}
-		err = n.flowLogsClient.CreateOrUpdateAndWait(ctx, networkWatcherResource.ResourceGroup, networkWatcherResource.ResourceName, res.ResourceName, *flowLog)
-		if err != nil {
-			return err
+
+		thisErr := n.deleteFlowLog(ctx, status.FlowLogID)
+		if thisErr != nil {
+			status.Error = thisErr.Error()
+			statuses = append(statuses, status)
+			err = thisErr
 		}
 	}
 
+	instance.Status.NSGFlowLogs = statuses
+
+	return err
+}
+
+func (n *nsgFlowLogsFeature) enableOne(ctx context.Context, instance *aropreviewv1alpha1.PreviewFeature, networkWatcherResource azure.Resource, nsgID string, status *aropreviewv1alpha1.NSGFlowLogStatus) error {
+	res, err := azure.ParseResourceID(nsgID)
+	if err != nil {
+		return err
+	}
+
+	flowLog := n.newFlowLog(instance, nsgID)
+
+	err = n.flowLogsClient.CreateOrUpdateAndWait(ctx, networkWatcherResource.ResourceGroup, networkWatcherResource.ResourceName, res.ResourceName, *flowLog)
+	if err != nil {
+		return err
+	}
+
+	status.FlowLogID = flowLogID(instance.Spec.NSGFlowLogs.NetworkWatcherID, res.ResourceName)
+	status.Enabled = true
+
 	return nil
 }
 
@@ -100,30 +149,80 @@ func (n *nsgFlowLogsFeature) newFlowLog(instance *aropreviewv1alpha1.PreviewFeat
 	}
 }
 
+// Disable removes the flow log of each NSG of the cluster, as well as those
+// recorded in the instance status, so that flow logs are removed even if the
+// network watcher or the NSGs of the cluster have changed since they were
+// applied.  NSGs whose flow log could not be removed remain in the status.
 func (n *nsgFlowLogsFeature) Disable(ctx context.Context, instance *aropreviewv1alpha1.PreviewFeature) error {
-	networkWatcherResource, err := azure.ParseResourceID(instance.Spec.NSGFlowLogs.NetworkWatcherID)
-	if err != nil {
-		return err
-	}
-
 	nsgs, err := n.getNSGs(ctx)
 	if err != nil {
 		return err
 	}
 
-	for nsgID := range nsgs {
-		res, err := azure.ParseResourceID(nsgID)
-		if err != nil {
-			return err
+	flowLogIDs := map[string]string{}
+	if instance.Spec.NSGFlowLogs.NetworkWatcherID != "" {
+		for nsgID := range nsgs {
+			res, err := azure.ParseResourceID(nsgID)
+			if err != nil {
+				return err
+			}
+
+			flowLogIDs[flowLogID(instance.Spec.NSGFlowLogs.NetworkWatcherID, res.ResourceName)] = nsgID
+		}
+	}
+	for _, status := range instance.Status.NSGFlowLogs {
+		if status.FlowLogID != "" {
+			flowLogIDs[status.FlowLogID] = status.NetworkSecurityGroupID
 		}
+	}
 
-		err = n.flowLogsClient.DeleteAndWait(ctx, networkWatcherResource.ResourceGroup, networkWatcherResource.ResourceName, res.ResourceName)
-		if err != nil {
-			return err
+	ids := make([]string, 0, len(flowLogIDs))
+	for id := range flowLogIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var statuses []aropreviewv1alpha1.NSGFlowLogStatus
+	for _, id := range ids {
+		thisErr := n.deleteFlowLog(ctx, id)
+		if thisErr != nil {
+			statuses = append(statuses, aropreviewv1alpha1.NSGFlowLogStatus{
+				NetworkSecurityGroupID: flowLogIDs[id],
+				FlowLogID:              id,
+				Error:                  thisErr.Error(),
+			})
+			err = thisErr
 		}
 	}
 
-	return nil
+	instance.Status.NSGFlowLogs = statuses
+
+	return err
+}
+
+// deleteFlowLog deletes the flow log with the given ID.  A flow log which does
+// not exist is not an error.
+func (n *nsgFlowLogsFeature) deleteFlowLog(ctx context.Context, id string) error {
+	networkWatcherID, name, ok := strings.Cut(id, "/flowLogs/")
+	if !ok {
+		return fmt.Errorf("invalid flow log ID %q", id)
+	}
+
+	networkWatcherResource, err := azure.ParseResourceID(networkWatcherID)
+	if err != nil {
+		return err
+	}
+
+	err = n.flowLogsClient.DeleteAndWait(ctx, networkWatcherResource.ResourceGroup, networkWatcherResource.ResourceName, name)
+	if azureerrors.IsNotFoundError(err) {
+		err = nil
+	}
+
+	return err
+}
+
+func flowLogID(networkWatcherID, nsgName string) string {
+	return networkWatcherID + "/flowLogs/" + nsgName
 }
 
 // getNSGs collects all unique NSG IDs. By default, master and worker subnets will use the same NSG.
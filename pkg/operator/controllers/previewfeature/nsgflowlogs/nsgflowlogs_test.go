@@ -5,9 +5,13 @@ package nsgflowlogs
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"reflect"
 	"testing"
 
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +41,7 @@ var (
 	subnetNameMaster        = "master"
 	resourceIdMaster        = "/subscriptions/" + subscriptionId + "/resourceGroups/" + vnetResourceGroup + "/providers/Microsoft.Network/virtualNetworks/" + vnetName + "/subnets/" + subnetNameMaster
 	resourceIdWorker        = "/subscriptions/" + subscriptionId + "/resourceGroups/" + vnetResourceGroup + "/providers/Microsoft.Network/virtualNetworks/" + vnetName + "/subnets/" + subnetNameWorker
+	oldNSGID                = "/subscriptions/" + subscriptionId + "/resourceGroups/" + vnetResourceGroup + "/providers/Microsoft.Network/networkSecurityGroups/oldNSG"
 	resourceIdWorker2       = "/subscriptions/" + subscriptionId + "/resourceGroups/" + vnetResourceGroup + "/providers/Microsoft.Network/virtualNetworks/" + vnetName + "/subnets/" + subnetNameWorker2
 )
 
@@ -87,10 +92,18 @@ func TestReconcileManager(t *testing.T) {
 		subnetMock        func(*mock_subnet.MockManager, *mock_subnet.MockKubeManager)
 		instance          func(*aropreviewv1alpha1.PreviewFeature)
 		flowLogClientMock func(*mock_network.MockFlowLogsClient)
+		wantStatus        []aropreviewv1alpha1.NSGFlowLogStatus
 		wantErr           string
 	}{
 		{
 			name: "do not enable flow log if parameters are missing/wrong",
+			instance: func(feature *aropreviewv1alpha1.PreviewFeature) {
+				feature.Spec.NSGFlowLogs.Enabled = true
+			},
+			wantErr: "parsing failed for . Invalid resource Id format",
+		},
+		{
+			name: "enable flow log",
 			subnetMock: func(mock *mock_subnet.MockManager, kmock *mock_subnet.MockKubeManager) {
 				kmock.EXPECT().List(gomock.Any()).Return([]subnet.Subnet{
 					{
@@ -99,6 +112,9 @@ func TestReconcileManager(t *testing.T) {
 					{
 						ResourceID: resourceIdWorker,
 					},
+					{
+						ResourceID: resourceIdWorker2,
+					},
 				}, nil)
 				mock.EXPECT().Get(gomock.Any(), resourceIdMaster).Return(&mgmtnetwork.Subnet{
 					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
@@ -110,18 +126,48 @@ func TestReconcileManager(t *testing.T) {
 				mock.EXPECT().Get(gomock.Any(), resourceIdWorker).Return(&mgmtnetwork.Subnet{
 					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
 						NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{
-							ID: &subnetNameWorkerNSGID,
+							ID: &subnetNameMasterNSGID, // same NSG as the master subnet
 						},
 					},
 				}, nil)
+				mock.EXPECT().Get(gomock.Any(), resourceIdWorker2).Return(&mgmtnetwork.Subnet{
+					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+						NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{
+							ID: &subnetNameWorkerNSGID, // different NSG ID. expect another one call to create
+						},
+					},
+				}, nil)
+			},
+			flowLogClientMock: func(client *mock_network.MockFlowLogsClient) {
+				flowLogMaster := getValidFlowLogFeature()
+				flowLogMaster.FlowLogPropertiesFormat.TargetResourceID = &subnetNameMasterNSGID
+
+				flowLogWorker := getValidFlowLogFeature()
+				flowLogWorker.FlowLogPropertiesFormat.TargetResourceID = &subnetNameWorkerNSGID
+				// enable once per NSG
+				client.EXPECT().CreateOrUpdateAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameMasterNSGName, *flowLogMaster)
+				client.EXPECT().CreateOrUpdateAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameWorkerNSGName, *flowLogWorker)
 			},
 			instance: func(feature *aropreviewv1alpha1.PreviewFeature) {
 				feature.Spec.NSGFlowLogs.Enabled = true
+				feature.Spec.NSGFlowLogs.NetworkWatcherID = networkWatcherResourceId
 			},
-			wantErr: "parsing failed for . Invalid resource Id format",
+			wantStatus: []aropreviewv1alpha1.NSGFlowLogStatus{
+				{
+					NetworkSecurityGroupID: subnetNameMasterNSGID,
+					FlowLogID:              networkWatcherResourceId + "/flowLogs/" + subnetNameMasterNSGName,
+					Enabled:                true,
+				},
+				{
+					NetworkSecurityGroupID: subnetNameWorkerNSGID,
+					FlowLogID:              networkWatcherResourceId + "/flowLogs/" + subnetNameWorkerNSGName,
+					Enabled:                true,
+				},
+			},
+			wantErr: "",
 		},
 		{
-			name: "enable flow log",
+			name: "disable flow log",
 			subnetMock: func(mock *mock_subnet.MockManager, kmock *mock_subnet.MockKubeManager) {
 				kmock.EXPECT().List(gomock.Any()).Return([]subnet.Subnet{
 					{
@@ -151,29 +197,23 @@ func TestReconcileManager(t *testing.T) {
 				mock.EXPECT().Get(gomock.Any(), resourceIdWorker2).Return(&mgmtnetwork.Subnet{
 					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
 						NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{
-							ID: &subnetNameWorkerNSGID, // different NSG ID. expect another one call to create
+							ID: &subnetNameWorkerNSGID, // in order to test calls to disable once per NSG
 						},
 					},
 				}, nil)
 			},
 			flowLogClientMock: func(client *mock_network.MockFlowLogsClient) {
-				flowLogMaster := getValidFlowLogFeature()
-				flowLogMaster.FlowLogPropertiesFormat.TargetResourceID = &subnetNameMasterNSGID
-
-				flowLogWorker := getValidFlowLogFeature()
-				flowLogWorker.FlowLogPropertiesFormat.TargetResourceID = &subnetNameWorkerNSGID
-				// enable once per NSG
-				client.EXPECT().CreateOrUpdateAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameMasterNSGName, *flowLogMaster)
-				client.EXPECT().CreateOrUpdateAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameWorkerNSGName, *flowLogWorker)
+				client.EXPECT().DeleteAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameMasterNSGName)
+				client.EXPECT().DeleteAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameWorkerNSGName)
 			},
 			instance: func(feature *aropreviewv1alpha1.PreviewFeature) {
-				feature.Spec.NSGFlowLogs.Enabled = true
+				feature.Spec.NSGFlowLogs.Enabled = false
 				feature.Spec.NSGFlowLogs.NetworkWatcherID = networkWatcherResourceId
 			},
 			wantErr: "",
 		},
 		{
-			name: "disable flow log",
+			name: "enable flow log records errors per NSG and removes flow logs of detached NSGs",
 			subnetMock: func(mock *mock_subnet.MockManager, kmock *mock_subnet.MockKubeManager) {
 				kmock.EXPECT().List(gomock.Any()).Return([]subnet.Subnet{
 					{
@@ -182,9 +222,6 @@ func TestReconcileManager(t *testing.T) {
 					{
 						ResourceID: resourceIdWorker,
 					},
-					{
-						ResourceID: resourceIdWorker2,
-					},
 				}, nil)
 				mock.EXPECT().Get(gomock.Any(), resourceIdMaster).Return(&mgmtnetwork.Subnet{
 					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
@@ -196,27 +233,95 @@ func TestReconcileManager(t *testing.T) {
 				mock.EXPECT().Get(gomock.Any(), resourceIdWorker).Return(&mgmtnetwork.Subnet{
 					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
 						NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{
-							ID: &subnetNameMasterNSGID, // same NSG as the master subnet
+							ID: &subnetNameWorkerNSGID,
 						},
 					},
 				}, nil)
-				mock.EXPECT().Get(gomock.Any(), resourceIdWorker2).Return(&mgmtnetwork.Subnet{
+			},
+			flowLogClientMock: func(client *mock_network.MockFlowLogsClient) {
+				flowLogMaster := getValidFlowLogFeature()
+				flowLogMaster.FlowLogPropertiesFormat.TargetResourceID = &subnetNameMasterNSGID
+
+				flowLogWorker := getValidFlowLogFeature()
+				flowLogWorker.FlowLogPropertiesFormat.TargetResourceID = &subnetNameWorkerNSGID
+
+				client.EXPECT().CreateOrUpdateAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameMasterNSGName, *flowLogMaster)
+				client.EXPECT().CreateOrUpdateAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameWorkerNSGName, *flowLogWorker).
+					Return(errors.New("storage account not found"))
+				// the NSG previously attached to the cluster is no longer in use
+				client.EXPECT().DeleteAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, "oldNSG")
+			},
+			instance: func(feature *aropreviewv1alpha1.PreviewFeature) {
+				feature.Spec.NSGFlowLogs.Enabled = true
+				feature.Spec.NSGFlowLogs.NetworkWatcherID = networkWatcherResourceId
+				feature.Status.NSGFlowLogs = []aropreviewv1alpha1.NSGFlowLogStatus{
+					{
+						NetworkSecurityGroupID: oldNSGID,
+						FlowLogID:              networkWatcherResourceId + "/flowLogs/oldNSG",
+						Enabled:                true,
+					},
+				}
+			},
+			wantStatus: []aropreviewv1alpha1.NSGFlowLogStatus{
+				{
+					NetworkSecurityGroupID: subnetNameMasterNSGID,
+					FlowLogID:              networkWatcherResourceId + "/flowLogs/" + subnetNameMasterNSGName,
+					Enabled:                true,
+				},
+				{
+					NetworkSecurityGroupID: subnetNameWorkerNSGID,
+					Error:                  "storage account not found",
+				},
+			},
+			wantErr: "storage account not found",
+		},
+		{
+			name: "disable flow log removes flow logs recorded in status",
+			subnetMock: func(mock *mock_subnet.MockManager, kmock *mock_subnet.MockKubeManager) {
+				kmock.EXPECT().List(gomock.Any()).Return([]subnet.Subnet{
+					{
+						ResourceID: resourceIdMaster,
+					},
+				}, nil)
+				mock.EXPECT().Get(gomock.Any(), resourceIdMaster).Return(&mgmtnetwork.Subnet{
 					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
 						NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{
-							ID: &subnetNameWorkerNSGID, // in order to test calls to disable once per NSG
+							ID: &subnetNameMasterNSGID,
 						},
 					},
 				}, nil)
 			},
 			flowLogClientMock: func(client *mock_network.MockFlowLogsClient) {
-				client.EXPECT().DeleteAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameMasterNSGName)
-				client.EXPECT().DeleteAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameWorkerNSGName)
+				// already removed
+				client.EXPECT().DeleteAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, subnetNameMasterNSGName).
+					Return(autorest.DetailedError{StatusCode: http.StatusNotFound})
+				client.EXPECT().DeleteAndWait(gomock.Any(), networkWatcherResourceGroupName, networkWatcherName, "oldNSG").
+					Return(errors.New("deletion failed"))
 			},
 			instance: func(feature *aropreviewv1alpha1.PreviewFeature) {
 				feature.Spec.NSGFlowLogs.Enabled = false
 				feature.Spec.NSGFlowLogs.NetworkWatcherID = networkWatcherResourceId
+				feature.Status.NSGFlowLogs = []aropreviewv1alpha1.NSGFlowLogStatus{
+					{
+						NetworkSecurityGroupID: subnetNameMasterNSGID,
+						FlowLogID:              networkWatcherResourceId + "/flowLogs/" + subnetNameMasterNSGName,
+						Enabled:                true,
+					},
+					{
+						NetworkSecurityGroupID: oldNSGID,
+						FlowLogID:              networkWatcherResourceId + "/flowLogs/oldNSG",
+						Enabled:                true,
+					},
+				}
 			},
-			wantErr: "",
+			wantStatus: []aropreviewv1alpha1.NSGFlowLogStatus{
+				{
+					NetworkSecurityGroupID: oldNSGID,
+					FlowLogID:              networkWatcherResourceId + "/flowLogs/oldNSG",
+					Error:                  "deletion failed",
+				},
+			},
+			wantErr: "deletion failed",
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
@@ -243,6 +348,10 @@ func TestReconcileManager(t *testing.T) {
 
 			err := r.Reconcile(context.Background(), instance)
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(instance.Status.NSGFlowLogs, tt.wantStatus) {
+				t.Errorf("got status %#v, wanted %#v", instance.Status.NSGFlowLogs, tt.wantStatus)
+			}
 		})
 	}
 }
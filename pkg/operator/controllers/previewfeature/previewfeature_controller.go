@@ -5,6 +5,7 @@ package previewfeature
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/sirupsen/logrus"
@@ -91,6 +92,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		nsgflowlogs.NewFeature(flowLogsClient, kubeSubnets, subnets, clusterInstance.Spec.Location),
 	}
 
+	status := instance.Status.DeepCopy()
+
 	err = nil
 	for _, f := range features {
 		thisErr := f.Reconcile(ctx, instance)
@@ -101,6 +104,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		}
 	}
 
+	// Features record what they have applied in the status, also on error
+	if !reflect.DeepEqual(status, &instance.Status) {
+		updateErr := r.client.Status().Update(ctx, instance)
+		if updateErr != nil {
+			r.log.Errorf("error updating status: %s", updateErr)
+			if err == nil {
+				err = updateErr
+			}
+		}
+	}
+
 	// Controller-runtime will requeue when err != nil
 	return reconcile.Result{}, err
 }
@@ -5,6 +5,7 @@ package portal
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"sort"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/gorilla/mux"
 
+	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/portal/cluster"
 	"github.com/Azure/ARO-RP/pkg/portal/prometheus"
 )
@@ -31,60 +33,82 @@ type AdminOpenShiftCluster struct {
 	ProvisionedBy           string `json:"provisionedBy"`
 }
 
+// clusters lists the clusters of the region.  The list is filtered by the
+// query parameters subscription, resourceGroup, version, provisioningState,
+// provisionedBy, createdAfter, createdBefore and q (a substring of the cluster
+// name).  If limit is given, at most limit clusters are returned and the
+// continuation header carries the token for the next page.  format=csv
+// returns the clusters as CSV rather than JSON.
 func (p *portal) clusters(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	q := r.URL.Query()
 
-	docs, err := p.dbOpenShiftClusters.ListAll(ctx)
+	filter, err := parseClusterFilter(q)
 	if err != nil {
-		p.internalServerError(w, err)
+		p.badRequest(w, err)
 		return
 	}
 
-	clusters := make([]*AdminOpenShiftCluster, 0, len(docs.OpenShiftClusterDocuments))
-	for _, doc := range docs.OpenShiftClusterDocuments {
-		if doc.OpenShiftCluster == nil {
-			continue
-		}
+	limit, err := parseClusterListLimit(q)
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	format := q.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		p.badRequest(w, fmt.Errorf("invalid format %q", format))
+		return
+	}
 
-		ps := doc.OpenShiftCluster.Properties.ProvisioningState
-		fps := doc.OpenShiftCluster.Properties.FailedProvisioningState
-		subscription := "Unknown"
-		resourceGroup := "Unknown"
-		name := "Unknown"
+	i, err := p.dbOpenShiftClusters.ListByFilter(filter.subscription, filter.query(), q.Get("continuation"))
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
 
-		if resource, err := azure.ParseResourceID(doc.OpenShiftCluster.ID); err == nil {
-			subscription = resource.SubscriptionID
-			resourceGroup = resource.ResourceGroup
-			name = resource.ResourceName
+	clusters := []*AdminOpenShiftCluster{}
+	for limit == 0 || len(clusters) < limit {
+		// only read as many documents as can still be returned, so that the
+		// continuation token does not skip any
+		maxItemCount := -1
+		if limit > 0 {
+			maxItemCount = limit - len(clusters)
 		}
 
-		createdAt := "Unknown"
-		if !doc.OpenShiftCluster.Properties.CreatedAt.IsZero() {
-			createdAt = doc.OpenShiftCluster.Properties.CreatedAt.Format(time.RFC3339)
+		docs, err := i.Next(ctx, maxItemCount)
+		if err != nil {
+			p.internalServerError(w, err)
+			return
+		}
+		if docs == nil {
+			break
 		}
 
-		lastModified := "Unknown"
-		if doc.OpenShiftCluster.SystemData.LastModifiedAt != nil {
-			lastModified = doc.OpenShiftCluster.SystemData.LastModifiedAt.Format(time.RFC3339)
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			if filter.matches(doc) {
+				clusters = append(clusters, adminOpenShiftCluster(doc))
+			}
 		}
+	}
 
-		clusters = append(clusters, &AdminOpenShiftCluster{
-			Key:                     doc.ID,
-			ResourceId:              doc.OpenShiftCluster.ID,
-			Name:                    name,
-			Subscription:            subscription,
-			ResourceGroup:           resourceGroup,
-			Version:                 doc.OpenShiftCluster.Properties.ClusterProfile.Version,
-			CreatedAt:               createdAt,
-			LastModified:            lastModified,
-			ProvisionedBy:           doc.OpenShiftCluster.Properties.ProvisionedBy,
-			ProvisioningState:       ps.String(),
-			FailedProvisioningState: fps.String(),
-		})
+	if limit > 0 && i.Continuation() != "" {
+		w.Header().Set(clusterListContinuationHeader, i.Continuation())
 	}
 
 	sort.SliceStable(clusters, func(i, j int) bool { return strings.Compare(clusters[i].Key, clusters[j].Key) < 0 })
 
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="clusters.csv"`)
+
+		err = writeClustersCSV(w, clusters)
+		if err != nil {
+			p.log.Error(err)
+		}
+		return
+	}
+
 	b, err := json.MarshalIndent(clusters, "", "    ")
 	if err != nil {
 		p.internalServerError(w, err)
@@ -95,6 +119,44 @@ func (p *portal) clusters(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(b)
 }
 
+func adminOpenShiftCluster(doc *api.OpenShiftClusterDocument) *AdminOpenShiftCluster {
+	ps := doc.OpenShiftCluster.Properties.ProvisioningState
+	fps := doc.OpenShiftCluster.Properties.FailedProvisioningState
+	subscription := "Unknown"
+	resourceGroup := "Unknown"
+	name := "Unknown"
+
+	if resource, err := azure.ParseResourceID(doc.OpenShiftCluster.ID); err == nil {
+		subscription = resource.SubscriptionID
+		resourceGroup = resource.ResourceGroup
+		name = resource.ResourceName
+	}
+
+	createdAt := "Unknown"
+	if !doc.OpenShiftCluster.Properties.CreatedAt.IsZero() {
+		createdAt = doc.OpenShiftCluster.Properties.CreatedAt.Format(time.RFC3339)
+	}
+
+	lastModified := "Unknown"
+	if doc.OpenShiftCluster.SystemData.LastModifiedAt != nil {
+		lastModified = doc.OpenShiftCluster.SystemData.LastModifiedAt.Format(time.RFC3339)
+	}
+
+	return &AdminOpenShiftCluster{
+		Key:                     doc.ID,
+		ResourceId:              doc.OpenShiftCluster.ID,
+		Name:                    name,
+		Subscription:            subscription,
+		ResourceGroup:           resourceGroup,
+		Version:                 doc.OpenShiftCluster.Properties.ClusterProfile.Version,
+		CreatedAt:               createdAt,
+		LastModified:            lastModified,
+		ProvisionedBy:           doc.OpenShiftCluster.Properties.ProvisionedBy,
+		ProvisioningState:       ps.String(),
+		FailedProvisioningState: fps.String(),
+	}
+}
+
 func (p *portal) clusterOperators(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -195,6 +257,117 @@ func (p *portal) machineSets(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(b)
 }
 
+// events lists the events of the cluster, newest first.  The list is filtered
+// by the query parameters namespace, type, reason, kind and name (of the
+// involved object) and since (a duration, e.g. 1h).
+func (p *portal) events(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := parseEventFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	events, err := fetcher.Events(ctx, filter)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(events, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// failingPods lists the pods in openshift-* namespaces which are failing
+func (p *portal) failingPods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	pods, err := fetcher.FailingPods(ctx)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(pods, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// etcd returns the health of the etcd members
+func (p *portal) etcd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	etcd, err := fetcher.Etcd(ctx)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(etcd, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func (p *portal) machineConfigPools(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	machineConfigPools, err := fetcher.MachineConfigPools(ctx)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(machineConfigPools, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
 func (p *portal) statistics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	duration := r.URL.Query().Get("duration")
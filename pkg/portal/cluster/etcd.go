@@ -0,0 +1,122 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EtcdMemberInformation describes the etcd member running on a control plane
+// node, as seen by its static pod and by the etcd operator
+type EtcdMemberInformation struct {
+	NodeName         string          `json:"nodeName"`
+	PodName          string          `json:"podName"`
+	Phase            corev1.PodPhase `json:"phase"`
+	Ready            bool            `json:"ready"`
+	RestartCount     int32           `json:"restartCount"`
+	CurrentRevision  int32           `json:"currentRevision"`
+	TargetRevision   int32           `json:"targetRevision"`
+	LastFailedReason string          `json:"lastFailedReason"`
+}
+
+type EtcdInformation struct {
+	Members    []EtcdMemberInformation `json:"members"`
+	Conditions []OperatorCondition     `json:"conditions"`
+}
+
+func podIsReady(pod *corev1.Pod) bool {
+	for _, cnd := range pod.Status.Conditions {
+		if cnd.Type == corev1.PodReady {
+			return cnd.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func etcdInformation(etcd *operatorv1.Etcd, pods *corev1.PodList) *EtcdInformation {
+	final := &EtcdInformation{
+		Members:    make([]EtcdMemberInformation, 0, len(pods.Items)),
+		Conditions: make([]OperatorCondition, 0, len(etcd.Status.Conditions)),
+	}
+
+	members := map[string]*EtcdMemberInformation{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		var restartCount int32
+		for _, status := range pod.Status.ContainerStatuses {
+			restartCount += status.RestartCount
+		}
+
+		members[pod.Spec.NodeName] = &EtcdMemberInformation{
+			NodeName:     pod.Spec.NodeName,
+			PodName:      pod.Name,
+			Phase:        pod.Status.Phase,
+			Ready:        podIsReady(pod),
+			RestartCount: restartCount,
+		}
+	}
+
+	// a node which the operator knows about but which has no etcd pod is
+	// still listed, as that is the most interesting case
+	for _, ns := range etcd.Status.NodeStatuses {
+		m, ok := members[ns.NodeName]
+		if !ok {
+			m = &EtcdMemberInformation{
+				NodeName: ns.NodeName,
+			}
+			members[ns.NodeName] = m
+		}
+
+		m.CurrentRevision = ns.CurrentRevision
+		m.TargetRevision = ns.TargetRevision
+		m.LastFailedReason = ns.LastFailedReason
+	}
+
+	for _, m := range members {
+		final.Members = append(final.Members, *m)
+	}
+
+	sort.Slice(final.Members, func(i, j int) bool { return final.Members[i].NodeName < final.Members[j].NodeName })
+
+	for _, cnd := range etcd.Status.Conditions {
+		final.Conditions = append(final.Conditions, OperatorCondition{
+			Type:        configv1.ClusterStatusConditionType(cnd.Type),
+			LastUpdated: cnd.LastTransitionTime.String(),
+			Status:      configv1.ConditionStatus(cnd.Status),
+			Reason:      cnd.Reason,
+			Message:     cnd.Message,
+		})
+	}
+
+	return final
+}
+
+// Etcd returns the health of the etcd members, combining the state of the
+// etcd static pods with the conditions and node statuses of the etcd operator
+func (f *realFetcher) Etcd(ctx context.Context) (*EtcdInformation, error) {
+	etcd, err := f.operatorCli.OperatorV1().Etcds().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := f.kubernetesCli.CoreV1().Pods("openshift-etcd").List(ctx, metav1.ListOptions{
+		LabelSelector: "app=etcd",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return etcdInformation(etcd, pods), nil
+}
+
+func (c *client) Etcd(ctx context.Context) (*EtcdInformation, error) {
+	return c.fetcher.Etcd(ctx)
+}
@@ -0,0 +1,142 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	operatorfake "github.com/openshift/client-go/operator/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestEtcd(t *testing.T) {
+	ctx := context.Background()
+
+	transitioned := metav1.NewTime(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	etcdPod := func(node string, ready corev1.ConditionStatus, restarts int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "openshift-etcd",
+				Name:      "etcd-" + node,
+				Labels:    map[string]string{"app": "etcd"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: node,
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: ready,
+					},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "etcd", RestartCount: restarts},
+					{Name: "etcdctl"},
+				},
+			},
+		}
+	}
+
+	kubernetes := fake.NewSimpleClientset(
+		etcdPod("aro-master-0", corev1.ConditionTrue, 0),
+		etcdPod("aro-master-1", corev1.ConditionFalse, 7),
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "openshift-etcd",
+				Name:      "etcd-guard-aro-master-0",
+				Labels:    map[string]string{"app": "guard"},
+			},
+		},
+	)
+
+	operator := operatorfake.NewSimpleClientset(&operatorv1.Etcd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Status: operatorv1.EtcdStatus{
+			StaticPodOperatorStatus: operatorv1.StaticPodOperatorStatus{
+				OperatorStatus: operatorv1.OperatorStatus{
+					Conditions: []operatorv1.OperatorCondition{
+						{
+							Type:               "EtcdMembersDegraded",
+							Status:             operatorv1.ConditionTrue,
+							LastTransitionTime: transitioned,
+							Reason:             "UnhealthyMembers",
+							Message:            "2 of 3 members are available, aro-master-2 is unhealthy",
+						},
+					},
+				},
+				NodeStatuses: []operatorv1.NodeStatus{
+					{NodeName: "aro-master-0", CurrentRevision: 3},
+					{NodeName: "aro-master-1", CurrentRevision: 3},
+					{NodeName: "aro-master-2", CurrentRevision: 2, TargetRevision: 3, LastFailedReason: "OperandFailedFallback"},
+				},
+			},
+		},
+	})
+
+	_, log := testlog.New()
+
+	rf := &realFetcher{
+		kubernetesCli: kubernetes,
+		operatorCli:   operator,
+		log:           log,
+	}
+
+	c := &client{fetcher: rf, log: log}
+
+	info, err := c.Etcd(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &EtcdInformation{
+		Members: []EtcdMemberInformation{
+			{
+				NodeName:        "aro-master-0",
+				PodName:         "etcd-aro-master-0",
+				Phase:           corev1.PodRunning,
+				Ready:           true,
+				CurrentRevision: 3,
+			},
+			{
+				NodeName:        "aro-master-1",
+				PodName:         "etcd-aro-master-1",
+				Phase:           corev1.PodRunning,
+				RestartCount:    7,
+				CurrentRevision: 3,
+			},
+			{
+				NodeName:         "aro-master-2",
+				CurrentRevision:  2,
+				TargetRevision:   3,
+				LastFailedReason: "OperandFailedFallback",
+			},
+		},
+		Conditions: []OperatorCondition{
+			{
+				Type:        "EtcdMembersDegraded",
+				LastUpdated: transitioned.String(),
+				Status:      "True",
+				Reason:      "UnhealthyMembers",
+				Message:     "2 of 3 members are available, aro-master-2 is unhealthy",
+			},
+		},
+	}
+
+	for _, l := range deep.Equal(info, expected) {
+		t.Error(l)
+	}
+}
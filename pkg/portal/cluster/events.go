@@ -0,0 +1,158 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// EventFilter selects the events returned by Events.  Empty fields match every
+// event; an empty Namespace matches all namespaces.
+type EventFilter struct {
+	Namespace string
+	Type      string
+	Reason    string
+	Kind      string
+	Name      string
+	Since     time.Time
+}
+
+type EventObject struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+type EventInformation struct {
+	Namespace string      `json:"namespace"`
+	Type      string      `json:"type"`
+	Reason    string      `json:"reason"`
+	Message   string      `json:"message"`
+	Object    EventObject `json:"object"`
+	Source    string      `json:"source"`
+	Count     int32       `json:"count"`
+	FirstSeen string      `json:"firstSeen"`
+	LastSeen  string      `json:"lastSeen"`
+}
+
+type EventListInformation struct {
+	Events []EventInformation `json:"events"`
+}
+
+// fieldSelector returns the field selector which lets the API server do most
+// of the filtering
+func (f *EventFilter) fieldSelector() string {
+	set := fields.Set{}
+	if f.Type != "" {
+		set["type"] = f.Type
+	}
+	if f.Reason != "" {
+		set["reason"] = f.Reason
+	}
+	if f.Kind != "" {
+		set["involvedObject.kind"] = f.Kind
+	}
+	if f.Name != "" {
+		set["involvedObject.name"] = f.Name
+	}
+
+	return fields.SelectorFromSet(set).String()
+}
+
+func (f *EventFilter) matches(event *corev1.Event) bool {
+	return (f.Type == "" || event.Type == f.Type) &&
+		(f.Reason == "" || event.Reason == f.Reason) &&
+		(f.Kind == "" || event.InvolvedObject.Kind == f.Kind) &&
+		(f.Name == "" || event.InvolvedObject.Name == f.Name) &&
+		(f.Since.IsZero() || !eventLastSeen(event).Before(f.Since))
+}
+
+// eventLastSeen returns the time at which the event was last observed.  Events
+// written by the events.k8s.io API only carry an event time.
+func eventLastSeen(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func eventSource(event *corev1.Event) string {
+	if event.Source.Component != "" {
+		return event.Source.Component
+	}
+	return event.ReportingController
+}
+
+func eventListInformationFromEventList(events *corev1.EventList, filter *EventFilter) *EventListInformation {
+	final := &EventListInformation{
+		Events: make([]EventInformation, 0, len(events.Items)),
+	}
+
+	items := make([]*corev1.Event, 0, len(events.Items))
+	for i := range events.Items {
+		if filter.matches(&events.Items[i]) {
+			items = append(items, &events.Items[i])
+		}
+	}
+
+	// newest first
+	sort.SliceStable(items, func(i, j int) bool {
+		return eventLastSeen(items[i]).After(eventLastSeen(items[j]))
+	})
+
+	for _, event := range items {
+		firstSeen := event.FirstTimestamp.Time
+		if firstSeen.IsZero() {
+			firstSeen = eventLastSeen(event)
+		}
+
+		count := event.Count
+		if count == 0 && event.Series != nil {
+			count = event.Series.Count
+		}
+
+		final.Events = append(final.Events, EventInformation{
+			Namespace: event.Namespace,
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Message:   event.Message,
+			Object: EventObject{
+				Kind:      event.InvolvedObject.Kind,
+				Namespace: event.InvolvedObject.Namespace,
+				Name:      event.InvolvedObject.Name,
+			},
+			Source:    eventSource(event),
+			Count:     count,
+			FirstSeen: firstSeen.UTC().Format(time.RFC3339),
+			LastSeen:  eventLastSeen(event).UTC().Format(time.RFC3339),
+		})
+	}
+
+	return final
+}
+
+func (f *realFetcher) Events(ctx context.Context, filter *EventFilter) (*EventListInformation, error) {
+	r, err := f.kubernetesCli.CoreV1().Events(filter.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: filter.fieldSelector(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return eventListInformationFromEventList(r, filter), nil
+}
+
+func (c *client) Events(ctx context.Context, filter *EventFilter) (*EventListInformation, error) {
+	return c.fetcher.Events(ctx, filter)
+}
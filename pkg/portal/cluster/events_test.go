@@ -0,0 +1,184 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestEvents(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	kubernetes := fake.NewSimpleClientset(
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-0.1",
+				Namespace: "openshift-etcd",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: "openshift-etcd",
+				Name:      "etcd-0",
+			},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Unhealthy",
+			Message:        "Readiness probe failed",
+			Source:         corev1.EventSource{Component: "kubelet"},
+			Count:          3,
+			FirstTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+			LastTimestamp:  metav1.NewTime(now.Add(-time.Hour)),
+		},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-1.1",
+				Namespace: "openshift-etcd",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: "openshift-etcd",
+				Name:      "etcd-1",
+			},
+			Type:                corev1.EventTypeWarning,
+			Reason:              "BackOff",
+			Message:             "Back-off restarting failed container",
+			ReportingController: "kubelet",
+			EventTime:           metav1.NewMicroTime(now.Add(-time.Minute)),
+			Series:              &corev1.EventSeries{Count: 5},
+		},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dns-default.1",
+				Namespace: "openshift-dns",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "DaemonSet",
+				Namespace: "openshift-dns",
+				Name:      "dns-default",
+			},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "SuccessfulCreate",
+			Message:        "Created pod: dns-default-abcde",
+			Source:         corev1.EventSource{Component: "daemonset-controller"},
+			Count:          1,
+			FirstTimestamp: metav1.NewTime(now.Add(-3 * time.Hour)),
+			LastTimestamp:  metav1.NewTime(now.Add(-3 * time.Hour)),
+		},
+	)
+
+	unhealthy := EventInformation{
+		Namespace: "openshift-etcd",
+		Type:      "Warning",
+		Reason:    "Unhealthy",
+		Message:   "Readiness probe failed",
+		Object: EventObject{
+			Kind:      "Pod",
+			Namespace: "openshift-etcd",
+			Name:      "etcd-0",
+		},
+		Source:    "kubelet",
+		Count:     3,
+		FirstSeen: "2023-01-02T10:00:00Z",
+		LastSeen:  "2023-01-02T11:00:00Z",
+	}
+
+	backOff := EventInformation{
+		Namespace: "openshift-etcd",
+		Type:      "Warning",
+		Reason:    "BackOff",
+		Message:   "Back-off restarting failed container",
+		Object: EventObject{
+			Kind:      "Pod",
+			Namespace: "openshift-etcd",
+			Name:      "etcd-1",
+		},
+		Source:    "kubelet",
+		Count:     5,
+		FirstSeen: "2023-01-02T11:59:00Z",
+		LastSeen:  "2023-01-02T11:59:00Z",
+	}
+
+	successfulCreate := EventInformation{
+		Namespace: "openshift-dns",
+		Type:      "Normal",
+		Reason:    "SuccessfulCreate",
+		Message:   "Created pod: dns-default-abcde",
+		Object: EventObject{
+			Kind:      "DaemonSet",
+			Namespace: "openshift-dns",
+			Name:      "dns-default",
+		},
+		Source:    "daemonset-controller",
+		Count:     1,
+		FirstSeen: "2023-01-02T09:00:00Z",
+		LastSeen:  "2023-01-02T09:00:00Z",
+	}
+
+	_, log := testlog.New()
+
+	rf := &realFetcher{
+		kubernetesCli: kubernetes,
+		log:           log,
+	}
+
+	c := &client{fetcher: rf, log: log}
+
+	for _, tt := range []struct {
+		name   string
+		filter *EventFilter
+		want   []EventInformation
+	}{
+		{
+			name:   "all events, newest first",
+			filter: &EventFilter{},
+			want:   []EventInformation{backOff, unhealthy, successfulCreate},
+		},
+		{
+			name:   "namespace",
+			filter: &EventFilter{Namespace: "openshift-dns"},
+			want:   []EventInformation{successfulCreate},
+		},
+		{
+			name:   "type and reason",
+			filter: &EventFilter{Type: "Warning", Reason: "Unhealthy"},
+			want:   []EventInformation{unhealthy},
+		},
+		{
+			name:   "involved object",
+			filter: &EventFilter{Kind: "Pod", Name: "etcd-1"},
+			want:   []EventInformation{backOff},
+		},
+		{
+			name:   "since",
+			filter: &EventFilter{Since: now.Add(-90 * time.Minute)},
+			want:   []EventInformation{backOff, unhealthy},
+		},
+		{
+			name:   "no match",
+			filter: &EventFilter{Reason: "FailedMount"},
+			want:   []EventInformation{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := c.Events(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, l := range deep.Equal(info, &EventListInformation{Events: tt.want}) {
+				t.Error(l)
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	machineclient "github.com/openshift/client-go/machine/clientset/versioned"
+	operatorclient "github.com/openshift/client-go/operator/clientset/versioned"
+	mcoclient "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned"
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
 
@@ -25,6 +27,10 @@ type FetchClient interface {
 	ClusterOperators(context.Context) (*ClusterOperatorsInformation, error)
 	Machines(context.Context) (*MachineListInformation, error)
 	MachineSets(context.Context) (*MachineSetListInformation, error)
+	Events(context.Context, *EventFilter) (*EventListInformation, error)
+	FailingPods(context.Context) (*PodListInformation, error)
+	Etcd(context.Context) (*EtcdInformation, error)
+	MachineConfigPools(context.Context) (*MachineConfigPoolListInformation, error)
 	Statistics(context.Context, *http.Client, string, time.Duration, time.Time, string) ([]Metrics, error)
 }
 
@@ -48,6 +54,8 @@ type realFetcher struct {
 	configCli     configclient.Interface
 	kubernetesCli kubernetes.Interface
 	machineClient machineclient.Interface
+	operatorCli   operatorclient.Interface
+	mcoCli        mcoclient.Interface
 }
 
 func newRealFetcher(log *logrus.Entry, dialer proxy.Dialer, doc *api.OpenShiftClusterDocument) (*realFetcher, error) {
@@ -74,11 +82,25 @@ func newRealFetcher(log *logrus.Entry, dialer proxy.Dialer, doc *api.OpenShiftCl
 		return nil, err
 	}
 
+	operatorCli, err := operatorclient.NewForConfig(restConfig)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	mcoCli, err := mcoclient.NewForConfig(restConfig)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
 	return &realFetcher{
 		log:           log,
 		configCli:     configCli,
 		kubernetesCli: kubernetesCli,
 		machineClient: machineClient,
+		operatorCli:   operatorCli,
+		mcoCli:        mcoCli,
 	}, nil
 }
 
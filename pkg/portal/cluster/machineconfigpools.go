@@ -0,0 +1,101 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MachineConfigPoolCondition struct {
+	Type        mcv1.MachineConfigPoolConditionType `json:"type"`
+	LastUpdated string                              `json:"lastUpdated"`
+	Status      corev1.ConditionStatus              `json:"status"`
+	Reason      string                              `json:"reason"`
+	Message     string                              `json:"message"`
+}
+
+type MachineConfigPoolInformation struct {
+	Name                    string                       `json:"name"`
+	Configuration           string                       `json:"configuration"`
+	Paused                  bool                         `json:"paused"`
+	Updated                 corev1.ConditionStatus       `json:"updated"`
+	Updating                corev1.ConditionStatus       `json:"updating"`
+	Degraded                corev1.ConditionStatus       `json:"degraded"`
+	MachineCount            int32                        `json:"machineCount"`
+	UpdatedMachineCount     int32                        `json:"updatedMachineCount"`
+	ReadyMachineCount       int32                        `json:"readyMachineCount"`
+	UnavailableMachineCount int32                        `json:"unavailableMachineCount"`
+	DegradedMachineCount    int32                        `json:"degradedMachineCount"`
+	Conditions              []MachineConfigPoolCondition `json:"conditions"`
+}
+
+type MachineConfigPoolListInformation struct {
+	MachineConfigPools []MachineConfigPoolInformation `json:"machineConfigPools"`
+}
+
+func machineConfigPoolListInformationFromList(pools *mcv1.MachineConfigPoolList) *MachineConfigPoolListInformation {
+	final := &MachineConfigPoolListInformation{
+		MachineConfigPools: make([]MachineConfigPoolInformation, 0, len(pools.Items)),
+	}
+
+	for _, pool := range pools.Items {
+		var updated = corev1.ConditionUnknown
+		var updating = corev1.ConditionUnknown
+		var degraded = corev1.ConditionUnknown
+
+		var conditions []MachineConfigPoolCondition
+		for _, cnd := range pool.Status.Conditions {
+			switch cnd.Type {
+			case mcv1.MachineConfigPoolUpdated:
+				updated = cnd.Status
+			case mcv1.MachineConfigPoolUpdating:
+				updating = cnd.Status
+			case mcv1.MachineConfigPoolDegraded:
+				degraded = cnd.Status
+			}
+
+			conditions = append(conditions, MachineConfigPoolCondition{
+				Type:        cnd.Type,
+				LastUpdated: cnd.LastTransitionTime.String(),
+				Status:      cnd.Status,
+				Reason:      cnd.Reason,
+				Message:     cnd.Message,
+			})
+		}
+
+		final.MachineConfigPools = append(final.MachineConfigPools, MachineConfigPoolInformation{
+			Name:                    pool.Name,
+			Configuration:           pool.Status.Configuration.Name,
+			Paused:                  pool.Spec.Paused,
+			Updated:                 updated,
+			Updating:                updating,
+			Degraded:                degraded,
+			MachineCount:            pool.Status.MachineCount,
+			UpdatedMachineCount:     pool.Status.UpdatedMachineCount,
+			ReadyMachineCount:       pool.Status.ReadyMachineCount,
+			UnavailableMachineCount: pool.Status.UnavailableMachineCount,
+			DegradedMachineCount:    pool.Status.DegradedMachineCount,
+			Conditions:              conditions,
+		})
+	}
+
+	return final
+}
+
+func (f *realFetcher) MachineConfigPools(ctx context.Context) (*MachineConfigPoolListInformation, error) {
+	r, err := f.mcoCli.MachineconfigurationV1().MachineConfigPools().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return machineConfigPoolListInformationFromList(r), nil
+}
+
+func (c *client) MachineConfigPools(ctx context.Context) (*MachineConfigPoolListInformation, error) {
+	return c.fetcher.MachineConfigPools(ctx)
+}
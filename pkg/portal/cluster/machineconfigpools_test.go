@@ -0,0 +1,101 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	mcofake "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestMachineConfigPools(t *testing.T) {
+	ctx := context.Background()
+
+	transitioned := metav1.NewTime(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	mco := mcofake.NewSimpleClientset(&mcv1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker",
+		},
+		Status: mcv1.MachineConfigPoolStatus{
+			Configuration: mcv1.MachineConfigPoolStatusConfiguration{
+				ObjectReference: corev1.ObjectReference{Name: "rendered-worker-1234"},
+			},
+			MachineCount:         3,
+			UpdatedMachineCount:  2,
+			ReadyMachineCount:    2,
+			DegradedMachineCount: 1,
+			Conditions: []mcv1.MachineConfigPoolCondition{
+				{
+					Type:               mcv1.MachineConfigPoolUpdated,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: transitioned,
+				},
+				{
+					Type:               mcv1.MachineConfigPoolDegraded,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: transitioned,
+					Reason:             "1 nodes are reporting degraded status on sync",
+					Message:            "Node aro-worker-2 is reporting: \"unexpected on-disk state\"",
+				},
+			},
+		},
+	})
+
+	_, log := testlog.New()
+
+	rf := &realFetcher{
+		mcoCli: mco,
+		log:    log,
+	}
+
+	c := &client{fetcher: rf, log: log}
+
+	info, err := c.MachineConfigPools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &MachineConfigPoolListInformation{
+		MachineConfigPools: []MachineConfigPoolInformation{
+			{
+				Name:                 "worker",
+				Configuration:        "rendered-worker-1234",
+				Updated:              corev1.ConditionFalse,
+				Updating:             corev1.ConditionUnknown,
+				Degraded:             corev1.ConditionTrue,
+				MachineCount:         3,
+				UpdatedMachineCount:  2,
+				ReadyMachineCount:    2,
+				DegradedMachineCount: 1,
+				Conditions: []MachineConfigPoolCondition{
+					{
+						Type:        mcv1.MachineConfigPoolUpdated,
+						LastUpdated: transitioned.String(),
+						Status:      corev1.ConditionFalse,
+					},
+					{
+						Type:        mcv1.MachineConfigPoolDegraded,
+						LastUpdated: transitioned.String(),
+						Status:      corev1.ConditionTrue,
+						Reason:      "1 nodes are reporting degraded status on sync",
+						Message:     "Node aro-worker-2 is reporting: \"unexpected on-disk state\"",
+					},
+				},
+			},
+		},
+	}
+
+	for _, l := range deep.Equal(info, expected) {
+		t.Error(l)
+	}
+}
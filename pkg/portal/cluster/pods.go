@@ -0,0 +1,148 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ContainerInformation struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	RestartCount int32  `json:"restartCount"`
+	State        string `json:"state"`
+	Reason       string `json:"reason"`
+	Message      string `json:"message"`
+}
+
+type PodInformation struct {
+	Namespace  string                 `json:"namespace"`
+	Name       string                 `json:"name"`
+	NodeName   string                 `json:"nodeName"`
+	Phase      corev1.PodPhase        `json:"phase"`
+	Reason     string                 `json:"reason"`
+	Message    string                 `json:"message"`
+	CreatedAt  string                 `json:"createdAt"`
+	Containers []ContainerInformation `json:"containers"`
+}
+
+type PodListInformation struct {
+	Pods []PodInformation `json:"pods"`
+}
+
+func containerInformation(status *corev1.ContainerStatus) ContainerInformation {
+	ci := ContainerInformation{
+		Name:         status.Name,
+		Ready:        status.Ready,
+		RestartCount: status.RestartCount,
+	}
+
+	switch {
+	case status.State.Waiting != nil:
+		ci.State = "Waiting"
+		ci.Reason = status.State.Waiting.Reason
+		ci.Message = status.State.Waiting.Message
+	case status.State.Terminated != nil:
+		ci.State = "Terminated"
+		ci.Reason = status.State.Terminated.Reason
+		ci.Message = status.State.Terminated.Message
+	case status.State.Running != nil:
+		ci.State = "Running"
+	}
+
+	return ci
+}
+
+// containerStatuses returns the statuses of the init containers followed by
+// those of the containers of a pod
+func containerStatuses(pod *corev1.Pod) []corev1.ContainerStatus {
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	return append(statuses, pod.Status.ContainerStatuses...)
+}
+
+// podIsFailing returns true if a pod has failed, has not started, or has a
+// container which is not ready.  Completed pods are never failing.
+func podIsFailing(pod *corev1.Pod) bool {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return false
+	case corev1.PodRunning:
+	default:
+		return true
+	}
+
+	for _, status := range containerStatuses(pod) {
+		if status.State.Waiting != nil {
+			return true
+		}
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if !status.Ready {
+			return true
+		}
+	}
+
+	return false
+}
+
+func failingPodListInformationFromPodList(pods *corev1.PodList) *PodListInformation {
+	final := &PodListInformation{
+		Pods: make([]PodInformation, 0),
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !strings.HasPrefix(pod.Namespace, "openshift-") || !podIsFailing(pod) {
+			continue
+		}
+
+		statuses := containerStatuses(pod)
+		containers := make([]ContainerInformation, 0, len(statuses))
+		for i := range statuses {
+			containers = append(containers, containerInformation(&statuses[i]))
+		}
+
+		final.Pods = append(final.Pods, PodInformation{
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+			NodeName:   pod.Spec.NodeName,
+			Phase:      pod.Status.Phase,
+			Reason:     pod.Status.Reason,
+			Message:    pod.Status.Message,
+			CreatedAt:  pod.CreationTimestamp.String(),
+			Containers: containers,
+		})
+	}
+
+	sort.SliceStable(final.Pods, func(i, j int) bool {
+		if final.Pods[i].Namespace != final.Pods[j].Namespace {
+			return final.Pods[i].Namespace < final.Pods[j].Namespace
+		}
+		return final.Pods[i].Name < final.Pods[j].Name
+	})
+
+	return final
+}
+
+// FailingPods returns the pods in openshift-* namespaces which are not
+// running or completed, or which have a container which is not ready
+func (f *realFetcher) FailingPods(ctx context.Context) (*PodListInformation, error) {
+	r, err := f.kubernetesCli.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return failingPodListInformationFromPodList(r), nil
+}
+
+func (c *client) FailingPods(ctx context.Context) (*PodListInformation, error) {
+	return c.fetcher.FailingPods(ctx)
+}
@@ -0,0 +1,149 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestFailingPods(t *testing.T) {
+	ctx := context.Background()
+
+	created := metav1.NewTime(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	pod := func(namespace, name string, phase corev1.PodPhase, statuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				CreationTimestamp: created,
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "aro-master-0",
+			},
+			Status: corev1.PodStatus{
+				Phase:             phase,
+				ContainerStatuses: statuses,
+			},
+		}
+	}
+
+	running := corev1.ContainerStatus{
+		Name:  "running",
+		Ready: true,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+
+	crashLooping := corev1.ContainerStatus{
+		Name:         "crashlooping",
+		RestartCount: 12,
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{
+				Reason:  "CrashLoopBackOff",
+				Message: "back-off 5m0s restarting failed container",
+			},
+		},
+	}
+
+	notReady := corev1.ContainerStatus{
+		Name:  "notready",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+
+	pending := pod("openshift-dns", "dns-default-abcde", corev1.PodPending)
+	pending.Status.Reason = "Unschedulable"
+
+	kubernetes := fake.NewSimpleClientset(
+		pod("openshift-apiserver", "apiserver-healthy", corev1.PodRunning, running),
+		pod("openshift-apiserver", "apiserver-crashlooping", corev1.PodRunning, running, crashLooping),
+		pod("openshift-console", "console-notready", corev1.PodRunning, notReady),
+		pod("openshift-etcd", "installer-2", corev1.PodSucceeded),
+		pod("openshift-etcd", "installer-3", corev1.PodFailed),
+		pending,
+		pod("customer", "crashlooping", corev1.PodRunning, crashLooping),
+	)
+
+	_, log := testlog.New()
+
+	rf := &realFetcher{
+		kubernetesCli: kubernetes,
+		log:           log,
+	}
+
+	c := &client{fetcher: rf, log: log}
+
+	info, err := c.FailingPods(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &PodListInformation{
+		Pods: []PodInformation{
+			{
+				Namespace: "openshift-apiserver",
+				Name:      "apiserver-crashlooping",
+				NodeName:  "aro-master-0",
+				Phase:     corev1.PodRunning,
+				CreatedAt: created.String(),
+				Containers: []ContainerInformation{
+					{
+						Name:  "running",
+						Ready: true,
+						State: "Running",
+					},
+					{
+						Name:         "crashlooping",
+						RestartCount: 12,
+						State:        "Waiting",
+						Reason:       "CrashLoopBackOff",
+						Message:      "back-off 5m0s restarting failed container",
+					},
+				},
+			},
+			{
+				Namespace: "openshift-console",
+				Name:      "console-notready",
+				NodeName:  "aro-master-0",
+				Phase:     corev1.PodRunning,
+				CreatedAt: created.String(),
+				Containers: []ContainerInformation{
+					{
+						Name:  "notready",
+						State: "Running",
+					},
+				},
+			},
+			{
+				Namespace:  "openshift-dns",
+				Name:       "dns-default-abcde",
+				NodeName:   "aro-master-0",
+				Phase:      corev1.PodPending,
+				Reason:     "Unschedulable",
+				CreatedAt:  created.String(),
+				Containers: []ContainerInformation{},
+			},
+			{
+				Namespace:  "openshift-etcd",
+				Name:       "installer-3",
+				NodeName:   "aro-master-0",
+				Phase:      corev1.PodFailed,
+				CreatedAt:  created.String(),
+				Containers: []ContainerInformation{},
+			},
+		},
+	}
+
+	for _, l := range deep.Equal(info, expected) {
+		t.Error(l)
+	}
+}
@@ -95,6 +95,11 @@ func GetPromQuery(statisticsType string) (string, error) {
 		"dnsalltraffic":       "histogram_quantile(0.95, sum(rate(coredns_dns_request_duration_seconds_bucket[5m])) by (le))",
 		// Ingress
 		"ingresscontrollercondition": "sum(ingress_controller_conditions) by (condition)",
+		// etcd
+		"etcddbsize":       "etcd_mvcc_db_total_size_in_bytes{job=\"etcd\"}",
+		"etcddbinuse":      "etcd_mvcc_db_total_size_in_use_in_bytes{job=\"etcd\"}",
+		"etcdhasleader":    "etcd_server_has_leader{job=\"etcd\"}",
+		"etcdleaderchange": "increase(etcd_server_leader_changes_seen_total{job=\"etcd\"}[1h])",
 	}
 	promQuery, ok := promQueries[statisticsType]
 	if !ok {
@@ -7,11 +7,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-test/deep"
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
@@ -235,3 +237,204 @@ func TestClusterDetail(t *testing.T) {
 		t.Error(l)
 	}
 }
+
+func TestClusterListFilters(t *testing.T) {
+	created := func(s string) time.Time {
+		t, _ := time.Parse(time.RFC3339, s)
+		return t
+	}
+
+	doc := func(id, subscription, resourceGroup, name, version string, state api.ProvisioningState, createdAt time.Time) *api.OpenShiftClusterDocument {
+		resourceID := "/subscriptions/" + subscription + "/resourcegroups/" + resourceGroup + "/providers/microsoft.redhatopenshift/openshiftclusters/" + name
+		return &api.OpenShiftClusterDocument{
+			ID:  id,
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: state,
+					ProvisionedBy:     "v" + version,
+					CreatedAt:         createdAt,
+					ClusterProfile: api.ClusterProfile{
+						Version: version,
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name             string
+		query            string
+		wantStatusCode   int
+		wantKeys         []string
+		wantContinuation string
+		wantCSV          string
+	}{
+		{
+			name:           "all",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"1", "2", "3", "4"},
+		},
+		{
+			name:           "subscription",
+			query:          "subscription=00000000-0000-0000-0000-00000000000B",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"4"},
+		},
+		{
+			name:           "subscription and resource group",
+			query:          "subscription=00000000-0000-0000-0000-00000000000a&resourceGroup=RG2",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"3"},
+		},
+		{
+			name:           "resource group",
+			query:          "resourceGroup=rg1",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"1", "2", "4"},
+		},
+		{
+			name:           "version, state and provisionedBy",
+			query:          "version=4.11.0&provisioningState=failed&provisionedBy=v4.11.0",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"2"},
+		},
+		{
+			name:           "created range",
+			query:          "createdAfter=2023-01-01T00:00:00Z&createdBefore=2023-03-01T00:00:00Z",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"2", "3"},
+		},
+		{
+			name:           "created bounds within a second",
+			query:          "createdAfter=2023-01-15T00:00:00.5Z&createdBefore=2023-02-15T00:00:00.5Z",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"3"},
+		},
+		{
+			name:           "name",
+			query:          "q=PROD",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"1", "3"},
+		},
+		{
+			name:             "first page",
+			query:            "limit=3",
+			wantStatusCode:   http.StatusOK,
+			wantKeys:         []string{"1", "2", "3"},
+			wantContinuation: "3",
+		},
+		{
+			name:           "last page",
+			query:          "limit=3&continuation=3",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"4"},
+		},
+		{
+			name:             "first filtered page",
+			query:            "version=4.11.0&limit=1",
+			wantStatusCode:   http.StatusOK,
+			wantKeys:         []string{"2"},
+			wantContinuation: "1",
+		},
+		{
+			name:           "last filtered page",
+			query:          "version=4.11.0&limit=1&continuation=1",
+			wantStatusCode: http.StatusOK,
+			wantKeys:       []string{"3"},
+		},
+		{
+			name:           "csv",
+			query:          "format=csv&subscription=00000000-0000-0000-0000-00000000000b",
+			wantStatusCode: http.StatusOK,
+			wantCSV: "key,name,subscription,resourceGroup,resourceId,provisioningState,failedprovisioningState,version,createdAt,lastModified,provisionedBy\n" +
+				"4,other,00000000-0000-0000-0000-00000000000b,rg1,/subscriptions/00000000-0000-0000-0000-00000000000b/resourcegroups/rg1/providers/microsoft.redhatopenshift/openshiftclusters/other,Succeeded,,4.12.0,Unknown,Unknown,v4.12.0\n",
+		},
+		{
+			name:           "invalid limit",
+			query:          "limit=0",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid created range",
+			query:          "createdAfter=yesterday",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid format",
+			query:          "format=xml",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+
+			fixture := testdatabase.NewFixture().
+				WithOpenShiftClusters(dbOpenShiftClusters)
+
+			fixture.AddOpenShiftClusterDocuments(
+				doc("1", "00000000-0000-0000-0000-00000000000a", "rg1", "prod-east", "4.10.0", api.ProvisioningStateSucceeded, created("2022-12-01T00:00:00Z")),
+				doc("2", "00000000-0000-0000-0000-00000000000a", "rg1", "test", "4.11.0", api.ProvisioningStateFailed, created("2023-01-15T00:00:00Z")),
+				doc("3", "00000000-0000-0000-0000-00000000000a", "rg2", "production", "4.11.0", api.ProvisioningStateSucceeded, created("2023-02-15T00:00:00Z")),
+				doc("4", "00000000-0000-0000-0000-00000000000b", "rg1", "other", "4.12.0", api.ProvisioningStateSucceeded, time.Time{}),
+			)
+
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p := &portal{
+				log:                 logrus.NewEntry(logrus.StandardLogger()),
+				dbOpenShiftClusters: dbOpenShiftClusters,
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/api/clusters?"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			aadAuthenticatedRouter := mux.NewRouter()
+			p.aadAuthenticatedRoutes(aadAuthenticatedRouter, nil, nil, nil)
+			w := httptest.NewRecorder()
+			aadAuthenticatedRouter.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatusCode {
+				t.Fatal(w.Code)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			if w.Header().Get("X-Ms-Continuation") != tt.wantContinuation {
+				t.Error(w.Header().Get("X-Ms-Continuation"))
+			}
+
+			if tt.wantCSV != "" {
+				if w.Header().Get("Content-Type") != "text/csv" {
+					t.Error(w.Header().Get("Content-Type"))
+				}
+				if w.Body.String() != tt.wantCSV {
+					t.Error(w.Body.String())
+				}
+				return
+			}
+
+			var r []AdminOpenShiftCluster
+			err = json.NewDecoder(w.Body).Decode(&r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			keys := []string{}
+			for _, c := range r {
+				keys = append(keys, c.Key)
+			}
+
+			for _, l := range deep.Equal(tt.wantKeys, keys) {
+				t.Error(l)
+			}
+		})
+	}
+}
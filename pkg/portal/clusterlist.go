@@ -0,0 +1,181 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	// clusterListMaxLimit is the largest page of clusters which may be
+	// requested
+	clusterListMaxLimit = 1000
+
+	// clusterListContinuationHeader carries the token with which the next page
+	// of clusters is requested.  It is not set on the last page.
+	clusterListContinuationHeader = "X-Ms-Continuation"
+)
+
+// clusterFilter selects the clusters returned by /api/clusters.  Empty fields
+// match every cluster.
+type clusterFilter struct {
+	subscription      string
+	resourceGroup     string
+	version           string
+	provisioningState string
+	provisionedBy     string
+	createdAfter      time.Time
+	createdBefore     time.Time
+	name              string
+}
+
+// parseClusterFilter parses the filter query parameters of /api/clusters
+func parseClusterFilter(q url.Values) (*clusterFilter, error) {
+	f := &clusterFilter{
+		subscription:      strings.ToLower(q.Get("subscription")),
+		resourceGroup:     strings.ToLower(q.Get("resourceGroup")),
+		version:           q.Get("version"),
+		provisioningState: q.Get("provisioningState"),
+		provisionedBy:     q.Get("provisionedBy"),
+		name:              strings.ToLower(q.Get("q")),
+	}
+
+	for _, t := range []struct {
+		param string
+		value *time.Time
+	}{
+		{param: "createdAfter", value: &f.createdAfter},
+		{param: "createdBefore", value: &f.createdBefore},
+	} {
+		if q.Get(t.param) == "" {
+			continue
+		}
+
+		var err error
+		*t.value, err = time.Parse(time.RFC3339, q.Get(t.param))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", t.param, q.Get(t.param))
+		}
+	}
+
+	return f, nil
+}
+
+// parseClusterListLimit parses the page size of /api/clusters.  0 means that
+// all matching clusters are returned.
+func parseClusterListLimit(q url.Values) (int, error) {
+	if q.Get("limit") == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit < 1 || limit > clusterListMaxLimit {
+		return 0, fmt.Errorf("invalid limit %q", q.Get("limit"))
+	}
+
+	return limit, nil
+}
+
+// query returns the database filter which selects the clusters, so that pages
+// of clusters are filtered by the database rather than by the portal
+func (f *clusterFilter) query() *database.OpenShiftClustersFilter {
+	q := &database.OpenShiftClustersFilter{
+		Prefix:            "/subscriptions/",
+		Version:           f.version,
+		ProvisioningState: f.provisioningState,
+		ProvisionedBy:     f.provisionedBy,
+		CreatedAfter:      f.createdAfter,
+		CreatedBefore:     f.createdBefore,
+	}
+
+	switch {
+	case f.subscription != "" && f.resourceGroup != "":
+		q.Prefix += f.subscription + "/resourcegroups/" + f.resourceGroup + "/"
+	case f.subscription != "":
+		q.Prefix += f.subscription + "/"
+	case f.resourceGroup != "":
+		q.KeyContains = "/resourcegroups/" + f.resourceGroup + "/"
+	}
+
+	return q
+}
+
+// matches evaluates the parts of the filter which the database does not
+// evaluate exactly: the cluster name, and the creation time within the second
+// of either bound
+func (f *clusterFilter) matches(doc *api.OpenShiftClusterDocument) bool {
+	if doc.OpenShiftCluster == nil {
+		return false
+	}
+
+	props := &doc.OpenShiftCluster.Properties
+
+	if !f.createdAfter.IsZero() && (props.CreatedAt.IsZero() || props.CreatedAt.Before(f.createdAfter)) {
+		return false
+	}
+
+	if !f.createdBefore.IsZero() && (props.CreatedAt.IsZero() || !props.CreatedAt.Before(f.createdBefore)) {
+		return false
+	}
+
+	if f.name != "" && !strings.Contains(stringutils.LastTokenByte(doc.Key, '/'), f.name) {
+		return false
+	}
+
+	return true
+}
+
+// writeClustersCSV writes clusters as CSV with a header row
+func writeClustersCSV(w io.Writer, clusters []*AdminOpenShiftCluster) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{
+		"key",
+		"name",
+		"subscription",
+		"resourceGroup",
+		"resourceId",
+		"provisioningState",
+		"failedprovisioningState",
+		"version",
+		"createdAt",
+		"lastModified",
+		"provisionedBy",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range clusters {
+		err = cw.Write([]string{
+			c.Key,
+			c.Name,
+			c.Subscription,
+			c.ResourceGroup,
+			c.ResourceId,
+			c.ProvisioningState,
+			c.FailedProvisioningState,
+			c.Version,
+			c.CreatedAt,
+			c.LastModified,
+			c.ProvisionedBy,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
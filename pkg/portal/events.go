@@ -0,0 +1,47 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+)
+
+// parseEventFilter parses the filter query parameters of the events API.
+// since is a duration relative to now.
+func parseEventFilter(q url.Values, now time.Time) (*cluster.EventFilter, error) {
+	filter := &cluster.EventFilter{
+		Namespace: q.Get("namespace"),
+		Type:      q.Get("type"),
+		Reason:    q.Get("reason"),
+		Kind:      q.Get("kind"),
+		Name:      q.Get("name"),
+	}
+
+	if filter.Namespace != "" && len(validation.IsDNS1123Label(filter.Namespace)) > 0 {
+		return nil, fmt.Errorf("invalid namespace %q", filter.Namespace)
+	}
+
+	switch filter.Type {
+	case "", "Normal", "Warning":
+	default:
+		return nil, fmt.Errorf("invalid type %q", filter.Type)
+	}
+
+	if q.Get("since") != "" {
+		since, err := time.ParseDuration(q.Get("since"))
+		if err != nil || since <= 0 {
+			return nil, fmt.Errorf("invalid since %q", q.Get("since"))
+		}
+
+		filter.Since = now.Add(-since)
+	}
+
+	return filter, nil
+}
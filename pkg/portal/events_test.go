@@ -0,0 +1,78 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestParseEventFilter(t *testing.T) {
+	now := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name    string
+		query   string
+		want    *cluster.EventFilter
+		wantErr string
+	}{
+		{
+			name:  "empty",
+			query: "",
+			want:  &cluster.EventFilter{},
+		},
+		{
+			name:  "all parameters",
+			query: "namespace=openshift-etcd&type=Warning&reason=Unhealthy&kind=Pod&name=etcd-0&since=1h",
+			want: &cluster.EventFilter{
+				Namespace: "openshift-etcd",
+				Type:      "Warning",
+				Reason:    "Unhealthy",
+				Kind:      "Pod",
+				Name:      "etcd-0",
+				Since:     now.Add(-time.Hour),
+			},
+		},
+		{
+			name:    "invalid namespace",
+			query:   "namespace=Openshift_Etcd",
+			wantErr: `invalid namespace "Openshift_Etcd"`,
+		},
+		{
+			name:    "invalid type",
+			query:   "type=Error",
+			wantErr: `invalid type "Error"`,
+		},
+		{
+			name:    "invalid since",
+			query:   "since=yesterday",
+			wantErr: `invalid since "yesterday"`,
+		},
+		{
+			name:    "negative since",
+			query:   "since=-1h",
+			wantErr: `invalid since "-1h"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			filter, err := parseEventFilter(q, now)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			for _, l := range deep.Equal(filter, tt.want) {
+				t.Error(l)
+			}
+		})
+	}
+}
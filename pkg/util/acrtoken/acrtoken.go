@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	mgmtcontainerregistry "github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/mgmt/2020-11-01-preview/containerregistry"
 	"github.com/Azure/go-autorest/autorest"
@@ -31,6 +32,7 @@ type Manager interface {
 type manager struct {
 	env env.Interface
 	r   azure.Resource
+	now func() time.Time
 
 	tokens     containerregistry.TokensClient
 	registries containerregistry.RegistriesClient
@@ -45,6 +47,7 @@ func NewManager(env env.Interface, localFPAuthorizer autorest.Authorizer) (Manag
 	m := &manager{
 		env: env,
 		r:   r,
+		now: time.Now,
 
 		tokens:     containerregistry.NewTokensClient(env.Environment(), r.SubscriptionID, localFPAuthorizer),
 		registries: containerregistry.NewRegistriesClient(env.Environment(), r.SubscriptionID, localFPAuthorizer),
@@ -53,9 +56,24 @@ func NewManager(env env.Interface, localFPAuthorizer autorest.Authorizer) (Manag
 	return m, nil
 }
 
+// RegistryProfileName returns the name of the registry profiles of the RP's
+// container registry
+func RegistryProfileName(env env.Interface) (string, error) {
+	r, err := azure.ParseResourceID(env.ACRResourceID())
+	if err != nil {
+		return "", err
+	}
+
+	return registryProfileName(env, r), nil
+}
+
+func registryProfileName(env env.Interface, r azure.Resource) string {
+	return fmt.Sprintf("%s.%s", r.ResourceName, env.Environment().ContainerRegistryDNSSuffix)
+}
+
 func (m *manager) GetRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProfile {
 	for i, rp := range oc.Properties.RegistryProfiles {
-		if rp.Name == fmt.Sprintf("%s.%s", m.r.ResourceName, m.env.Environment().ContainerRegistryDNSSuffix) {
+		if rp.Name == registryProfileName(m.env, m.r) {
 			return oc.Properties.RegistryProfiles[i]
 		}
 	}
@@ -65,7 +83,7 @@ func (m *manager) GetRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProf
 
 func (m *manager) NewRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProfile {
 	return &api.RegistryProfile{
-		Name:     fmt.Sprintf("%s.%s", m.r.ResourceName, m.env.Environment().ContainerRegistryDNSSuffix),
+		Name:     registryProfileName(m.env, m.r),
 		Username: "token-" + uuid.DefaultGenerator.Generate(),
 	}
 }
@@ -143,8 +161,12 @@ func (m *manager) RotateTokenPassword(ctx context.Context, rp *api.RegistryProfi
 }
 
 // generateTokenPassword takes an existing ACR token and generates
-// a password for the specified password name
+// a password for the specified password name.  The password does not
+// expire; it is invalidated when a later rotation regenerates it.  The
+// creation time of the password is recorded in the registry profile.
 func (m *manager) generateTokenPassword(ctx context.Context, passwordName mgmtcontainerregistry.TokenPasswordName, rp *api.RegistryProfile) (string, error) {
+	now := m.now().UTC()
+
 	creds, err := m.registries.GenerateCredentials(ctx, m.r.ResourceGroup, m.r.ResourceName, mgmtcontainerregistry.GenerateCredentialsParameters{
 		TokenID: to.StringPtr(m.env.ACRResourceID() + "/tokens/" + rp.Username),
 		Name:    passwordName,
@@ -153,6 +175,8 @@ func (m *manager) generateTokenPassword(ctx context.Context, passwordName mgmtco
 		return "", err
 	}
 
+	rp.PasswordCreationTime = &now
+
 	// response details from Azure API
 	// https://learn.microsoft.com/en-us/rest/api/containerregistry/tokens/create?tabs=Go#tokencreate
 
@@ -62,6 +62,7 @@ func TestEnsureTokenAndPassword(t *testing.T) {
 	m := &manager{
 		env: env,
 		r:   r,
+		now: time.Now,
 
 		registries: registries,
 		tokens:     tokens,
@@ -167,6 +168,9 @@ func TestRotateTokenPassword(t *testing.T) {
 			if registryProfile.Password != api.SecureString(tt.wantPassword) {
 				t.Error(registryProfile.Password)
 			}
+			if registryProfile.PasswordCreationTime == nil {
+				t.Error("password creation time not recorded")
+			}
 		})
 	}
 }
@@ -182,6 +186,7 @@ func setupManager(controller *gomock.Controller, tc *mock_containerregistry.Mock
 	return &manager{
 		env:        env,
 		r:          r,
+		now:        time.Now,
 		tokens:     tc,
 		registries: rc,
 	}
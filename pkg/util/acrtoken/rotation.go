@@ -0,0 +1,73 @@
+package acrtoken
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const envRotationInterval = "ACR_TOKEN_ROTATION_INTERVAL"
+
+// RotationPolicy describes how often the password of a cluster's ACR token is
+// rotated.  Each rotation regenerates the older of the token's two passwords,
+// so the password in the cluster's pull secret remains valid until the next
+// rotation.  Passwords are generated without an expiry: a cluster whose
+// rotation is delayed keeps pulling images with its current password.
+type RotationPolicy struct {
+	// Interval is the age at which a password is rotated.  Scheduled
+	// rotation is disabled if it is zero.
+	Interval time.Duration
+}
+
+// NewRotationPolicyFromEnvironment reads the rotation policy from the
+// ACR_TOKEN_ROTATION_INTERVAL environment variable
+func NewRotationPolicyFromEnvironment() (*RotationPolicy, error) {
+	p := &RotationPolicy{}
+
+	if v := os.Getenv(envRotationInterval); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", envRotationInterval, err)
+		}
+		p.Interval = d
+	}
+
+	if p.Interval < 0 {
+		return nil, fmt.Errorf("invalid %s: must not be negative", envRotationInterval)
+	}
+
+	return p, nil
+}
+
+// Enabled returns true if passwords are rotated on a schedule
+func (p *RotationPolicy) Enabled() bool {
+	return p != nil && p.Interval > 0
+}
+
+// Due returns true if the password of the registry profile is due to be
+// rotated at the given time.  Passwords whose creation time was not recorded
+// are always due.
+func (p *RotationPolicy) Due(rp *api.RegistryProfile, now time.Time) bool {
+	if !p.Enabled() || rp == nil {
+		return false
+	}
+
+	return rp.PasswordCreationTime == nil ||
+		!now.Before(rp.PasswordCreationTime.Add(p.Interval))
+}
+
+// Overdue returns true if the password of the registry profile has missed a
+// whole rotation interval, i.e. its scheduled rotation has been failing or
+// skipped for at least Interval
+func (p *RotationPolicy) Overdue(rp *api.RegistryProfile, now time.Time) bool {
+	if !p.Enabled() || rp == nil || rp.PasswordCreationTime == nil {
+		return false
+	}
+
+	return !now.Before(rp.PasswordCreationTime.Add(2 * p.Interval))
+}
@@ -0,0 +1,192 @@
+package acrtoken
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	mgmtcontainerregistry "github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/mgmt/2020-11-01-preview/containerregistry"
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_containerregistry "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/containerregistry"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestNewRotationPolicyFromEnvironment(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		interval   string
+		wantPolicy *RotationPolicy
+		wantErr    string
+	}{
+		{
+			name:       "disabled by default",
+			wantPolicy: &RotationPolicy{},
+		},
+		{
+			name:       "interval",
+			interval:   "720h",
+			wantPolicy: &RotationPolicy{Interval: 720 * time.Hour},
+		},
+		{
+			name:     "invalid interval",
+			interval: "monthly",
+			wantErr:  `invalid ACR_TOKEN_ROTATION_INTERVAL: time: invalid duration "monthly"`,
+		},
+		{
+			name:     "negative interval",
+			interval: "-1h",
+			wantErr:  "invalid ACR_TOKEN_ROTATION_INTERVAL: must not be negative",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envRotationInterval, tt.interval)
+
+			policy, err := NewRotationPolicyFromEnvironment()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(policy, tt.wantPolicy) {
+				t.Error(policy)
+			}
+		})
+	}
+}
+
+func TestRotationPolicyDue(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	created := now.Add(-30 * 24 * time.Hour)
+
+	for _, tt := range []struct {
+		name    string
+		policy  *RotationPolicy
+		rp      *api.RegistryProfile
+		wantDue bool
+	}{
+		{
+			name: "nil policy",
+			rp:   &api.RegistryProfile{},
+		},
+		{
+			name:   "disabled policy",
+			policy: &RotationPolicy{},
+			rp:     &api.RegistryProfile{},
+		},
+		{
+			name:   "no registry profile",
+			policy: &RotationPolicy{Interval: 30 * 24 * time.Hour},
+		},
+		{
+			name:    "creation time not recorded",
+			policy:  &RotationPolicy{Interval: 30 * 24 * time.Hour},
+			rp:      &api.RegistryProfile{},
+			wantDue: true,
+		},
+		{
+			name:    "password reached interval",
+			policy:  &RotationPolicy{Interval: 30 * 24 * time.Hour},
+			rp:      &api.RegistryProfile{PasswordCreationTime: &created},
+			wantDue: true,
+		},
+		{
+			name:   "password younger than interval",
+			policy: &RotationPolicy{Interval: 31 * 24 * time.Hour},
+			rp:     &api.RegistryProfile{PasswordCreationTime: &created},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			due := tt.policy.Due(tt.rp, now)
+			if due != tt.wantDue {
+				t.Error(due)
+			}
+		})
+	}
+}
+
+func TestRotationPolicyOverdue(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	created := now.Add(-60 * 24 * time.Hour)
+
+	for _, tt := range []struct {
+		name        string
+		policy      *RotationPolicy
+		rp          *api.RegistryProfile
+		wantOverdue bool
+	}{
+		{
+			name:   "disabled policy",
+			policy: &RotationPolicy{},
+			rp:     &api.RegistryProfile{PasswordCreationTime: &created},
+		},
+		{
+			name:   "creation time not recorded",
+			policy: &RotationPolicy{Interval: 30 * 24 * time.Hour},
+			rp:     &api.RegistryProfile{},
+		},
+		{
+			name:        "password missed a whole interval",
+			policy:      &RotationPolicy{Interval: 30 * 24 * time.Hour},
+			rp:          &api.RegistryProfile{PasswordCreationTime: &created},
+			wantOverdue: true,
+		},
+		{
+			name:   "password due but not overdue",
+			policy: &RotationPolicy{Interval: 31 * 24 * time.Hour},
+			rp:     &api.RegistryProfile{PasswordCreationTime: &created},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			overdue := tt.policy.Overdue(tt.rp, now)
+			if overdue != tt.wantOverdue {
+				t.Error(overdue)
+			}
+		})
+	}
+}
+
+func TestRotateTokenPasswordRecordsCreationTime(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tokens := mock_containerregistry.NewMockTokensClient(controller)
+	tokens.EXPECT().
+		GetTokenProperties(ctx, "global", "arointsvc", tokenName).
+		Return(fakeTokenProperties(&[]mgmtcontainerregistry.TokenPassword{
+			{
+				Name:         mgmtcontainerregistry.TokenPasswordNamePassword1,
+				CreationTime: toDate(now.Add(-30 * 24 * time.Hour)),
+			},
+		}), nil)
+
+	// passwords are generated without an expiry
+	registries := mock_containerregistry.NewMockRegistriesClient(controller)
+	registries.EXPECT().
+		GenerateCredentials(ctx, "global", "arointsvc", generateCredentialsParameters(mgmtcontainerregistry.TokenPasswordNamePassword2)).
+		Return(fakeCredentialResult(), nil)
+
+	m := setupManager(controller, tokens, registries)
+	m.now = func() time.Time { return now }
+
+	rp := &api.RegistryProfile{
+		Username: tokenName,
+	}
+
+	err := m.RotateTokenPassword(ctx, rp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rp.Password != "bar" {
+		t.Error(rp.Password)
+	}
+	if rp.PasswordCreationTime == nil || !rp.PasswordCreationTime.Equal(now) {
+		t.Error(rp.PasswordCreationTime)
+	}
+}
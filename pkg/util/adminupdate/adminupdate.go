@@ -0,0 +1,63 @@
+package adminupdate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/maintenancewindow"
+)
+
+// Idle returns true if the cluster can be admin updated without disrupting
+// other work.  Clusters whose last admin update failed are left for an SRE to
+// investigate.
+func Idle(doc *api.OpenShiftClusterDocument) bool {
+	props := &doc.OpenShiftCluster.Properties
+
+	return props.ProvisioningState == api.ProvisioningStateSucceeded &&
+		props.LastAdminUpdateError == "" &&
+		(props.MaintenanceState == "" || props.MaintenanceState == api.MaintenanceStateNone)
+}
+
+// Queue queues an admin update of the cluster which runs the given
+// maintenance task
+func Queue(doc *api.OpenShiftClusterDocument, task api.MaintenanceTask) {
+	doc.OpenShiftCluster.Properties.MaintenanceTask = task
+	doc.OpenShiftCluster.Properties.LastProvisioningState = doc.OpenShiftCluster.Properties.ProvisioningState
+	doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+	doc.OpenShiftCluster.Properties.LastAdminUpdateError = ""
+	doc.Dequeues = 0
+}
+
+// QueueMaintenance queues an admin update which runs the given customer
+// impacting maintenance task and sets the maintenance signal: planned if the
+// customer was told that maintenance was pending, otherwise unplanned
+func QueueMaintenance(doc *api.OpenShiftClusterDocument, task api.MaintenanceTask) {
+	Queue(doc, task)
+
+	if doc.OpenShiftCluster.Properties.MaintenanceState == api.MaintenanceStatePending {
+		doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStatePlanned
+	} else {
+		doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateUnplanned
+	}
+}
+
+// MustDefer returns true if customer impacting maintenance queued at now would
+// be planned maintenance outside the customer's maintenance windows.
+// Unplanned maintenance is never deferred.
+func MustDefer(doc *api.OpenShiftClusterDocument, now time.Time) bool {
+	if doc.OpenShiftCluster.Properties.MaintenanceState != api.MaintenanceStatePending {
+		return false
+	}
+
+	s, err := maintenancewindow.New(doc.OpenShiftCluster.Properties.MaintenanceWindowProfile)
+	if err != nil {
+		// the profile was validated when it was set; don't let a bad profile
+		// block maintenance indefinitely
+		return false
+	}
+
+	return !s.Open(now)
+}
@@ -17,6 +17,7 @@ type RecordSetsClient interface {
 	CreateOrUpdate(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType mgmtdns.RecordType, parameters mgmtdns.RecordSet, ifMatch string, ifNoneMatch string) (result mgmtdns.RecordSet, err error)
 	Delete(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType mgmtdns.RecordType, ifMatch string) (result autorest.Response, err error)
 	Get(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType mgmtdns.RecordType) (result mgmtdns.RecordSet, err error)
+	RecordSetsClientAddons
 }
 
 type recordSetsClient struct {
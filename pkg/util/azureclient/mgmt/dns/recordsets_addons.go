@@ -0,0 +1,32 @@
+package dns
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtdns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+)
+
+// RecordSetsClientAddons contains addons for RecordSetsClient
+type RecordSetsClientAddons interface {
+	ListByType(ctx context.Context, resourceGroupName string, zoneName string, recordType mgmtdns.RecordType) (result []mgmtdns.RecordSet, err error)
+}
+
+func (c *recordSetsClient) ListByType(ctx context.Context, resourceGroupName string, zoneName string, recordType mgmtdns.RecordType) (result []mgmtdns.RecordSet, err error) {
+	page, err := c.RecordSetsClient.ListByType(ctx, resourceGroupName, zoneName, recordType, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for page.NotDone() {
+		result = append(result, page.Values()...)
+
+		err = page.NextWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,288 @@
+package billing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+)
+
+const (
+	// deletedRecordRetention is how long billing records of deleted clusters
+	// are kept before they are considered expired
+	deletedRecordRetention = 90 * 24 * time.Hour
+
+	// creationTimeTolerance is the maximum expected gap between a cluster
+	// being created and its billing record being created at the end of the
+	// install
+	creationTimeTolerance = 2 * time.Hour
+)
+
+type FindingKind string
+
+const (
+	// FindingKindMissingRecord is a cluster which should be billed but has no
+	// billing record
+	FindingKindMissingRecord FindingKind = "MissingRecord"
+	// FindingKindOrphanedRecord is a billing record which is not marked for
+	// deletion but whose cluster no longer exists
+	FindingKindOrphanedRecord FindingKind = "OrphanedRecord"
+	// FindingKindExpiredRecord is a billing record whose cluster was deleted
+	// longer ago than the retention period
+	FindingKindExpiredRecord FindingKind = "ExpiredRecord"
+	// FindingKindDeletionTimeDrift is a billing record marked for deletion
+	// whose cluster still exists and is not being deleted
+	FindingKindDeletionTimeDrift FindingKind = "DeletionTimeDrift"
+	// FindingKindCreationTimeDrift is a billing record whose creation time
+	// does not match the creation time of its cluster
+	FindingKindCreationTimeDrift FindingKind = "CreationTimeDrift"
+)
+
+// Finding is a single inconsistency between the Billing and
+// OpenShiftClusters collections
+type Finding struct {
+	Kind       FindingKind `json:"kind"`
+	ID         string      `json:"id"`
+	ResourceID string      `json:"resourceId,omitempty"`
+	Message    string      `json:"message"`
+	Fixed      bool        `json:"fixed,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// ReconcileReport is the result of a billing reconciliation
+type ReconcileReport struct {
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+	Clusters       int       `json:"clusters"`
+	BillingRecords int       `json:"billingRecords"`
+	Findings       []Finding `json:"findings"`
+}
+
+// Count returns the number of findings of the given kind
+func (r *ReconcileReport) Count(kind FindingKind) int {
+	var count int
+	for _, f := range r.Findings {
+		if f.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Reconciler finds, and optionally fixes, billing records which are
+// inconsistent with the cluster documents
+type Reconciler interface {
+	Reconcile(ctx context.Context, fix bool) (*ReconcileReport, error)
+}
+
+type reconciler struct {
+	log                 *logrus.Entry
+	dbBilling           database.Billing
+	dbOpenShiftClusters database.OpenShiftClusters
+	dbSubscriptions     database.Subscriptions
+	billing             Manager
+
+	now func() time.Time
+}
+
+func NewReconciler(log *logrus.Entry, dbBilling database.Billing, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, billing Manager) Reconciler {
+	return &reconciler{
+		log:                 log,
+		dbBilling:           dbBilling,
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		dbSubscriptions:     dbSubscriptions,
+		billing:             billing,
+
+		now: time.Now,
+	}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, fix bool) (*ReconcileReport, error) {
+	report := &ReconcileReport{
+		StartTime: r.now(),
+		Findings:  []Finding{},
+	}
+
+	clusterDocs, err := r.dbOpenShiftClusters.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	billingDocs, err := r.dbBilling.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Clusters = len(clusterDocs.OpenShiftClusterDocuments)
+	report.BillingRecords = len(billingDocs.BillingDocuments)
+
+	clusters := make(map[string]*api.OpenShiftClusterDocument, len(clusterDocs.OpenShiftClusterDocuments))
+	for _, doc := range clusterDocs.OpenShiftClusterDocuments {
+		clusters[doc.ID] = doc
+	}
+
+	records := make(map[string]*api.BillingDocument, len(billingDocs.BillingDocuments))
+	for _, doc := range billingDocs.BillingDocuments {
+		records[doc.ID] = doc
+	}
+
+	for _, doc := range clusterDocs.OpenShiftClusterDocuments {
+		if _, found := records[doc.ID]; found || !shouldBeBilled(doc) {
+			continue
+		}
+
+		report.Findings = append(report.Findings, r.fix(fix, Finding{
+			Kind:       FindingKindMissingRecord,
+			ID:         doc.ID,
+			ResourceID: doc.Key,
+			Message:    "cluster has no billing record",
+		}, func() error {
+			return r.ensure(ctx, doc)
+		}))
+	}
+
+	for _, record := range billingDocs.BillingDocuments {
+		if record.Billing == nil {
+			continue
+		}
+
+		doc, found := clusters[record.ID]
+
+		switch {
+		case !found && record.Billing.DeletionTime == 0:
+			report.Findings = append(report.Findings, r.fix(fix, Finding{
+				Kind:       FindingKindOrphanedRecord,
+				ID:         record.ID,
+				ResourceID: record.Key,
+				Message:    "billing record is not marked for deletion but the cluster does not exist",
+			}, func() error {
+				_, err := r.dbBilling.MarkForDeletion(ctx, record.ID)
+				return err
+			}))
+
+		case !found && r.now().Sub(time.Unix(int64(record.Billing.DeletionTime), 0)) > deletedRecordRetention:
+			report.Findings = append(report.Findings, r.fix(fix, Finding{
+				Kind:       FindingKindExpiredRecord,
+				ID:         record.ID,
+				ResourceID: record.Key,
+				Message:    "billing record of a cluster deleted at " + time.Unix(int64(record.Billing.DeletionTime), 0).UTC().Format(time.RFC3339) + " is past its retention",
+			}, func() error {
+				return r.dbBilling.Delete(ctx, record)
+			}))
+
+		case found && record.Billing.DeletionTime != 0 &&
+			doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateDeleting:
+			report.Findings = append(report.Findings, r.fix(fix, Finding{
+				Kind:       FindingKindDeletionTimeDrift,
+				ID:         record.ID,
+				ResourceID: record.Key,
+				Message:    "billing record is marked for deletion but the cluster is not being deleted",
+			}, func() error {
+				_, err := r.dbBilling.UnmarkForDeletion(ctx, record.ID)
+				return err
+			}))
+
+		case found && hasCreationTimeDrift(doc, record):
+			createdAt := doc.OpenShiftCluster.Properties.CreatedAt
+			report.Findings = append(report.Findings, r.fix(fix, Finding{
+				Kind:       FindingKindCreationTimeDrift,
+				ID:         record.ID,
+				ResourceID: record.Key,
+				Message:    "billing record creation time " + time.Unix(int64(record.Billing.CreationTime), 0).UTC().Format(time.RFC3339) + " does not match cluster creation time " + createdAt.UTC().Format(time.RFC3339),
+			}, func() error {
+				_, err := r.dbBilling.UpdateCreationTimestamp(ctx, record.ID, int(createdAt.Unix()))
+				return err
+			}))
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		if report.Findings[i].Kind != report.Findings[j].Kind {
+			return report.Findings[i].Kind < report.Findings[j].Kind
+		}
+		return report.Findings[i].ID < report.Findings[j].ID
+	})
+
+	report.EndTime = r.now()
+
+	return report, nil
+}
+
+// fix runs f if fixing is enabled and records the outcome on the finding
+func (r *reconciler) fix(fix bool, finding Finding, f func() error) Finding {
+	log := r.log.WithFields(logrus.Fields{
+		"kind":        finding.Kind,
+		"resource_id": finding.ResourceID,
+	})
+
+	if !fix {
+		log.Info(finding.Message)
+		return finding
+	}
+
+	err := f()
+	if err != nil {
+		log.Errorf("%s: fixing failed: %s", finding.Message, err)
+		finding.Error = err.Error()
+		return finding
+	}
+
+	log.Infof("%s: fixed", finding.Message)
+	finding.Fixed = true
+	return finding
+}
+
+func (r *reconciler) ensure(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	resource, err := azure.ParseResourceID(doc.Key)
+	if err != nil {
+		return err
+	}
+
+	subDoc, err := r.dbSubscriptions.Get(ctx, resource.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	return r.billing.Ensure(ctx, doc, subDoc)
+}
+
+// shouldBeBilled returns true if a cluster has completed its install and is
+// not being deleted, i.e. it is expected to have a billing record
+func shouldBeBilled(doc *api.OpenShiftClusterDocument) bool {
+	provisioningState := doc.OpenShiftCluster.Properties.ProvisioningState
+	if provisioningState == api.ProvisioningStateAdminUpdating {
+		provisioningState = doc.OpenShiftCluster.Properties.LastProvisioningState
+	}
+
+	switch provisioningState {
+	case api.ProvisioningStateCreating, api.ProvisioningStateDeleting:
+		return false
+	case api.ProvisioningStateFailed:
+		return doc.OpenShiftCluster.Properties.FailedProvisioningState != api.ProvisioningStateCreating &&
+			doc.OpenShiftCluster.Properties.FailedProvisioningState != api.ProvisioningStateDeleting
+	}
+
+	return true
+}
+
+// hasCreationTimeDrift returns true if the billing record was created before
+// the cluster, or longer after it than an install can take
+func hasCreationTimeDrift(doc *api.OpenShiftClusterDocument, record *api.BillingDocument) bool {
+	createdAt := doc.OpenShiftCluster.Properties.CreatedAt
+	if createdAt.IsZero() || record.Billing.CreationTime == 0 {
+		return false
+	}
+
+	diff := time.Unix(int64(record.Billing.CreationTime), 0).Sub(createdAt)
+
+	// billing timestamps have a resolution of one second
+	return diff < -time.Second || diff > creationTimeTolerance
+}
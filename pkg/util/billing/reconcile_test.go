@@ -0,0 +1,312 @@
+package billing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		subID    = "11111111-1111-1111-1111-111111111111"
+		tenantID = "22222222-2222-2222-2222-222222222222"
+		location = "eastus"
+	)
+
+	now := time.Unix(1700000000, 0)
+	createdAt := now.Add(-30 * 24 * time.Hour)
+
+	clusterDoc := func(id string, provisioningState api.ProvisioningState) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			ID:                        id,
+			Key:                       strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-"+id)),
+			ClusterResourceGroupIDKey: fmt.Sprintf("/subscriptions/%s/resourcegroups/cluster-%s", subID, id),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				Location: location,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: provisioningState,
+					CreatedAt:         createdAt,
+				},
+			},
+		}
+	}
+
+	billingDoc := func(id string, creationTime, deletionTime time.Time) *api.BillingDocument {
+		doc := &api.BillingDocument{
+			ID:                        id,
+			Key:                       strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-"+id)),
+			ClusterResourceGroupIDKey: fmt.Sprintf("/subscriptions/%s/resourcegroups/cluster-%s", subID, id),
+			Billing: &api.Billing{
+				TenantID: tenantID,
+				Location: location,
+			},
+		}
+		if !creationTime.IsZero() {
+			doc.Billing.CreationTime = int(creationTime.Unix())
+		}
+		if !deletionTime.IsZero() {
+			doc.Billing.DeletionTime = int(deletionTime.Unix())
+		}
+		return doc
+	}
+
+	for _, tt := range []struct {
+		name          string
+		fix           bool
+		fixture       func(*testdatabase.Fixture)
+		wantFindings  []Finding
+		wantDocuments func(*testdatabase.Checker)
+		// CreationTime is not compared by the checker
+		wantCreationTimes map[string]time.Time
+	}{
+		{
+			name: "consistent",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("a", api.ProvisioningStateSucceeded),
+					clusterDoc("b", api.ProvisioningStateCreating),
+					clusterDoc("c", api.ProvisioningStateDeleting),
+				)
+				f.AddBillingDocuments(
+					billingDoc("a", createdAt.Add(time.Hour), time.Time{}),
+					billingDoc("c", createdAt.Add(time.Hour), now),
+					billingDoc("d", createdAt, now.Add(-24*time.Hour)),
+				)
+			},
+			wantFindings: []Finding{},
+		},
+		{
+			name: "report only",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("a", api.ProvisioningStateSucceeded),
+					clusterDoc("b", api.ProvisioningStateSucceeded),
+					clusterDoc("c", api.ProvisioningStateSucceeded),
+				)
+				f.AddBillingDocuments(
+					billingDoc("b", createdAt.Add(time.Hour), now),
+					billingDoc("c", createdAt.Add(10*24*time.Hour), time.Time{}),
+					billingDoc("d", createdAt, time.Time{}),
+					billingDoc("e", createdAt, now.Add(-100*24*time.Hour)),
+				)
+			},
+			wantFindings: []Finding{
+				{
+					Kind:       FindingKindCreationTimeDrift,
+					ID:         "c",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-c")),
+					Message:    "billing record creation time 2023-10-25T22:13:20Z does not match cluster creation time 2023-10-15T22:13:20Z",
+				},
+				{
+					Kind:       FindingKindDeletionTimeDrift,
+					ID:         "b",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-b")),
+					Message:    "billing record is marked for deletion but the cluster is not being deleted",
+				},
+				{
+					Kind:       FindingKindExpiredRecord,
+					ID:         "e",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-e")),
+					Message:    "billing record of a cluster deleted at 2023-08-06T22:13:20Z is past its retention",
+				},
+				{
+					Kind:       FindingKindMissingRecord,
+					ID:         "a",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-a")),
+					Message:    "cluster has no billing record",
+				},
+				{
+					Kind:       FindingKindOrphanedRecord,
+					ID:         "d",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-d")),
+					Message:    "billing record is not marked for deletion but the cluster does not exist",
+				},
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddBillingDocuments(
+					billingDoc("b", createdAt.Add(time.Hour), now),
+					billingDoc("c", createdAt.Add(10*24*time.Hour), time.Time{}),
+					billingDoc("d", createdAt, time.Time{}),
+					billingDoc("e", createdAt, now.Add(-100*24*time.Hour)),
+				)
+			},
+		},
+		{
+			name: "fix",
+			fix:  true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					clusterDoc("b", api.ProvisioningStateSucceeded),
+					clusterDoc("c", api.ProvisioningStateSucceeded),
+				)
+				f.AddBillingDocuments(
+					billingDoc("b", createdAt.Add(time.Hour), now),
+					billingDoc("c", createdAt.Add(-time.Hour), time.Time{}),
+					billingDoc("d", createdAt, time.Time{}),
+					billingDoc("e", createdAt, now.Add(-100*24*time.Hour)),
+				)
+			},
+			wantFindings: []Finding{
+				{
+					Kind:       FindingKindCreationTimeDrift,
+					ID:         "c",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-c")),
+					Message:    "billing record creation time 2023-10-15T21:13:20Z does not match cluster creation time 2023-10-15T22:13:20Z",
+					Fixed:      true,
+				},
+				{
+					Kind:       FindingKindDeletionTimeDrift,
+					ID:         "b",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-b")),
+					Message:    "billing record is marked for deletion but the cluster is not being deleted",
+					Fixed:      true,
+				},
+				{
+					Kind:       FindingKindExpiredRecord,
+					ID:         "e",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-e")),
+					Message:    "billing record of a cluster deleted at 2023-08-06T22:13:20Z is past its retention",
+					Fixed:      true,
+				},
+				{
+					Kind:       FindingKindOrphanedRecord,
+					ID:         "d",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-d")),
+					Message:    "billing record is not marked for deletion but the cluster does not exist",
+					Fixed:      true,
+				},
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddBillingDocuments(
+					billingDoc("b", createdAt.Add(time.Hour), time.Time{}),
+					billingDoc("c", createdAt, time.Time{}),
+					billingDoc("d", createdAt, now),
+				)
+			},
+			wantCreationTimes: map[string]time.Time{
+				"b": createdAt.Add(time.Hour),
+				"c": createdAt,
+			},
+		},
+		{
+			name: "fix missing record",
+			fix:  true,
+			fixture: func(f *testdatabase.Fixture) {
+				doc := clusterDoc("a", api.ProvisioningStateAdminUpdating)
+				doc.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+				f.AddOpenShiftClusterDocuments(doc)
+
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: subID,
+					Subscription: &api.Subscription{
+						Properties: &api.SubscriptionProperties{
+							TenantID: tenantID,
+						},
+					},
+				})
+			},
+			wantFindings: []Finding{
+				{
+					Kind:       FindingKindMissingRecord,
+					ID:         "a",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-a")),
+					Message:    "cluster has no billing record",
+					Fixed:      true,
+				},
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddBillingDocuments(billingDoc("a", time.Time{}, time.Time{}))
+			},
+		},
+		{
+			name: "failed installs are not billed",
+			fixture: func(f *testdatabase.Fixture) {
+				doc := clusterDoc("a", api.ProvisioningStateFailed)
+				doc.OpenShiftCluster.Properties.FailedProvisioningState = api.ProvisioningStateCreating
+				f.AddOpenShiftClusterDocuments(doc)
+
+				doc = clusterDoc("b", api.ProvisioningStateFailed)
+				doc.OpenShiftCluster.Properties.FailedProvisioningState = api.ProvisioningStateUpdating
+				f.AddOpenShiftClusterDocuments(doc)
+			},
+			wantFindings: []Finding{
+				{
+					Kind:       FindingKindMissingRecord,
+					ID:         "b",
+					ResourceID: strings.ToLower(testdatabase.GetResourcePath(subID, "cluster-b")),
+					Message:    "cluster has no billing record",
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logrus.NewEntry(logrus.StandardLogger())
+			openShiftClusterDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			billingDatabase, billingClient := testdatabase.NewFakeBilling()
+			subscriptionsDatabase, _ := testdatabase.NewFakeSubscriptions()
+
+			fixture := testdatabase.NewFixture().
+				WithOpenShiftClusters(openShiftClusterDatabase).
+				WithBilling(billingDatabase).
+				WithSubscriptions(subscriptionsDatabase)
+			tt.fixture(fixture)
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := &reconciler{
+				log:                 log,
+				dbBilling:           billingDatabase,
+				dbOpenShiftClusters: openShiftClusterDatabase,
+				dbSubscriptions:     subscriptionsDatabase,
+				billing: &manager{
+					log:       log,
+					billingDB: billingDatabase,
+					subDB:     subscriptionsDatabase,
+				},
+				now: func() time.Time { return now },
+			}
+
+			report, err := r.Reconcile(ctx, tt.fix)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, diff := range deep.Equal(report.Findings, tt.wantFindings) {
+				t.Error(diff)
+			}
+
+			if tt.wantDocuments != nil {
+				checker := testdatabase.NewChecker()
+				tt.wantDocuments(checker)
+				for _, err := range checker.CheckBilling(billingClient) {
+					t.Error(err)
+				}
+			}
+
+			for id, want := range tt.wantCreationTimes {
+				doc, err := billingDatabase.Get(ctx, id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if doc.Billing.CreationTime != int(want.Unix()) {
+					t.Errorf("%s: got creation time %d, want %d", id, doc.Billing.CreationTime, want.Unix())
+				}
+			}
+		})
+	}
+}
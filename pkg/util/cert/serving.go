@@ -0,0 +1,74 @@
+package cert
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+// ServingCertificateMinimumValidity is how long a customer supplied serving
+// certificate must remain valid for when it is configured on a cluster
+const ServingCertificateMinimumValidity = 7 * 24 * time.Hour
+
+// ValidateServingCertificate checks that certs is a certificate chain, leaf
+// first, in which each certificate is signed by the next; that the leaf is a
+// server certificate for hostname whose public key matches key; and that every
+// certificate in the chain is valid at now and for at least minValidity
+// afterwards.  The chain is not verified against any root, as customers may
+// use a private CA.
+func ValidateServingCertificate(key *rsa.PrivateKey, certs []*x509.Certificate, hostname string, now time.Time, minValidity time.Duration) error {
+	if key == nil {
+		return fmt.Errorf("no private key found")
+	}
+
+	if len(certs) == 0 {
+		return fmt.Errorf("no certificate found")
+	}
+
+	leaf := certs[0]
+
+	pub, ok := leaf.PublicKey.(*rsa.PublicKey)
+	if !ok || !key.PublicKey.Equal(pub) {
+		return fmt.Errorf("the private key does not match the certificate")
+	}
+
+	if len(leaf.ExtKeyUsage) > 0 {
+		var serverAuth bool
+		for _, eku := range leaf.ExtKeyUsage {
+			if eku == x509.ExtKeyUsageServerAuth || eku == x509.ExtKeyUsageAny {
+				serverAuth = true
+			}
+		}
+		if !serverAuth {
+			return fmt.Errorf("the certificate is not valid for server authentication")
+		}
+	}
+
+	err := leaf.VerifyHostname(hostname)
+	if err != nil {
+		return fmt.Errorf("the certificate is not valid for %s", hostname)
+	}
+
+	for i, cert := range certs {
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate %q is not valid until %s", cert.Subject.CommonName, cert.NotBefore.UTC().Format(time.RFC3339))
+		}
+
+		if now.Add(minValidity).After(cert.NotAfter) {
+			return fmt.Errorf("certificate %q expires at %s", cert.Subject.CommonName, cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+
+		if i+1 < len(certs) {
+			err = cert.CheckSignatureFrom(certs[i+1])
+			if err != nil {
+				return fmt.Errorf("certificate %q is not signed by the next certificate in the chain %q", cert.Subject.CommonName, certs[i+1].Subject.CommonName)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package cert
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateServingCertificate(t *testing.T) {
+	// certificates are generated valid from the time they are created
+	now := time.Now().Add(time.Minute)
+
+	caKey, caCerts, err := utiltls.GenerateKeyAndCertificate("ca", nil, nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherCAKey, otherCACerts, err := utiltls.GenerateKeyAndCertificate("other-ca", nil, nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		tweak    func(*x509.Certificate)
+		parent   func() (*rsa.PrivateKey, *x509.Certificate)
+		chain    func(leaf []*x509.Certificate) []*x509.Certificate
+		isClient bool
+		otherKey bool
+		hostname string
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			hostname: "api.cluster.example.com",
+		},
+		{
+			name: "valid wildcard",
+			tweak: func(c *x509.Certificate) {
+				c.DNSNames = []string{"*.apps.cluster.example.com"}
+			},
+			hostname: "console-openshift-console.apps.cluster.example.com",
+		},
+		{
+			name:     "wrong hostname",
+			hostname: "api.other.example.com",
+			wantErr:  "the certificate is not valid for api.other.example.com",
+		},
+		{
+			name:     "mismatched key",
+			otherKey: true,
+			hostname: "api.cluster.example.com",
+			wantErr:  "the private key does not match the certificate",
+		},
+		{
+			name:     "client certificate",
+			isClient: true,
+			hostname: "api.cluster.example.com",
+			wantErr:  "the certificate is not valid for server authentication",
+		},
+		{
+			name: "expiring soon",
+			tweak: func(c *x509.Certificate) {
+				c.NotAfter = now.Add(24 * time.Hour).Truncate(time.Second)
+			},
+			hostname: "api.cluster.example.com",
+			wantErr:  `certificate "api.cluster.example.com" expires at ` + now.Add(24*time.Hour).Truncate(time.Second).UTC().Format(time.RFC3339),
+		},
+		{
+			name: "not yet valid",
+			tweak: func(c *x509.Certificate) {
+				c.NotBefore = now.Add(time.Hour).Truncate(time.Second)
+			},
+			hostname: "api.cluster.example.com",
+			wantErr:  `certificate "api.cluster.example.com" is not valid until ` + now.Add(time.Hour).Truncate(time.Second).UTC().Format(time.RFC3339),
+		},
+		{
+			name: "valid chain",
+			parent: func() (*rsa.PrivateKey, *x509.Certificate) {
+				return caKey, caCerts[0]
+			},
+			chain: func(leaf []*x509.Certificate) []*x509.Certificate {
+				return append(leaf, caCerts[0])
+			},
+			hostname: "api.cluster.example.com",
+		},
+		{
+			name: "broken chain",
+			parent: func() (*rsa.PrivateKey, *x509.Certificate) {
+				return otherCAKey, otherCACerts[0]
+			},
+			chain: func(leaf []*x509.Certificate) []*x509.Certificate {
+				return append(leaf, caCerts[0])
+			},
+			hostname: "api.cluster.example.com",
+			wantErr:  `certificate "api.cluster.example.com" is not signed by the next certificate in the chain "ca"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var parentKey *rsa.PrivateKey
+			var parentCert *x509.Certificate
+			if tt.parent != nil {
+				parentKey, parentCert = tt.parent()
+			}
+
+			key, certs, err := utiltls.GenerateTestKeyAndCertificate("api.cluster.example.com", parentKey, parentCert, false, tt.isClient, tt.tweak)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.chain != nil {
+				certs = tt.chain(certs)
+			}
+
+			if tt.otherKey {
+				key = caKey
+			}
+
+			err = ValidateServingCertificate(key, certs, tt.hostname, now, ServingCertificateMinimumValidity)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package clusterdelete
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sort"
+	"strings"
+
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+
+	"github.com/Azure/ARO-RP/pkg/util/rbac"
+)
+
+// Kind is the kind of object affected by the deletion of a cluster
+type Kind string
+
+const (
+	KindResourceGroup   Kind = "ResourceGroup"
+	KindResource        Kind = "Resource"
+	KindRoleAssignment  Kind = "RoleAssignment"
+	KindRoleDefinition  Kind = "RoleDefinition"
+	KindDNSRecord       Kind = "DNSRecord"
+	KindPrivateEndpoint Kind = "PrivateEndpoint"
+	KindGatewayRecord   Kind = "GatewayRecord"
+	KindCertificate     Kind = "Certificate"
+	KindACRToken        Kind = "ACRToken"
+	KindHiveNamespace   Kind = "HiveNamespace"
+	KindVirtualNetwork  Kind = "VirtualNetwork"
+	KindSubnet          Kind = "Subnet"
+)
+
+// Action is what the deletion of a cluster does to an object
+type Action string
+
+const (
+	// ActionDelete objects are removed
+	ActionDelete Action = "Delete"
+	// ActionModify objects live outside the cluster resource group and are
+	// changed, for example a customer subnet from which the cluster network
+	// security group is disconnected
+	ActionModify Action = "Modify"
+	// ActionRetain objects live outside the cluster resource group and are
+	// left untouched
+	ActionRetain Action = "Retain"
+)
+
+// Item is an object affected by the deletion of a cluster
+type Item struct {
+	Kind    Kind
+	ID      string
+	Type    string
+	Action  Action
+	Message string
+}
+
+// order maps resource types to the deletion level.  We walk the levels from
+// lowest to highest, deleting all the resources in the given level in parallel
+// and waiting for completion before we proceed.  Any type not in the map is
+// considered to be at level 0.  Keys must be lower case.
+var order = map[string]int{
+	"microsoft.compute/virtualmachines":                 -3, // first, and before microsoft.compute/disks, microsoft.network/networkinterfaces
+	"microsoft.network/privatelinkservices":             -3, // before microsoft.network/loadbalancers
+	"microsoft.network/privateendpoints":                -3, // before microsoft.network/networkinterfaces
+	"microsoft.compute/galleries/applications/versions": -2, // before microsoft.compute/galleries/applications
+	"microsoft.compute/galleries/images/versions":       -2, // before microsoft.compute/galleries/images
+	"microsoft.compute/galleries/applications":          -1, // before microsoft.compute/galleries
+	"microsoft.compute/galleries/images":                -1, // before microsoft.compute/galleries
+	"microsoft.compute/galleries/serviceArtifacts":      -1, // before microsoft.compute/galleries
+	"microsoft.network/networkinterfaces":               -1, // before microsoft.network/loadbalancers
+	"microsoft.network/privatednszones":                 1,  // after everything else: get other deletions underway first
+	"microsoft.compute/galleries":                       1,  // after everything else in case there are nested microsoft.compute/galleries resources
+}
+
+// Levels groups resources by deletion level, in the order in which the levels
+// are deleted.  The order of resources within a level is deterministic.
+func Levels(resources []mgmtfeatures.GenericResourceExpanded) [][]*mgmtfeatures.GenericResourceExpanded {
+	resourceMap := map[int][]*mgmtfeatures.GenericResourceExpanded{}
+	for i, resource := range resources {
+		level := order[strings.ToLower(*resource.Type)]
+		resourceMap[level] = append(resourceMap[level], &resources[i])
+	}
+
+	levels := make([]int, 0, len(resourceMap))
+	for level := range resourceMap {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+
+	grouped := make([][]*mgmtfeatures.GenericResourceExpanded, 0, len(levels))
+	for _, level := range levels {
+		sort.Slice(resourceMap[level], func(i, j int) bool {
+			return strings.Compare(
+				strings.ToLower(*resourceMap[level][i].ID),
+				strings.ToLower(*resourceMap[level][j].ID)) < 0
+		})
+
+		grouped = append(grouped, resourceMap[level])
+	}
+
+	return grouped
+}
+
+// IsClusterRoleAssignment returns true if the role assignment is removed when
+// the cluster with the given resource group is deleted
+func IsClusterRoleAssignment(assignment mgmtauthorization.RoleAssignment, resourceGroupID string) bool {
+	if assignment.RoleAssignmentPropertiesWithScope == nil ||
+		assignment.Scope == nil || assignment.RoleDefinitionID == nil {
+		return false
+	}
+
+	return strings.EqualFold(*assignment.Scope, resourceGroupID) &&
+		!strings.HasSuffix(strings.ToLower(*assignment.RoleDefinitionID), strings.ToLower(rbac.RoleOwner)) /* should only matter in development */
+}
+
+// IsClusterRoleDefinition returns true if the role definition is removed when
+// the cluster with the given resource group is deleted
+func IsClusterRoleDefinition(definition mgmtauthorization.RoleDefinition, resourceGroupID string) bool {
+	if definition.RoleDefinitionProperties == nil ||
+		definition.AssignableScopes == nil || definition.RoleName == nil {
+		return false
+	}
+
+	return len(*definition.AssignableScopes) == 1 &&
+		strings.EqualFold((*definition.AssignableScopes)[0], resourceGroupID) &&
+		strings.HasPrefix(*definition.RoleName, "Azure Red Hat OpenShift cluster")
+}
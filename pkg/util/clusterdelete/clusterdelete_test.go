@@ -0,0 +1,167 @@
+package clusterdelete
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/util/rbac"
+)
+
+func TestLevels(t *testing.T) {
+	resources := []mgmtfeatures.GenericResourceExpanded{
+		{
+			ID:   to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateDnsZones/zone"),
+			Type: to.StringPtr("Microsoft.Network/privateDnsZones"),
+		},
+		{
+			ID:   to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/nic"),
+			Type: to.StringPtr("Microsoft.Network/networkInterfaces"),
+		},
+		{
+			ID:   to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/master-1"),
+			Type: to.StringPtr("Microsoft.Compute/virtualMachines"),
+		},
+		{
+			ID:   to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/loadBalancers/lb"),
+			Type: to.StringPtr("Microsoft.Network/loadBalancers"),
+		},
+		{
+			ID:   to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/master-0"),
+			Type: to.StringPtr("Microsoft.Compute/virtualMachines"),
+		},
+	}
+
+	var got [][]string
+	for _, level := range Levels(resources) {
+		var ids []string
+		for _, resource := range level {
+			ids = append(ids, *resource.ID)
+		}
+		got = append(got, ids)
+	}
+
+	want := [][]string{
+		{
+			"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/master-0",
+			"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/master-1",
+		},
+		{
+			"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/nic",
+		},
+		{
+			"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/loadBalancers/lb",
+		},
+		{
+			"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateDnsZones/zone",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Error(got)
+	}
+}
+
+func TestIsClusterRoleAssignment(t *testing.T) {
+	resourceGroupID := "/subscriptions/sub/resourceGroups/aro-cluster"
+
+	for _, tt := range []struct {
+		name       string
+		assignment mgmtauthorization.RoleAssignment
+		want       bool
+	}{
+		{
+			name: "cluster assignment",
+			assignment: mgmtauthorization.RoleAssignment{
+				RoleAssignmentPropertiesWithScope: &mgmtauthorization.RoleAssignmentPropertiesWithScope{
+					Scope:            to.StringPtr("/subscriptions/sub/resourcegroups/ARO-CLUSTER"),
+					RoleDefinitionID: to.StringPtr("/subscriptions/sub/providers/Microsoft.Authorization/roleDefinitions/" + rbac.RoleContributor),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "inherited assignment",
+			assignment: mgmtauthorization.RoleAssignment{
+				RoleAssignmentPropertiesWithScope: &mgmtauthorization.RoleAssignmentPropertiesWithScope{
+					Scope:            to.StringPtr("/subscriptions/sub"),
+					RoleDefinitionID: to.StringPtr("/subscriptions/sub/providers/Microsoft.Authorization/roleDefinitions/" + rbac.RoleContributor),
+				},
+			},
+		},
+		{
+			name: "owner assignment",
+			assignment: mgmtauthorization.RoleAssignment{
+				RoleAssignmentPropertiesWithScope: &mgmtauthorization.RoleAssignmentPropertiesWithScope{
+					Scope:            to.StringPtr(resourceGroupID),
+					RoleDefinitionID: to.StringPtr("/subscriptions/sub/providers/Microsoft.Authorization/roleDefinitions/" + rbac.RoleOwner),
+				},
+			},
+		},
+		{
+			name: "no properties",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsClusterRoleAssignment(tt.assignment, resourceGroupID)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestIsClusterRoleDefinition(t *testing.T) {
+	resourceGroupID := "/subscriptions/sub/resourceGroups/aro-cluster"
+
+	for _, tt := range []struct {
+		name       string
+		definition mgmtauthorization.RoleDefinition
+		want       bool
+	}{
+		{
+			name: "cluster definition",
+			definition: mgmtauthorization.RoleDefinition{
+				RoleDefinitionProperties: &mgmtauthorization.RoleDefinitionProperties{
+					RoleName:         to.StringPtr("Azure Red Hat OpenShift cluster-12345"),
+					AssignableScopes: &[]string{resourceGroupID},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "other definition",
+			definition: mgmtauthorization.RoleDefinition{
+				RoleDefinitionProperties: &mgmtauthorization.RoleDefinitionProperties{
+					RoleName:         to.StringPtr("Custom role"),
+					AssignableScopes: &[]string{resourceGroupID},
+				},
+			},
+		},
+		{
+			name: "multiple scopes",
+			definition: mgmtauthorization.RoleDefinition{
+				RoleDefinitionProperties: &mgmtauthorization.RoleDefinitionProperties{
+					RoleName:         to.StringPtr("Azure Red Hat OpenShift cluster-12345"),
+					AssignableScopes: &[]string{resourceGroupID, "/subscriptions/sub"},
+				},
+			},
+		},
+		{
+			name: "no properties",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsClusterRoleDefinition(tt.definition, resourceGroupID)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package diagnostics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Bundle writes files into a gzipped tarball.  Failures to gather a file are
+// recorded with Errorf and written to errors.txt when the bundle is closed, so
+// that a partial bundle is still useful.
+type Bundle struct {
+	gw   *gzip.Writer
+	tw   *tar.Writer
+	now  time.Time
+	errs []string
+}
+
+// NewBundle returns a Bundle which writes to w.  now is the modification time
+// of the files in the bundle.
+func NewBundle(w io.Writer, now time.Time) *Bundle {
+	gw := gzip.NewWriter(w)
+
+	return &Bundle{
+		gw:  gw,
+		tw:  tar.NewWriter(gw),
+		now: now,
+	}
+}
+
+// Add adds a file to the bundle
+func (b *Bundle) Add(name string, data []byte) error {
+	err := b.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  b.now,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.tw.Write(data)
+	return err
+}
+
+// AddJSON adds o to the bundle as an indented JSON file
+func (b *Bundle) AddJSON(name string, o interface{}) error {
+	data, err := json.MarshalIndent(o, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return b.Add(name, data)
+}
+
+// Errorf records a failure to gather part of the bundle
+func (b *Bundle) Errorf(format string, args ...interface{}) {
+	b.errs = append(b.errs, fmt.Sprintf(format, args...))
+}
+
+// Errors returns the failures recorded so far
+func (b *Bundle) Errors() []string {
+	return b.errs
+}
+
+// Close writes errors.txt, if any failures were recorded, and flushes the
+// bundle
+func (b *Bundle) Close() error {
+	if len(b.errs) > 0 {
+		err := b.Add("errors.txt", []byte(strings.Join(b.errs, "\n")+"\n"))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := b.tw.Close()
+	if err != nil {
+		return err
+	}
+
+	return b.gw.Close()
+}
@@ -0,0 +1,143 @@
+package diagnostics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	utilnamespace "github.com/Azure/ARO-RP/pkg/util/namespace"
+)
+
+// Collector gathers cluster resources and pod logs into a bundle
+type Collector struct {
+	kubernetescli kubernetes.Interface
+	dynamiccli    dynamic.Interface
+	options       *Options
+}
+
+// NewCollector returns a Collector.  Either client may be nil, for example if
+// an installation failed before the cluster API server was reachable, in which
+// case what depends on it is skipped.
+func NewCollector(kubernetescli kubernetes.Interface, dynamiccli dynamic.Interface, options *Options) *Collector {
+	if options == nil {
+		options = DefaultOptions()
+	}
+
+	return &Collector{
+		kubernetescli: kubernetescli,
+		dynamiccli:    dynamiccli,
+		options:       options,
+	}
+}
+
+// Collect gathers what the options ask for into b.  It is best effort: what
+// cannot be gathered is recorded in the bundle and collection continues.  It
+// only returns an error if writing to the bundle fails.
+func (c *Collector) Collect(ctx context.Context, b *Bundle) error {
+	if c.dynamiccli != nil {
+		for _, r := range c.options.Resources {
+			err := c.collectResource(ctx, b, r)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.kubernetescli != nil && c.options.LogLines > 0 {
+		for _, namespace := range c.options.LogNamespaces {
+			err := c.collectLogs(ctx, b, namespace)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectResource(ctx context.Context, b *Bundle, r Resource) error {
+	gvr := schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+
+	l, err := c.dynamiccli.Resource(gvr).Namespace(r.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.Errorf("%s: %v", gvr.String(), err)
+		return nil
+	}
+
+	// resources listed across all namespaces must not include customer
+	// workloads
+	items := l.Items[:0]
+	for _, item := range l.Items {
+		if utilnamespace.IsOpenShiftNamespace(item.GetNamespace()) {
+			items = append(items, item)
+		}
+	}
+	l.Items = items
+
+	group := r.Group
+	if group == "" {
+		group = "core"
+	}
+
+	name := r.Resource
+	if r.Namespace != "" {
+		name = r.Namespace + "-" + name
+	}
+
+	return b.AddJSON(path.Join("resources", group, name+".json"), l)
+}
+
+func (c *Collector) collectLogs(ctx context.Context, b *Bundle, namespace string) error {
+	pods, err := c.kubernetescli.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.Errorf("pods in %s: %v", namespace, err)
+		return nil
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			err = c.collectLog(ctx, b, &pod, status.Name, false)
+			if err != nil {
+				return err
+			}
+
+			if status.RestartCount > 0 {
+				err = c.collectLog(ctx, b, &pod, status.Name, true)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectLog(ctx context.Context, b *Bundle, pod *corev1.Pod, container string, previous bool) error {
+	name := container + ".log"
+	if previous {
+		name = container + ".previous.log"
+	}
+	name = path.Join("logs", pod.Namespace, pod.Name, name)
+
+	tailLines := c.options.LogLines
+	data, err := c.kubernetescli.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).Do(ctx).Raw()
+	if err != nil {
+		b.Errorf("%s: %v", name, err)
+		return nil
+	}
+
+	return b.Add(name, data)
+}
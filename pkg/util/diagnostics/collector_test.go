@@ -0,0 +1,212 @@
+package diagnostics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func readBundle(t *testing.T, data []byte) map[string]string {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{}
+
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files[h.Name] = string(b)
+	}
+
+	return files
+}
+
+func TestCollect(t *testing.T) {
+	ctx := context.Background()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "operator",
+			Namespace: "openshift-operator",
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "first",
+				},
+				{
+					Name:         "second",
+					RestartCount: 1,
+				},
+			},
+		},
+	}
+
+	clusterVersion := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "config.openshift.io/v1",
+			"kind":       "ClusterVersion",
+			"metadata": map[string]interface{}{
+				"name": "version",
+			},
+		},
+	}
+
+	options := &Options{
+		Resources: []Resource{
+			{Group: "config.openshift.io", Version: "v1", Resource: "clusterversions"},
+			{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machines", Namespace: "openshift-machine-api"},
+		},
+		LogNamespaces: []string{"openshift-operator"},
+		LogLines:      10,
+	}
+
+	for _, tt := range []struct {
+		name       string
+		nilClients bool
+		wantFiles  []string
+		wantErrors string
+	}{
+		{
+			name: "collects resources and logs",
+			wantFiles: []string{
+				"errors.txt",
+				"logs/openshift-operator/operator/first.log",
+				"logs/openshift-operator/operator/second.log",
+				"logs/openshift-operator/operator/second.previous.log",
+				"resources/config.openshift.io/clusterversions.json",
+			},
+			wantErrors: "machine.openshift.io/v1beta1, Resource=machines: machines unavailable\n",
+		},
+		{
+			name:       "no clients",
+			nilClients: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollector(nil, nil, options)
+			if !tt.nilClients {
+				dynamiccli := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+					{Group: "config.openshift.io", Version: "v1", Resource: "clusterversions"}: "ClusterVersionList",
+					{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machines"}:  "MachineList",
+				}, clusterVersion)
+				dynamiccli.PrependReactor("list", "machines", func(action ktesting.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("machines unavailable")
+				})
+
+				c = NewCollector(fake.NewSimpleClientset(pod), dynamiccli, options)
+			}
+
+			buf := &bytes.Buffer{}
+			b := NewBundle(buf, time.Now())
+
+			err := c.Collect(ctx, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = b.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			files := readBundle(t, buf.Bytes())
+
+			var names []string
+			for name := range files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			if !reflect.DeepEqual(names, tt.wantFiles) {
+				t.Error(names)
+			}
+
+			if files["errors.txt"] != tt.wantErrors {
+				t.Error(files["errors.txt"])
+			}
+		})
+	}
+}
+
+func TestCollectSkipsCustomerNamespaces(t *testing.T) {
+	ctx := context.Background()
+
+	event := func(namespace string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Event",
+				"metadata": map[string]interface{}{
+					"name":      "event",
+					"namespace": namespace,
+				},
+			},
+		}
+	}
+
+	dynamiccli := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "events"}: "EventList",
+	}, event("openshift-etcd"), event("customer"))
+
+	c := NewCollector(nil, dynamiccli, &Options{
+		Resources: []Resource{{Version: "v1", Resource: "events"}},
+	})
+
+	buf := &bytes.Buffer{}
+	b := NewBundle(buf, time.Now())
+
+	err := c.Collect(ctx, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = b.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := readBundle(t, buf.Bytes())["resources/core/events.json"]
+
+	if !strings.Contains(events, `"namespace": "openshift-etcd"`) {
+		t.Error(events)
+	}
+
+	if strings.Contains(events, `"namespace": "customer"`) {
+		t.Error(events)
+	}
+}
@@ -0,0 +1,166 @@
+package diagnostics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	utilnamespace "github.com/Azure/ARO-RP/pkg/util/namespace"
+)
+
+const (
+	// Container is the blob container in the cluster storage account in which
+	// diagnostic bundles are stored
+	Container = "diagnostic-bundles"
+
+	// Retention is the number of diagnostic bundles kept per cluster
+	Retention = 10
+
+	// MaxAge is the age after which diagnostic bundles are removed
+	MaxAge = 30 * 24 * time.Hour
+
+	blobPrefix     = "diagnostics-"
+	blobSuffix     = ".tar.gz.enc"
+	blobTimeFormat = "20060102T150405Z"
+
+	maxLogNamespaces = 20
+	maxLogLines      = 10000
+)
+
+// Resource is a kind of cluster resource which is gathered into a bundle
+type Resource struct {
+	Group    string `json:"group,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Resource string `json:"resource,omitempty"`
+
+	// Namespace limits the resources gathered to a namespace.  If it is empty,
+	// resources are gathered from all of the OpenShift namespaces.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Options configures what is gathered into a bundle
+type Options struct {
+	// Resources are the cluster resources which are gathered
+	Resources []Resource `json:"resources,omitempty"`
+
+	// LogNamespaces are the namespaces whose pod logs are gathered
+	LogNamespaces []string `json:"logNamespaces,omitempty"`
+
+	// LogLines is the number of lines gathered from the end of each container
+	// log
+	LogLines int64 `json:"logLines,omitempty"`
+}
+
+// DefaultOptions gathers the state of the cluster operators, nodes and
+// machines, the events and pods of the OpenShift namespaces and the logs of
+// the core operators
+func DefaultOptions() *Options {
+	return &Options{
+		Resources: []Resource{
+			{Group: "config.openshift.io", Version: "v1", Resource: "clusterversions"},
+			{Group: "config.openshift.io", Version: "v1", Resource: "clusteroperators"},
+			{Version: "v1", Resource: "nodes"},
+			{Version: "v1", Resource: "pods"},
+			{Version: "v1", Resource: "events"},
+			{Group: "machineconfiguration.openshift.io", Version: "v1", Resource: "machineconfigpools"},
+			{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machines", Namespace: "openshift-machine-api"},
+			{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machinesets", Namespace: "openshift-machine-api"},
+			{Group: "operator.openshift.io", Version: "v1", Resource: "ingresscontrollers", Namespace: "openshift-ingress-operator"},
+		},
+		LogNamespaces: []string{
+			"openshift-azure-operator",
+			"openshift-cluster-version",
+			"openshift-etcd-operator",
+			"openshift-ingress-operator",
+			"openshift-kube-apiserver-operator",
+			"openshift-machine-api",
+			"openshift-machine-config-operator",
+			"openshift-network-operator",
+		},
+		LogLines: 1000,
+	}
+}
+
+// Validate returns an error if the options would gather from customer
+// namespaces or gather unreasonably large logs.  Which resources may be
+// gathered is validated by the frontend, like any other admin access to
+// cluster resources.
+func (o *Options) Validate() error {
+	for _, r := range o.Resources {
+		if r.Version == "" || r.Resource == "" {
+			return fmt.Errorf("resource %q in group %q must have a version and a resource", r.Resource, r.Group)
+		}
+
+		if !utilnamespace.IsOpenShiftNamespace(r.Namespace) {
+			return fmt.Errorf("resources may not be gathered from namespace %q", r.Namespace)
+		}
+	}
+
+	if len(o.LogNamespaces) > maxLogNamespaces {
+		return fmt.Errorf("logs may be gathered from at most %d namespaces", maxLogNamespaces)
+	}
+
+	for _, namespace := range o.LogNamespaces {
+		if namespace == "" || !utilnamespace.IsOpenShiftNamespace(namespace) {
+			return fmt.Errorf("logs may not be gathered from namespace %q", namespace)
+		}
+	}
+
+	if o.LogLines < 0 || o.LogLines > maxLogLines {
+		return fmt.Errorf("logLines must be between 0 and %d", maxLogLines)
+	}
+
+	return nil
+}
+
+// BlobName returns the name of the blob in which a bundle collected at the
+// given time is stored
+func BlobName(t time.Time) string {
+	return blobPrefix + t.UTC().Format(blobTimeFormat) + blobSuffix
+}
+
+// IsBlobName returns true if name is the name of a diagnostic bundle blob
+func IsBlobName(name string) bool {
+	_, ok := blobTime(name)
+	return ok
+}
+
+func blobTime(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, blobPrefix) || !strings.HasSuffix(name, blobSuffix) {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(blobTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, blobPrefix), blobSuffix))
+	return t, err == nil
+}
+
+// Expired returns the names of the bundles which are older than MaxAge or
+// beyond the Retention newest bundles, oldest first.  Names which are not
+// bundle blob names are ignored.
+func Expired(names []string, now time.Time) []string {
+	var bundles []string
+	for _, name := range names {
+		if IsBlobName(name) {
+			bundles = append(bundles, name)
+		}
+	}
+
+	// the blob time format sorts chronologically
+	sort.Sort(sort.Reverse(sort.StringSlice(bundles)))
+
+	var expired []string
+	for i, name := range bundles {
+		t, _ := blobTime(name)
+		if i >= Retention || now.Sub(t) > MaxAge {
+			expired = append(expired, name)
+		}
+	}
+
+	sort.Strings(expired)
+
+	return expired
+}
@@ -0,0 +1,127 @@
+package diagnostics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		options *Options
+		wantErr string
+	}{
+		{
+			name:    "default",
+			options: DefaultOptions(),
+		},
+		{
+			name: "missing version",
+			options: &Options{
+				Resources: []Resource{{Group: "config.openshift.io", Resource: "clusterversions"}},
+			},
+			wantErr: `resource "clusterversions" in group "config.openshift.io" must have a version and a resource`,
+		},
+		{
+			name: "resources in customer namespace",
+			options: &Options{
+				Resources: []Resource{{Version: "v1", Resource: "configmaps", Namespace: "customer"}},
+			},
+			wantErr: `resources may not be gathered from namespace "customer"`,
+		},
+		{
+			name: "logs in customer namespace",
+			options: &Options{
+				LogNamespaces: []string{"customer"},
+			},
+			wantErr: `logs may not be gathered from namespace "customer"`,
+		},
+		{
+			name: "logs in all namespaces",
+			options: &Options{
+				LogNamespaces: []string{""},
+			},
+			wantErr: `logs may not be gathered from namespace ""`,
+		},
+		{
+			name: "too many log namespaces",
+			options: &Options{
+				LogNamespaces: func() []string {
+					namespaces := make([]string, 21)
+					for i := range namespaces {
+						namespaces[i] = "openshift-etcd"
+					}
+					return namespaces
+				}(),
+			},
+			wantErr: "logs may be gathered from at most 20 namespaces",
+		},
+		{
+			name: "too many log lines",
+			options: &Options{
+				LogLines: 10001,
+			},
+			wantErr: "logLines must be between 0 and 10000",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBlobName(t *testing.T) {
+	name := BlobName(time.Date(2023, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600)))
+	if name != "diagnostics-20230102T020405Z.tar.gz.enc" {
+		t.Error(name)
+	}
+
+	if !IsBlobName(name) {
+		t.Error(name)
+	}
+
+	for _, name := range []string{
+		"diagnostics-20230102T020405Z.tar.gz",
+		"etcd-snapshot-20230102T020405Z.tar.gz.enc",
+		"diagnostics-latest.tar.gz.enc",
+	} {
+		if IsBlobName(name) {
+			t.Error(name)
+		}
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var names []string
+	for i := 0; i < 12; i++ {
+		names = append(names, BlobName(now.Add(-time.Duration(i)*time.Hour)))
+	}
+	names = append(names, BlobName(now.Add(-31*24*time.Hour)), "other")
+
+	got := Expired(names, now)
+
+	want := []string{
+		BlobName(now.Add(-31 * 24 * time.Hour)),
+		BlobName(now.Add(-11 * time.Hour)),
+		BlobName(now.Add(-10 * time.Hour)),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Error(fmt.Sprint(got))
+	}
+
+	// a single old bundle is removed even within the retention count
+	got = Expired([]string{BlobName(now.Add(-31 * 24 * time.Hour))}, now)
+	if len(got) != 1 {
+		t.Error(got)
+	}
+}
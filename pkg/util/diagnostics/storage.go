@@ -0,0 +1,65 @@
+package diagnostics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"time"
+
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+)
+
+// List returns the diagnostic bundles stored in container, oldest first.  It
+// returns nil if the container does not exist yet.
+func List(container *azstorage.Container) ([]azstorage.Blob, error) {
+	var bundles []azstorage.Blob
+
+	params := azstorage.ListBlobsParameters{}
+	for {
+		res, err := container.ListBlobs(params)
+		if azErr, ok := err.(azstorage.AzureStorageServiceError); ok && azErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, blob := range res.Blobs {
+			if IsBlobName(blob.Name) {
+				bundles = append(bundles, blob)
+			}
+		}
+
+		if res.NextMarker == "" {
+			break
+		}
+		params.Marker = res.NextMarker
+	}
+
+	return bundles, nil
+}
+
+// Prune removes the diagnostic bundles in container which are beyond the
+// retention policy and returns their names
+func Prune(container *azstorage.Container, now time.Time) ([]string, error) {
+	bundles, err := List(container)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(bundles))
+	for _, blob := range bundles {
+		names = append(names, blob.Name)
+	}
+
+	expired := Expired(names, now)
+	for _, name := range expired {
+		_, err := container.GetBlobReference(name).DeleteIfExists(nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return expired, nil
+}
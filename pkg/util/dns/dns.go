@@ -27,6 +27,10 @@ type Manager interface {
 	Update(context.Context, *api.OpenShiftCluster, string) error
 	CreateOrUpdateRouter(context.Context, *api.OpenShiftCluster, string) error
 	Delete(context.Context, *api.OpenShiftCluster) error
+	ListRecords(context.Context, *api.OpenShiftCluster) ([]string, error)
+	CheckDrift(context.Context, *api.OpenShiftCluster) ([]RecordDrift, error)
+	Repair(context.Context, *api.OpenShiftCluster) error
+	ListDangling(context.Context, func(context.Context, string) (bool, error)) ([]string, error)
 }
 
 type manager struct {
@@ -151,6 +155,40 @@ func (m *manager) Delete(ctx context.Context, oc *api.OpenShiftCluster) error {
 	return err
 }
 
+// ListRecords returns the names of the api and *.apps records of a cluster's
+// managed domain which exist and would be removed by Delete
+func (m *manager) ListRecords(ctx context.Context, oc *api.OpenShiftCluster) ([]string, error) {
+	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
+	if err != nil || prefix == "" {
+		return nil, err
+	}
+
+	var records []string
+
+	rs, err := m.recordsets.Get(ctx, m.env.ResourceGroup(), m.env.Domain(), "api."+prefix, mgmtdns.A)
+	switch {
+	case isNotFound(err):
+	case err != nil:
+		return nil, err
+	case rs.Metadata[resourceID] == nil || *rs.Metadata[resourceID] != oc.ID:
+		// the domain is registered to another cluster
+		return nil, nil
+	default:
+		records = append(records, "api."+prefix)
+	}
+
+	_, err = m.recordsets.Get(ctx, m.env.ResourceGroup(), m.env.Domain(), "*.apps."+prefix, mgmtdns.A)
+	switch {
+	case isNotFound(err):
+	case err != nil:
+		return nil, err
+	default:
+		records = append(records, "*.apps."+prefix)
+	}
+
+	return records, nil
+}
+
 func (m *manager) createOrUpdate(ctx context.Context, oc *api.OpenShiftCluster, ip, ifMatch, ifNoneMatch string) error {
 	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
 	if err != nil || prefix == "" {
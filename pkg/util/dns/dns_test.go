@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 
 	mgmtdns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
@@ -524,6 +525,125 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestListRecords(t *testing.T) {
+	ctx := context.Background()
+
+	oc := &api.OpenShiftCluster{
+		ID: "id",
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain: "domain",
+			},
+		},
+	}
+
+	notFound := autorest.DetailedError{
+		StatusCode: http.StatusNotFound,
+	}
+
+	for _, tt := range []struct {
+		name        string
+		oc          *api.OpenShiftCluster
+		mocks       func(*mock_dns.MockRecordSetsClient)
+		wantRecords []string
+		wantErr     string
+	}{
+		{
+			name: "both records exist",
+			oc:   oc,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(aRecordSet("id", "1.2.3.4"), nil)
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A).
+					Return(aRecordSet("", "5.6.7.8"), nil)
+			},
+			wantRecords: []string{"api.domain", "*.apps.domain"},
+		},
+		{
+			name: "only *.apps record exists",
+			oc:   oc,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{}, notFound)
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A).
+					Return(aRecordSet("", "5.6.7.8"), nil)
+			},
+			wantRecords: []string{"*.apps.domain"},
+		},
+		{
+			name: "no records",
+			oc:   oc,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{}, notFound)
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{}, notFound)
+			},
+		},
+		{
+			name: "someone else's record exists",
+			oc:   oc,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(aRecordSet("not us", "1.2.3.4"), nil)
+			},
+		},
+		{
+			name: "error",
+			oc:   oc,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{}, fmt.Errorf("random error"))
+			},
+			wantErr: "random error",
+		},
+		{
+			name: "unmanaged",
+			oc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Domain: "domain.notmanaged",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().ResourceGroup().AnyTimes().Return("rpResourcegroup")
+			env.EXPECT().Domain().AnyTimes().Return("domain")
+
+			recordsets := mock_dns.NewMockRecordSetsClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(recordsets)
+			}
+
+			m := &manager{
+				env:        env,
+				recordsets: recordsets,
+			}
+
+			records, err := m.ListRecords(ctx, tt.oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(records, tt.wantRecords) {
+				t.Error(records)
+			}
+		})
+	}
+}
+
 func TestManagedDomain(t *testing.T) {
 	for _, tt := range []struct {
 		domain  string
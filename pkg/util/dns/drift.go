@@ -0,0 +1,187 @@
+package dns
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	mgmtdns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// RecordDrift describes a record of a cluster's managed domain which does not
+// match the cluster document
+type RecordDrift struct {
+	// Name is the name of the record set, relative to the zone
+	Name string `json:"name"`
+	// Expected is the IP address recorded in the cluster document
+	Expected string `json:"expected"`
+	// Actual are the IP addresses in the record set.  It is empty if the
+	// record set does not exist
+	Actual []string `json:"actual,omitempty"`
+}
+
+// CheckDrift compares the api and *.apps records of a cluster's managed domain
+// with the API server and ingress IPs in the cluster document.  Records whose
+// IP has not yet been recorded in the cluster document are not checked.
+func (m *manager) CheckDrift(ctx context.Context, oc *api.OpenShiftCluster) ([]RecordDrift, error) {
+	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
+	if err != nil || prefix == "" {
+		return nil, err
+	}
+
+	var drift []RecordDrift
+	for name, ip := range expectedRecords(oc, prefix) {
+		if ip == "" {
+			continue
+		}
+
+		actual, err := m.getARecords(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(actual) != 1 || actual[0] != ip {
+			drift = append(drift, RecordDrift{
+				Name:     name,
+				Expected: ip,
+				Actual:   actual,
+			})
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Name < drift[j].Name })
+
+	return drift, nil
+}
+
+// Repair recreates the api and *.apps records of a cluster's managed domain
+// from the API server and ingress IPs in the cluster document
+func (m *manager) Repair(ctx context.Context, oc *api.OpenShiftCluster) error {
+	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
+	if err != nil || prefix == "" {
+		return err
+	}
+
+	if oc.Properties.APIServerProfile.IP != "" {
+		_, err = m.recordsets.Get(ctx, m.env.ResourceGroup(), m.env.Domain(), "api."+prefix, mgmtdns.A)
+		if isNotFound(err) {
+			err = m.createOrUpdate(ctx, oc, oc.Properties.APIServerProfile.IP, "", "*")
+		} else if err == nil {
+			err = m.Update(ctx, oc, oc.Properties.APIServerProfile.IP)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(oc.Properties.IngressProfiles) > 0 && oc.Properties.IngressProfiles[0].IP != "" {
+		err = m.CreateOrUpdateRouter(ctx, oc, oc.Properties.IngressProfiles[0].IP)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListDangling returns the names of the api and *.apps records in the managed
+// domain zone which belong to clusters which no longer exist.  clusterExists is
+// called with the resource ID recorded in each api record.
+func (m *manager) ListDangling(ctx context.Context, clusterExists func(ctx context.Context, resourceID string) (bool, error)) ([]string, error) {
+	rss, err := m.recordsets.ListByType(ctx, m.env.ResourceGroup(), m.env.Domain(), mgmtdns.A)
+	if err != nil {
+		return nil, err
+	}
+
+	apiRecords := map[string]mgmtdns.RecordSet{}
+	appsRecords := map[string]struct{}{}
+	for _, rs := range rss {
+		if rs.Name == nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(*rs.Name, "api."):
+			apiRecords[strings.TrimPrefix(*rs.Name, "api.")] = rs
+		case strings.HasPrefix(*rs.Name, "*.apps."):
+			appsRecords[strings.TrimPrefix(*rs.Name, "*.apps.")] = struct{}{}
+		}
+	}
+
+	var dangling []string
+	for prefix, rs := range apiRecords {
+		if rs.RecordSetProperties == nil || rs.Metadata[resourceID] == nil {
+			continue
+		}
+
+		exists, err := clusterExists(ctx, *rs.Metadata[resourceID])
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+
+		dangling = append(dangling, "api."+prefix)
+		if _, found := appsRecords[prefix]; found {
+			dangling = append(dangling, "*.apps."+prefix)
+		}
+	}
+
+	// *.apps records are created after the api record and deleted before it,
+	// so one without an api record is dangling
+	for prefix := range appsRecords {
+		if _, found := apiRecords[prefix]; !found {
+			dangling = append(dangling, "*.apps."+prefix)
+		}
+	}
+
+	sort.Strings(dangling)
+
+	return dangling, nil
+}
+
+func expectedRecords(oc *api.OpenShiftCluster, prefix string) map[string]string {
+	records := map[string]string{
+		"api." + prefix: oc.Properties.APIServerProfile.IP,
+	}
+
+	if len(oc.Properties.IngressProfiles) > 0 {
+		records["*.apps."+prefix] = oc.Properties.IngressProfiles[0].IP
+	}
+
+	return records
+}
+
+func (m *manager) getARecords(ctx context.Context, name string) ([]string, error) {
+	rs, err := m.recordsets.Get(ctx, m.env.ResourceGroup(), m.env.Domain(), name, mgmtdns.A)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []string
+	if rs.RecordSetProperties != nil && rs.ARecords != nil {
+		for _, a := range *rs.ARecords {
+			if a.Ipv4Address != nil {
+				ips = append(ips, *a.Ipv4Address)
+			}
+		}
+	}
+
+	return ips, nil
+}
+
+func isNotFound(err error) bool {
+	detailedErr, ok := err.(autorest.DetailedError)
+	return ok && detailedErr.StatusCode == http.StatusNotFound
+}
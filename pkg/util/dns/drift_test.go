@@ -0,0 +1,361 @@
+package dns
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	mgmtdns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_dns "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/dns"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func aRecordSet(id string, ips ...string) mgmtdns.RecordSet {
+	rs := mgmtdns.RecordSet{
+		Etag: to.StringPtr("etag"),
+		RecordSetProperties: &mgmtdns.RecordSetProperties{
+			ARecords: &[]mgmtdns.ARecord{},
+		},
+	}
+
+	if id != "" {
+		rs.Metadata = map[string]*string{
+			resourceID: to.StringPtr(id),
+		}
+	}
+
+	for _, ip := range ips {
+		*rs.ARecords = append(*rs.ARecords, mgmtdns.ARecord{Ipv4Address: to.StringPtr(ip)})
+	}
+
+	return rs
+}
+
+func TestCheckDrift(t *testing.T) {
+	ctx := context.Background()
+
+	oc := &api.OpenShiftCluster{
+		ID: "id",
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain: "domain",
+			},
+			APIServerProfile: api.APIServerProfile{
+				IP: "1.2.3.4",
+			},
+			IngressProfiles: []api.IngressProfile{
+				{
+					IP: "5.6.7.8",
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		oc        *api.OpenShiftCluster
+		mocks     func(*mock_dns.MockRecordSetsClient)
+		wantDrift []RecordDrift
+		wantErr   string
+	}{
+		{
+			name: "no drift",
+			oc:   oc,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(aRecordSet("id", "1.2.3.4"), nil)
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A).
+					Return(aRecordSet("", "5.6.7.8"), nil)
+			},
+		},
+		{
+			name: "drift",
+			oc:   oc,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(aRecordSet("id"), nil)
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					})
+			},
+			wantDrift: []RecordDrift{
+				{
+					Name:     "*.apps.domain",
+					Expected: "5.6.7.8",
+				},
+				{
+					Name:     "api.domain",
+					Expected: "1.2.3.4",
+				},
+			},
+		},
+		{
+			name: "IPs not yet recorded",
+			oc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Domain: "domain",
+					},
+				},
+			},
+		},
+		{
+			name: "error",
+			oc:   oc,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", gomock.Any(), mgmtdns.A).
+					Return(mgmtdns.RecordSet{}, fmt.Errorf("random error"))
+			},
+			wantErr: "random error",
+		},
+		{
+			name: "unmanaged",
+			oc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Domain: "domain.notmanaged",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().ResourceGroup().AnyTimes().Return("rpResourcegroup")
+			env.EXPECT().Domain().AnyTimes().Return("domain")
+
+			recordsets := mock_dns.NewMockRecordSetsClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(recordsets)
+			}
+
+			m := &manager{
+				env:        env,
+				recordsets: recordsets,
+			}
+
+			drift, err := m.CheckDrift(ctx, tt.oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(drift, tt.wantDrift) {
+				t.Errorf("got %#v, want %#v", drift, tt.wantDrift)
+			}
+		})
+	}
+}
+
+func TestRepair(t *testing.T) {
+	ctx := context.Background()
+
+	oc := &api.OpenShiftCluster{
+		ID: "id",
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain: "domain",
+			},
+			APIServerProfile: api.APIServerProfile{
+				IP: "1.2.3.4",
+			},
+			IngressProfiles: []api.IngressProfile{
+				{
+					IP: "5.6.7.8",
+				},
+			},
+		},
+	}
+
+	apiRecordSet := func(ifMatch, ifNoneMatch string) func(*mock_dns.MockRecordSetsClient) *gomock.Call {
+		return func(recordsets *mock_dns.MockRecordSetsClient) *gomock.Call {
+			return recordsets.EXPECT().
+				CreateOrUpdate(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A, mgmtdns.RecordSet{
+					RecordSetProperties: &mgmtdns.RecordSetProperties{
+						Metadata: map[string]*string{
+							resourceID: to.StringPtr("id"),
+						},
+						TTL: to.Int64Ptr(300),
+						ARecords: &[]mgmtdns.ARecord{
+							{
+								Ipv4Address: to.StringPtr("1.2.3.4"),
+							},
+						},
+					},
+				}, ifMatch, ifNoneMatch).
+				Return(mgmtdns.RecordSet{}, nil)
+		}
+	}
+
+	appsRecordSet := func(recordsets *mock_dns.MockRecordSetsClient) {
+		recordsets.EXPECT().
+			Get(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A).
+			Return(aRecordSet("", "9.9.9.9"), nil)
+		recordsets.EXPECT().
+			CreateOrUpdate(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A, mgmtdns.RecordSet{
+				RecordSetProperties: &mgmtdns.RecordSetProperties{
+					TTL: to.Int64Ptr(300),
+					ARecords: &[]mgmtdns.ARecord{
+						{
+							Ipv4Address: to.StringPtr("5.6.7.8"),
+						},
+					},
+				},
+			}, "", "").
+			Return(mgmtdns.RecordSet{}, nil)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_dns.MockRecordSetsClient)
+		wantErr string
+	}{
+		{
+			name: "records exist",
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Times(2).
+					Return(aRecordSet("id", "9.9.9.9"), nil)
+				apiRecordSet("etag", "")(recordsets)
+				appsRecordSet(recordsets)
+			},
+		},
+		{
+			name: "api record missing",
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					})
+				apiRecordSet("", "*")(recordsets)
+				appsRecordSet(recordsets)
+			},
+		},
+		{
+			name: "api record owned by another cluster",
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Times(2).
+					Return(aRecordSet("other", "9.9.9.9"), nil)
+			},
+			wantErr: `recordset "api.domain" already registered`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().ResourceGroup().AnyTimes().Return("rpResourcegroup")
+			env.EXPECT().Domain().AnyTimes().Return("domain")
+
+			recordsets := mock_dns.NewMockRecordSetsClient(controller)
+			tt.mocks(recordsets)
+
+			m := &manager{
+				env:        env,
+				recordsets: recordsets,
+			}
+
+			err := m.Repair(ctx, oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestListDangling(t *testing.T) {
+	ctx := context.Background()
+
+	named := func(name string, rs mgmtdns.RecordSet) mgmtdns.RecordSet {
+		rs.Name = to.StringPtr(name)
+		return rs
+	}
+
+	for _, tt := range []struct {
+		name         string
+		mocks        func(*mock_dns.MockRecordSetsClient)
+		exists       map[string]bool
+		wantDangling []string
+		wantErr      string
+	}{
+		{
+			name: "dangling records",
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					ListByType(ctx, "rpResourcegroup", "domain", mgmtdns.A).
+					Return([]mgmtdns.RecordSet{
+						named("api.live", aRecordSet("live", "1.1.1.1")),
+						named("*.apps.live", aRecordSet("", "1.1.1.2")),
+						named("api.deleted", aRecordSet("deleted", "2.2.2.1")),
+						named("*.apps.deleted", aRecordSet("", "2.2.2.2")),
+						named("*.apps.orphan", aRecordSet("", "3.3.3.3")),
+						named("api.nometadata", aRecordSet("", "4.4.4.4")),
+						named("other", aRecordSet("", "5.5.5.5")),
+					}, nil)
+			},
+			exists: map[string]bool{
+				"live": true,
+			},
+			wantDangling: []string{
+				"*.apps.deleted",
+				"*.apps.orphan",
+				"api.deleted",
+			},
+		},
+		{
+			name: "error",
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					ListByType(ctx, "rpResourcegroup", "domain", mgmtdns.A).
+					Return(nil, fmt.Errorf("random error"))
+			},
+			wantErr: "random error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().ResourceGroup().AnyTimes().Return("rpResourcegroup")
+			env.EXPECT().Domain().AnyTimes().Return("domain")
+
+			recordsets := mock_dns.NewMockRecordSetsClient(controller)
+			tt.mocks(recordsets)
+
+			m := &manager{
+				env:        env,
+				recordsets: recordsets,
+			}
+
+			dangling, err := m.ListDangling(ctx, func(ctx context.Context, resourceID string) (bool, error) {
+				return tt.exists[resourceID], nil
+			})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(dangling, tt.wantDangling) {
+				t.Errorf("got %#v, want %#v", dangling, tt.wantDangling)
+			}
+		})
+	}
+}
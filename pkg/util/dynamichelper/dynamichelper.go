@@ -259,6 +259,10 @@ func merge(old, new kruntime.Object) (kruntime.Object, bool, string, error) {
 
 	case *arov1alpha1.Cluster:
 		old, new := old.(*arov1alpha1.Cluster), new.(*arov1alpha1.Cluster)
+		// the worker autoscaling and node drain policies are set in the
+		// cluster by SREs, not by the RP
+		new.Spec.WorkerAutoscalingPolicy = old.Spec.WorkerAutoscalingPolicy
+		new.Spec.NodeDrainPolicy = old.Spec.NodeDrainPolicy
 		new.Status = old.Status
 
 	case *hivev1.ClusterDeployment:
@@ -531,6 +531,56 @@ func TestMerge(t *testing.T) {
 			},
 			wantEmptyDiff: true,
 		},
+		{
+			name: "Cluster worker autoscaling policy preserved",
+			old: &arov1alpha1.Cluster{
+				Spec: arov1alpha1.ClusterSpec{
+					InfraID: "old",
+					WorkerAutoscalingPolicy: &arov1alpha1.WorkerAutoscalingPolicy{
+						AllowedVMSizes: []string{"Standard_D4s_v3"},
+					},
+				},
+			},
+			new: &arov1alpha1.Cluster{
+				Spec: arov1alpha1.ClusterSpec{
+					InfraID: "new",
+				},
+			},
+			want: &arov1alpha1.Cluster{
+				Spec: arov1alpha1.ClusterSpec{
+					InfraID: "new",
+					WorkerAutoscalingPolicy: &arov1alpha1.WorkerAutoscalingPolicy{
+						AllowedVMSizes: []string{"Standard_D4s_v3"},
+					},
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "Cluster node drain policy preserved",
+			old: &arov1alpha1.Cluster{
+				Spec: arov1alpha1.ClusterSpec{
+					InfraID: "old",
+					NodeDrainPolicy: &arov1alpha1.NodeDrainPolicy{
+						IgnoreDaemonSets: to.BoolPtr(false),
+					},
+				},
+			},
+			new: &arov1alpha1.Cluster{
+				Spec: arov1alpha1.ClusterSpec{
+					InfraID: "new",
+				},
+			},
+			want: &arov1alpha1.Cluster{
+				Spec: arov1alpha1.ClusterSpec{
+					InfraID: "new",
+					NodeDrainPolicy: &arov1alpha1.NodeDrainPolicy{
+						IgnoreDaemonSets: to.BoolPtr(false),
+					},
+				},
+			},
+			wantChanged: true,
+		},
 		{
 			name: "CustomResourceDefinition Betav1 no changes",
 			old: &extensionsv1beta1.CustomResourceDefinition{
@@ -7,3 +7,11 @@ type AEAD interface {
 	Open([]byte) ([]byte, error)
 	Seal([]byte) ([]byte, error)
 }
+
+// Resealer is an AEAD which can tell whether a ciphertext should be resealed
+// because it was not sealed with the current key, so that old keys can be
+// retired
+type Resealer interface {
+	AEAD
+	NeedsReseal([]byte) bool
+}
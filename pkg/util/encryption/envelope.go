@@ -0,0 +1,77 @@
+package encryption
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+// Envelopes wrap a ciphertext with the algorithm and key used to seal it, so
+// that it can be opened without trying every known key, and so that
+// ciphertexts sealed with old keys can be found and resealed.  An envelope is:
+//
+//	magic (4 bytes) | version (1 byte) | algorithm (1 byte) | key id (8 bytes) | ciphertext
+//
+// Ciphertexts sealed before envelopes were introduced have no header.
+
+// Algorithm identifies the AEAD used to seal a ciphertext
+type Algorithm byte
+
+// Algorithm constants
+const (
+	AlgorithmAES256SHA512      Algorithm = 1
+	AlgorithmXChaCha20Poly1305 Algorithm = 2
+)
+
+const (
+	envelopeMagic        = "AROE"
+	envelopeVersion byte = 1
+
+	envelopeVersionOffset   = len(envelopeMagic)
+	envelopeAlgorithmOffset = envelopeVersionOffset + 1
+	envelopeKeyIDOffset     = envelopeAlgorithmOffset + 1
+	envelopeKeyIDLength     = 8
+	envelopeHeaderLength    = envelopeKeyIDOffset + envelopeKeyIDLength
+)
+
+// envelopeKey identifies the key used to seal an envelope
+type envelopeKey struct {
+	algorithm Algorithm
+	id        [envelopeKeyIDLength]byte
+}
+
+// newEnvelopeKey identifies key by a truncated SHA-256 hash, so that the key
+// itself is not disclosed
+func newEnvelopeKey(algorithm Algorithm, key []byte) envelopeKey {
+	sum := sha256.Sum256(key)
+
+	k := envelopeKey{algorithm: algorithm}
+	copy(k.id[:], sum[:])
+
+	return k
+}
+
+func sealEnvelope(k envelopeKey, ciphertext []byte) []byte {
+	b := make([]byte, 0, envelopeHeaderLength+len(ciphertext))
+	b = append(b, envelopeMagic...)
+	b = append(b, envelopeVersion, byte(k.algorithm))
+	b = append(b, k.id[:]...)
+	return append(b, ciphertext...)
+}
+
+// openEnvelope returns the key and ciphertext in input, or false if input is
+// not an envelope
+func openEnvelope(input []byte) (envelopeKey, []byte, bool) {
+	if len(input) < envelopeHeaderLength ||
+		!bytes.HasPrefix(input, []byte(envelopeMagic)) ||
+		input[envelopeVersionOffset] != envelopeVersion {
+		return envelopeKey{}, nil, false
+	}
+
+	k := envelopeKey{algorithm: Algorithm(input[envelopeAlgorithmOffset])}
+	copy(k.id[:], input[envelopeKeyIDOffset:])
+
+	return k, input[envelopeHeaderLength:], true
+}
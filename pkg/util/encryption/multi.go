@@ -10,13 +10,26 @@ import (
 )
 
 type multi struct {
-	sealer  AEAD
+	sealer    AEAD
+	sealerKey envelopeKey
+
+	// sealEnvelopes is false until every reader of the sealed data can open
+	// envelopes
+	sealEnvelopes bool
+
+	// keys holds every opener by the key identifier recorded in envelopes;
+	// openers are tried in turn for ciphertexts sealed without one
+	keys    map[envelopeKey]AEAD
 	openers []AEAD
 }
 
-var _ AEAD = (*multi)(nil)
+var _ Resealer = (*multi)(nil)
 
-func NewMulti(ctx context.Context, serviceKeyvault keyvault.Manager, secretName, legacySecretName string) (AEAD, error) {
+// NewMulti returns a Resealer which seals using the current version of
+// secretName and opens using any enabled version of secretName or
+// legacySecretName.  Ciphertexts are only sealed in envelopes if sealEnvelopes
+// is set, but envelopes are always opened.
+func NewMulti(ctx context.Context, serviceKeyvault keyvault.Manager, secretName, legacySecretName string, sealEnvelopes bool) (Resealer, error) {
 	key, err := serviceKeyvault.GetBase64Secret(ctx, secretName, "")
 	if err != nil {
 		return nil, err
@@ -28,15 +41,19 @@ func NewMulti(ctx context.Context, serviceKeyvault keyvault.Manager, secretName,
 	}
 
 	m := &multi{
-		sealer: aead,
+		sealer:        aead,
+		sealerKey:     newEnvelopeKey(AlgorithmAES256SHA512, key),
+		sealEnvelopes: sealEnvelopes,
+		keys:          map[envelopeKey]AEAD{},
 	}
 
 	for _, x := range []struct {
 		secretName  string
+		algorithm   Algorithm
 		aeadFactory func(context.Context, []byte) (AEAD, error)
 	}{
-		{secretName, NewAES256SHA512},
-		{legacySecretName, NewXChaCha20Poly1305},
+		{secretName, AlgorithmAES256SHA512, NewAES256SHA512},
+		{legacySecretName, AlgorithmXChaCha20Poly1305, NewXChaCha20Poly1305},
 	} {
 		keys, err := serviceKeyvault.GetBase64Secrets(ctx, x.secretName)
 		if err != nil {
@@ -49,6 +66,7 @@ func NewMulti(ctx context.Context, serviceKeyvault keyvault.Manager, secretName,
 				return nil, err
 			}
 
+			m.keys[newEnvelopeKey(x.algorithm, key)] = aead
 			m.openers = append(m.openers, aead)
 		}
 	}
@@ -57,6 +75,14 @@ func NewMulti(ctx context.Context, serviceKeyvault keyvault.Manager, secretName,
 }
 
 func (c *multi) Open(input []byte) (b []byte, err error) {
+	// a ciphertext without an envelope could start with the envelope magic by
+	// chance, so fall back to trying every opener if the key is not known
+	if k, ciphertext, ok := openEnvelope(input); ok {
+		if opener, found := c.keys[k]; found {
+			return opener.Open(ciphertext)
+		}
+	}
+
 	for _, opener := range c.openers {
 		b, err = opener.Open(input)
 		if err == nil {
@@ -68,5 +94,29 @@ func (c *multi) Open(input []byte) (b []byte, err error) {
 }
 
 func (c *multi) Seal(input []byte) ([]byte, error) {
-	return c.sealer.Seal(input)
+	b, err := c.sealer.Seal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.sealEnvelopes {
+		return b, nil
+	}
+
+	return sealEnvelope(c.sealerKey, b), nil
+}
+
+// NeedsReseal returns true unless input was sealed as Seal would seal it now,
+// i.e. with the current key, and in an envelope only if envelopes are sealed.
+// Resealing envelopes without them allows rolling back to an RP which cannot
+// open envelopes.
+func (c *multi) NeedsReseal(input []byte) bool {
+	if !c.sealEnvelopes {
+		// without an envelope the key can only be identified by trying it
+		_, err := c.sealer.Open(input)
+		return err != nil
+	}
+
+	k, _, ok := openEnvelope(input)
+	return !ok || k != c.sealerKey
 }
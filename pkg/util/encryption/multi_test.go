@@ -4,6 +4,8 @@ package encryption
 // Licensed under the Apache License 2.0.
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"reflect"
 	"testing"
@@ -11,6 +13,7 @@ import (
 	"github.com/golang/mock/gomock"
 
 	mock_encryption "github.com/Azure/ARO-RP/pkg/util/mocks/encryption"
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/keyvault"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
@@ -74,3 +77,199 @@ func TestOpen(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiEnvelope(t *testing.T) {
+	ctx := context.Background()
+
+	currentKey := bytes.Repeat([]byte{1}, 64)
+	oldKey := bytes.Repeat([]byte{2}, 64)
+	legacyKey := bytes.Repeat([]byte{3}, 32)
+
+	newAEAD := func(factory func(context.Context, []byte) (AEAD, error), key []byte) AEAD {
+		aead, err := factory(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return aead
+	}
+
+	newMulti := func(controller *gomock.Controller, current []byte, sealEnvelopes bool) Resealer {
+		keyvault := mock_keyvault.NewMockManager(controller)
+		keyvault.EXPECT().GetBase64Secret(gomock.Any(), "secret", "").Return(current, nil)
+		keyvault.EXPECT().GetBase64Secrets(gomock.Any(), "secret").Return([][]byte{currentKey, oldKey}, nil)
+		keyvault.EXPECT().GetBase64Secrets(gomock.Any(), "legacy").Return([][]byte{legacyKey}, nil)
+
+		m, err := NewMulti(ctx, keyvault, "secret", "legacy", sealEnvelopes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	for _, tt := range []struct {
+		name            string
+		seal            func(*gomock.Controller) ([]byte, error)
+		sealEnvelopes   bool
+		wantNeedsReseal bool
+	}{
+		{
+			name: "envelope with current key",
+			seal: func(controller *gomock.Controller) ([]byte, error) {
+				return newMulti(controller, currentKey, true).Seal([]byte("test"))
+			},
+			sealEnvelopes: true,
+		},
+		{
+			name: "envelope with old key",
+			seal: func(controller *gomock.Controller) ([]byte, error) {
+				return newMulti(controller, oldKey, true).Seal([]byte("test"))
+			},
+			sealEnvelopes:   true,
+			wantNeedsReseal: true,
+		},
+		{
+			name: "no envelope",
+			seal: func(controller *gomock.Controller) ([]byte, error) {
+				return newAEAD(NewAES256SHA512, currentKey).Seal([]byte("test"))
+			},
+			sealEnvelopes:   true,
+			wantNeedsReseal: true,
+		},
+		{
+			name: "no envelope, legacy key",
+			seal: func(controller *gomock.Controller) ([]byte, error) {
+				return newAEAD(NewXChaCha20Poly1305, legacyKey).Seal([]byte("test"))
+			},
+			sealEnvelopes:   true,
+			wantNeedsReseal: true,
+		},
+		{
+			name: "envelopes not sealed: no envelope with current key",
+			seal: func(controller *gomock.Controller) ([]byte, error) {
+				return newMulti(controller, currentKey, false).Seal([]byte("test"))
+			},
+		},
+		{
+			name: "envelopes not sealed: no envelope with old key",
+			seal: func(controller *gomock.Controller) ([]byte, error) {
+				return newMulti(controller, oldKey, false).Seal([]byte("test"))
+			},
+			wantNeedsReseal: true,
+		},
+		{
+			name: "envelopes not sealed: envelope with current key",
+			seal: func(controller *gomock.Controller) ([]byte, error) {
+				return newMulti(controller, currentKey, true).Seal([]byte("test"))
+			},
+			wantNeedsReseal: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			sealed, err := tt.seal(controller)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := newMulti(controller, currentKey, tt.sealEnvelopes)
+
+			opened, err := m.Open(sealed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(opened) != "test" {
+				t.Error(string(opened))
+			}
+
+			needsReseal := m.NeedsReseal(sealed)
+			if needsReseal != tt.wantNeedsReseal {
+				t.Errorf("got %v, want %v", needsReseal, tt.wantNeedsReseal)
+			}
+		})
+	}
+}
+
+func TestMultiSealWithoutEnvelopes(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	key := bytes.Repeat([]byte{1}, 64)
+
+	keyvault := mock_keyvault.NewMockManager(controller)
+	keyvault.EXPECT().GetBase64Secret(gomock.Any(), "secret", "").Return(key, nil)
+	keyvault.EXPECT().GetBase64Secrets(gomock.Any(), "secret").Return([][]byte{key}, nil)
+	keyvault.EXPECT().GetBase64Secrets(gomock.Any(), "legacy").Return(nil, nil)
+
+	m, err := NewMulti(ctx, keyvault, "secret", "legacy", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := m.Seal([]byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an RP without envelope support must be able to open the ciphertext
+	aead, err := NewAES256SHA512(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opened, err := aead.Open(sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(opened) != "test" {
+		t.Error(string(opened))
+	}
+}
+
+func TestOpenEnvelope(t *testing.T) {
+	k := newEnvelopeKey(AlgorithmAES256SHA512, []byte("key"))
+
+	for _, tt := range []struct {
+		name           string
+		input          []byte
+		wantKey        envelopeKey
+		wantCiphertext []byte
+		wantOK         bool
+	}{
+		{
+			name:           "envelope",
+			input:          sealEnvelope(k, []byte("ciphertext")),
+			wantKey:        k,
+			wantCiphertext: []byte("ciphertext"),
+			wantOK:         true,
+		},
+		{
+			name:  "too short",
+			input: []byte("AROE\x01"),
+		},
+		{
+			name:  "no magic",
+			input: []byte("random ciphertext without a header"),
+		},
+		{
+			name:  "unknown version",
+			input: append([]byte("AROE\x02\x01"), make([]byte, 16)...),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ciphertext, ok := openEnvelope(tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("got %v, want %v", ok, tt.wantOK)
+			}
+			if key != tt.wantKey {
+				t.Errorf("got %v, want %v", key, tt.wantKey)
+			}
+			if !bytes.Equal(ciphertext, tt.wantCiphertext) {
+				t.Error(string(ciphertext))
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package etcdsnapshot
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const (
+	// Container is the blob container in the cluster storage account in which
+	// snapshots are stored
+	Container = "etcd-snapshots"
+
+	// DefaultRetention is the number of snapshots kept if the cluster's
+	// EtcdSnapshotProfile does not specify one
+	DefaultRetention = 7
+
+	blobPrefix     = "etcd-snapshot-"
+	blobSuffix     = ".tar.gz.enc"
+	blobTimeFormat = "20060102T150405Z"
+)
+
+// Interval returns the time between scheduled snapshots of the cluster, or
+// zero if scheduled snapshots are disabled
+func Interval(p *api.EtcdSnapshotProfile) time.Duration {
+	if p == nil || p.Interval == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(p.Interval)
+	if err != nil || d < 0 {
+		return 0
+	}
+
+	return d
+}
+
+// Retention returns the number of snapshots of the cluster which are kept
+func Retention(p *api.EtcdSnapshotProfile) int {
+	if p == nil || p.Retention <= 0 {
+		return DefaultRetention
+	}
+
+	return p.Retention
+}
+
+// Due returns true if a scheduled snapshot of the cluster is due
+func Due(p *api.EtcdSnapshotProfile, now time.Time) bool {
+	interval := Interval(p)
+	if interval == 0 {
+		return false
+	}
+
+	return p.LastSnapshotTime == nil || !now.Before(p.LastSnapshotTime.Add(interval))
+}
+
+// BlobName returns the name of the blob in which a snapshot taken at t is
+// stored.  Blob names sort in the order in which the snapshots were taken.
+func BlobName(t time.Time) string {
+	return blobPrefix + t.UTC().Format(blobTimeFormat) + blobSuffix
+}
+
+// IsBlobName returns true if name is the name of a snapshot blob
+func IsBlobName(name string) bool {
+	if !strings.HasPrefix(name, blobPrefix) || !strings.HasSuffix(name, blobSuffix) {
+		return false
+	}
+
+	_, err := time.Parse(blobTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, blobPrefix), blobSuffix))
+	return err == nil
+}
+
+// Expired returns the snapshot blobs among names which are not within the
+// newest retention snapshots, oldest first
+func Expired(names []string, retention int) []string {
+	var snapshots []string
+	for _, name := range names {
+		if IsBlobName(name) {
+			snapshots = append(snapshots, name)
+		}
+	}
+
+	if len(snapshots) <= retention {
+		return nil
+	}
+
+	sort.Strings(snapshots)
+
+	return snapshots[:len(snapshots)-retention]
+}
@@ -0,0 +1,133 @@
+package etcdsnapshot
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestDue(t *testing.T) {
+	now := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name    string
+		profile *api.EtcdSnapshotProfile
+		want    bool
+	}{
+		{
+			name: "no profile",
+		},
+		{
+			name:    "scheduled snapshots disabled",
+			profile: &api.EtcdSnapshotProfile{},
+		},
+		{
+			name: "invalid interval",
+			profile: &api.EtcdSnapshotProfile{
+				Interval: "daily",
+			},
+		},
+		{
+			name: "no snapshot taken",
+			profile: &api.EtcdSnapshotProfile{
+				Interval: "24h",
+			},
+			want: true,
+		},
+		{
+			name: "snapshot not due",
+			profile: &api.EtcdSnapshotProfile{
+				Interval:         "24h",
+				LastSnapshotTime: &last,
+			},
+		},
+		{
+			name: "snapshot due",
+			profile: &api.EtcdSnapshotProfile{
+				Interval:         "12h",
+				LastSnapshotTime: &last,
+			},
+			want: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Due(tt.profile, now)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestRetention(t *testing.T) {
+	if got := Retention(nil); got != DefaultRetention {
+		t.Error(got)
+	}
+
+	if got := Retention(&api.EtcdSnapshotProfile{Retention: 3}); got != 3 {
+		t.Error(got)
+	}
+}
+
+func TestBlobName(t *testing.T) {
+	name := BlobName(time.Date(2023, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600)))
+	if name != "etcd-snapshot-20230102T020405Z.tar.gz.enc" {
+		t.Error(name)
+	}
+
+	if !IsBlobName(name) {
+		t.Error("IsBlobName returned false")
+	}
+
+	for _, name := range []string{
+		"etcd-snapshot-latest.tar.gz.enc",
+		"etcd-snapshot-20230102T020405Z.tar.gz",
+		"other",
+	} {
+		if IsBlobName(name) {
+			t.Errorf("IsBlobName(%q) returned true", name)
+		}
+	}
+}
+
+func TestExpired(t *testing.T) {
+	names := []string{
+		"etcd-snapshot-20230103T000000Z.tar.gz.enc",
+		"etcd-snapshot-20230101T000000Z.tar.gz.enc",
+		"unrelated",
+		"etcd-snapshot-20230104T000000Z.tar.gz.enc",
+		"etcd-snapshot-20230102T000000Z.tar.gz.enc",
+	}
+
+	for _, tt := range []struct {
+		name      string
+		retention int
+		want      []string
+	}{
+		{
+			name:      "oldest snapshots expire",
+			retention: 2,
+			want: []string{
+				"etcd-snapshot-20230101T000000Z.tar.gz.enc",
+				"etcd-snapshot-20230102T000000Z.tar.gz.enc",
+			},
+		},
+		{
+			name:      "nothing expires within retention",
+			retention: 4,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Expired(names, tt.retention)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package etcdsnapshot
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Namespace is the namespace in which snapshot and restore jobs run.  It
+	// is a run-level 0 namespace, so the jobs may run privileged.
+	Namespace = "openshift-etcd"
+
+	SnapshotJobName = "aro-etcd-snapshot"
+	RestoreJobName  = "aro-etcd-restore"
+
+	// SnapshotTimeout bounds the time for which a snapshot job may run
+	SnapshotTimeout = 30 * time.Minute
+
+	// RestoreTimeout bounds the time for which a restore job may run
+	RestoreTimeout = time.Hour
+
+	// RestoreTTL is the time after which a finished restore job is removed,
+	// and its secret with it
+	RestoreTTL = time.Hour
+
+	image = "ubi8/ubi-minimal"
+)
+
+//go:embed scripts/snapshot.sh
+var snapshotScript string
+
+//go:embed scripts/restore.sh
+var restoreScript string
+
+// SnapshotJob returns the secret and the job which take a snapshot on node,
+// encrypt it with key and upload it to snapshotURL
+func SnapshotJob(node, snapshotURL string, key []byte) (*corev1.Secret, *batchv1.Job) {
+	secret := newSecret(SnapshotJobName, map[string]string{
+		"ENCRYPTION_KEY": base64.StdEncoding.EncodeToString(key),
+		"SNAPSHOT_URL":   snapshotURL,
+	})
+
+	return secret, newJob(SnapshotJobName, node, snapshotScript, SnapshotTimeout)
+}
+
+// RestoreJob returns the secret and the job which restore the snapshot at
+// snapshotURL, encrypted with key, on node.  masters are the addresses of the
+// other control plane nodes, which the job reaches over SSH with sshKey, a
+// PKCS#1 private key.  The RP does not wait for the restore, so the job is
+// removed RestoreTTL after it finishes; the secret must be made owned by the
+// job once it is created with SetOwner, so that it is removed too.
+func RestoreJob(node, snapshotURL string, key, sshKey []byte, masters []string) (*corev1.Secret, *batchv1.Job) {
+	secret := newSecret(RestoreJobName, map[string]string{
+		"ENCRYPTION_KEY": base64.StdEncoding.EncodeToString(key),
+		"SNAPSHOT_URL":   snapshotURL,
+		"SSH_KEY": string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: sshKey,
+		})),
+		"MASTERS": strings.Join(masters, " "),
+	})
+
+	job := newJob(RestoreJobName, node, restoreScript, RestoreTimeout)
+	job.Spec.TTLSecondsAfterFinished = to.Int32Ptr(int32(RestoreTTL / time.Second))
+
+	return secret, job
+}
+
+// SetOwner makes job, which must have been created, the owner of secret, so
+// that the secret is garbage collected when the job is removed
+func SetOwner(secret *corev1.Secret, job *batchv1.Job) {
+	secret.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+			Name:       job.Name,
+			UID:        job.UID,
+		},
+	}
+}
+
+func newSecret(name string, data map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: Namespace,
+		},
+		StringData: data,
+	}
+}
+
+func newJob(name, node, script string, timeout time.Duration) *batchv1.Job {
+	labels := map[string]string{"app": name}
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          to.Int32Ptr(0),
+			ActiveDeadlineSeconds: to.Int64Ptr(int64(timeout / time.Second)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					NodeName:      node,
+					HostNetwork:   true,
+					Tolerations: []corev1.Toleration{
+						{
+							Operator: corev1.TolerationOpExists,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: image,
+							Command: []string{
+								"chroot",
+								"/host",
+								"/bin/bash",
+								"-c",
+								script,
+							},
+							EnvFrom: []corev1.EnvFromSource{
+								{
+									SecretRef: &corev1.SecretEnvSource{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: name,
+										},
+									},
+								},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: to.BoolPtr(true),
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "host",
+									MountPath: "/host",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "host",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: "/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
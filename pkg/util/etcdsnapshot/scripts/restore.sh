@@ -0,0 +1,67 @@
+#!/bin/bash
+#
+# Restores the etcd snapshot at SNAPSHOT_URL on this node.  The static pods of
+# the other control plane nodes, whose addresses are in MASTERS, are stopped
+# over SSH first and kubelet is restarted on every control plane node once the
+# snapshot has been restored.
+#
+# See for more information: https://docs.openshift.com/container-platform/4.10/backup_and_restore/control_plane_backup_and_restore/disaster_recovery/scenario-2-restoring-cluster-state.html
+
+set -euo pipefail
+
+dir=/var/lib/etcd-backup/aro-restore
+
+rm -rf "$dir"
+mkdir -p "$dir"
+trap 'rm -rf "$dir"' EXIT
+
+install -m 0600 /dev/null "$dir/id_rsa"
+echo "$SSH_KEY" >"$dir/id_rsa"
+
+remote() {
+	ssh -i "$dir/id_rsa" \
+		-o StrictHostKeyChecking=no \
+		-o UserKnownHostsFile=/dev/null \
+		-o ConnectTimeout=30 \
+		"core@$1" sudo bash -s <<<"$2"
+}
+
+stop_static_pods='
+set -euo pipefail
+mkdir -p /etc/kubernetes/aro-restore
+for pod in etcd kube-apiserver kube-controller-manager kube-scheduler; do
+	if [[ -f /etc/kubernetes/manifests/$pod-pod.yaml ]]; then
+		mv /etc/kubernetes/manifests/$pod-pod.yaml /etc/kubernetes/aro-restore/
+	fi
+	while [[ -n "$(crictl ps --quiet --name "^$pod\$")" ]]; do
+		sleep 5
+	done
+done
+if [[ -d /var/lib/etcd ]]; then
+	mv /var/lib/etcd "/var/lib/etcd-aro-restore-$(date +%s)"
+fi
+'
+
+echo "downloading snapshot"
+curl --fail --silent --show-error \
+	-H "x-ms-version: 2019-12-12" \
+	--output "$dir/snapshot" \
+	"$SNAPSHOT_URL"
+
+openssl enc -d -aes-256-cbc -pbkdf2 -pass env:ENCRYPTION_KEY -in "$dir/snapshot" | tar -C "$dir" -xzf -
+
+for master in $MASTERS; do
+	echo "stopping static pods on $master"
+	remote "$master" "$stop_static_pods"
+done
+
+echo "restoring snapshot"
+/usr/local/bin/cluster-restore.sh "$dir/backup"
+
+for master in $MASTERS; do
+	echo "restarting kubelet on $master"
+	remote "$master" "systemctl restart kubelet"
+done
+
+echo "restarting kubelet"
+systemctl restart kubelet
@@ -0,0 +1,26 @@
+#!/bin/bash
+#
+# Takes an etcd snapshot with the backup script shipped on the control plane
+# nodes, encrypts it with ENCRYPTION_KEY and uploads it to SNAPSHOT_URL.
+#
+# See for more information: https://docs.openshift.com/container-platform/4.10/backup_and_restore/control_plane_backup_and_restore/backing-up-etcd.html
+
+set -euo pipefail
+
+dir=/var/lib/etcd-backup/aro-snapshot
+
+rm -rf "$dir"
+trap 'rm -rf "$dir"' EXIT
+
+/usr/local/bin/cluster-backup.sh "$dir/backup"
+
+tar -C "$dir" -czf - backup | openssl enc -aes-256-cbc -pbkdf2 -pass env:ENCRYPTION_KEY -out "$dir/snapshot"
+
+curl --fail --silent --show-error \
+	-X PUT \
+	-H "x-ms-blob-type: BlockBlob" \
+	-H "x-ms-version: 2019-12-12" \
+	--upload-file "$dir/snapshot" \
+	"$SNAPSHOT_URL"
+
+echo "uploaded $(stat -c %s "$dir/snapshot") bytes"
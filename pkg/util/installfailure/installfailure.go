@@ -0,0 +1,256 @@
+package installfailure
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// rulesVersion is the version of the rule file format understood by load.
+const rulesVersion = 1
+
+// ReasonUnknown is returned by ReasonOf for failures which no rule matched.
+const ReasonUnknown = "UnknownError"
+
+//go:embed rules.json
+var defaultRules []byte
+
+var deploymentFailedRegex = regexp.MustCompile(`level=error msg=400: DeploymentFailed: : Deployment failed. Details: : : (\{.*\})`)
+
+// Rule describes a known cause of an install failure, the install log
+// patterns which identify it and the user-facing error returned for it.
+type Rule struct {
+	Name       string
+	Reason     string
+	Message    string
+	Code       string
+	StatusCode int
+
+	// ARMErrorDetails indicates that the install log contains a failed ARM
+	// deployment whose error details should be surfaced to the user.
+	ARMErrorDetails bool
+
+	SearchRegexes []*regexp.Regexp
+}
+
+// RuleSet is an ordered list of rules. Order determines precedence: earlier
+// rules take priority over later ones.
+type RuleSet struct {
+	Version int
+	Rules   []Rule
+}
+
+type ruleFile struct {
+	Version int    `json:"version"`
+	Rules   []rule `json:"rules"`
+}
+
+type rule struct {
+	Name               string   `json:"name"`
+	Reason             string   `json:"reason"`
+	Message            string   `json:"message"`
+	Code               string   `json:"code,omitempty"`
+	StatusCode         int      `json:"statusCode,omitempty"`
+	ARMErrorDetails    bool     `json:"armErrorDetails,omitempty"`
+	SearchRegexStrings []string `json:"searchRegexStrings"`
+}
+
+var defaultRuleSet = mustLoad(defaultRules)
+
+// Default returns the rule set shipped with the RP.
+func Default() *RuleSet {
+	return defaultRuleSet
+}
+
+func mustLoad(b []byte) *RuleSet {
+	rs, err := load(bytes.NewReader(b))
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+// load reads a rule set in JSON format from r.
+func load(r io.Reader) (*RuleSet, error) {
+	var rf ruleFile
+	err := json.NewDecoder(r).Decode(&rf)
+	if err != nil {
+		return nil, err
+	}
+
+	if rf.Version != rulesVersion {
+		return nil, fmt.Errorf("unsupported install failure rules version %d", rf.Version)
+	}
+
+	rs := &RuleSet{
+		Version: rf.Version,
+		Rules:   make([]Rule, 0, len(rf.Rules)),
+	}
+
+	reasons := map[string]struct{}{}
+	for _, fr := range rf.Rules {
+		if fr.Reason == "" {
+			return nil, fmt.Errorf("rule %q has no reason", fr.Name)
+		}
+		if _, found := reasons[fr.Reason]; found {
+			return nil, fmt.Errorf("duplicate rule reason %q", fr.Reason)
+		}
+		reasons[fr.Reason] = struct{}{}
+
+		if len(fr.SearchRegexStrings) == 0 {
+			return nil, fmt.Errorf("rule %q has no search regexes", fr.Name)
+		}
+
+		r := Rule{
+			Name:            fr.Name,
+			Reason:          fr.Reason,
+			Message:         fr.Message,
+			Code:            fr.Code,
+			StatusCode:      fr.StatusCode,
+			ARMErrorDetails: fr.ARMErrorDetails,
+			SearchRegexes:   make([]*regexp.Regexp, 0, len(fr.SearchRegexStrings)),
+		}
+
+		if r.Code == "" {
+			r.Code = api.CloudErrorCodeDeploymentFailed
+		}
+		if r.StatusCode == 0 {
+			r.StatusCode = http.StatusBadRequest
+		}
+
+		for _, s := range fr.SearchRegexStrings {
+			regex, err := regexp.Compile(s)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", fr.Name, err)
+			}
+			r.SearchRegexes = append(r.SearchRegexes, regex)
+		}
+
+		rs.Rules = append(rs.Rules, r)
+	}
+
+	return rs, nil
+}
+
+// Classify returns the first rule matching installLog, or nil if no rule
+// matches.
+func (rs *RuleSet) Classify(installLog string) *Rule {
+	for i := range rs.Rules {
+		for _, regex := range rs.Rules[i].SearchRegexes {
+			if regex.MatchString(installLog) {
+				return &rs.Rules[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// Lookup returns the rule with the given reason, or nil if there is none.
+func (rs *RuleSet) Lookup(reason string) *Rule {
+	for i := range rs.Rules {
+		if rs.Rules[i].Reason == reason {
+			return &rs.Rules[i]
+		}
+	}
+
+	return nil
+}
+
+// Error is the user-facing error returned for an install failure which was
+// matched by a rule.  It carries the reason of the rule, so that the failure
+// can be classified without inspecting the error message.
+type Error struct {
+	*api.CloudError
+	Reason string
+}
+
+func (err *Error) Unwrap() error {
+	return err.CloudError
+}
+
+// ReasonOf returns the reason of the rule which produced err, or
+// ReasonUnknown if err was not produced by a rule.
+func ReasonOf(err error) string {
+	var installErr *Error
+	if errors.As(err, &installErr) {
+		return installErr.Reason
+	}
+
+	return ReasonUnknown
+}
+
+// NewError returns the user-facing error for the rule. If the rule surfaces
+// ARM error details and they can be found in installLog, they are attached to
+// the error.
+func (r *Rule) NewError(installLog string) *Error {
+	installErr := &Error{
+		CloudError: &api.CloudError{
+			StatusCode: r.StatusCode,
+			CloudErrorBody: &api.CloudErrorBody{
+				Code:    r.Code,
+				Message: r.Message,
+			},
+		},
+		Reason: r.Reason,
+	}
+
+	if !r.ARMErrorDetails {
+		return installErr
+	}
+
+	armError, err := parseDeploymentFailedJSON(installLog)
+	if err != nil || armError.Details == nil {
+		return installErr
+	}
+
+	installErr.Details = make([]api.CloudErrorBody, 0, len(*armError.Details))
+	for _, detail := range *armError.Details {
+		installErr.Details = append(installErr.Details, errorResponseToCloudErrorBody(detail))
+	}
+
+	return installErr
+}
+
+func parseDeploymentFailedJSON(installLog string) (*mgmtfeatures.ErrorResponse, error) {
+	m := deploymentFailedRegex.FindStringSubmatch(installLog)
+	if m == nil {
+		return nil, fmt.Errorf("no failed deployment found in install log")
+	}
+
+	armResponse := &mgmtfeatures.ErrorResponse{}
+	err := json.Unmarshal([]byte(m[1]), armResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return armResponse, nil
+}
+
+func errorResponseToCloudErrorBody(errorResponse mgmtfeatures.ErrorResponse) api.CloudErrorBody {
+	body := api.CloudErrorBody{}
+
+	if errorResponse.Code != nil {
+		body.Code = *errorResponse.Code
+	}
+	if errorResponse.Message != nil {
+		body.Message = *errorResponse.Message
+	}
+	if errorResponse.Target != nil {
+		body.Target = *errorResponse.Target
+	}
+
+	return body
+}
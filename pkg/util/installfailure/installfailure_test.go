@@ -0,0 +1,235 @@
+package installfailure
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestLoad(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			rules: `{"version": 1, "rules": [{"name": "A", "reason": "A", "message": "a", "searchRegexStrings": ["a"]}]}`,
+		},
+		{
+			name:    "unsupported version",
+			rules:   `{"version": 2, "rules": []}`,
+			wantErr: "unsupported install failure rules version 2",
+		},
+		{
+			name:    "missing reason",
+			rules:   `{"version": 1, "rules": [{"name": "A", "searchRegexStrings": ["a"]}]}`,
+			wantErr: `rule "A" has no reason`,
+		},
+		{
+			name:    "duplicate reason",
+			rules:   `{"version": 1, "rules": [{"name": "A", "reason": "A", "searchRegexStrings": ["a"]}, {"name": "B", "reason": "A", "searchRegexStrings": ["b"]}]}`,
+			wantErr: `duplicate rule reason "A"`,
+		},
+		{
+			name:    "no regexes",
+			rules:   `{"version": 1, "rules": [{"name": "A", "reason": "A"}]}`,
+			wantErr: `rule "A" has no search regexes`,
+		},
+		{
+			name:    "invalid regex",
+			rules:   `{"version": 1, "rules": [{"name": "A", "reason": "A", "searchRegexStrings": ["("]}]}`,
+			wantErr: "rule \"A\": error parsing regexp: missing closing ): `(`",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, err := load(strings.NewReader(tt.rules))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if err == nil {
+				r := rs.Rules[0]
+				if r.Code != api.CloudErrorCodeDeploymentFailed {
+					t.Error(r.Code)
+				}
+				if r.StatusCode != http.StatusBadRequest {
+					t.Error(r.StatusCode)
+				}
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		installLog string
+		wantReason string
+	}{
+		{
+			name:       "quota exceeded",
+			installLog: `level=error msg=400: DeploymentFailed: : Deployment failed. Details: : : {"code":"InvalidTemplateDeployment","message":"The template deployment failed.","details":[{"code":"QuotaExceeded","message":"Operation could not be completed as it results in exceeding approved standardDSv3Family Cores quota."}]}`,
+			wantReason: "AzureQuotaExceeded",
+		},
+		{
+			name:       "sku not available",
+			installLog: `level=error msg=400: DeploymentFailed: : Deployment failed. Details: : : {"code":"InvalidTemplateDeployment","message":"The template deployment failed.","details":[{"code":"SkuNotAvailable","message":"The requested size for resource 'master-0' is currently not available."}]}`,
+			wantReason: "AzureSkuNotAvailable",
+		},
+		{
+			name:       "policy takes precedence over template deployment",
+			installLog: `level=error msg=400: DeploymentFailed: : Deployment failed. Details: : : {"code":"InvalidTemplateDeployment","message":"The template deployment failed.","details":[{"code":"RequestDisallowedByPolicy","message":"Resource 'bootstrap' was disallowed by policy."}]}`,
+			wantReason: "AzureRequestDisallowedByPolicy",
+		},
+		{
+			name:       "invalid template deployment",
+			installLog: `level=error msg=400: DeploymentFailed: : Deployment failed. Details: : : {"code":"InvalidTemplateDeployment","message":"The template deployment failed.","details":[]}`,
+			wantReason: "AzureInvalidTemplateDeployment",
+		},
+		{
+			name:       "dns resolution",
+			installLog: `level=error msg=Get "https://api.cluster.example.com:6443/version": dial tcp: lookup api.cluster.example.com on 168.63.129.16:53: no such host`,
+			wantReason: "DNSResolutionFailed",
+		},
+		{
+			name:       "pull secret",
+			installLog: `level=error msg=Error pulling image quay.io/openshift-release-dev/ocp-release: unauthorized: authentication required`,
+			wantReason: "PullSecretInvalid",
+		},
+		{
+			name:       "bootstrap timeout",
+			installLog: `level=error msg=Bootstrap failed to complete: timed out waiting for the condition`,
+			wantReason: "BootstrapTimeout",
+		},
+		{
+			name:       "unknown",
+			installLog: `level=error msg=something unexpected happened`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := Default().Classify(tt.installLog)
+
+			var reason string
+			if rule != nil {
+				reason = rule.Reason
+			}
+
+			if reason != tt.wantReason {
+				t.Errorf("got %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestNewError(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		reason     string
+		installLog string
+		want       *api.CloudError
+	}{
+		{
+			name:       "arm error details",
+			reason:     "AzureSkuNotAvailable",
+			installLog: `level=error msg=400: DeploymentFailed: : Deployment failed. Details: : : {"code":"InvalidTemplateDeployment","message":"The template deployment failed.","details":[{"code":"SkuNotAvailable","message":"The requested size is not available.","target":"master-0"}]}`,
+			want: &api.CloudError{
+				StatusCode: http.StatusBadRequest,
+				CloudErrorBody: &api.CloudErrorBody{
+					Code:    "SkuNotAvailable",
+					Message: "Deployment failed because the requested VM size is not available in the location or zone. Please see details for more information.",
+					Details: []api.CloudErrorBody{
+						{
+							Code:    "SkuNotAvailable",
+							Message: "The requested size is not available.",
+							Target:  "master-0",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "arm error details not found in log",
+			reason:     "AzureInvalidTemplateDeployment",
+			installLog: `"code":"InvalidTemplateDeployment"`,
+			want: &api.CloudError{
+				StatusCode: http.StatusBadRequest,
+				CloudErrorBody: &api.CloudErrorBody{
+					Code:    api.CloudErrorCodeDeploymentFailed,
+					Message: "Deployment failed. Please see details for more information.",
+				},
+			},
+		},
+		{
+			name:       "no arm error details",
+			reason:     "PullSecretInvalid",
+			installLog: `invalid pull secret`,
+			want: &api.CloudError{
+				StatusCode: http.StatusBadRequest,
+				CloudErrorBody: &api.CloudErrorBody{
+					Code:    "InvalidPullSecret",
+					Message: "Deployment failed because the cluster could not pull release images. Please verify the pull secret.",
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Default().Lookup(tt.reason).NewError(tt.installLog)
+
+			if got.Reason != tt.reason {
+				t.Errorf("got reason %q, want %q", got.Reason, tt.reason)
+			}
+			if !reflect.DeepEqual(got.CloudError, tt.want) {
+				t.Errorf("got %#v, want %#v", got.CloudError, tt.want)
+			}
+		})
+	}
+}
+
+func TestReasonOf(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "rule error",
+			err:  Default().Lookup("AzureQuotaExceeded").NewError(""),
+			want: "AzureQuotaExceeded",
+		},
+		{
+			name: "wrapped rule error",
+			err:  fmt.Errorf("install failed: %w", Default().Lookup("AzureQuotaExceeded").NewError("")),
+			want: "AzureQuotaExceeded",
+		},
+		{
+			name: "cloud error with the message of a rule",
+			err:  Default().Lookup("AzureQuotaExceeded").NewError("").CloudError,
+			want: ReasonUnknown,
+		},
+		{
+			name: "other cloud error",
+			err:  api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeDeploymentFailed, "", "Something else."),
+			want: ReasonUnknown,
+		},
+		{
+			name: "not a cloud error",
+			err:  errors.New("failed"),
+			want: ReasonUnknown,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReasonOf(tt.err)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
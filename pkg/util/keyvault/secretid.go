@@ -0,0 +1,108 @@
+package keyvault
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strings"
+
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/keyvault"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+)
+
+// ParseSecretID returns the vault URI, secret name and secret version of a Key
+// Vault secret identifier such as
+// https://myvault.vault.azure.net/secrets/mysecret/version.  The version is
+// empty if the identifier refers to the latest version of the secret.
+func ParseSecretID(secretID string) (vaultURI, secretName, secretVersion string, err error) {
+	u, err := url.Parse(secretID)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.Scheme != "https" || u.Host == "" ||
+		len(parts) < 2 || len(parts) > 3 ||
+		parts[0] != "secrets" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid secret identifier %q", secretID)
+	}
+
+	if len(parts) == 3 {
+		secretVersion = parts[2]
+	}
+
+	return "https://" + u.Host + "/", parts[1], secretVersion, nil
+}
+
+// GetCertificateSecretByID returns the private key and certificate chain held
+// PEM encoded in the secret with the given identifier, and the version of the
+// secret which holds them.  Unlike the Manager methods, the secret may be in
+// any key vault which kv can access.
+func GetCertificateSecretByID(ctx context.Context, kv keyvault.BaseClient, secretID string) (*rsa.PrivateKey, []*x509.Certificate, string, error) {
+	bundle, version, err := getSecretByID(ctx, kv, secretID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if bundle.Value == nil {
+		return nil, nil, "", fmt.Errorf("secret %q has no value", secretID)
+	}
+
+	key, certs, err := utilpem.Parse([]byte(*bundle.Value))
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("secret %q is not PEM encoded: %w", secretID, err)
+	}
+
+	if key == nil || len(certs) == 0 {
+		return nil, nil, "", fmt.Errorf("secret %q does not hold a PEM encoded certificate and private key", secretID)
+	}
+
+	return key, certs, version, nil
+}
+
+// GetSecretVersionByID returns the version of the secret to which the given
+// identifier refers.  If the identifier has no version, this is the latest
+// version of the secret.
+func GetSecretVersionByID(ctx context.Context, kv keyvault.BaseClient, secretID string) (string, error) {
+	_, _, secretVersion, err := ParseSecretID(secretID)
+	if err != nil {
+		return "", err
+	}
+
+	if secretVersion != "" {
+		return secretVersion, nil
+	}
+
+	_, version, err := getSecretByID(ctx, kv, secretID)
+	return version, err
+}
+
+func getSecretByID(ctx context.Context, kv keyvault.BaseClient, secretID string) (azkeyvault.SecretBundle, string, error) {
+	vaultURI, secretName, secretVersion, err := ParseSecretID(secretID)
+	if err != nil {
+		return azkeyvault.SecretBundle{}, "", err
+	}
+
+	bundle, err := kv.GetSecret(ctx, vaultURI, secretName, secretVersion)
+	if err != nil {
+		return azkeyvault.SecretBundle{}, "", err
+	}
+
+	if bundle.ID == nil {
+		return azkeyvault.SecretBundle{}, "", fmt.Errorf("secret %q has no identifier", secretID)
+	}
+
+	_, _, version, err := ParseSecretID(*bundle.ID)
+	if err != nil {
+		return azkeyvault.SecretBundle{}, "", err
+	}
+
+	return bundle, version, nil
+}
@@ -0,0 +1,231 @@
+package maintenancewindow
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// the RP images do not necessarily ship the time zone database
+	_ "time/tzdata"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04"
+
+	// MinDurationHours and MaxDurationHours bound the length of a window.  A
+	// window must be long enough for a planned admin update to be dequeued.
+	MinDurationHours = 4
+	MaxDurationHours = 24
+
+	// horizon bounds the search for the next window, so that a profile whose
+	// windows are all blacked out does not loop forever
+	horizon = 400 * 24 * time.Hour
+)
+
+// ParseDayOfWeek parses a day name, e.g. "Saturday", case insensitively
+func ParseDayOfWeek(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) {
+			return d, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid day of week %q", s)
+}
+
+// ParseStartTime parses a window start time as HH:MM
+func ParseStartTime(s string) (time.Duration, error) {
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid start time %q", s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseDate parses a blackout date as YYYY-MM-DD
+func ParseDate(s string) (time.Time, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+
+	return t, nil
+}
+
+type window struct {
+	day      time.Weekday
+	start    time.Duration // since midnight
+	duration time.Duration
+}
+
+// startOn returns the start of the window on the given day.  The wall clock
+// start time is kept across daylight saving time changes.
+func (w *window) startOn(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(w.start/time.Hour), int(w.start%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+type blackout struct {
+	start, end time.Time // dates in UTC, both inclusive
+}
+
+// Schedule is a parsed MaintenanceWindowProfile
+type Schedule struct {
+	location  *time.Location
+	windows   []window
+	blackouts []blackout
+}
+
+// New parses p.  A nil profile or a profile without windows results in a
+// nil Schedule, which is always open.
+func New(p *api.MaintenanceWindowProfile) (*Schedule, error) {
+	if p == nil || len(p.Windows) == 0 {
+		return nil, nil
+	}
+
+	location, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q", p.TimeZone)
+	}
+
+	s := &Schedule{
+		location: location,
+	}
+
+	for _, w := range p.Windows {
+		day, err := ParseDayOfWeek(w.DayOfWeek)
+		if err != nil {
+			return nil, err
+		}
+
+		start, err := ParseStartTime(w.StartTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if w.DurationHours < MinDurationHours || w.DurationHours > MaxDurationHours {
+			return nil, fmt.Errorf("invalid duration %d hours", w.DurationHours)
+		}
+
+		s.windows = append(s.windows, window{
+			day:      day,
+			start:    start,
+			duration: time.Duration(w.DurationHours) * time.Hour,
+		})
+	}
+
+	for _, b := range p.BlackoutDates {
+		start, err := ParseDate(b.StartDate)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := ParseDate(b.EndDate)
+		if err != nil {
+			return nil, err
+		}
+
+		if end.Before(start) {
+			return nil, fmt.Errorf("blackout end date %q is before start date %q", b.EndDate, b.StartDate)
+		}
+
+		s.blackouts = append(s.blackouts, blackout{
+			start: start,
+			end:   end,
+		})
+	}
+
+	return s, nil
+}
+
+// midnight returns the start of the day of t, in t's location
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// blackedOut returns true if the local date of t is a blackout date
+func (s *Schedule) blackedOut(t time.Time) bool {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	for _, b := range s.blackouts {
+		if !date.Before(b.start) && !date.After(b.end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inWindow returns true if t falls in one of the windows
+func (s *Schedule) inWindow(t time.Time) bool {
+	// windows last at most a day, so only a window starting today or
+	// yesterday can contain t
+	for _, day := range []time.Time{midnight(t), midnight(t).AddDate(0, 0, -1)} {
+		for i := range s.windows {
+			w := &s.windows[i]
+			if day.Weekday() != w.day {
+				continue
+			}
+
+			start := w.startOn(day)
+			if !t.Before(start) && t.Before(start.Add(w.duration)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Open returns true if planned maintenance may start at t
+func (s *Schedule) Open(t time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	t = t.In(s.location)
+
+	return s.inWindow(t) && !s.blackedOut(t)
+}
+
+// Next returns the earliest time at or after t at which planned maintenance
+// may start.  It returns the zero time if there is no such time within the
+// search horizon, for example because every window is blacked out.
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s.Open(t) {
+		return t
+	}
+
+	t = t.In(s.location)
+
+	// the schedule can only open at the start of a window or at the end of a
+	// blackout (i.e. midnight) part way through a window
+	for day := midnight(t); day.Sub(t) < horizon; day = day.AddDate(0, 0, 1) {
+		candidates := []time.Time{day}
+		for i := range s.windows {
+			w := &s.windows[i]
+			if day.Weekday() == w.day {
+				candidates = append(candidates, w.startOn(day))
+			}
+		}
+
+		var next time.Time
+		for _, c := range candidates {
+			if c.After(t) && s.Open(c) && (next.IsZero() || c.Before(next)) {
+				next = c
+			}
+		}
+
+		if !next.IsZero() {
+			return next
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,219 @@
+package maintenancewindow
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		p       *api.MaintenanceWindowProfile
+		wantNil bool
+		wantErr string
+	}{
+		{
+			name:    "nil profile",
+			wantNil: true,
+		},
+		{
+			name: "no windows",
+			p: &api.MaintenanceWindowProfile{
+				TimeZone: "UTC",
+			},
+			wantNil: true,
+		},
+		{
+			name: "valid",
+			p: &api.MaintenanceWindowProfile{
+				TimeZone: "Europe/London",
+				Windows: []api.MaintenanceWindow{
+					{DayOfWeek: "saturday", StartTime: "22:00", DurationHours: 6},
+				},
+				BlackoutDates: []api.MaintenanceBlackout{
+					{StartDate: "2023-12-20", EndDate: "2024-01-02"},
+				},
+			},
+		},
+		{
+			name: "invalid time zone",
+			p: &api.MaintenanceWindowProfile{
+				TimeZone: "Mars/Olympus_Mons",
+				Windows: []api.MaintenanceWindow{
+					{DayOfWeek: "Saturday", StartTime: "22:00", DurationHours: 6},
+				},
+			},
+			wantErr: `invalid time zone "Mars/Olympus_Mons"`,
+		},
+		{
+			name: "invalid day",
+			p: &api.MaintenanceWindowProfile{
+				TimeZone: "UTC",
+				Windows: []api.MaintenanceWindow{
+					{DayOfWeek: "Caturday", StartTime: "22:00", DurationHours: 6},
+				},
+			},
+			wantErr: `invalid day of week "Caturday"`,
+		},
+		{
+			name: "invalid start time",
+			p: &api.MaintenanceWindowProfile{
+				TimeZone: "UTC",
+				Windows: []api.MaintenanceWindow{
+					{DayOfWeek: "Saturday", StartTime: "24:00", DurationHours: 6},
+				},
+			},
+			wantErr: `invalid start time "24:00"`,
+		},
+		{
+			name: "invalid duration",
+			p: &api.MaintenanceWindowProfile{
+				TimeZone: "UTC",
+				Windows: []api.MaintenanceWindow{
+					{DayOfWeek: "Saturday", StartTime: "22:00", DurationHours: 2},
+				},
+			},
+			wantErr: "invalid duration 2 hours",
+		},
+		{
+			name: "blackout ends before it starts",
+			p: &api.MaintenanceWindowProfile{
+				TimeZone: "UTC",
+				Windows: []api.MaintenanceWindow{
+					{DayOfWeek: "Saturday", StartTime: "22:00", DurationHours: 6},
+				},
+				BlackoutDates: []api.MaintenanceBlackout{
+					{StartDate: "2024-01-02", EndDate: "2023-12-20"},
+				},
+			},
+			wantErr: `blackout end date "2023-12-20" is before start date "2024-01-02"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.p)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr == "" && (s == nil) != tt.wantNil {
+				t.Error(s)
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Saturday 22:00 to Sunday 04:00 and Wednesday 12:00 to 16:00, London time
+	s, err := New(&api.MaintenanceWindowProfile{
+		TimeZone: "Europe/London",
+		Windows: []api.MaintenanceWindow{
+			{DayOfWeek: "Saturday", StartTime: "22:00", DurationHours: 6},
+			{DayOfWeek: "Wednesday", StartTime: "12:00", DurationHours: 4},
+		},
+		BlackoutDates: []api.MaintenanceBlackout{
+			{StartDate: "2023-12-23", EndDate: "2023-12-23"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		t        time.Time
+		wantOpen bool
+		wantNext time.Time
+	}{
+		{
+			name:     "in window",
+			t:        time.Date(2023, 12, 2, 23, 0, 0, 0, london),
+			wantOpen: true,
+			wantNext: time.Date(2023, 12, 2, 23, 0, 0, 0, london),
+		},
+		{
+			name:     "in window after midnight",
+			t:        time.Date(2023, 12, 3, 3, 59, 0, 0, london),
+			wantOpen: true,
+			wantNext: time.Date(2023, 12, 3, 3, 59, 0, 0, london),
+		},
+		{
+			name:     "at end of window",
+			t:        time.Date(2023, 12, 3, 4, 0, 0, 0, london),
+			wantNext: time.Date(2023, 12, 6, 12, 0, 0, 0, london),
+		},
+		{
+			name:     "before window",
+			t:        time.Date(2023, 12, 2, 21, 0, 0, 0, london),
+			wantNext: time.Date(2023, 12, 2, 22, 0, 0, 0, london),
+		},
+		{
+			name:     "time zone is honoured",
+			t:        time.Date(2023, 12, 6, 11, 30, 0, 0, time.UTC),
+			wantOpen: false,
+			wantNext: time.Date(2023, 12, 6, 12, 0, 0, 0, london),
+		},
+		{
+			name:     "blacked out window opens at the end of the blackout",
+			t:        time.Date(2023, 12, 23, 22, 30, 0, 0, london),
+			wantNext: time.Date(2023, 12, 24, 0, 0, 0, 0, london),
+		},
+		{
+			name:     "summer time",
+			t:        time.Date(2023, 7, 1, 21, 30, 0, 0, time.UTC),
+			wantOpen: true,
+			wantNext: time.Date(2023, 7, 1, 21, 30, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if open := s.Open(tt.t); open != tt.wantOpen {
+				t.Error(open)
+			}
+
+			if next := s.Next(tt.t); !next.Equal(tt.wantNext) {
+				t.Error(next)
+			}
+		})
+	}
+}
+
+func TestNilSchedule(t *testing.T) {
+	var s *Schedule
+
+	now := time.Now()
+
+	if !s.Open(now) {
+		t.Error("nil schedule should be open")
+	}
+
+	if !s.Next(now).Equal(now) {
+		t.Error(s.Next(now))
+	}
+}
+
+func TestNextWithoutOpenWindow(t *testing.T) {
+	s, err := New(&api.MaintenanceWindowProfile{
+		TimeZone: "UTC",
+		Windows: []api.MaintenanceWindow{
+			{DayOfWeek: "Saturday", StartTime: "22:00", DurationHours: 6},
+		},
+		BlackoutDates: []api.MaintenanceBlackout{
+			{StartDate: "2023-01-01", EndDate: "2099-12-31"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next := s.Next(time.Date(2023, 12, 2, 0, 0, 0, 0, time.UTC)); !next.IsZero() {
+		t.Error(next)
+	}
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Azure/ARO-RP/pkg/util/acrtoken (interfaces: Manager)
+
+// Package mock_acrtoken is a generated GoMock package.
+package mock_acrtoken
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	api "github.com/Azure/ARO-RP/pkg/api"
+)
+
+// MockManager is a mock of Manager interface.
+type MockManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockManagerMockRecorder
+}
+
+// MockManagerMockRecorder is the mock recorder for MockManager.
+type MockManagerMockRecorder struct {
+	mock *MockManager
+}
+
+// NewMockManager creates a new mock instance.
+func NewMockManager(ctrl *gomock.Controller) *MockManager {
+	mock := &MockManager{ctrl: ctrl}
+	mock.recorder = &MockManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockManager) EXPECT() *MockManagerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockManager) Delete(arg0 context.Context, arg1 *api.RegistryProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockManagerMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockManager)(nil).Delete), arg0, arg1)
+}
+
+// EnsureTokenAndPassword mocks base method.
+func (m *MockManager) EnsureTokenAndPassword(arg0 context.Context, arg1 *api.RegistryProfile) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureTokenAndPassword", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureTokenAndPassword indicates an expected call of EnsureTokenAndPassword.
+func (mr *MockManagerMockRecorder) EnsureTokenAndPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureTokenAndPassword", reflect.TypeOf((*MockManager)(nil).EnsureTokenAndPassword), arg0, arg1)
+}
+
+// GetRegistryProfile mocks base method.
+func (m *MockManager) GetRegistryProfile(arg0 *api.OpenShiftCluster) *api.RegistryProfile {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegistryProfile", arg0)
+	ret0, _ := ret[0].(*api.RegistryProfile)
+	return ret0
+}
+
+// GetRegistryProfile indicates an expected call of GetRegistryProfile.
+func (mr *MockManagerMockRecorder) GetRegistryProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistryProfile", reflect.TypeOf((*MockManager)(nil).GetRegistryProfile), arg0)
+}
+
+// NewRegistryProfile mocks base method.
+func (m *MockManager) NewRegistryProfile(arg0 *api.OpenShiftCluster) *api.RegistryProfile {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewRegistryProfile", arg0)
+	ret0, _ := ret[0].(*api.RegistryProfile)
+	return ret0
+}
+
+// NewRegistryProfile indicates an expected call of NewRegistryProfile.
+func (mr *MockManagerMockRecorder) NewRegistryProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRegistryProfile", reflect.TypeOf((*MockManager)(nil).NewRegistryProfile), arg0)
+}
+
+// PutRegistryProfile mocks base method.
+func (m *MockManager) PutRegistryProfile(arg0 *api.OpenShiftCluster, arg1 *api.RegistryProfile) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PutRegistryProfile", arg0, arg1)
+}
+
+// PutRegistryProfile indicates an expected call of PutRegistryProfile.
+func (mr *MockManagerMockRecorder) PutRegistryProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRegistryProfile", reflect.TypeOf((*MockManager)(nil).PutRegistryProfile), arg0, arg1)
+}
+
+// RotateTokenPassword mocks base method.
+func (m *MockManager) RotateTokenPassword(arg0 context.Context, arg1 *api.RegistryProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateTokenPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateTokenPassword indicates an expected call of RotateTokenPassword.
+func (mr *MockManagerMockRecorder) RotateTokenPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateTokenPassword", reflect.TypeOf((*MockManager)(nil).RotateTokenPassword), arg0, arg1)
+}
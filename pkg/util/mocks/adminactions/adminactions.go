@@ -12,12 +12,17 @@ import (
 
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	features "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	storage "github.com/Azure/azure-sdk-for-go/storage"
 	gomock "github.com/golang/mock/gomock"
 	logrus "github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	watch "k8s.io/apimachinery/pkg/watch"
+
+	clusterdelete "github.com/Azure/ARO-RP/pkg/util/clusterdelete"
+	diagnostics "github.com/Azure/ARO-RP/pkg/util/diagnostics"
+	dns "github.com/Azure/ARO-RP/pkg/util/dns"
 )
 
 // MockKubeActions is a mock of KubeActions interface.
@@ -71,6 +76,20 @@ func (mr *MockKubeActionsMockRecorder) ApproveCsr(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveCsr", reflect.TypeOf((*MockKubeActions)(nil).ApproveCsr), arg0, arg1)
 }
 
+// CollectDiagnostics mocks base method.
+func (m *MockKubeActions) CollectDiagnostics(arg0 context.Context, arg1 *diagnostics.Bundle, arg2 *diagnostics.Options) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectDiagnostics", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CollectDiagnostics indicates an expected call of CollectDiagnostics.
+func (mr *MockKubeActionsMockRecorder) CollectDiagnostics(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectDiagnostics", reflect.TypeOf((*MockKubeActions)(nil).CollectDiagnostics), arg0, arg1, arg2)
+}
+
 // CordonNode mocks base method.
 func (m *MockKubeActions) CordonNode(arg0 context.Context, arg1 string, arg2 bool) error {
 	m.ctrl.T.Helper()
@@ -255,6 +274,124 @@ func (mr *MockAzureActionsMockRecorder) AppLensListDetectors(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppLensListDetectors", reflect.TypeOf((*MockAzureActions)(nil).AppLensListDetectors), arg0)
 }
 
+// DNSRecordDrift mocks base method.
+func (m *MockAzureActions) DNSRecordDrift(arg0 context.Context) ([]dns.RecordDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DNSRecordDrift", arg0)
+	ret0, _ := ret[0].([]dns.RecordDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DNSRecordDrift indicates an expected call of DNSRecordDrift.
+func (mr *MockAzureActionsMockRecorder) DNSRecordDrift(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DNSRecordDrift", reflect.TypeOf((*MockAzureActions)(nil).DNSRecordDrift), arg0)
+}
+
+// DNSRepair mocks base method.
+func (m *MockAzureActions) DNSRepair(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DNSRepair", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DNSRepair indicates an expected call of DNSRepair.
+func (mr *MockAzureActionsMockRecorder) DNSRepair(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DNSRepair", reflect.TypeOf((*MockAzureActions)(nil).DNSRepair), arg0)
+}
+
+// DeletePreview mocks base method.
+func (m *MockAzureActions) DeletePreview(arg0 context.Context, arg1 string) ([]clusterdelete.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePreview", arg0, arg1)
+	ret0, _ := ret[0].([]clusterdelete.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePreview indicates an expected call of DeletePreview.
+func (mr *MockAzureActionsMockRecorder) DeletePreview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreview", reflect.TypeOf((*MockAzureActions)(nil).DeletePreview), arg0, arg1)
+}
+
+// DiagnosticBundleGet mocks base method.
+func (m *MockAzureActions) DiagnosticBundleGet(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiagnosticBundleGet", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiagnosticBundleGet indicates an expected call of DiagnosticBundleGet.
+func (mr *MockAzureActionsMockRecorder) DiagnosticBundleGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiagnosticBundleGet", reflect.TypeOf((*MockAzureActions)(nil).DiagnosticBundleGet), arg0, arg1)
+}
+
+// DiagnosticBundleList mocks base method.
+func (m *MockAzureActions) DiagnosticBundleList(arg0 context.Context) ([]storage.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiagnosticBundleList", arg0)
+	ret0, _ := ret[0].([]storage.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiagnosticBundleList indicates an expected call of DiagnosticBundleList.
+func (mr *MockAzureActionsMockRecorder) DiagnosticBundleList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiagnosticBundleList", reflect.TypeOf((*MockAzureActions)(nil).DiagnosticBundleList), arg0)
+}
+
+// DiagnosticBundleUpload mocks base method.
+func (m *MockAzureActions) DiagnosticBundleUpload(arg0 context.Context, arg1 string, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiagnosticBundleUpload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiagnosticBundleUpload indicates an expected call of DiagnosticBundleUpload.
+func (mr *MockAzureActionsMockRecorder) DiagnosticBundleUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiagnosticBundleUpload", reflect.TypeOf((*MockAzureActions)(nil).DiagnosticBundleUpload), arg0, arg1, arg2)
+}
+
+// EtcdSnapshotList mocks base method.
+func (m *MockAzureActions) EtcdSnapshotList(arg0 context.Context) ([]storage.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EtcdSnapshotList", arg0)
+	ret0, _ := ret[0].([]storage.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EtcdSnapshotList indicates an expected call of EtcdSnapshotList.
+func (mr *MockAzureActionsMockRecorder) EtcdSnapshotList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EtcdSnapshotList", reflect.TypeOf((*MockAzureActions)(nil).EtcdSnapshotList), arg0)
+}
+
+// EtcdSnapshotURL mocks base method.
+func (m *MockAzureActions) EtcdSnapshotURL(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EtcdSnapshotURL", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EtcdSnapshotURL indicates an expected call of EtcdSnapshotURL.
+func (mr *MockAzureActionsMockRecorder) EtcdSnapshotURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EtcdSnapshotURL", reflect.TypeOf((*MockAzureActions)(nil).EtcdSnapshotURL), arg0, arg1)
+}
+
 // GroupResourceList mocks base method.
 func (m *MockAzureActions) GroupResourceList(arg0 context.Context) ([]features.GenericResourceExpanded, error) {
 	m.ctrl.T.Helper()
@@ -412,6 +549,20 @@ func (mr *MockAzureActionsMockRecorder) VMStopAndWait(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VMStopAndWait", reflect.TypeOf((*MockAzureActions)(nil).VMStopAndWait), arg0, arg1, arg2)
 }
 
+// ValidateNSGFlowLogs mocks base method.
+func (m *MockAzureActions) ValidateNSGFlowLogs(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateNSGFlowLogs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateNSGFlowLogs indicates an expected call of ValidateNSGFlowLogs.
+func (mr *MockAzureActionsMockRecorder) ValidateNSGFlowLogs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateNSGFlowLogs", reflect.TypeOf((*MockAzureActions)(nil).ValidateNSGFlowLogs), arg0, arg1, arg2)
+}
+
 // WriteToStream mocks base method.
 func (m *MockAzureActions) WriteToStream(arg0 context.Context, arg1 io.WriteCloser) error {
 	m.ctrl.T.Helper()
@@ -81,6 +81,21 @@ func (mr *MockRecordSetsClientMockRecorder) Get(arg0, arg1, arg2, arg3, arg4 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRecordSetsClient)(nil).Get), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ListByType mocks base method.
+func (m *MockRecordSetsClient) ListByType(arg0 context.Context, arg1, arg2 string, arg3 dns.RecordType) ([]dns.RecordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByType", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]dns.RecordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByType indicates an expected call of ListByType.
+func (mr *MockRecordSetsClientMockRecorder) ListByType(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByType", reflect.TypeOf((*MockRecordSetsClient)(nil).ListByType), arg0, arg1, arg2, arg3)
+}
+
 // MockZonesClient is a mock of ZonesClient interface.
 type MockZonesClient struct {
 	ctrl     *gomock.Controller
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	api "github.com/Azure/ARO-RP/pkg/api"
+	dns "github.com/Azure/ARO-RP/pkg/util/dns"
 )
 
 // MockManager is a mock of Manager interface.
@@ -36,6 +37,21 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// CheckDrift mocks base method.
+func (m *MockManager) CheckDrift(arg0 context.Context, arg1 *api.OpenShiftCluster) ([]dns.RecordDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDrift", arg0, arg1)
+	ret0, _ := ret[0].([]dns.RecordDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDrift indicates an expected call of CheckDrift.
+func (mr *MockManagerMockRecorder) CheckDrift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDrift", reflect.TypeOf((*MockManager)(nil).CheckDrift), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockManager) Create(arg0 context.Context, arg1 *api.OpenShiftCluster) error {
 	m.ctrl.T.Helper()
@@ -78,6 +94,50 @@ func (mr *MockManagerMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockManager)(nil).Delete), arg0, arg1)
 }
 
+// ListDangling mocks base method.
+func (m *MockManager) ListDangling(arg0 context.Context, arg1 func(context.Context, string) (bool, error)) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDangling", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDangling indicates an expected call of ListDangling.
+func (mr *MockManagerMockRecorder) ListDangling(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDangling", reflect.TypeOf((*MockManager)(nil).ListDangling), arg0, arg1)
+}
+
+// ListRecords mocks base method.
+func (m *MockManager) ListRecords(arg0 context.Context, arg1 *api.OpenShiftCluster) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecords", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecords indicates an expected call of ListRecords.
+func (mr *MockManagerMockRecorder) ListRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecords", reflect.TypeOf((*MockManager)(nil).ListRecords), arg0, arg1)
+}
+
+// Repair mocks base method.
+func (m *MockManager) Repair(arg0 context.Context, arg1 *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repair", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Repair indicates an expected call of Repair.
+func (mr *MockManagerMockRecorder) Repair(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockManager)(nil).Repair), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockManager) Update(arg0 context.Context, arg1 *api.OpenShiftCluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Azure/ARO-RP/pkg/util/encryption (interfaces: AEAD)
+// Source: github.com/Azure/ARO-RP/pkg/util/encryption (interfaces: AEAD,Resealer)
 
 // Package mock_encryption is a generated GoMock package.
 package mock_encryption
@@ -62,3 +62,70 @@ func (mr *MockAEADMockRecorder) Seal(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*MockAEAD)(nil).Seal), arg0)
 }
+
+// MockResealer is a mock of Resealer interface.
+type MockResealer struct {
+	ctrl     *gomock.Controller
+	recorder *MockResealerMockRecorder
+}
+
+// MockResealerMockRecorder is the mock recorder for MockResealer.
+type MockResealerMockRecorder struct {
+	mock *MockResealer
+}
+
+// NewMockResealer creates a new mock instance.
+func NewMockResealer(ctrl *gomock.Controller) *MockResealer {
+	mock := &MockResealer{ctrl: ctrl}
+	mock.recorder = &MockResealerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResealer) EXPECT() *MockResealerMockRecorder {
+	return m.recorder
+}
+
+// NeedsReseal mocks base method.
+func (m *MockResealer) NeedsReseal(arg0 []byte) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsReseal", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsReseal indicates an expected call of NeedsReseal.
+func (mr *MockResealerMockRecorder) NeedsReseal(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsReseal", reflect.TypeOf((*MockResealer)(nil).NeedsReseal), arg0)
+}
+
+// Open mocks base method.
+func (m *MockResealer) Open(arg0 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockResealerMockRecorder) Open(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockResealer)(nil).Open), arg0)
+}
+
+// Seal mocks base method.
+func (m *MockResealer) Seal(arg0 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seal", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seal indicates an expected call of Seal.
+func (mr *MockResealerMockRecorder) Seal(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*MockResealer)(nil).Seal), arg0)
+}
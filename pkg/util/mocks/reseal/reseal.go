@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Azure/ARO-RP/pkg/util/reseal (interfaces: Sweeper)
+
+// Package mock_reseal is a generated GoMock package.
+package mock_reseal
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	reseal "github.com/Azure/ARO-RP/pkg/util/reseal"
+)
+
+// MockSweeper is a mock of Sweeper interface.
+type MockSweeper struct {
+	ctrl     *gomock.Controller
+	recorder *MockSweeperMockRecorder
+}
+
+// MockSweeperMockRecorder is the mock recorder for MockSweeper.
+type MockSweeperMockRecorder struct {
+	mock *MockSweeper
+}
+
+// NewMockSweeper creates a new mock instance.
+func NewMockSweeper(ctrl *gomock.Controller) *MockSweeper {
+	mock := &MockSweeper{ctrl: ctrl}
+	mock.recorder = &MockSweeperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSweeper) EXPECT() *MockSweeperMockRecorder {
+	return m.recorder
+}
+
+// Sweep mocks base method.
+func (m *MockSweeper) Sweep(arg0 context.Context, arg1 bool) (*reseal.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sweep", arg0, arg1)
+	ret0, _ := ret[0].(*reseal.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sweep indicates an expected call of Sweep.
+func (mr *MockSweeperMockRecorder) Sweep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sweep", reflect.TypeOf((*MockSweeper)(nil).Sweep), arg0, arg1)
+}
@@ -36,6 +36,21 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// BlobSASURL mocks base method.
+func (m *MockManager) BlobSASURL(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 storage.Permissions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlobSASURL", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlobSASURL indicates an expected call of BlobSASURL.
+func (mr *MockManagerMockRecorder) BlobSASURL(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlobSASURL", reflect.TypeOf((*MockManager)(nil).BlobSASURL), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // BlobService mocks base method.
 func (m *MockManager) BlobService(arg0 context.Context, arg1, arg2 string, arg3 storage.Permissions, arg4 storage.SignedResourceTypes) (*storage0.BlobStorageClient, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,199 @@
+package reseal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+)
+
+// CollectionReport is the result of sweeping a single Cosmos collection
+type CollectionReport struct {
+	Collection string   `json:"collection"`
+	Documents  int      `json:"documents"`
+	Stale      int      `json:"stale"`
+	Resealed   int      `json:"resealed"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// Report is the result of a reseal sweep
+type Report struct {
+	StartTime   time.Time           `json:"startTime"`
+	EndTime     time.Time           `json:"endTime"`
+	Collections []*CollectionReport `json:"collections"`
+}
+
+// Stale returns the number of documents found with fields needing resealing
+func (r *Report) Stale() int {
+	var stale int
+	for _, c := range r.Collections {
+		stale += c.Stale
+	}
+	return stale
+}
+
+// Sweeper finds, and optionally reseals, documents with secure fields which
+// were not sealed with the current encryption key.  Once a sweep has resealed
+// every document, keys other than the current one can be retired.
+//
+// Only the OpenShiftClusters, AsyncOperations and ClusterManagerConfigurations
+// collections hold secure fields; the other collections are not swept.
+type Sweeper interface {
+	Sweep(ctx context.Context, fix bool) (*Report, error)
+}
+
+type sweeper struct {
+	log     *logrus.Entry
+	tracker database.ResealTracker
+
+	dbOpenShiftClusters            database.OpenShiftClusters
+	dbAsyncOperations              database.AsyncOperations
+	dbClusterManagerConfigurations database.ClusterManagerConfigurations
+
+	now func() time.Time
+}
+
+// NewSweeper returns a new Sweeper.  The databases must use a client created
+// with tracker, and must not be used by anything else while sweeping.
+func NewSweeper(log *logrus.Entry, tracker database.ResealTracker, dbOpenShiftClusters database.OpenShiftClusters, dbAsyncOperations database.AsyncOperations, dbClusterManagerConfigurations database.ClusterManagerConfigurations) Sweeper {
+	return &sweeper{
+		log:     log,
+		tracker: tracker,
+
+		dbOpenShiftClusters:            dbOpenShiftClusters,
+		dbAsyncOperations:              dbAsyncOperations,
+		dbClusterManagerConfigurations: dbClusterManagerConfigurations,
+
+		now: time.Now,
+	}
+}
+
+func (s *sweeper) Sweep(ctx context.Context, fix bool) (*Report, error) {
+	report := &Report{
+		StartTime: s.now(),
+	}
+
+	for _, f := range []func(context.Context, bool) (*CollectionReport, error){
+		s.sweepOpenShiftClusters,
+		s.sweepAsyncOperations,
+		s.sweepClusterManagerConfigurations,
+	} {
+		r, err := f(ctx, fix)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Collections = append(report.Collections, r)
+	}
+
+	report.EndTime = s.now()
+
+	return report, nil
+}
+
+// Documents are read one at a time so that the tracker's state can be
+// attributed to a single document.  Resealing a document simply rewrites it:
+// the database client always seals with the current key.
+
+func (s *sweeper) sweepOpenShiftClusters(ctx context.Context, fix bool) (*CollectionReport, error) {
+	r := &CollectionReport{Collection: "OpenShiftClusters"}
+
+	i := s.dbOpenShiftClusters.List("")
+	for {
+		s.tracker.Reset()
+
+		docs, err := i.Next(ctx, 1)
+		if err != nil {
+			return nil, err
+		}
+		if docs == nil {
+			return r, nil
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			s.check(r, doc.ID, fix, func() error {
+				_, err := s.dbOpenShiftClusters.Patch(ctx, doc.Key, func(*api.OpenShiftClusterDocument) error { return nil })
+				return err
+			})
+		}
+	}
+}
+
+func (s *sweeper) sweepAsyncOperations(ctx context.Context, fix bool) (*CollectionReport, error) {
+	r := &CollectionReport{Collection: "AsyncOperations"}
+
+	i := s.dbAsyncOperations.List("")
+	for {
+		s.tracker.Reset()
+
+		docs, err := i.Next(ctx, 1)
+		if err != nil {
+			return nil, err
+		}
+		if docs == nil {
+			return r, nil
+		}
+
+		for _, doc := range docs.AsyncOperationDocuments {
+			s.check(r, doc.ID, fix, func() error {
+				_, err := s.dbAsyncOperations.Patch(ctx, doc.ID, func(*api.AsyncOperationDocument) error { return nil })
+				return err
+			})
+		}
+	}
+}
+
+func (s *sweeper) sweepClusterManagerConfigurations(ctx context.Context, fix bool) (*CollectionReport, error) {
+	r := &CollectionReport{Collection: "ClusterManagerConfigurations"}
+
+	i := s.dbClusterManagerConfigurations.List("")
+	for {
+		s.tracker.Reset()
+
+		docs, err := i.Next(ctx, 1)
+		if err != nil {
+			return nil, err
+		}
+		if docs == nil {
+			return r, nil
+		}
+
+		for _, doc := range docs.ClusterManagerConfigurationDocuments {
+			s.check(r, doc.ID, fix, func() error {
+				_, err := s.dbClusterManagerConfigurations.Update(ctx, doc)
+				return err
+			})
+		}
+	}
+}
+
+// check records a document which has just been read, resealing it if needed
+// and fix is set.  Errors resealing a document are reported rather than
+// stopping the sweep.
+func (s *sweeper) check(r *CollectionReport, id string, fix bool, reseal func() error) {
+	r.Documents++
+
+	if !s.tracker.Stale() {
+		return
+	}
+
+	r.Stale++
+	if !fix {
+		return
+	}
+
+	err := reseal()
+	if err != nil {
+		s.log.Errorf("resealing %s document %s: %s", r.Collection, id, err)
+		r.Errors = append(r.Errors, id+": "+err.Error())
+		return
+	}
+
+	r.Resealed++
+}
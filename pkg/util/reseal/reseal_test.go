@@ -0,0 +1,150 @@
+package reseal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+// fakeTracker reports the documents read in the given positions as stale
+type fakeTracker struct {
+	encryption.AEAD
+
+	reads int
+	stale map[int]bool
+}
+
+func (t *fakeTracker) Reset() {
+	t.reads++
+}
+
+func (t *fakeTracker) Stale() bool {
+	return t.stale[t.reads]
+}
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+
+	clusterID := func(name string) string {
+		return "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/" + name
+	}
+
+	for _, tt := range []struct {
+		name  string
+		fix   bool
+		stale map[int]bool
+		want  []*CollectionReport
+	}{
+		{
+			name: "nothing stale",
+			fix:  true,
+			want: []*CollectionReport{
+				{Collection: "OpenShiftClusters", Documents: 2},
+				{Collection: "AsyncOperations", Documents: 1},
+				{Collection: "ClusterManagerConfigurations", Documents: 1},
+			},
+		},
+		{
+			// documents are read in order, with an extra read at the end of
+			// each collection
+			name:  "report only",
+			stale: map[int]bool{2: true, 4: true, 6: true},
+			want: []*CollectionReport{
+				{Collection: "OpenShiftClusters", Documents: 2, Stale: 1},
+				{Collection: "AsyncOperations", Documents: 1, Stale: 1},
+				{Collection: "ClusterManagerConfigurations", Documents: 1, Stale: 1},
+			},
+		},
+		{
+			name:  "fix",
+			fix:   true,
+			stale: map[int]bool{1: true, 2: true, 6: true},
+			want: []*CollectionReport{
+				{Collection: "OpenShiftClusters", Documents: 2, Stale: 2, Resealed: 2},
+				{Collection: "AsyncOperations", Documents: 1},
+				{Collection: "ClusterManagerConfigurations", Documents: 1, Stale: 1, Resealed: 1},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dbOpenShiftClusters, clusterClient := testdatabase.NewFakeOpenShiftClusters()
+			dbAsyncOperations, _ := testdatabase.NewFakeAsyncOperations()
+			dbClusterManagerConfigurations, _ := testdatabase.NewFakeClusterManager()
+
+			clusterClient.SetSorter(func(docs []*api.OpenShiftClusterDocument) {
+				sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+			})
+
+			fixture := testdatabase.NewFixture().
+				WithOpenShiftClusters(dbOpenShiftClusters).
+				WithAsyncOperations(dbAsyncOperations).
+				WithClusterManagerConfigurations(dbClusterManagerConfigurations)
+
+			for _, name := range []string{"a", "b"} {
+				fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					ID:  name,
+					Key: clusterID(name),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: clusterID(name),
+						Properties: api.OpenShiftClusterProperties{
+							KubeadminPassword: "password",
+						},
+					},
+				})
+			}
+			fixture.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+				ID: "operation",
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						KubeadminPassword: "password",
+					},
+				},
+			})
+			fixture.AddClusterManagerConfigurationDocuments(&api.ClusterManagerConfigurationDocument{
+				ID:  "configuration",
+				Key: clusterID("a") + "/syncsets/syncset",
+				SyncSet: &api.SyncSet{
+					Name: "syncset",
+				},
+			})
+
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := &sweeper{
+				log:     logrus.NewEntry(logrus.StandardLogger()),
+				tracker: &fakeTracker{AEAD: testdatabase.NewFakeAEAD(), stale: tt.stale},
+
+				dbOpenShiftClusters:            dbOpenShiftClusters,
+				dbAsyncOperations:              dbAsyncOperations,
+				dbClusterManagerConfigurations: dbClusterManagerConfigurations,
+
+				now: func() time.Time { return time.Unix(0, 0) },
+			}
+
+			report, err := s.Sweep(ctx, tt.fix)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(report.Collections, tt.want) {
+				for _, c := range report.Collections {
+					t.Errorf("%#v", c)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package steps
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// ProgressFunc is called by RunWithProgress before each step is run with the
+// index of the step
+type ProgressFunc func(ctx context.Context, i int, step Step)
+
+// Name returns the short name of a step, e.g. "ensureResourceGroup"
+func Name(step Step) string {
+	name := step.metricsName()
+	if i := strings.IndexByte(name, '.'); i != -1 {
+		name = name[i+1:]
+	}
+
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// PercentComplete estimates the percentage of the time needed to run steps
+// which has passed once the first completed steps have run.  durations holds
+// the typical durations of steps by Name.  Steps without a typical duration are
+// taken to last the mean duration of the steps which have one; if none has,
+// every step is taken to last equally long.
+func PercentComplete(steps []Step, completed int, durations map[string]time.Duration) int {
+	if len(steps) == 0 {
+		return 0
+	}
+	if completed >= len(steps) {
+		return 100
+	}
+
+	var known, total time.Duration
+	for _, step := range steps {
+		if d, ok := durations[Name(step)]; ok {
+			known++
+			total += d
+		}
+	}
+
+	if total == 0 {
+		return completed * 100 / len(steps)
+	}
+
+	mean := total / known
+
+	var done, all time.Duration
+	for i, step := range steps {
+		d, ok := durations[Name(step)]
+		if !ok {
+			d = mean
+		}
+
+		all += d
+		if i < completed {
+			done += d
+		}
+	}
+
+	return int(done * 100 / all)
+}
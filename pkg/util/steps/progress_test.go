@@ -0,0 +1,123 @@
+package steps
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestName(t *testing.T) {
+	for _, tt := range []struct {
+		step Step
+		want string
+	}{
+		{
+			step: Action(successfulFunc),
+			want: "successfulFunc",
+		},
+		{
+			step: Condition(alwaysTrueCondition, time.Second, true),
+			want: "alwaysTrueCondition",
+		},
+	} {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Name(tt.step); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestPercentComplete(t *testing.T) {
+	s := []Step{
+		Action(successfulFunc),
+		Condition(alwaysTrueCondition, time.Second, true),
+		Action(failingFunc),
+	}
+
+	for _, tt := range []struct {
+		name      string
+		steps     []Step
+		completed int
+		durations map[string]time.Duration
+		want      int
+	}{
+		{
+			name: "no steps",
+		},
+		{
+			name:  "no steps completed",
+			steps: s,
+		},
+		{
+			name:      "some steps completed without durations",
+			steps:     s,
+			completed: 2,
+			want:      66,
+		},
+		{
+			name:      "some steps completed with durations",
+			steps:     s,
+			completed: 1,
+			durations: map[string]time.Duration{
+				"successfulFunc":      time.Minute,
+				"alwaysTrueCondition": 2 * time.Minute,
+				"failingFunc":         time.Minute,
+			},
+			want: 25,
+		},
+		{
+			name:      "steps without durations take the mean duration",
+			steps:     s,
+			completed: 2,
+			durations: map[string]time.Duration{
+				"successfulFunc":      time.Minute,
+				"alwaysTrueCondition": 3 * time.Minute,
+			},
+			want: 66,
+		},
+		{
+			name:      "all steps completed",
+			steps:     s[:2],
+			completed: 2,
+			want:      100,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PercentComplete(tt.steps, tt.completed, tt.durations)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestRunWithProgress(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+
+	s := []Step{
+		Action(successfulFunc),
+		Condition(alwaysTrueCondition, time.Second, true),
+		Action(failingFunc),
+	}
+
+	var got []string
+
+	_, err := RunWithProgress(ctx, log, time.Millisecond, s, nil, func(ctx context.Context, i int, step Step) {
+		got = append(got, Name(step))
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if !reflect.DeepEqual(got, []string{"successfulFunc", "alwaysTrueCondition", "failingFunc"}) {
+		t.Error(got)
+	}
+}
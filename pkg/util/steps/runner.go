@@ -45,8 +45,18 @@ type Step interface {
 // are completed. Errors from failed steps are returned directly.
 // time cost for each step run will be recorded for metrics usage
 func Run(ctx context.Context, log *logrus.Entry, pollInterval time.Duration, steps []Step, now func() time.Time) (map[string]int64, error) {
+	return RunWithProgress(ctx, log, pollInterval, steps, now, nil)
+}
+
+// RunWithProgress executes the provided steps like Run.  If progress is not
+// nil, it is called before each step is run.
+func RunWithProgress(ctx context.Context, log *logrus.Entry, pollInterval time.Duration, steps []Step, now func() time.Time, progress ProgressFunc) (map[string]int64, error) {
 	stepTimeRun := make(map[string]int64)
-	for _, step := range steps {
+	for i, step := range steps {
+		if progress != nil {
+			progress(ctx, i, step)
+		}
+
 		log.Infof("running step %s", step)
 
 		startTime := time.Now()
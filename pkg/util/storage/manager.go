@@ -19,6 +19,7 @@ import (
 
 type Manager interface {
 	BlobService(ctx context.Context, resourceGroup, account string, p mgmtstorage.Permissions, r mgmtstorage.SignedResourceTypes) (*azstorage.BlobStorageClient, error)
+	BlobSASURL(ctx context.Context, resourceGroup, account, container, blob string, p mgmtstorage.Permissions) (string, error)
 }
 
 type manager struct {
@@ -34,6 +35,36 @@ func NewManager(env env.Core, subscriptionID string, authorizer autorest.Authori
 }
 
 func (m *manager) BlobService(ctx context.Context, resourceGroup, account string, p mgmtstorage.Permissions, r mgmtstorage.SignedResourceTypes) (*azstorage.BlobStorageClient, error) {
+	v, err := m.accountSAS(ctx, resourceGroup, account, p, r)
+	if err != nil {
+		return nil, err
+	}
+
+	blobcli := azstorage.NewAccountSASClient(account, v, (*m.env.Environment()).Environment).GetBlobService()
+
+	return &blobcli, nil
+}
+
+// BlobSASURL returns a URL with which the given blob can be accessed with the
+// given permissions, without further credentials, for the next 24 hours
+func (m *manager) BlobSASURL(ctx context.Context, resourceGroup, account, container, blob string, p mgmtstorage.Permissions) (string, error) {
+	v, err := m.accountSAS(ctx, resourceGroup, account, p, mgmtstorage.SignedResourceTypesO)
+	if err != nil {
+		return "", err
+	}
+
+	blobcli := azstorage.NewAccountSASClient(account, v, (*m.env.Environment()).Environment).GetBlobService()
+
+	u, err := url.Parse(blobcli.GetContainerReference(container).GetBlobReference(blob).GetURL())
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = v.Encode()
+
+	return u.String(), nil
+}
+
+func (m *manager) accountSAS(ctx context.Context, resourceGroup, account string, p mgmtstorage.Permissions, r mgmtstorage.SignedResourceTypes) (url.Values, error) {
 	t := time.Now().UTC().Truncate(time.Second)
 	res, err := m.storageAccounts.ListAccountSAS(ctx, resourceGroup, account, mgmtstorage.AccountSasParameters{
 		Services:               mgmtstorage.B,
@@ -47,12 +78,5 @@ func (m *manager) BlobService(ctx context.Context, resourceGroup, account string
 		return nil, err
 	}
 
-	v, err := url.ParseQuery(*res.AccountSasToken)
-	if err != nil {
-		return nil, err
-	}
-
-	blobcli := azstorage.NewAccountSASClient(account, v, (*m.env.Environment()).Environment).GetBlobService()
-
-	return &blobcli, nil
+	return url.ParseQuery(*res.AccountSasToken)
 }
@@ -4,6 +4,8 @@ package version
 // Licensed under the Apache License 2.0.
 
 import (
+	"github.com/coreos/go-semver/semver"
+
 	"github.com/Azure/ARO-RP/pkg/api"
 )
 
@@ -25,6 +27,21 @@ const (
 
 var GitCommit = "unknown"
 
+// OCP versions older than this will not receive ARO operator updates
+const OperatorCutoffVersion = "4.7.0"
+
+// OperatorSupported returns true if a cluster running OCP version vsn
+// receives ARO operator updates
+func OperatorSupported(vsn string) bool {
+	runningVersion, err := semver.NewVersion(vsn)
+	if err != nil {
+		return false
+	}
+
+	cutoffVersion := semver.New(OperatorCutoffVersion)
+	return cutoffVersion.Compare(*runningVersion) <= 0
+}
+
 type Stream struct {
 	Version  *Version `json:"version"`
 	PullSpec string   `json:"-"`
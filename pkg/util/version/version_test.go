@@ -141,3 +141,35 @@ func TestEq(t *testing.T) {
 		})
 	}
 }
+
+func TestOperatorSupported(t *testing.T) {
+	for _, tt := range []struct {
+		vsn  string
+		want bool
+	}{
+		{
+			vsn:  "4.7.0",
+			want: true,
+		},
+		{
+			vsn:  "4.12.25",
+			want: true,
+		},
+		{
+			vsn: "4.6.62",
+		},
+		{
+			vsn: "4.7.0-rc.1",
+		},
+		{
+			vsn: "invalid",
+		},
+	} {
+		t.Run(tt.vsn, func(t *testing.T) {
+			got := OperatorSupported(tt.vsn)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
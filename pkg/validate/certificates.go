@@ -0,0 +1,73 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	keyvaultclient "github.com/Azure/ARO-RP/pkg/util/azureclient/keyvault"
+	utilcert "github.com/Azure/ARO-RP/pkg/util/cert"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+)
+
+// validateCertificates checks the customer supplied serving certificates, if
+// any.  kv must read the customer's key vault as the cluster service
+// principal, which is what the cluster manager will use.
+func validateCertificates(ctx context.Context, kv keyvaultclient.BaseClient, oc *api.OpenShiftCluster, now time.Time) error {
+	domain := oc.Properties.ClusterProfile.Domain
+
+	refs := []struct {
+		path     string
+		secretID string
+		hostname string
+	}{
+		{
+			path:     "properties.apiserverProfile.certificateSecretId",
+			secretID: oc.Properties.APIServerProfile.CertificateSecretID,
+			hostname: "api." + domain,
+		},
+	}
+	for _, p := range oc.Properties.IngressProfiles {
+		refs = append(refs, struct {
+			path     string
+			secretID string
+			hostname string
+		}{
+			path:     "properties.ingressProfiles['" + p.Name + "'].certificateSecretId",
+			secretID: p.CertificateSecretID,
+			hostname: "*.apps." + domain,
+		})
+	}
+
+	for _, ref := range refs {
+		if ref.secretID == "" {
+			continue
+		}
+
+		key, certs, _, err := keyvault.GetCertificateSecretByID(ctx, kv, ref.secretID)
+		if detailedErr, ok := err.(autorest.DetailedError); ok {
+			switch detailedErr.StatusCode {
+			case http.StatusForbidden:
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidServicePrincipalPermissions, ref.path, "The cluster service principal does not have permission to read the secret '%s'.", ref.secretID)
+			case http.StatusNotFound:
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, ref.path, "The secret '%s' could not be found.", ref.secretID)
+			}
+			return err
+		} else if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, ref.path, "The secret '%s' does not hold a PEM encoded certificate and private key.", ref.secretID)
+		}
+
+		err = utilcert.ValidateServingCertificate(key, certs, ref.hostname, now, utilcert.ServingCertificateMinimumValidity)
+		if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, ref.path, "The certificate in the secret '%s' is invalid: %s.", ref.secretID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/keyvault"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateCertificates(t *testing.T) {
+	ctx := context.Background()
+
+	secretID := "https://myvault.vault.azure.net/secrets/mycert"
+
+	certificateSecret := func(commonName string) *string {
+		key, certs, err := utiltls.GenerateKeyAndCertificate(commonName, nil, nil, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := utilpem.Encode(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := utilpem.Encode(certs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return to.StringPtr(string(append(b, c...)))
+	}
+
+	for _, tt := range []struct {
+		name    string
+		modify  func(*api.OpenShiftCluster)
+		mocks   func(*mock_keyvault.MockBaseClient)
+		wantErr string
+	}{
+		{
+			name: "no customer certificates",
+		},
+		{
+			name: "valid apiserver certificate",
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.APIServerProfile.CertificateSecretID = secretID
+			},
+			mocks: func(kv *mock_keyvault.MockBaseClient) {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{ID: to.StringPtr(secretID + "/0123456789abcdef0123456789abcdef"), Value: certificateSecret("api.cluster.example.com")}, nil)
+			},
+		},
+		{
+			name: "ingress certificate for the wrong hostname",
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].CertificateSecretID = secretID
+			},
+			mocks: func(kv *mock_keyvault.MockBaseClient) {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{ID: to.StringPtr(secretID + "/0123456789abcdef0123456789abcdef"), Value: certificateSecret("api.cluster.example.com")}, nil)
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].certificateSecretId: The certificate in the secret '" + secretID + "' is invalid: the certificate is not valid for *.apps.cluster.example.com.",
+		},
+		{
+			name: "secret not PEM encoded",
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.APIServerProfile.CertificateSecretID = secretID
+			},
+			mocks: func(kv *mock_keyvault.MockBaseClient) {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{ID: to.StringPtr(secretID + "/0123456789abcdef0123456789abcdef"), Value: to.StringPtr("MIIKPAIBAzCCCfYGCSqGSIb3DQEHAa")}, nil)
+			},
+			wantErr: "400: InvalidParameter: properties.apiserverProfile.certificateSecretId: The secret '" + secretID + "' does not hold a PEM encoded certificate and private key.",
+		},
+		{
+			name: "secret forbidden",
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.APIServerProfile.CertificateSecretID = secretID
+			},
+			mocks: func(kv *mock_keyvault.MockBaseClient) {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{}, autorest.DetailedError{StatusCode: http.StatusForbidden})
+			},
+			wantErr: "400: InvalidServicePrincipalPermissions: properties.apiserverProfile.certificateSecretId: The cluster service principal does not have permission to read the secret '" + secretID + "'.",
+		},
+		{
+			name: "secret not found",
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.APIServerProfile.CertificateSecretID = secretID
+			},
+			mocks: func(kv *mock_keyvault.MockBaseClient) {
+				kv.EXPECT().GetSecret(gomock.Any(), "https://myvault.vault.azure.net/", "mycert", "").
+					Return(azkeyvault.SecretBundle{}, autorest.DetailedError{StatusCode: http.StatusNotFound})
+			},
+			wantErr: "400: InvalidParameter: properties.apiserverProfile.certificateSecretId: The secret '" + secretID + "' could not be found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			kv := mock_keyvault.NewMockBaseClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(kv)
+			}
+
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Domain: "cluster.example.com",
+					},
+					IngressProfiles: []api.IngressProfile{
+						{
+							Name: "default",
+						},
+					},
+				},
+			}
+			if tt.modify != nil {
+				tt.modify(oc)
+			}
+
+			err := validateCertificates(ctx, kv, oc, time.Now().Add(time.Minute))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+)
+
+var errMsgNSGNotFound = "The network security group '%s' could not be found."
+
+// NetworkPlan describes the networking a cluster will be created in, using the
+// same JSON representation as the Azure Resource Manager API.  It allows a
+// cluster to be validated before any of its networking exists.  Subnets
+// without a provisioningState are assumed to be planned rather than failed.
+type NetworkPlan struct {
+	VirtualNetworks       []mgmtnetwork.VirtualNetwork `json:"virtualNetworks,omitempty"`
+	RouteTables           []mgmtnetwork.RouteTable     `json:"routeTables,omitempty"`
+	NetworkSecurityGroups []mgmtnetwork.SecurityGroup  `json:"networkSecurityGroups,omitempty"`
+}
+
+// NetworkPlanValidator runs the network checks which do not depend on Azure
+// permissions against a NetworkPlan.  Its verdicts match those of Dynamic.
+type NetworkPlanValidator interface {
+	ValidateVnet(ctx context.Context, location string, subnets []Subnet, additionalCIDRs ...string) error
+	ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
+	ValidatePreConfiguredNSGs(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
+}
+
+type networkPlanValidator struct {
+	*dynamic
+
+	plan *NetworkPlan
+}
+
+func NewNetworkPlanValidator(log *logrus.Entry, plan *NetworkPlan) NetworkPlanValidator {
+	return &networkPlanValidator{
+		dynamic: &dynamic{
+			log:             log,
+			virtualNetworks: &networkPlanVirtualNetworks{plan: plan},
+		},
+		plan: plan,
+	}
+}
+
+func (nv *networkPlanValidator) ValidateVnet(ctx context.Context, location string, subnets []Subnet, additionalCIDRs ...string) error {
+	if len(subnets) == 0 {
+		return fmt.Errorf("no subnets provided")
+	}
+
+	subnets = uniqueSubnetSlice(subnets)
+
+	vnets := make(map[string]azure.Resource)
+	for _, s := range subnets {
+		vnetID, _, err := apisubnet.Split(s.ID)
+		if err != nil {
+			return err
+		}
+
+		vnetr, err := azure.ParseResourceID(vnetID)
+		if err != nil {
+			return err
+		}
+		vnets[strings.ToLower(vnetID)] = vnetr
+	}
+
+	for _, vnet := range vnets {
+		err := nv.validateVnetExists(vnet)
+		if err != nil {
+			return err
+		}
+
+		err = nv.validateVnetLocation(ctx, vnet, location)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, s := range subnets {
+		err := nv.validateRouteTable(ctx, s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nv.validateCIDRRanges(ctx, subnets, additionalCIDRs...)
+}
+
+// ValidateSubnets makes the same checks as Dynamic once the subnets' vnets are
+// known to be part of the plan
+func (nv *networkPlanValidator) ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
+	err := nv.validateSubnetVnetsExist(subnets)
+	if err != nil {
+		return err
+	}
+
+	return nv.dynamic.ValidateSubnets(ctx, oc, subnets)
+}
+
+// validateVnetExists takes the place of the not found check made when
+// validating vnet permissions
+func (nv *networkPlanValidator) validateVnetExists(vnetr azure.Resource) error {
+	for _, vnet := range nv.plan.VirtualNetworks {
+		if vnet.ID != nil && strings.EqualFold(*vnet.ID, vnetr.String()) {
+			return nil
+		}
+	}
+
+	return api.NewCloudError(
+		http.StatusBadRequest,
+		api.CloudErrorCodeInvalidLinkedVNet,
+		"",
+		errMsgVnetNotFound,
+		vnetr.String(),
+	)
+}
+
+func (nv *networkPlanValidator) validateSubnetVnetsExist(subnets []Subnet) error {
+	for _, s := range subnets {
+		vnetID, _, err := apisubnet.Split(s.ID)
+		if err != nil {
+			return err
+		}
+
+		vnetr, err := azure.ParseResourceID(vnetID)
+		if err != nil {
+			return err
+		}
+
+		err = nv.validateVnetExists(vnetr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRouteTable checks that the route table attached to the subnet, if
+// any, is part of the plan.  It takes the place of the not found check made
+// when validating route table permissions.
+func (nv *networkPlanValidator) validateRouteTable(ctx context.Context, s Subnet) error {
+	vnetID, _, err := apisubnet.Split(s.ID)
+	if err != nil {
+		return err
+	}
+
+	vnetr, err := azure.ParseResourceID(vnetID)
+	if err != nil {
+		return err
+	}
+
+	vnet, err := nv.virtualNetworks.Get(ctx, vnetr.ResourceGroup, vnetr.ResourceName, "")
+	if err != nil {
+		return err
+	}
+
+	rtID, err := getRouteTableID(&vnet, s.ID)
+	if err != nil || rtID == "" { // error or no route table
+		return err
+	}
+
+	for _, rt := range nv.plan.RouteTables {
+		if rt.ID != nil && strings.EqualFold(*rt.ID, rtID) {
+			return nil
+		}
+	}
+
+	return api.NewCloudError(
+		http.StatusBadRequest,
+		api.CloudErrorCodeInvalidLinkedRouteTable,
+		"",
+		errMsgRTNotFound,
+		rtID,
+	)
+}
+
+// ValidatePreConfiguredNSGs checks that the network security groups attached
+// to the subnets are part of the plan in place of checking permissions on
+// them.
+func (nv *networkPlanValidator) ValidatePreConfiguredNSGs(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
+	if oc.Properties.NetworkProfile.PreconfiguredNSG != api.PreconfiguredNSGEnabled {
+		return nil // exit early
+	}
+
+	err := nv.validateSubnetVnetsExist(subnets)
+	if err != nil {
+		return err
+	}
+
+	subnetByID, err := nv.createSubnetMapByID(ctx, subnets)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range subnets {
+		ss := subnetByID[s.ID]
+		if !subnetHasNSGAttached(ss) || *ss.NetworkSecurityGroup.ID == "" {
+			return api.NewCloudError(
+				http.StatusBadRequest,
+				api.CloudErrorCodeNotFound,
+				"",
+				errMsgNSGNotProperlyAttached,
+			)
+		}
+
+		nsgID := *ss.NetworkSecurityGroup.ID
+		if !nv.planHasNSG(nsgID) {
+			return api.NewCloudError(
+				http.StatusBadRequest,
+				api.CloudErrorCodeNotFound,
+				s.Path,
+				errMsgNSGNotFound,
+				nsgID,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (nv *networkPlanValidator) planHasNSG(nsgID string) bool {
+	for _, nsg := range nv.plan.NetworkSecurityGroups {
+		if nsg.ID != nil && isTheSameNSG(*nsg.ID, nsgID) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkPlanVirtualNetworks returns virtual networks from a NetworkPlan in
+// place of the Azure API
+type networkPlanVirtualNetworks struct {
+	plan *NetworkPlan
+}
+
+func (c *networkPlanVirtualNetworks) Get(ctx context.Context, resourceGroupName string, virtualNetworkName string, expand string) (mgmtnetwork.VirtualNetwork, error) {
+	for _, vnet := range c.plan.VirtualNetworks {
+		if vnet.ID == nil {
+			continue
+		}
+
+		r, err := azure.ParseResourceID(*vnet.ID)
+		if err != nil {
+			return mgmtnetwork.VirtualNetwork{}, err
+		}
+
+		if !strings.EqualFold(r.ResourceGroup, resourceGroupName) ||
+			!strings.EqualFold(r.ResourceName, virtualNetworkName) {
+			continue
+		}
+
+		// planned vnets may omit fields which always exist in Azure
+		if vnet.Location == nil {
+			vnet.Location = to.StringPtr("")
+		}
+
+		if vnet.Subnets == nil {
+			return vnet, nil
+		}
+
+		// don't modify the plan when treating planned subnets as Succeeded
+		subnets := make([]mgmtnetwork.Subnet, 0, len(*vnet.Subnets))
+		for _, s := range *vnet.Subnets {
+			if s.ID == nil || s.SubnetPropertiesFormat == nil ||
+				(s.AddressPrefix == nil && s.AddressPrefixes == nil) {
+				return mgmtnetwork.VirtualNetwork{}, fmt.Errorf("subnets of virtual network %s must have an id and an addressPrefix or addressPrefixes", *vnet.ID)
+			}
+
+			if s.ProvisioningState == "" {
+				properties := *s.SubnetPropertiesFormat
+				properties.ProvisioningState = mgmtnetwork.Succeeded
+				s.SubnetPropertiesFormat = &properties
+			}
+			subnets = append(subnets, s)
+		}
+		vnet.Subnets = &subnets
+
+		return vnet, nil
+	}
+
+	return mgmtnetwork.VirtualNetwork{}, fmt.Errorf("virtual network %s/%s is not part of the plan", resourceGroupName, virtualNetworkName)
+}
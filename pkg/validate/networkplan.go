@@ -0,0 +1,45 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/validate/dynamic"
+)
+
+// ValidateNetworkPlan makes the network checks of dynamic validation against
+// a plan of the cluster's networking rather than against Azure, so that
+// networking can be validated before it is created.  Checks which depend on
+// permissions are not made.  It only returns an error if validation could not
+// be completed.
+func ValidateNetworkPlan(ctx context.Context, log *logrus.Entry, oc *api.OpenShiftCluster, plan *dynamic.NetworkPlan) (*api.ValidationReport, error) {
+	report := &api.ValidationReport{}
+	subnets := clusterSubnets(oc)
+	nv := dynamic.NewNetworkPlanValidator(log, plan)
+
+	for _, f := range []func() error{
+		func() error {
+			return nv.ValidateVnet(
+				ctx,
+				oc.Location,
+				subnets,
+				oc.Properties.NetworkProfile.PodCIDR,
+				oc.Properties.NetworkProfile.ServiceCIDR,
+			)
+		},
+		func() error { return nv.ValidateSubnets(ctx, oc, subnets) },
+		func() error { return nv.ValidatePreConfiguredNSGs(ctx, oc, subnets) },
+	} {
+		err := report.Add(f())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
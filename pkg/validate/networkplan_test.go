@@ -0,0 +1,205 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/validate/dynamic"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateNetworkPlan(t *testing.T) {
+	ctx := context.Background()
+
+	resourceGroupID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet"
+	vnetID := resourceGroupID + "/providers/Microsoft.Network/virtualNetworks/vnet"
+	masterSubnetID := vnetID + "/subnets/master"
+	workerSubnetID := vnetID + "/subnets/worker"
+	rtID := resourceGroupID + "/providers/Microsoft.Network/routeTables/rt"
+	nsgID := resourceGroupID + "/providers/Microsoft.Network/networkSecurityGroups/nsg"
+
+	// the plan is written as a network team would, without provisioning
+	// states
+	planJSON := []byte(`{
+		"virtualNetworks": [
+			{
+				"id": "` + vnetID + `",
+				"location": "eastus",
+				"properties": {
+					"addressSpace": {"addressPrefixes": ["10.0.0.0/16"]},
+					"subnets": [
+						{
+							"id": "` + masterSubnetID + `",
+							"properties": {
+								"addressPrefix": "10.0.0.0/24",
+								"routeTable": {"id": "` + rtID + `"}
+							}
+						},
+						{
+							"id": "` + workerSubnetID + `",
+							"properties": {
+								"addressPrefix": "10.0.1.0/24",
+								"routeTable": {"id": "` + rtID + `"}
+							}
+						}
+					]
+				}
+			}
+		],
+		"routeTables": [
+			{"id": "` + rtID + `", "location": "eastus"}
+		],
+		"networkSecurityGroups": [
+			{"id": "` + nsgID + `", "location": "eastus"}
+		]
+	}`)
+
+	for _, tt := range []struct {
+		name      string
+		modifyOC  func(*api.OpenShiftCluster)
+		modify    func(*dynamic.NetworkPlan)
+		wantErr   string
+		wantCodes []string
+	}{
+		{
+			name: "valid plan",
+		},
+		{
+			name: "missing vnet",
+			modify: func(plan *dynamic.NetworkPlan) {
+				plan.VirtualNetworks = nil
+			},
+			wantCodes: []string{api.CloudErrorCodeInvalidLinkedVNet},
+		},
+		{
+			name: "vnet in the wrong location",
+			modify: func(plan *dynamic.NetworkPlan) {
+				plan.VirtualNetworks[0].Location = to.StringPtr("westus")
+			},
+			wantCodes: []string{api.CloudErrorCodeInvalidLinkedVNet},
+		},
+		{
+			name: "missing route table",
+			modify: func(plan *dynamic.NetworkPlan) {
+				plan.RouteTables = nil
+			},
+			wantCodes: []string{api.CloudErrorCodeInvalidLinkedRouteTable},
+		},
+		{
+			name: "overlapping CIDRs and undersized subnet are all reported",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDR = "10.0.0.0/18"
+			},
+			modify: func(plan *dynamic.NetworkPlan) {
+				(*plan.VirtualNetworks[0].Subnets)[1].AddressPrefix = to.StringPtr("10.0.1.0/28")
+			},
+			wantCodes: []string{api.CloudErrorCodeInvalidLinkedVNet, api.CloudErrorCodeInvalidLinkedVNet},
+		},
+		{
+			name: "subnet in a failed state",
+			modify: func(plan *dynamic.NetworkPlan) {
+				(*plan.VirtualNetworks[0].Subnets)[0].ProvisioningState = mgmtnetwork.Failed
+			},
+			wantCodes: []string{api.CloudErrorCodeInvalidLinkedVNet},
+		},
+		{
+			name: "unexpected NSG attached",
+			modify: func(plan *dynamic.NetworkPlan) {
+				(*plan.VirtualNetworks[0].Subnets)[0].NetworkSecurityGroup = &mgmtnetwork.SecurityGroup{ID: to.StringPtr(nsgID)}
+			},
+			wantCodes: []string{api.CloudErrorCodeInvalidLinkedVNet},
+		},
+		{
+			name: "preconfigured NSGs",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PreconfiguredNSG = api.PreconfiguredNSGEnabled
+			},
+			modify: func(plan *dynamic.NetworkPlan) {
+				for i := range *plan.VirtualNetworks[0].Subnets {
+					(*plan.VirtualNetworks[0].Subnets)[i].NetworkSecurityGroup = &mgmtnetwork.SecurityGroup{ID: to.StringPtr(nsgID)}
+				}
+			},
+		},
+		{
+			name: "preconfigured NSG missing from plan",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PreconfiguredNSG = api.PreconfiguredNSGEnabled
+			},
+			modify: func(plan *dynamic.NetworkPlan) {
+				for i := range *plan.VirtualNetworks[0].Subnets {
+					(*plan.VirtualNetworks[0].Subnets)[i].NetworkSecurityGroup = &mgmtnetwork.SecurityGroup{ID: to.StringPtr(nsgID)}
+				}
+				plan.NetworkSecurityGroups = nil
+			},
+			wantCodes: []string{api.CloudErrorCodeNotFound},
+		},
+		{
+			name: "malformed subnet",
+			modify: func(plan *dynamic.NetworkPlan) {
+				(*plan.VirtualNetworks[0].Subnets)[0].AddressPrefix = nil
+			},
+			wantErr: "subnets of virtual network " + vnetID + " must have an id and an addressPrefix or addressPrefixes",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &api.OpenShiftCluster{
+				Location: "eastus",
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: api.ProvisioningStateCreating,
+					ClusterProfile: api.ClusterProfile{
+						ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/cluster",
+					},
+					NetworkProfile: api.NetworkProfile{
+						PodCIDR:     "10.128.0.0/14",
+						ServiceCIDR: "172.30.0.0/16",
+					},
+					MasterProfile: api.MasterProfile{
+						SubnetID: masterSubnetID,
+					},
+					WorkerProfiles: []api.WorkerProfile{
+						{
+							SubnetID: workerSubnetID,
+						},
+					},
+				},
+			}
+			if tt.modifyOC != nil {
+				tt.modifyOC(oc)
+			}
+
+			plan := &dynamic.NetworkPlan{}
+			err := json.Unmarshal(planJSON, plan)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.modify != nil {
+				tt.modify(plan)
+			}
+
+			report, err := ValidateNetworkPlan(ctx, logrus.NewEntry(logrus.StandardLogger()), oc, plan)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+
+			errs := report.Errors()
+			if len(errs) != len(tt.wantCodes) {
+				t.Fatalf("got %d errors %#v, want %d", len(errs), errs, len(tt.wantCodes))
+			}
+			for i, f := range errs {
+				if f.Code != tt.wantCodes[i] {
+					t.Errorf("error %d: got code %s, want %s", i, f.Code, tt.wantCodes[i])
+				}
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
@@ -19,6 +20,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/authz/remotepdp"
+	keyvaultclient "github.com/Azure/ARO-RP/pkg/util/azureclient/keyvault"
 	"github.com/Azure/ARO-RP/pkg/util/feature"
 	"github.com/Azure/ARO-RP/pkg/validate/dynamic"
 )
@@ -97,22 +99,30 @@ func ensureAccessTokenClaims(ctx context.Context, spTokenCredential azcore.Token
 		"The Azure Red Hat Openshift resource provider service principal has been removed from your tenant. To restore, please unregister and then re-register the Azure Red Hat OpenShift resource provider.")
 }
 
-// Dynamic validates an OpenShift cluster
+// Dynamic validates an OpenShift cluster.  All the problems found are
+// returned together; use api.FirstError for a single error.
 func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
-	// Get all subnets
-	subnets := []dynamic.Subnet{{
-		ID:   dv.oc.Properties.MasterProfile.SubnetID,
-		Path: "properties.masterProfile.subnetId",
-	}}
+	report := &api.ValidationReport{}
 
-	workerProfiles, propertyName := api.GetEnrichedWorkerProfiles(dv.oc.Properties)
-	for i, wp := range workerProfiles {
-		subnets = append(subnets, dynamic.Subnet{
-			ID:   wp.SubnetID,
-			Path: fmt.Sprintf("properties.%s[%d].subnetId", propertyName, i),
-		})
+	err := dv.validate(ctx, report)
+	if err != nil {
+		if len(report.Errors()) == 0 {
+			return err
+		}
+
+		// the problems already found are more useful to the customer than
+		// whatever stopped us looking for more
+		dv.log.Warnf("dynamic validation stopped early: %s", err)
 	}
 
+	return report.CloudError()
+}
+
+// validate adds the problems found with the cluster to report.  It only
+// returns an error if validation could not be completed.
+func (dv *openShiftClusterDynamicValidator) validate(ctx context.Context, report *api.ValidationReport) error {
+	subnets := clusterSubnets(dv.oc)
+
 	var pdpClient remotepdp.RemotePDPClient
 	spp := dv.oc.Properties.ServicePrincipalProfile
 
@@ -150,10 +160,6 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 	}
 
 	scopes := []string{dv.env.Environment().ResourceManagerScope}
-	err = ensureAccessTokenClaims(ctx, spClientCred, scopes)
-	if err != nil {
-		return err
-	}
 	spAuthorizer := azidext.NewTokenCredentialAdapter(spClientCred, scopes)
 
 	spDynamic := dynamic.NewValidator(
@@ -169,53 +175,36 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 	)
 
 	// SP validation
-	err = spDynamic.ValidateServicePrincipal(ctx, spClientCred)
-	if err != nil {
-		return err
-	}
-
-	err = spDynamic.ValidateVnet(
-		ctx,
-		dv.oc.Location,
-		subnets,
-		dv.oc.Properties.NetworkProfile.PodCIDR,
-		dv.oc.Properties.NetworkProfile.ServiceCIDR,
+	err = validateIdentity(report,
+		[]func() error{
+			func() error { return ensureAccessTokenClaims(ctx, spClientCred, scopes) },
+			func() error { return spDynamic.ValidateServicePrincipal(ctx, spClientCred) },
+		},
+		[]func() error{
+			func() error {
+				return spDynamic.ValidateVnet(
+					ctx,
+					dv.oc.Location,
+					subnets,
+					dv.oc.Properties.NetworkProfile.PodCIDR,
+					dv.oc.Properties.NetworkProfile.ServiceCIDR,
+				)
+			},
+			func() error { return spDynamic.ValidateSubnets(ctx, dv.oc, subnets) },
+			func() error { return spDynamic.ValidateDiskEncryptionSets(ctx, dv.oc) },
+			func() error { return spDynamic.ValidateEncryptionAtHost(ctx, dv.oc) },
+			func() error { return spDynamic.ValidateLoadBalancerProfile(ctx, dv.oc) },
+			func() error { return spDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets) },
+			func() error {
+				spKeyvault := keyvaultclient.New(azidext.NewTokenCredentialAdapter(spClientCred, []string{dv.env.Environment().KeyVaultScope}))
+				return validateCertificates(ctx, spKeyvault, dv.oc, time.Now())
+			},
+		},
 	)
 	if err != nil {
 		return err
 	}
 
-	err = spDynamic.ValidateSubnets(ctx, dv.oc, subnets)
-	if err != nil {
-		return err
-	}
-
-	err = spDynamic.ValidateDiskEncryptionSets(ctx, dv.oc)
-	if err != nil {
-		return err
-	}
-
-	err = spDynamic.ValidateEncryptionAtHost(ctx, dv.oc)
-	if err != nil {
-		return err
-	}
-
-	err = spDynamic.ValidateLoadBalancerProfile(ctx, dv.oc)
-	if err != nil {
-		return err
-	}
-
-	err = spDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets)
-	if err != nil {
-		return err
-	}
-
-	err = ensureAccessTokenClaims(ctx, fpClientCred, scopes)
-	if err != nil {
-		return err
-	}
-
-	// FP validation
 	fpDynamic := dynamic.NewValidator(
 		dv.log,
 		dv.env,
@@ -228,26 +217,70 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		pdpClient,
 	)
 
-	err = fpDynamic.ValidateVnet(
-		ctx,
-		dv.oc.Location,
-		subnets,
-		dv.oc.Properties.NetworkProfile.PodCIDR,
-		dv.oc.Properties.NetworkProfile.ServiceCIDR,
+	// FP validation
+	return validateIdentity(report,
+		[]func() error{
+			func() error { return ensureAccessTokenClaims(ctx, fpClientCred, scopes) },
+		},
+		[]func() error{
+			func() error {
+				return fpDynamic.ValidateVnet(
+					ctx,
+					dv.oc.Location,
+					subnets,
+					dv.oc.Properties.NetworkProfile.PodCIDR,
+					dv.oc.Properties.NetworkProfile.ServiceCIDR,
+				)
+			},
+			func() error { return fpDynamic.ValidateDiskEncryptionSets(ctx, dv.oc) },
+			func() error { return fpDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets) },
+		},
 	)
-	if err != nil {
-		return err
-	}
+}
 
-	err = fpDynamic.ValidateDiskEncryptionSets(ctx, dv.oc)
-	if err != nil {
-		return err
+// validateIdentity adds the problems found by checks run as a single identity
+// to report.  The identity itself is checked first by identityChecks, in
+// order; the remaining checks can't succeed if it is invalid, so they are
+// skipped.  Otherwise all the remaining checks run, even if some of them find
+// problems.  An error is only returned if a check could not be completed.
+func validateIdentity(report *api.ValidationReport, identityChecks, checks []func() error) error {
+	errs := len(report.Errors())
+
+	for _, f := range identityChecks {
+		err := report.Add(f())
+		if err != nil {
+			return err
+		}
+
+		if len(report.Errors()) > errs {
+			return nil
+		}
 	}
 
-	err = fpDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets)
-	if err != nil {
-		return err
+	for _, f := range checks {
+		err := report.Add(f())
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// clusterSubnets returns the master and worker subnets of the cluster
+func clusterSubnets(oc *api.OpenShiftCluster) []dynamic.Subnet {
+	subnets := []dynamic.Subnet{{
+		ID:   oc.Properties.MasterProfile.SubnetID,
+		Path: "properties.masterProfile.subnetId",
+	}}
+
+	workerProfiles, propertyName := api.GetEnrichedWorkerProfiles(oc.Properties)
+	for i, wp := range workerProfiles {
+		subnets = append(subnets, dynamic.Subnet{
+			ID:   wp.SubnetID,
+			Path: fmt.Sprintf("properties.%s[%d].subnetId", propertyName, i),
+		})
+	}
+
+	return subnets
+}
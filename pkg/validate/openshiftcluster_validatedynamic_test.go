@@ -6,6 +6,7 @@ package validate
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 
+	"github.com/Azure/ARO-RP/pkg/api"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
@@ -77,3 +79,56 @@ func TestEnsureAccessTokenClaims(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateIdentity(t *testing.T) {
+	invalid := func(target string) func() error {
+		return func() error {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, target, "The provided value is invalid.")
+		}
+	}
+	valid := func() error { return nil }
+	failed := func() error { return errors.New("failed") }
+
+	for _, tt := range []struct {
+		name           string
+		identityChecks []func() error
+		checks         []func() error
+		wantErr        string
+		wantReportErr  string
+	}{
+		{
+			name:           "valid",
+			identityChecks: []func() error{valid},
+			checks:         []func() error{valid, valid},
+		},
+		{
+			name:           "all the problems are reported",
+			identityChecks: []func() error{valid},
+			checks:         []func() error{invalid("a"), valid, invalid("b")},
+			wantReportErr: "400: MultipleErrorsOccurred: : Multiple validation errors occurred. Please see details for more information. Details: " +
+				"InvalidParameter: a: The provided value is invalid., " +
+				"InvalidParameter: b: The provided value is invalid.",
+		},
+		{
+			name:           "checks are skipped if the identity is invalid",
+			identityChecks: []func() error{invalid("identity"), invalid("skipped")},
+			checks:         []func() error{invalid("a")},
+			wantReportErr:  "400: InvalidParameter: identity: The provided value is invalid.",
+		},
+		{
+			name:           "validation stops if a check can't be completed",
+			identityChecks: []func() error{valid},
+			checks:         []func() error{invalid("a"), failed, invalid("b")},
+			wantErr:        "failed",
+			wantReportErr:  "400: InvalidParameter: a: The provided value is invalid.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &api.ValidationReport{}
+
+			err := validateIdentity(report, tt.identityChecks, tt.checks)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilerror.AssertErrorMessage(t, report.CloudError(), tt.wantReportErr)
+		})
+	}
+}
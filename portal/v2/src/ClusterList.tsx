@@ -11,6 +11,7 @@ import {
   mergeStyleSets,  
   TextField,
   Link,
+  DefaultButton,
 } from "@fluentui/react"
 import {
   DetailsList,
@@ -19,7 +20,7 @@ import {
   IColumn,
   IDetailsListStyles,
 } from "@fluentui/react/lib/DetailsList"
-import { fetchClusters } from "./Request"
+import { fetchClusters, IClusterFilter, clusterListContinuationHeader } from "./Request"
 import { ToolIcons } from "./ToolIcons"
 import { AxiosResponse } from "axios"
 import { ICluster, headerStyles } from "./App"
@@ -54,6 +55,10 @@ const classNames = mergeStyleSets({
   itemsCount: {
     padding: "10px 0px",
   },
+  filterField: {
+    marginRight: 10,
+    marginBottom: 10,
+  },
 })
 
 const controlStyles = {
@@ -252,9 +257,6 @@ class ClusterListComponent extends Component<ClusterListComponentProps, ICluster
 
     return (
       <Stack>
-        <div className={classNames.controlWrapper}>
-          <TextField placeholder="Filter on resource ID" onChange={this._onChangeText} />
-        </div>
         <Text id="ClusterCount" className={classNames.itemsCount}>
           Showing {items.length} items
         </Text>
@@ -276,19 +278,6 @@ class ClusterListComponent extends Component<ClusterListComponentProps, ICluster
     return item.key
   }
 
-  private _onChangeText = (
-    ev: React.FormEvent<HTMLInputElement | HTMLTextAreaElement>,
-    text?: string
-  ): void => {
-    this.setState({
-      items: text
-        ? this.props.items.filter(
-            (i) => i.resourceId.toLowerCase().indexOf(text.trim().toLowerCase()) != -1
-          )
-        : this.props.items,
-    })
-  }
-
   private _onColumnClick = (ev: React.MouseEvent<HTMLElement>, column: IColumn): void => {
     const { columns, items } = this.state
     const newColumns: IColumn[] = columns.slice()
@@ -318,6 +307,8 @@ export function ClusterList(props: {
   const [error, setError] = useState<AxiosResponse | null>(null)
   const state = useRef<ClusterListComponent>(null)
   const [fetching, setFetching] = useState("")
+  const [filter, setFilter] = useState<IClusterFilter>({})
+  const [continuation, setContinuation] = useState("")
 
   const errorBar = (): any => {
     return (
@@ -341,34 +332,57 @@ export function ClusterList(props: {
     }
   }
 
+  // fetching is "" to fetch the first page of clusters matching the filter,
+  // and "MORE" to append the page following continuation
   useEffect(() => {
-    const onData = (result: AxiosResponse | null) => {
+    const onData = (previous: ICluster[]) => (result: AxiosResponse | null) => {
       if (result?.status === 200) {
-        updateData(result.data)
+        updateData(previous.concat(result.data))
+        setContinuation(result.headers[clusterListContinuationHeader] || "")
       } else {
         setError(result)
       }
       setFetching("DONE")
     }
 
-    if (fetching === "" && props.csrfTokenAvailable === "DONE") {
+    if (props.csrfTokenAvailable !== "DONE") {
+      return
+    }
+
+    if (fetching === "") {
+      setFetching("FETCHING")
+      fetchClusters(filter).then(onData([]))
+    } else if (fetching === "MORE") {
       setFetching("FETCHING")
-      fetchClusters().then(onData)
+      fetchClusters(filter, continuation).then(onData(data))
     }
-  }, [data, fetching, setFetching, props.csrfTokenAvailable])
+  }, [data, filter, continuation, fetching, setFetching, props.csrfTokenAvailable])
+
+  const refresh = () => {
+    updateData([])
+    setContinuation("")
+    setFetching("")
+  }
 
   const _items: ICommandBarItemProps[] = [
     {
       key: "refresh",
       text: "Refresh",
       iconProps: { iconName: "Refresh" },
-      onClick: () => {
-        updateData([])
-        setFetching("")
-      },
+      onClick: refresh,
     },
   ]
 
+  const filterField = (field: keyof IClusterFilter, placeholder: string) => (
+    <TextField
+      className={classNames.filterField}
+      placeholder={placeholder}
+      value={filter[field] || ""}
+      onChange={(_, text) => setFilter({ ...filter, [field]: text })}
+      onKeyDown={(ev) => ev.key === "Enter" && refresh()}
+    />
+  )
+
   return (
     <Stack>
       <span className={headerStyles.titleText}>Clusters</span>
@@ -383,12 +397,32 @@ export function ClusterList(props: {
 
       {error && errorBar()}
 
+      <div className={classNames.controlWrapper}>
+        {filterField("q", "Filter on name")}
+        {filterField("subscription", "Subscription")}
+        {filterField("resourceGroup", "Resource group")}
+        {filterField("version", "Version")}
+        {filterField("provisioningState", "State")}
+        {filterField("provisionedBy", "Provisioned by")}
+        <DefaultButton className={classNames.filterField} text="Apply filters" onClick={refresh} />
+      </div>
+
       <ClusterListComponent
         items={data}
         ref={state} // why do we need ref here?
         sshModalRef={props.sshBox}
         csrfToken={props.csrfToken}
       />
+
+      {continuation !== "" && (
+        <Stack horizontal horizontalAlign="center">
+          <DefaultButton
+            text="Load more"
+            disabled={fetching !== "DONE"}
+            onClick={() => setFetching("MORE")}
+          />
+        </Stack>
+      )}
     </Stack>
   )
 }
\ No newline at end of file
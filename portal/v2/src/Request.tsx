@@ -15,9 +15,31 @@ const OnError = (err: AxiosResponse): AxiosResponse | null => {
   }
 }
 
-export const fetchClusters = async (): Promise<AxiosResponse | null> => {
+// IClusterFilter holds the filters of /api/clusters, which are evaluated by
+// the server.  Empty fields match every cluster.
+export interface IClusterFilter {
+  subscription?: string
+  resourceGroup?: string
+  version?: string
+  provisioningState?: string
+  provisionedBy?: string
+  q?: string
+}
+
+export const clusterListPageSize = 100
+
+// clusterListContinuationHeader carries the token with which the next page of
+// clusters is requested.  It is not set on the last page.
+export const clusterListContinuationHeader = "x-ms-continuation"
+
+export const fetchClusters = async (
+  filter: IClusterFilter,
+  continuation?: string
+): Promise<AxiosResponse | null> => {
   try {
-    const result = await axios("/api/clusters")
+    const result = await axios("/api/clusters", {
+      params: { ...filter, limit: clusterListPageSize, continuation: continuation || undefined },
+    })
     return result
   } catch (e: any) {
     const err = e.response as AxiosResponse
@@ -0,0 +1,1750 @@
+# coding=utf-8
+# --------------------------------------------------------------------------
+# Copyright (c) Microsoft Corporation. All rights reserved.
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#   http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# 
+# Code generated by Microsoft (R) AutoRest Code Generator.Changes may cause incorrect behavior and will be lost if the code is regenerated.
+# --------------------------------------------------------------------------
+
+import msrest.serialization
+
+
+class APIServerProfile(msrest.serialization.Model):
+    """APIServerProfile represents an API server profile.
+
+    :ivar visibility: API server visibility. Possible values include: "Private", "Public".
+    :vartype visibility: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Visibility
+    :ivar url: The URL to access the cluster API server.
+    :vartype url: str
+    :ivar ip: The IP of the cluster API server.
+    :vartype ip: str
+    :ivar certificate_secret_id: The Key Vault secret identifier of a customer supplied API server
+     certificate.
+    :vartype certificate_secret_id: str
+    """
+
+    _attribute_map = {
+        'visibility': {'key': 'visibility', 'type': 'str'},
+        'url': {'key': 'url', 'type': 'str'},
+        'ip': {'key': 'ip', 'type': 'str'},
+        'certificate_secret_id': {'key': 'certificateSecretId', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword visibility: API server visibility. Possible values include: "Private", "Public".
+        :paramtype visibility: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Visibility
+        :keyword url: The URL to access the cluster API server.
+        :paramtype url: str
+        :keyword ip: The IP of the cluster API server.
+        :paramtype ip: str
+        :keyword certificate_secret_id: The Key Vault secret identifier of a customer supplied API
+         server certificate.
+        :paramtype certificate_secret_id: str
+        """
+        super(APIServerProfile, self).__init__(**kwargs)
+        self.visibility = kwargs.get('visibility', None)
+        self.url = kwargs.get('url', None)
+        self.ip = kwargs.get('ip', None)
+        self.certificate_secret_id = kwargs.get('certificate_secret_id', None)
+
+
+class CloudErrorBody(msrest.serialization.Model):
+    """CloudErrorBody represents the body of a cloud error.
+
+    :ivar code: An identifier for the error. Codes are invariant and are intended to be consumed
+     programmatically.
+    :vartype code: str
+    :ivar message: A message describing the error, intended to be suitable for display in a user
+     interface.
+    :vartype message: str
+    :ivar target: The target of the particular error. For example, the name of the property in
+     error.
+    :vartype target: str
+    :ivar details: A list of additional details about the error.
+    :vartype details: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CloudErrorBody]
+    """
+
+    _attribute_map = {
+        'code': {'key': 'code', 'type': 'str'},
+        'message': {'key': 'message', 'type': 'str'},
+        'target': {'key': 'target', 'type': 'str'},
+        'details': {'key': 'details', 'type': '[CloudErrorBody]'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword code: An identifier for the error. Codes are invariant and are intended to be consumed
+         programmatically.
+        :paramtype code: str
+        :keyword message: A message describing the error, intended to be suitable for display in a user
+         interface.
+        :paramtype message: str
+        :keyword target: The target of the particular error. For example, the name of the property in
+         error.
+        :paramtype target: str
+        :keyword details: A list of additional details about the error.
+        :paramtype details: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CloudErrorBody]
+        """
+        super(CloudErrorBody, self).__init__(**kwargs)
+        self.code = kwargs.get('code', None)
+        self.message = kwargs.get('message', None)
+        self.target = kwargs.get('target', None)
+        self.details = kwargs.get('details', None)
+
+
+class ClusterProfile(msrest.serialization.Model):
+    """ClusterProfile represents a cluster profile.
+
+    :ivar pull_secret: The pull secret for the cluster.
+    :vartype pull_secret: str
+    :ivar domain: The domain for the cluster.
+    :vartype domain: str
+    :ivar version: The version of the cluster.
+    :vartype version: str
+    :ivar resource_group_id: The ID of the cluster resource group.
+    :vartype resource_group_id: str
+    :ivar fips_validated_modules: If FIPS validated crypto modules are used. Possible values
+     include: "Disabled", "Enabled".
+    :vartype fips_validated_modules: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.FipsValidatedModules
+    """
+
+    _attribute_map = {
+        'pull_secret': {'key': 'pullSecret', 'type': 'str'},
+        'domain': {'key': 'domain', 'type': 'str'},
+        'version': {'key': 'version', 'type': 'str'},
+        'resource_group_id': {'key': 'resourceGroupId', 'type': 'str'},
+        'fips_validated_modules': {'key': 'fipsValidatedModules', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword pull_secret: The pull secret for the cluster.
+        :paramtype pull_secret: str
+        :keyword domain: The domain for the cluster.
+        :paramtype domain: str
+        :keyword version: The version of the cluster.
+        :paramtype version: str
+        :keyword resource_group_id: The ID of the cluster resource group.
+        :paramtype resource_group_id: str
+        :keyword fips_validated_modules: If FIPS validated crypto modules are used. Possible values
+         include: "Disabled", "Enabled".
+        :paramtype fips_validated_modules: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.FipsValidatedModules
+        """
+        super(ClusterProfile, self).__init__(**kwargs)
+        self.pull_secret = kwargs.get('pull_secret', None)
+        self.domain = kwargs.get('domain', None)
+        self.version = kwargs.get('version', None)
+        self.resource_group_id = kwargs.get('resource_group_id', None)
+        self.fips_validated_modules = kwargs.get('fips_validated_modules', None)
+
+
+class ConsoleProfile(msrest.serialization.Model):
+    """ConsoleProfile represents a console profile.
+
+    :ivar url: The URL to access the cluster console.
+    :vartype url: str
+    """
+
+    _attribute_map = {
+        'url': {'key': 'url', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword url: The URL to access the cluster console.
+        :paramtype url: str
+        """
+        super(ConsoleProfile, self).__init__(**kwargs)
+        self.url = kwargs.get('url', None)
+
+
+class Display(msrest.serialization.Model):
+    """Display represents the display details of an operation.
+
+    :ivar provider: Friendly name of the resource provider.
+    :vartype provider: str
+    :ivar resource: Resource type on which the operation is performed.
+    :vartype resource: str
+    :ivar operation: Operation type: read, write, delete, listKeys/action, etc.
+    :vartype operation: str
+    :ivar description: Friendly name of the operation.
+    :vartype description: str
+    """
+
+    _attribute_map = {
+        'provider': {'key': 'provider', 'type': 'str'},
+        'resource': {'key': 'resource', 'type': 'str'},
+        'operation': {'key': 'operation', 'type': 'str'},
+        'description': {'key': 'description', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword provider: Friendly name of the resource provider.
+        :paramtype provider: str
+        :keyword resource: Resource type on which the operation is performed.
+        :paramtype resource: str
+        :keyword operation: Operation type: read, write, delete, listKeys/action, etc.
+        :paramtype operation: str
+        :keyword description: Friendly name of the operation.
+        :paramtype description: str
+        """
+        super(Display, self).__init__(**kwargs)
+        self.provider = kwargs.get('provider', None)
+        self.resource = kwargs.get('resource', None)
+        self.operation = kwargs.get('operation', None)
+        self.description = kwargs.get('description', None)
+
+
+class EffectiveOutboundIP(msrest.serialization.Model):
+    """EffectiveOutboundIP represents an effective outbound IP resource of the cluster public load balancer.
+
+    :ivar id: The fully qualified Azure resource id of an IP address resource.
+    :vartype id: str
+    """
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword id: The fully qualified Azure resource id of an IP address resource.
+        :paramtype id: str
+        """
+        super(EffectiveOutboundIP, self).__init__(**kwargs)
+        self.id = kwargs.get('id', None)
+
+
+class IngressProfile(msrest.serialization.Model):
+    """IngressProfile represents an ingress profile.
+
+    :ivar name: The ingress profile name.
+    :vartype name: str
+    :ivar visibility: Ingress visibility. Possible values include: "Private", "Public".
+    :vartype visibility: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Visibility
+    :ivar ip: The IP of the ingress.
+    :vartype ip: str
+    :ivar certificate_secret_id: The Key Vault secret identifier of a customer supplied ingress
+     certificate.
+    :vartype certificate_secret_id: str
+    """
+
+    _attribute_map = {
+        'name': {'key': 'name', 'type': 'str'},
+        'visibility': {'key': 'visibility', 'type': 'str'},
+        'ip': {'key': 'ip', 'type': 'str'},
+        'certificate_secret_id': {'key': 'certificateSecretId', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword name: The ingress profile name.
+        :paramtype name: str
+        :keyword visibility: Ingress visibility. Possible values include: "Private", "Public".
+        :paramtype visibility: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Visibility
+        :keyword ip: The IP of the ingress.
+        :paramtype ip: str
+        :keyword certificate_secret_id: The Key Vault secret identifier of a customer supplied ingress
+         certificate.
+        :paramtype certificate_secret_id: str
+        """
+        super(IngressProfile, self).__init__(**kwargs)
+        self.name = kwargs.get('name', None)
+        self.visibility = kwargs.get('visibility', None)
+        self.ip = kwargs.get('ip', None)
+        self.certificate_secret_id = kwargs.get('certificate_secret_id', None)
+
+
+class LoadBalancerProfile(msrest.serialization.Model):
+    """LoadBalancerProfile represents the profile of the cluster public load balancer.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar managed_outbound_ips: The desired managed outbound IPs for the cluster public load
+     balancer.
+    :vartype managed_outbound_ips:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ManagedOutboundIPs
+    :ivar effective_outbound_ips: The list of effective outbound IP addresses of the public load
+     balancer.
+    :vartype effective_outbound_ips:
+     list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.EffectiveOutboundIP]
+    """
+
+    _validation = {
+        'effective_outbound_ips': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'managed_outbound_ips': {'key': 'managedOutboundIps', 'type': 'ManagedOutboundIPs'},
+        'effective_outbound_ips': {'key': 'effectiveOutboundIps', 'type': '[EffectiveOutboundIP]'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword managed_outbound_ips: The desired managed outbound IPs for the cluster public load
+         balancer.
+        :paramtype managed_outbound_ips:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ManagedOutboundIPs
+        """
+        super(LoadBalancerProfile, self).__init__(**kwargs)
+        self.managed_outbound_ips = kwargs.get('managed_outbound_ips', None)
+        self.effective_outbound_ips = None
+
+
+class Resource(msrest.serialization.Model):
+    """Common fields that are returned in the response for all Azure Resource Manager resources.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        """
+        super(Resource, self).__init__(**kwargs)
+        self.id = None
+        self.name = None
+        self.type = None
+        self.system_data = None
+
+
+class ProxyResource(Resource):
+    """The resource model definition for a Azure Resource Manager proxy resource. It will not have tags and a location.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        """
+        super(ProxyResource, self).__init__(**kwargs)
+
+
+class MachinePool(ProxyResource):
+    """MachinePool represents a MachinePool.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar resources:
+    :vartype resources: str
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'resources': {'key': 'properties.resources', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword resources:
+        :paramtype resources: str
+        """
+        super(MachinePool, self).__init__(**kwargs)
+        self.resources = kwargs.get('resources', None)
+
+
+class MachinePoolList(msrest.serialization.Model):
+    """MachinePoolList represents a list of MachinePools.
+
+    :ivar value: The list of Machine Pools.
+    :vartype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MachinePool]
+    :ivar next_link: The link used to get the next page of operations.
+    :vartype next_link: str
+    """
+
+    _attribute_map = {
+        'value': {'key': 'value', 'type': '[MachinePool]'},
+        'next_link': {'key': 'nextLink', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword value: The list of Machine Pools.
+        :paramtype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MachinePool]
+        :keyword next_link: The link used to get the next page of operations.
+        :paramtype next_link: str
+        """
+        super(MachinePoolList, self).__init__(**kwargs)
+        self.value = kwargs.get('value', None)
+        self.next_link = kwargs.get('next_link', None)
+
+
+class MachinePoolUpdate(msrest.serialization.Model):
+    """MachinePool represents a MachinePool.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar system_data: The system meta data relating to this resource.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar resources:
+    :vartype resources: str
+    """
+
+    _validation = {
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'resources': {'key': 'properties.resources', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword resources:
+        :paramtype resources: str
+        """
+        super(MachinePoolUpdate, self).__init__(**kwargs)
+        self.system_data = None
+        self.resources = kwargs.get('resources', None)
+
+
+class MaintenanceBlackout(msrest.serialization.Model):
+    """MaintenanceBlackout represents a range of dates during which planned maintenance may not start.
+
+    :ivar start_date: The first date of the blackout, as YYYY-MM-DD.
+    :vartype start_date: str
+    :ivar end_date: The last date of the blackout, as YYYY-MM-DD.
+    :vartype end_date: str
+    """
+
+    _attribute_map = {
+        'start_date': {'key': 'startDate', 'type': 'str'},
+        'end_date': {'key': 'endDate', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword start_date: The first date of the blackout, as YYYY-MM-DD.
+        :paramtype start_date: str
+        :keyword end_date: The last date of the blackout, as YYYY-MM-DD.
+        :paramtype end_date: str
+        """
+        super(MaintenanceBlackout, self).__init__(**kwargs)
+        self.start_date = kwargs.get('start_date', None)
+        self.end_date = kwargs.get('end_date', None)
+
+
+class MaintenanceWindow(msrest.serialization.Model):
+    """MaintenanceWindow represents a weekly recurring maintenance window.
+
+    :ivar day_of_week: The day on which the window starts, e.g. Saturday.
+    :vartype day_of_week: str
+    :ivar start_time: The time at which the window starts, as HH:MM.
+    :vartype start_time: str
+    :ivar duration_hours: The length of the window in hours.
+    :vartype duration_hours: int
+    """
+
+    _attribute_map = {
+        'day_of_week': {'key': 'dayOfWeek', 'type': 'str'},
+        'start_time': {'key': 'startTime', 'type': 'str'},
+        'duration_hours': {'key': 'durationHours', 'type': 'int'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword day_of_week: The day on which the window starts, e.g. Saturday.
+        :paramtype day_of_week: str
+        :keyword start_time: The time at which the window starts, as HH:MM.
+        :paramtype start_time: str
+        :keyword duration_hours: The length of the window in hours.
+        :paramtype duration_hours: int
+        """
+        super(MaintenanceWindow, self).__init__(**kwargs)
+        self.day_of_week = kwargs.get('day_of_week', None)
+        self.start_time = kwargs.get('start_time', None)
+        self.duration_hours = kwargs.get('duration_hours', None)
+
+
+class MaintenanceWindowProfile(msrest.serialization.Model):
+    """MaintenanceWindowProfile represents the times at which planned maintenance may start.  Unplanned maintenance is not restricted.
+
+    :ivar time_zone: The IANA time zone in which the windows and blackout dates are interpreted,
+     e.g. Europe/London.
+    :vartype time_zone: str
+    :ivar windows: The weekly recurring maintenance windows.
+    :vartype windows: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceWindow]
+    :ivar blackout_dates: The date ranges during which planned maintenance may not start.
+    :vartype blackout_dates:
+     list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceBlackout]
+    """
+
+    _attribute_map = {
+        'time_zone': {'key': 'timeZone', 'type': 'str'},
+        'windows': {'key': 'windows', 'type': '[MaintenanceWindow]'},
+        'blackout_dates': {'key': 'blackoutDates', 'type': '[MaintenanceBlackout]'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword time_zone: The IANA time zone in which the windows and blackout dates are
+         interpreted, e.g. Europe/London.
+        :paramtype time_zone: str
+        :keyword windows: The weekly recurring maintenance windows.
+        :paramtype windows: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceWindow]
+        :keyword blackout_dates: The date ranges during which planned maintenance may not start.
+        :paramtype blackout_dates:
+         list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceBlackout]
+        """
+        super(MaintenanceWindowProfile, self).__init__(**kwargs)
+        self.time_zone = kwargs.get('time_zone', None)
+        self.windows = kwargs.get('windows', None)
+        self.blackout_dates = kwargs.get('blackout_dates', None)
+
+
+class ManagedOutboundIPs(msrest.serialization.Model):
+    """ManagedOutboundIPs represents the desired managed outbound IPs for the cluster public load balancer.
+
+    :ivar count: Count represents the desired number of IPv4 outbound IPs created and managed by
+     Azure for the cluster public load balancer.  Allowed values are in the range of 1 - 20.  The
+     default value is 1.
+    :vartype count: int
+    """
+
+    _attribute_map = {
+        'count': {'key': 'count', 'type': 'int'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword count: Count represents the desired number of IPv4 outbound IPs created and managed by
+         Azure for the cluster public load balancer.  Allowed values are in the range of 1 - 20.  The
+         default value is 1.
+        :paramtype count: int
+        """
+        super(ManagedOutboundIPs, self).__init__(**kwargs)
+        self.count = kwargs.get('count', None)
+
+
+class MasterProfile(msrest.serialization.Model):
+    """MasterProfile represents a master profile.
+
+    :ivar vm_size: The size of the master VMs.
+    :vartype vm_size: str
+    :ivar subnet_id: The Azure resource ID of the master subnet.
+    :vartype subnet_id: str
+    :ivar encryption_at_host: Whether master virtual machines are encrypted at host. Possible
+     values include: "Disabled", "Enabled".
+    :vartype encryption_at_host: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.EncryptionAtHost
+    :ivar disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
+     applicable.
+    :vartype disk_encryption_set_id: str
+    """
+
+    _attribute_map = {
+        'vm_size': {'key': 'vmSize', 'type': 'str'},
+        'subnet_id': {'key': 'subnetId', 'type': 'str'},
+        'encryption_at_host': {'key': 'encryptionAtHost', 'type': 'str'},
+        'disk_encryption_set_id': {'key': 'diskEncryptionSetId', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword vm_size: The size of the master VMs.
+        :paramtype vm_size: str
+        :keyword subnet_id: The Azure resource ID of the master subnet.
+        :paramtype subnet_id: str
+        :keyword encryption_at_host: Whether master virtual machines are encrypted at host. Possible
+         values include: "Disabled", "Enabled".
+        :paramtype encryption_at_host: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.EncryptionAtHost
+        :keyword disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
+         applicable.
+        :paramtype disk_encryption_set_id: str
+        """
+        super(MasterProfile, self).__init__(**kwargs)
+        self.vm_size = kwargs.get('vm_size', None)
+        self.subnet_id = kwargs.get('subnet_id', None)
+        self.encryption_at_host = kwargs.get('encryption_at_host', None)
+        self.disk_encryption_set_id = kwargs.get('disk_encryption_set_id', None)
+
+
+class NetworkProfile(msrest.serialization.Model):
+    """NetworkProfile represents a network profile.
+
+    :ivar pod_cidr: The CIDR used for OpenShift/Kubernetes Pods.
+    :vartype pod_cidr: str
+    :ivar service_cidr: The CIDR used for OpenShift/Kubernetes Services.
+    :vartype service_cidr: str
+    :ivar outbound_type: The OutboundType used for egress traffic. Possible values include:
+     "Loadbalancer", "UserDefinedRouting".
+    :vartype outbound_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.OutboundType
+    :ivar load_balancer_profile: The cluster load balancer profile.
+    :vartype load_balancer_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.LoadBalancerProfile
+    :ivar preconfigured_nsg: Specifies whether subnets are pre-attached with an NSG. Possible
+     values include: "Disabled", "Enabled".
+    :vartype preconfigured_nsg: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.PreconfiguredNSG
+    """
+
+    _attribute_map = {
+        'pod_cidr': {'key': 'podCidr', 'type': 'str'},
+        'service_cidr': {'key': 'serviceCidr', 'type': 'str'},
+        'outbound_type': {'key': 'outboundType', 'type': 'str'},
+        'load_balancer_profile': {'key': 'loadBalancerProfile', 'type': 'LoadBalancerProfile'},
+        'preconfigured_nsg': {'key': 'preconfiguredNSG', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword pod_cidr: The CIDR used for OpenShift/Kubernetes Pods.
+        :paramtype pod_cidr: str
+        :keyword service_cidr: The CIDR used for OpenShift/Kubernetes Services.
+        :paramtype service_cidr: str
+        :keyword outbound_type: The OutboundType used for egress traffic. Possible values include:
+         "Loadbalancer", "UserDefinedRouting".
+        :paramtype outbound_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.OutboundType
+        :keyword load_balancer_profile: The cluster load balancer profile.
+        :paramtype load_balancer_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.LoadBalancerProfile
+        :keyword preconfigured_nsg: Specifies whether subnets are pre-attached with an NSG. Possible
+         values include: "Disabled", "Enabled".
+        :paramtype preconfigured_nsg: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.PreconfiguredNSG
+        """
+        super(NetworkProfile, self).__init__(**kwargs)
+        self.pod_cidr = kwargs.get('pod_cidr', None)
+        self.service_cidr = kwargs.get('service_cidr', None)
+        self.outbound_type = kwargs.get('outbound_type', None)
+        self.load_balancer_profile = kwargs.get('load_balancer_profile', None)
+        self.preconfigured_nsg = kwargs.get('preconfigured_nsg', None)
+
+
+class TrackedResource(Resource):
+    """The resource model definition for an Azure Resource Manager tracked top level resource which has 'tags' and a 'location'.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    All required parameters must be populated in order to send to Azure.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar tags: A set of tags. Resource tags.
+    :vartype tags: dict[str, str]
+    :ivar location: Required. The geo-location where the resource lives.
+    :vartype location: str
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+        'location': {'required': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'tags': {'key': 'tags', 'type': '{str}'},
+        'location': {'key': 'location', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword tags: A set of tags. Resource tags.
+        :paramtype tags: dict[str, str]
+        :keyword location: Required. The geo-location where the resource lives.
+        :paramtype location: str
+        """
+        super(TrackedResource, self).__init__(**kwargs)
+        self.tags = kwargs.get('tags', None)
+        self.location = kwargs['location']
+
+
+class OpenShiftCluster(TrackedResource):
+    """OpenShiftCluster represents an Azure Red Hat OpenShift cluster.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    All required parameters must be populated in order to send to Azure.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar tags: A set of tags. Resource tags.
+    :vartype tags: dict[str, str]
+    :ivar location: Required. The geo-location where the resource lives.
+    :vartype location: str
+    :ivar provisioning_state: The cluster provisioning state. Possible values include:
+     "AdminUpdating", "Cancelled", "Creating", "Deleting", "Failed", "Succeeded", "Updating".
+    :vartype provisioning_state: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ProvisioningState
+    :ivar cluster_profile: The cluster profile.
+    :vartype cluster_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ClusterProfile
+    :ivar console_profile: The console profile.
+    :vartype console_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ConsoleProfile
+    :ivar service_principal_profile: The cluster service principal profile.
+    :vartype service_principal_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ServicePrincipalProfile
+    :ivar network_profile: The cluster network profile.
+    :vartype network_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.NetworkProfile
+    :ivar master_profile: The cluster master profile.
+    :vartype master_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MasterProfile
+    :ivar worker_profiles: The cluster worker profiles.
+    :vartype worker_profiles: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+    :ivar worker_profiles_status: The cluster worker profiles status.
+    :vartype worker_profiles_status:
+     list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+    :ivar apiserver_profile: The cluster API server profile.
+    :vartype apiserver_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
+    :ivar ingress_profiles: The cluster ingress profiles.
+    :vartype ingress_profiles: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+    :ivar maintenance_window_profile: The cluster maintenance window profile.
+    :vartype maintenance_window_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceWindowProfile
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+        'location': {'required': True},
+        'worker_profiles_status': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'tags': {'key': 'tags', 'type': '{str}'},
+        'location': {'key': 'location', 'type': 'str'},
+        'provisioning_state': {'key': 'properties.provisioningState', 'type': 'str'},
+        'cluster_profile': {'key': 'properties.clusterProfile', 'type': 'ClusterProfile'},
+        'console_profile': {'key': 'properties.consoleProfile', 'type': 'ConsoleProfile'},
+        'service_principal_profile': {'key': 'properties.servicePrincipalProfile', 'type': 'ServicePrincipalProfile'},
+        'network_profile': {'key': 'properties.networkProfile', 'type': 'NetworkProfile'},
+        'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
+        'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
+        'worker_profiles_status': {'key': 'properties.workerProfilesStatus', 'type': '[WorkerProfile]'},
+        'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
+        'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'maintenance_window_profile': {'key': 'properties.maintenanceWindowProfile', 'type': 'MaintenanceWindowProfile'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword tags: A set of tags. Resource tags.
+        :paramtype tags: dict[str, str]
+        :keyword location: Required. The geo-location where the resource lives.
+        :paramtype location: str
+        :keyword provisioning_state: The cluster provisioning state. Possible values include:
+         "AdminUpdating", "Cancelled", "Creating", "Deleting", "Failed", "Succeeded", "Updating".
+        :paramtype provisioning_state: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ProvisioningState
+        :keyword cluster_profile: The cluster profile.
+        :paramtype cluster_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ClusterProfile
+        :keyword console_profile: The console profile.
+        :paramtype console_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ConsoleProfile
+        :keyword service_principal_profile: The cluster service principal profile.
+        :paramtype service_principal_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ServicePrincipalProfile
+        :keyword network_profile: The cluster network profile.
+        :paramtype network_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.NetworkProfile
+        :keyword master_profile: The cluster master profile.
+        :paramtype master_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MasterProfile
+        :keyword worker_profiles: The cluster worker profiles.
+        :paramtype worker_profiles: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+        :keyword apiserver_profile: The cluster API server profile.
+        :paramtype apiserver_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
+        :keyword ingress_profiles: The cluster ingress profiles.
+        :paramtype ingress_profiles:
+         list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+        :keyword maintenance_window_profile: The cluster maintenance window profile.
+        :paramtype maintenance_window_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceWindowProfile
+        """
+        super(OpenShiftCluster, self).__init__(**kwargs)
+        self.provisioning_state = kwargs.get('provisioning_state', None)
+        self.cluster_profile = kwargs.get('cluster_profile', None)
+        self.console_profile = kwargs.get('console_profile', None)
+        self.service_principal_profile = kwargs.get('service_principal_profile', None)
+        self.network_profile = kwargs.get('network_profile', None)
+        self.master_profile = kwargs.get('master_profile', None)
+        self.worker_profiles = kwargs.get('worker_profiles', None)
+        self.worker_profiles_status = None
+        self.apiserver_profile = kwargs.get('apiserver_profile', None)
+        self.ingress_profiles = kwargs.get('ingress_profiles', None)
+        self.maintenance_window_profile = kwargs.get('maintenance_window_profile', None)
+
+
+class OpenShiftClusterAdminKubeconfig(msrest.serialization.Model):
+    """OpenShiftClusterAdminKubeconfig represents an OpenShift cluster's admin kubeconfig.
+
+    :ivar kubeconfig: The base64-encoded kubeconfig file.
+    :vartype kubeconfig: str
+    """
+
+    _attribute_map = {
+        'kubeconfig': {'key': 'kubeconfig', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword kubeconfig: The base64-encoded kubeconfig file.
+        :paramtype kubeconfig: str
+        """
+        super(OpenShiftClusterAdminKubeconfig, self).__init__(**kwargs)
+        self.kubeconfig = kwargs.get('kubeconfig', None)
+
+
+class OpenShiftClusterCredentials(msrest.serialization.Model):
+    """OpenShiftClusterCredentials represents an OpenShift cluster's credentials.
+
+    :ivar kubeadmin_username: The username for the kubeadmin user.
+    :vartype kubeadmin_username: str
+    :ivar kubeadmin_password: The password for the kubeadmin user.
+    :vartype kubeadmin_password: str
+    """
+
+    _attribute_map = {
+        'kubeadmin_username': {'key': 'kubeadminUsername', 'type': 'str'},
+        'kubeadmin_password': {'key': 'kubeadminPassword', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword kubeadmin_username: The username for the kubeadmin user.
+        :paramtype kubeadmin_username: str
+        :keyword kubeadmin_password: The password for the kubeadmin user.
+        :paramtype kubeadmin_password: str
+        """
+        super(OpenShiftClusterCredentials, self).__init__(**kwargs)
+        self.kubeadmin_username = kwargs.get('kubeadmin_username', None)
+        self.kubeadmin_password = kwargs.get('kubeadmin_password', None)
+
+
+class OpenShiftClusterList(msrest.serialization.Model):
+    """OpenShiftClusterList represents a list of OpenShift clusters.
+
+    :ivar value: The list of OpenShift clusters.
+    :vartype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.OpenShiftCluster]
+    :ivar next_link: The link used to get the next page of operations.
+    :vartype next_link: str
+    """
+
+    _attribute_map = {
+        'value': {'key': 'value', 'type': '[OpenShiftCluster]'},
+        'next_link': {'key': 'nextLink', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword value: The list of OpenShift clusters.
+        :paramtype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.OpenShiftCluster]
+        :keyword next_link: The link used to get the next page of operations.
+        :paramtype next_link: str
+        """
+        super(OpenShiftClusterList, self).__init__(**kwargs)
+        self.value = kwargs.get('value', None)
+        self.next_link = kwargs.get('next_link', None)
+
+
+class OpenShiftClusterUpdate(msrest.serialization.Model):
+    """OpenShiftCluster represents an Azure Red Hat OpenShift cluster.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar tags: A set of tags. The resource tags.
+    :vartype tags: dict[str, str]
+    :ivar system_data: The system meta data relating to this resource.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar provisioning_state: The cluster provisioning state. Possible values include:
+     "AdminUpdating", "Cancelled", "Creating", "Deleting", "Failed", "Succeeded", "Updating".
+    :vartype provisioning_state: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ProvisioningState
+    :ivar cluster_profile: The cluster profile.
+    :vartype cluster_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ClusterProfile
+    :ivar console_profile: The console profile.
+    :vartype console_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ConsoleProfile
+    :ivar service_principal_profile: The cluster service principal profile.
+    :vartype service_principal_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ServicePrincipalProfile
+    :ivar network_profile: The cluster network profile.
+    :vartype network_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.NetworkProfile
+    :ivar master_profile: The cluster master profile.
+    :vartype master_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MasterProfile
+    :ivar worker_profiles: The cluster worker profiles.
+    :vartype worker_profiles: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+    :ivar worker_profiles_status: The cluster worker profiles status.
+    :vartype worker_profiles_status:
+     list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+    :ivar apiserver_profile: The cluster API server profile.
+    :vartype apiserver_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
+    :ivar ingress_profiles: The cluster ingress profiles.
+    :vartype ingress_profiles: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+    :ivar maintenance_window_profile: The cluster maintenance window profile.
+    :vartype maintenance_window_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceWindowProfile
+    """
+
+    _validation = {
+        'system_data': {'readonly': True},
+        'worker_profiles_status': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'tags': {'key': 'tags', 'type': '{str}'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'provisioning_state': {'key': 'properties.provisioningState', 'type': 'str'},
+        'cluster_profile': {'key': 'properties.clusterProfile', 'type': 'ClusterProfile'},
+        'console_profile': {'key': 'properties.consoleProfile', 'type': 'ConsoleProfile'},
+        'service_principal_profile': {'key': 'properties.servicePrincipalProfile', 'type': 'ServicePrincipalProfile'},
+        'network_profile': {'key': 'properties.networkProfile', 'type': 'NetworkProfile'},
+        'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
+        'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
+        'worker_profiles_status': {'key': 'properties.workerProfilesStatus', 'type': '[WorkerProfile]'},
+        'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
+        'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'maintenance_window_profile': {'key': 'properties.maintenanceWindowProfile', 'type': 'MaintenanceWindowProfile'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword tags: A set of tags. The resource tags.
+        :paramtype tags: dict[str, str]
+        :keyword provisioning_state: The cluster provisioning state. Possible values include:
+         "AdminUpdating", "Cancelled", "Creating", "Deleting", "Failed", "Succeeded", "Updating".
+        :paramtype provisioning_state: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ProvisioningState
+        :keyword cluster_profile: The cluster profile.
+        :paramtype cluster_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ClusterProfile
+        :keyword console_profile: The console profile.
+        :paramtype console_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ConsoleProfile
+        :keyword service_principal_profile: The cluster service principal profile.
+        :paramtype service_principal_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ServicePrincipalProfile
+        :keyword network_profile: The cluster network profile.
+        :paramtype network_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.NetworkProfile
+        :keyword master_profile: The cluster master profile.
+        :paramtype master_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MasterProfile
+        :keyword worker_profiles: The cluster worker profiles.
+        :paramtype worker_profiles: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+        :keyword apiserver_profile: The cluster API server profile.
+        :paramtype apiserver_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
+        :keyword ingress_profiles: The cluster ingress profiles.
+        :paramtype ingress_profiles:
+         list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+        :keyword maintenance_window_profile: The cluster maintenance window profile.
+        :paramtype maintenance_window_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceWindowProfile
+        """
+        super(OpenShiftClusterUpdate, self).__init__(**kwargs)
+        self.tags = kwargs.get('tags', None)
+        self.system_data = None
+        self.provisioning_state = kwargs.get('provisioning_state', None)
+        self.cluster_profile = kwargs.get('cluster_profile', None)
+        self.console_profile = kwargs.get('console_profile', None)
+        self.service_principal_profile = kwargs.get('service_principal_profile', None)
+        self.network_profile = kwargs.get('network_profile', None)
+        self.master_profile = kwargs.get('master_profile', None)
+        self.worker_profiles = kwargs.get('worker_profiles', None)
+        self.worker_profiles_status = None
+        self.apiserver_profile = kwargs.get('apiserver_profile', None)
+        self.ingress_profiles = kwargs.get('ingress_profiles', None)
+        self.maintenance_window_profile = kwargs.get('maintenance_window_profile', None)
+
+
+class OpenShiftVersion(ProxyResource):
+    """OpenShiftVersion represents an OpenShift version that can be installed.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar version: Version represents the version to create the cluster at.
+    :vartype version: str
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'version': {'key': 'properties.version', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword version: Version represents the version to create the cluster at.
+        :paramtype version: str
+        """
+        super(OpenShiftVersion, self).__init__(**kwargs)
+        self.version = kwargs.get('version', None)
+
+
+class OpenShiftVersionList(msrest.serialization.Model):
+    """OpenShiftVersionList represents a List of available versions.
+
+    :ivar value: The List of available versions.
+    :vartype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.OpenShiftVersion]
+    :ivar next_link: Next Link to next operation.
+    :vartype next_link: str
+    """
+
+    _attribute_map = {
+        'value': {'key': 'value', 'type': '[OpenShiftVersion]'},
+        'next_link': {'key': 'nextLink', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword value: The List of available versions.
+        :paramtype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.OpenShiftVersion]
+        :keyword next_link: Next Link to next operation.
+        :paramtype next_link: str
+        """
+        super(OpenShiftVersionList, self).__init__(**kwargs)
+        self.value = kwargs.get('value', None)
+        self.next_link = kwargs.get('next_link', None)
+
+
+class Operation(msrest.serialization.Model):
+    """Operation represents an RP operation.
+
+    :ivar name: Operation name: {provider}/{resource}/{operation}.
+    :vartype name: str
+    :ivar display: The object that describes the operation.
+    :vartype display: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Display
+    :ivar origin: Sources of requests to this operation.  Comma separated list with valid values
+     user or system, e.g. "user,system".
+    :vartype origin: str
+    """
+
+    _attribute_map = {
+        'name': {'key': 'name', 'type': 'str'},
+        'display': {'key': 'display', 'type': 'Display'},
+        'origin': {'key': 'origin', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword name: Operation name: {provider}/{resource}/{operation}.
+        :paramtype name: str
+        :keyword display: The object that describes the operation.
+        :paramtype display: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Display
+        :keyword origin: Sources of requests to this operation.  Comma separated list with valid values
+         user or system, e.g. "user,system".
+        :paramtype origin: str
+        """
+        super(Operation, self).__init__(**kwargs)
+        self.name = kwargs.get('name', None)
+        self.display = kwargs.get('display', None)
+        self.origin = kwargs.get('origin', None)
+
+
+class OperationList(msrest.serialization.Model):
+    """OperationList represents an RP operation list.
+
+    :ivar value: List of operations supported by the resource provider.
+    :vartype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Operation]
+    :ivar next_link: The link used to get the next page of operations.
+    :vartype next_link: str
+    """
+
+    _attribute_map = {
+        'value': {'key': 'value', 'type': '[Operation]'},
+        'next_link': {'key': 'nextLink', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword value: List of operations supported by the resource provider.
+        :paramtype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Operation]
+        :keyword next_link: The link used to get the next page of operations.
+        :paramtype next_link: str
+        """
+        super(OperationList, self).__init__(**kwargs)
+        self.value = kwargs.get('value', None)
+        self.next_link = kwargs.get('next_link', None)
+
+
+class Secret(ProxyResource):
+    """Secret represents a secret.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar secret_resources: The Secrets Resources.
+    :vartype secret_resources: str
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'secret_resources': {'key': 'properties.secretResources', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword secret_resources: The Secrets Resources.
+        :paramtype secret_resources: str
+        """
+        super(Secret, self).__init__(**kwargs)
+        self.secret_resources = kwargs.get('secret_resources', None)
+
+
+class SecretList(msrest.serialization.Model):
+    """SecretList represents a list of Secrets.
+
+    :ivar value: The list of secrets.
+    :vartype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Secret]
+    :ivar next_link: The link used to get the next page of operations.
+    :vartype next_link: str
+    """
+
+    _attribute_map = {
+        'value': {'key': 'value', 'type': '[Secret]'},
+        'next_link': {'key': 'nextLink', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword value: The list of secrets.
+        :paramtype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Secret]
+        :keyword next_link: The link used to get the next page of operations.
+        :paramtype next_link: str
+        """
+        super(SecretList, self).__init__(**kwargs)
+        self.value = kwargs.get('value', None)
+        self.next_link = kwargs.get('next_link', None)
+
+
+class SecretUpdate(msrest.serialization.Model):
+    """Secret represents a secret.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar system_data: The system meta data relating to this resource.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar secret_resources: The Secrets Resources.
+    :vartype secret_resources: str
+    """
+
+    _validation = {
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'secret_resources': {'key': 'properties.secretResources', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword secret_resources: The Secrets Resources.
+        :paramtype secret_resources: str
+        """
+        super(SecretUpdate, self).__init__(**kwargs)
+        self.system_data = None
+        self.secret_resources = kwargs.get('secret_resources', None)
+
+
+class ServicePrincipalProfile(msrest.serialization.Model):
+    """ServicePrincipalProfile represents a service principal profile.
+
+    :ivar client_id: The client ID used for the cluster.
+    :vartype client_id: str
+    :ivar client_secret: The client secret used for the cluster.
+    :vartype client_secret: str
+    """
+
+    _attribute_map = {
+        'client_id': {'key': 'clientId', 'type': 'str'},
+        'client_secret': {'key': 'clientSecret', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword client_id: The client ID used for the cluster.
+        :paramtype client_id: str
+        :keyword client_secret: The client secret used for the cluster.
+        :paramtype client_secret: str
+        """
+        super(ServicePrincipalProfile, self).__init__(**kwargs)
+        self.client_id = kwargs.get('client_id', None)
+        self.client_secret = kwargs.get('client_secret', None)
+
+
+class SyncIdentityProvider(ProxyResource):
+    """SyncIdentityProvider represents a SyncIdentityProvider.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar resources:
+    :vartype resources: str
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'resources': {'key': 'properties.resources', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword resources:
+        :paramtype resources: str
+        """
+        super(SyncIdentityProvider, self).__init__(**kwargs)
+        self.resources = kwargs.get('resources', None)
+
+
+class SyncIdentityProviderList(msrest.serialization.Model):
+    """SyncSetList represents a list of SyncSets.
+
+    :ivar value: The list of sync identity providers.
+    :vartype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SyncIdentityProvider]
+    :ivar next_link: The link used to get the next page of operations.
+    :vartype next_link: str
+    """
+
+    _attribute_map = {
+        'value': {'key': 'value', 'type': '[SyncIdentityProvider]'},
+        'next_link': {'key': 'nextLink', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword value: The list of sync identity providers.
+        :paramtype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SyncIdentityProvider]
+        :keyword next_link: The link used to get the next page of operations.
+        :paramtype next_link: str
+        """
+        super(SyncIdentityProviderList, self).__init__(**kwargs)
+        self.value = kwargs.get('value', None)
+        self.next_link = kwargs.get('next_link', None)
+
+
+class SyncIdentityProviderUpdate(msrest.serialization.Model):
+    """SyncIdentityProvider represents a SyncIdentityProvider.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar system_data: The system meta data relating to this resource.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar resources:
+    :vartype resources: str
+    """
+
+    _validation = {
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'resources': {'key': 'properties.resources', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword resources:
+        :paramtype resources: str
+        """
+        super(SyncIdentityProviderUpdate, self).__init__(**kwargs)
+        self.system_data = None
+        self.resources = kwargs.get('resources', None)
+
+
+class SyncSet(ProxyResource):
+    """SyncSet represents a SyncSet for an Azure Red Hat OpenShift Cluster.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar id: Fully qualified resource ID for the resource. Ex -
+     /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}.
+    :vartype id: str
+    :ivar name: The name of the resource.
+    :vartype name: str
+    :ivar type: The type of the resource. E.g. "Microsoft.Compute/virtualMachines" or
+     "Microsoft.Storage/storageAccounts".
+    :vartype type: str
+    :ivar system_data: Azure Resource Manager metadata containing createdBy and modifiedBy
+     information.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar resources: Resources represents the SyncSets configuration.
+    :vartype resources: str
+    """
+
+    _validation = {
+        'id': {'readonly': True},
+        'name': {'readonly': True},
+        'type': {'readonly': True},
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'name': {'key': 'name', 'type': 'str'},
+        'type': {'key': 'type', 'type': 'str'},
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'resources': {'key': 'properties.resources', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword resources: Resources represents the SyncSets configuration.
+        :paramtype resources: str
+        """
+        super(SyncSet, self).__init__(**kwargs)
+        self.resources = kwargs.get('resources', None)
+
+
+class SyncSetList(msrest.serialization.Model):
+    """SyncSetList represents a list of SyncSets.
+
+    :ivar value: The list of syncsets.
+    :vartype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SyncSet]
+    :ivar next_link: The link used to get the next page of operations.
+    :vartype next_link: str
+    """
+
+    _attribute_map = {
+        'value': {'key': 'value', 'type': '[SyncSet]'},
+        'next_link': {'key': 'nextLink', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword value: The list of syncsets.
+        :paramtype value: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SyncSet]
+        :keyword next_link: The link used to get the next page of operations.
+        :paramtype next_link: str
+        """
+        super(SyncSetList, self).__init__(**kwargs)
+        self.value = kwargs.get('value', None)
+        self.next_link = kwargs.get('next_link', None)
+
+
+class SyncSetUpdate(msrest.serialization.Model):
+    """SyncSet represents a SyncSet for an Azure Red Hat OpenShift Cluster.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar system_data: The system meta data relating to this resource.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
+    :ivar resources: Resources represents the SyncSets configuration.
+    :vartype resources: str
+    """
+
+    _validation = {
+        'system_data': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'resources': {'key': 'properties.resources', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword resources: Resources represents the SyncSets configuration.
+        :paramtype resources: str
+        """
+        super(SyncSetUpdate, self).__init__(**kwargs)
+        self.system_data = None
+        self.resources = kwargs.get('resources', None)
+
+
+class SystemData(msrest.serialization.Model):
+    """Metadata pertaining to creation and last modification of the resource.
+
+    :ivar created_by: The identity that created the resource.
+    :vartype created_by: str
+    :ivar created_by_type: The type of identity that created the resource. Possible values include:
+     "User", "Application", "ManagedIdentity", "Key".
+    :vartype created_by_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CreatedByType
+    :ivar created_at: The timestamp of resource creation (UTC).
+    :vartype created_at: ~datetime.datetime
+    :ivar last_modified_by: The identity that last modified the resource.
+    :vartype last_modified_by: str
+    :ivar last_modified_by_type: The type of identity that last modified the resource. Possible
+     values include: "User", "Application", "ManagedIdentity", "Key".
+    :vartype last_modified_by_type: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CreatedByType
+    :ivar last_modified_at: The timestamp of resource last modification (UTC).
+    :vartype last_modified_at: ~datetime.datetime
+    """
+
+    _attribute_map = {
+        'created_by': {'key': 'createdBy', 'type': 'str'},
+        'created_by_type': {'key': 'createdByType', 'type': 'str'},
+        'created_at': {'key': 'createdAt', 'type': 'iso-8601'},
+        'last_modified_by': {'key': 'lastModifiedBy', 'type': 'str'},
+        'last_modified_by_type': {'key': 'lastModifiedByType', 'type': 'str'},
+        'last_modified_at': {'key': 'lastModifiedAt', 'type': 'iso-8601'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword created_by: The identity that created the resource.
+        :paramtype created_by: str
+        :keyword created_by_type: The type of identity that created the resource. Possible values
+         include: "User", "Application", "ManagedIdentity", "Key".
+        :paramtype created_by_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CreatedByType
+        :keyword created_at: The timestamp of resource creation (UTC).
+        :paramtype created_at: ~datetime.datetime
+        :keyword last_modified_by: The identity that last modified the resource.
+        :paramtype last_modified_by: str
+        :keyword last_modified_by_type: The type of identity that last modified the resource. Possible
+         values include: "User", "Application", "ManagedIdentity", "Key".
+        :paramtype last_modified_by_type: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CreatedByType
+        :keyword last_modified_at: The timestamp of resource last modification (UTC).
+        :paramtype last_modified_at: ~datetime.datetime
+        """
+        super(SystemData, self).__init__(**kwargs)
+        self.created_by = kwargs.get('created_by', None)
+        self.created_by_type = kwargs.get('created_by_type', None)
+        self.created_at = kwargs.get('created_at', None)
+        self.last_modified_by = kwargs.get('last_modified_by', None)
+        self.last_modified_by_type = kwargs.get('last_modified_by_type', None)
+        self.last_modified_at = kwargs.get('last_modified_at', None)
+
+
+class WorkerProfile(msrest.serialization.Model):
+    """WorkerProfile represents a worker profile.
+
+    :ivar name: The worker profile name.
+    :vartype name: str
+    :ivar vm_size: The size of the worker VMs.
+    :vartype vm_size: str
+    :ivar disk_size_gb: The disk size of the worker VMs.
+    :vartype disk_size_gb: int
+    :ivar subnet_id: The Azure resource ID of the worker subnet.
+    :vartype subnet_id: str
+    :ivar count: The number of worker VMs.
+    :vartype count: int
+    :ivar encryption_at_host: Whether master virtual machines are encrypted at host. Possible
+     values include: "Disabled", "Enabled".
+    :vartype encryption_at_host: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.EncryptionAtHost
+    :ivar disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
+     applicable.
+    :vartype disk_encryption_set_id: str
+    """
+
+    _attribute_map = {
+        'name': {'key': 'name', 'type': 'str'},
+        'vm_size': {'key': 'vmSize', 'type': 'str'},
+        'disk_size_gb': {'key': 'diskSizeGB', 'type': 'int'},
+        'subnet_id': {'key': 'subnetId', 'type': 'str'},
+        'count': {'key': 'count', 'type': 'int'},
+        'encryption_at_host': {'key': 'encryptionAtHost', 'type': 'str'},
+        'disk_encryption_set_id': {'key': 'diskEncryptionSetId', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword name: The worker profile name.
+        :paramtype name: str
+        :keyword vm_size: The size of the worker VMs.
+        :paramtype vm_size: str
+        :keyword disk_size_gb: The disk size of the worker VMs.
+        :paramtype disk_size_gb: int
+        :keyword subnet_id: The Azure resource ID of the worker subnet.
+        :paramtype subnet_id: str
+        :keyword count: The number of worker VMs.
+        :paramtype count: int
+        :keyword encryption_at_host: Whether master virtual machines are encrypted at host. Possible
+         values include: "Disabled", "Enabled".
+        :paramtype encryption_at_host: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.EncryptionAtHost
+        :keyword disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
+         applicable.
+        :paramtype disk_encryption_set_id: str
+        """
+        super(WorkerProfile, self).__init__(**kwargs)
+        self.name = kwargs.get('name', None)
+        self.vm_size = kwargs.get('vm_size', None)
+        self.disk_size_gb = kwargs.get('disk_size_gb', None)
+        self.subnet_id = kwargs.get('subnet_id', None)
+        self.count = kwargs.get('count', None)
+        self.encryption_at_host = kwargs.get('encryption_at_host', None)
+        self.disk_encryption_set_id = kwargs.get('disk_encryption_set_id', None)
@@ -12,7 +12,9 @@ import (
 )
 
 func fakeBillingCreationTimestampTrigger(ctx context.Context, doc *api.BillingDocument) error {
-	doc.Billing.CreationTime = int(time.Now().Unix())
+	if doc.Billing.CreationTime == 0 {
+		doc.Billing.CreationTime = int(time.Now().Unix())
+	}
 	return nil
 }
 
@@ -160,13 +160,19 @@ func (f *Checker) CheckBilling(billing *cosmosdb.FakeBillingDocumentClient) (err
 		}
 	}
 
-	if len(f.billingDocuments) != 0 && len(all.BillingDocuments) == len(f.billingDocuments) {
-		diff := deep.Equal(all.BillingDocuments, f.billingDocuments)
+	// The fake client lists documents in random order, so compare both sides
+	// sorted by ID
+	billingDocuments := append([]*api.BillingDocument(nil), f.billingDocuments...)
+	sort.Slice(all.BillingDocuments, func(i, j int) bool { return all.BillingDocuments[i].ID < all.BillingDocuments[j].ID })
+	sort.Slice(billingDocuments, func(i, j int) bool { return billingDocuments[i].ID < billingDocuments[j].ID })
+
+	if len(billingDocuments) != 0 && len(all.BillingDocuments) == len(billingDocuments) {
+		diff := deep.Equal(all.BillingDocuments, billingDocuments)
 		for _, i := range diff {
 			errs = append(errs, errors.New(i))
 		}
-	} else if len(all.BillingDocuments) != 0 || len(f.billingDocuments) != 0 {
-		errs = append(errs, fmt.Errorf("billing length different, %d vs %d", len(all.BillingDocuments), len(f.billingDocuments)))
+	} else if len(all.BillingDocuments) != 0 || len(billingDocuments) != 0 {
+		errs = append(errs, fmt.Errorf("billing length different, %d vs %d", len(all.BillingDocuments), len(billingDocuments)))
 	}
 
 	return errs
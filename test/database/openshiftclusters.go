@@ -136,6 +136,69 @@ func fakeOpenshiftClustersPrefixQuery(client cosmosdb.OpenShiftClusterDocumentCl
 	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
 }
 
+// fakeOpenShiftClustersFilterQuery returns a query handler which returns the
+// documents matched by f
+func fakeOpenShiftClustersFilterQuery(f func(*api.OpenShiftClusterDocument) bool) func(cosmosdb.OpenShiftClusterDocumentClient, *cosmosdb.Query, *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	return func(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+		startingIndex, err := fakeOpenShiftClustersGetContinuation(options)
+		if err != nil {
+			return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+		}
+
+		docs, err := fakeOpenShiftClustersGetAllDocuments(client)
+		if err != nil {
+			return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+		}
+		var results []*api.OpenShiftClusterDocument
+		for _, r := range docs {
+			if f(r) {
+				results = append(results, r)
+			}
+		}
+
+		return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
+	}
+}
+
+func fakeOpenShiftClustersACRTokenQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	name := query.Parameters[0].Value
+	createdBefore, err := time.Parse(time.RFC3339, query.Parameters[1].Value)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	return fakeOpenShiftClustersFilterQuery(func(doc *api.OpenShiftClusterDocument) bool {
+		for _, rp := range doc.OpenShiftCluster.Properties.RegistryProfiles {
+			if rp.Name == name && (rp.PasswordCreationTime == nil || rp.PasswordCreationTime.Before(createdBefore)) {
+				return true
+			}
+		}
+		return false
+	})(client, query, options)
+}
+
+func fakeOpenShiftClustersFilterByParametersQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	params := map[string]string{}
+	for _, p := range query.Parameters {
+		params[p.Name] = p.Value
+	}
+
+	return fakeOpenShiftClustersFilterQuery(func(doc *api.OpenShiftClusterDocument) bool {
+		props := &doc.OpenShiftCluster.Properties
+
+		// createdAt is compared as the RFC3339 string which it is stored as
+		createdAt := props.CreatedAt.Format(time.RFC3339Nano)
+
+		return strings.HasPrefix(doc.Key, params["@prefix"]) &&
+			strings.Contains(doc.Key, params["@keyContains"]) &&
+			(params["@version"] == "" || props.ClusterProfile.Version == params["@version"]) &&
+			(params["@provisioningState"] == "" || strings.EqualFold(string(props.ProvisioningState), params["@provisioningState"])) &&
+			(params["@provisionedBy"] == "" || props.ProvisionedBy == params["@provisionedBy"]) &&
+			(params["@createdAfter"] == "" || createdAt >= params["@createdAfter"]) &&
+			(params["@createdBefore"] == "" || createdAt < params["@createdBefore"])
+	})(client, query, options)
+}
+
 func fakeOpenShiftClustersRenewLeaseTrigger(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
 	doc.LeaseExpires = int(time.Now().Unix()) + 60
 	return nil
@@ -158,6 +221,26 @@ func injectOpenShiftClusters(c *cosmosdb.FakeOpenShiftClusterDocumentClient) {
 	c.SetQueryHandler(database.OpenshiftClustersClientIdQuery, fakeOpenshiftClustersMatchQuery)
 	c.SetQueryHandler(database.OpenshiftClustersResourceGroupQuery, fakeOpenshiftClustersMatchQuery)
 	c.SetQueryHandler(database.OpenshiftClustersPrefixQuery, fakeOpenshiftClustersPrefixQuery)
+	c.SetQueryHandler(database.OpenShiftClustersFilterQuery, fakeOpenShiftClustersFilterByParametersQuery)
+	c.SetQueryHandler(database.OpenShiftClustersEtcdSnapshotQuery, fakeOpenShiftClustersFilterQuery(func(doc *api.OpenShiftClusterDocument) bool {
+		p := doc.OpenShiftCluster.Properties.EtcdSnapshotProfile
+		return p != nil && p.Interval != ""
+	}))
+	c.SetQueryHandler(database.OpenShiftClustersACRTokenQuery, fakeOpenShiftClustersACRTokenQuery)
+	c.SetQueryHandler(database.OpenShiftClustersDeferredQuery, fakeOpenShiftClustersFilterQuery(func(doc *api.OpenShiftClusterDocument) bool {
+		return doc.OpenShiftCluster.Properties.DeferredMaintenanceTask != ""
+	}))
+	c.SetQueryHandler(database.OpenShiftClustersCertificateQuery, fakeOpenShiftClustersFilterQuery(func(doc *api.OpenShiftClusterDocument) bool {
+		if doc.OpenShiftCluster.Properties.APIServerProfile.CertificateSecretID != "" {
+			return true
+		}
+		for _, p := range doc.OpenShiftCluster.Properties.IngressProfiles {
+			if p.CertificateSecretID != "" {
+				return true
+			}
+		}
+		return false
+	}))
 
 	c.SetTriggerHandler("renewLease", fakeOpenShiftClustersRenewLeaseTrigger)
 